@@ -6,8 +6,15 @@
 package webrtc
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"sync"
+	"time"
+
 	"github.com/pion/interceptor"
 	"github.com/pion/logging"
+	"github.com/pion/webrtc/v4/pkg/rtcerr"
 )
 
 // API allows configuration of a PeerConnection
@@ -21,6 +28,19 @@ type API struct {
 	interceptorRegistry *interceptor.Registry
 
 	interceptor interceptor.Interceptor // Generated per PeerConnection
+
+	certificateCache *certificateCache // Shared by the PeerConnections of the API
+}
+
+// certificateReuseRenewalMargin is how long before it expires the certificate
+// WithCertificateReuse shares is renewed, so the PeerConnections that got it
+// last can still use it for a while, like for ICE restarts.
+const certificateReuseRenewalMargin = 7 * 24 * time.Hour
+
+// certificateCache holds the certificate WithCertificateReuse shares.
+type certificateCache struct {
+	mu          sync.Mutex
+	certificate *Certificate
 }
 
 // NewAPI Creates a new API object for keeping semi-global settings to WebRTC objects
@@ -94,3 +114,49 @@ func WithInterceptorRegistry(ir *interceptor.Registry) func(a *API) {
 		}
 	}
 }
+
+// WithCertificateReuse makes the PeerConnections and DTLSTransports created by
+// the API without certificates share one generated certificate, instead of
+// generating a key pair and certificate each. This cuts the setup cost of
+// services creating many short-lived PeerConnections, like call probes. A new
+// certificate is generated a week before the shared one expires.
+// Peers can link the connections by the fingerprint of the shared certificate,
+// so applications that don't want that shouldn't reuse it.
+//
+// Only the certificate is reused. The interceptor chains and the buffers keep
+// the state of their PeerConnection, so they are still created per
+// PeerConnection.
+func WithCertificateReuse() func(a *API) {
+	return func(a *API) {
+		a.certificateCache = &certificateCache{}
+	}
+}
+
+// generateCertificate returns a new certificate, or the shared one if
+// WithCertificateReuse is used.
+func (api *API) generateCertificate() (*Certificate, error) {
+	if cache := api.certificateCache; cache != nil {
+		cache.mu.Lock()
+		defer cache.mu.Unlock()
+
+		if cache.certificate != nil &&
+			time.Now().Add(certificateReuseRenewalMargin).Before(cache.certificate.Expires()) {
+			return cache.certificate, nil
+		}
+	}
+
+	sk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, &rtcerr.UnknownError{Err: err}
+	}
+	certificate, err := api.settingEngine.generateCertificate(sk)
+	if err != nil {
+		return nil, err
+	}
+
+	if api.certificateCache != nil {
+		api.certificateCache.certificate = certificate
+	}
+
+	return certificate, nil
+}
@@ -7,6 +7,7 @@ package webrtc
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -41,3 +42,33 @@ func TestNewAPI_OptionsDefaultize(t *testing.T) {
 	assert.NotNil(t, api.mediaEngine)
 	assert.NotNil(t, api.interceptorRegistry)
 }
+
+func TestNewAPI_WithCertificateReuse(t *testing.T) {
+	fingerprint := func(api *API) string {
+		pc, err := api.NewPeerConnection(Configuration{})
+		assert.NoError(t, err)
+		defer func() { assert.NoError(t, pc.Close()) }()
+
+		fingerprints, err := pc.configuration.Certificates[0].GetFingerprints()
+		assert.NoError(t, err)
+
+		return fingerprints[0].Value
+	}
+
+	api := NewAPI()
+	assert.NotEqual(t, fingerprint(api), fingerprint(api))
+
+	api = NewAPI(WithCertificateReuse())
+	reused := fingerprint(api)
+	assert.Equal(t, reused, fingerprint(api))
+
+	dtlsTransport, err := api.NewDTLSTransport(nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, api.certificateCache.certificate.x509Cert, dtlsTransport.certificates[0].x509Cert)
+
+	// A certificate is replaced before it expires.
+	api.certificateCache.certificate.x509Cert.NotAfter = time.Now().Add(certificateReuseRenewalMargin - time.Hour)
+	renewed := fingerprint(api)
+	assert.NotEqual(t, reused, renewed)
+	assert.Equal(t, renewed, fingerprint(api))
+}
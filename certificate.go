@@ -6,6 +6,8 @@ package webrtc
 import (
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -21,17 +23,28 @@ import (
 	"github.com/pion/webrtc/v4/pkg/rtcerr"
 )
 
+// defaultFingerprintAlgorithms are the hash algorithms of the fingerprints of
+// the local certificate unless SettingEngine.SetDTLSFingerprintAlgorithms is used.
+// SHA-256 comes first for remote peers that only look at the first fingerprint.
+var defaultFingerprintAlgorithms = []crypto.Hash{crypto.SHA256, crypto.SHA384, crypto.SHA512} //nolint:gochecknoglobals
+
 // Certificate represents a x509Cert used to authenticate WebRTC communications.
 type Certificate struct {
 	privateKey crypto.PrivateKey
 	x509Cert   *x509.Certificate
 	statsID    string
+
+	// intermediates are the DER encoded certificates sent after x509Cert in the
+	// DTLS handshake, see CertificateFromX509Chain.
+	intermediates [][]byte
 }
 
 // NewCertificate generates a new x509 compliant Certificate to be used
 // by DTLS for encrypting data sent over the wire. This method differs from
 // GenerateCertificate by allowing to specify a template x509.Certificate to
-// be used in order to define certificate parameters.
+// be used in order to define certificate parameters. The key is a
+// *rsa.PrivateKey, a *ecdsa.PrivateKey, whose certificate is signed with the
+// hash matching the size of its curve, or an ed25519.PrivateKey.
 func NewCertificate(key crypto.PrivateKey, tpl x509.Certificate) (*Certificate, error) {
 	var err error
 	var certDER []byte
@@ -45,7 +58,14 @@ func NewCertificate(key crypto.PrivateKey, tpl x509.Certificate) (*Certificate,
 		}
 	case *ecdsa.PrivateKey:
 		pk := sk.Public()
-		tpl.SignatureAlgorithm = x509.ECDSAWithSHA256
+		tpl.SignatureAlgorithm = ecdsaSignatureAlgorithm(sk.Curve)
+		certDER, err = x509.CreateCertificate(rand.Reader, &tpl, &tpl, pk, sk)
+		if err != nil {
+			return nil, &rtcerr.UnknownError{Err: err}
+		}
+	case ed25519.PrivateKey:
+		pk := sk.Public()
+		tpl.SignatureAlgorithm = x509.PureEd25519
 		certDER, err = x509.CreateCertificate(rand.Reader, &tpl, &tpl, pk, sk)
 		if err != nil {
 			return nil, &rtcerr.UnknownError{Err: err}
@@ -66,6 +86,19 @@ func NewCertificate(key crypto.PrivateKey, tpl x509.Certificate) (*Certificate,
 	}, nil
 }
 
+// ecdsaSignatureAlgorithm returns the ECDSA signature algorithm with the hash
+// matching the size of the curve, like P-384 with SHA-384.
+func ecdsaSignatureAlgorithm(curve elliptic.Curve) x509.SignatureAlgorithm {
+	switch curve.Params().BitSize {
+	case 384:
+		return x509.ECDSAWithSHA384
+	case 521:
+		return x509.ECDSAWithSHA512
+	default:
+		return x509.ECDSAWithSHA256
+	}
+}
+
 // Equals determines if two certificates are identical by comparing both the
 // secretKeys and x509Certificates.
 func (c Certificate) Equals(cert Certificate) bool {
@@ -89,6 +122,16 @@ func (c Certificate) Equals(cert Certificate) bool {
 			return c.x509Cert.Equal(cert.x509Cert)
 		}
 
+		return false
+	case ed25519.PrivateKey:
+		if oSK, ok := cert.privateKey.(ed25519.PrivateKey); ok {
+			if !cSK.Equal(oSK) {
+				return false
+			}
+
+			return c.x509Cert.Equal(cert.x509Cert)
+		}
+
 		return false
 	default:
 		return false
@@ -107,11 +150,14 @@ func (c Certificate) Expires() time.Time {
 // GetFingerprints returns the list of certificate fingerprints, one of which
 // is computed with the digest algorithm used in the certificate signature.
 func (c Certificate) GetFingerprints() ([]DTLSFingerprint, error) {
-	fingerprintAlgorithms := []crypto.Hash{crypto.SHA256}
-	res := make([]DTLSFingerprint, len(fingerprintAlgorithms))
+	return c.getFingerprints(defaultFingerprintAlgorithms)
+}
 
-	i := 0
-	for _, algo := range fingerprintAlgorithms {
+// getFingerprints returns a fingerprint of the certificate for each of the hash
+// algorithms.
+func (c Certificate) getFingerprints(algorithms []crypto.Hash) ([]DTLSFingerprint, error) {
+	res := make([]DTLSFingerprint, 0, len(algorithms))
+	for _, algo := range algorithms {
 		name, err := fingerprint.StringFromHash(algo)
 		if err != nil {
 			// nolint
@@ -122,17 +168,17 @@ func (c Certificate) GetFingerprints() ([]DTLSFingerprint, error) {
 			// nolint
 			return nil, fmt.Errorf("%w: %v", ErrFailedToGenerateCertificateFingerprint, err)
 		}
-		res[i] = DTLSFingerprint{
+		res = append(res, DTLSFingerprint{
 			Algorithm: name,
 			Value:     value,
-		}
+		})
 	}
 
-	return res[:i+1], nil
+	return res, nil
 }
 
 // GenerateCertificate causes the creation of an X.509 certificate and
-// corresponding private key.
+// corresponding private key. See NewCertificate for the supported keys.
 func GenerateCertificate(secretKey crypto.PrivateKey) (*Certificate, error) {
 	// Max random value, a 130-bits integer, i.e 2^130 - 1
 	maxBigInt := new(big.Int)
@@ -144,6 +190,10 @@ func GenerateCertificate(secretKey crypto.PrivateKey) (*Certificate, error) {
 		return nil, &rtcerr.UnknownError{Err: err}
 	}
 
+	return generateCertificate(secretKey, serialNumber)
+}
+
+func generateCertificate(secretKey crypto.PrivateKey, serialNumber *big.Int) (*Certificate, error) {
 	return NewCertificate(secretKey, x509.Certificate{
 		Issuer:       pkix.Name{CommonName: generatedCertificateOrigin},
 		NotBefore:    time.Now().AddDate(0, 0, -1),
@@ -158,7 +208,33 @@ func GenerateCertificate(secretKey crypto.PrivateKey) (*Certificate, error) {
 //
 // This can be used if you want to share a certificate across multiple PeerConnections.
 func CertificateFromX509(privateKey crypto.PrivateKey, certificate *x509.Certificate) Certificate {
-	return Certificate{privateKey, certificate, fmt.Sprintf("certificate-%d", time.Now().UnixNano())}
+	return Certificate{
+		privateKey: privateKey,
+		x509Cert:   certificate,
+		statsID:    fmt.Sprintf("certificate-%d", time.Now().UnixNano()),
+	}
+}
+
+// CertificateFromX509Chain creates a new WebRTC Certificate like
+// CertificateFromX509, the intermediates are sent after the certificate in the
+// DTLS handshake. The remote peer can verify the chain with
+// SettingEngine.SetDTLSVerifyPeerCertificate, the fingerprints in the session
+// description are still the ones of the certificate. PEM doesn't encode the
+// intermediates.
+func CertificateFromX509Chain(
+	privateKey crypto.PrivateKey, certificate *x509.Certificate, intermediates ...*x509.Certificate,
+) Certificate {
+	ret := CertificateFromX509(privateKey, certificate)
+	for _, intermediate := range intermediates {
+		ret.intermediates = append(ret.intermediates, intermediate.Raw)
+	}
+
+	return ret
+}
+
+// chain returns the DER encoded certificate followed by its intermediates.
+func (c Certificate) chain() [][]byte {
+	return append([][]byte{c.x509Cert.Raw}, c.intermediates...)
 }
 
 func (c Certificate) collectStats(report *statsReportCollector) error {
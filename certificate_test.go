@@ -6,16 +6,20 @@
 package webrtc
 
 import (
+	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/pem"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/pion/transport/v4/test"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -64,6 +68,87 @@ func TestGenerateCertificateECDSA(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestGenerateCertificateKeyTypes(t *testing.T) {
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	assert.NoError(t, err)
+	p521, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	assert.NoError(t, err)
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	for _, tc := range []struct {
+		key                crypto.PrivateKey
+		signatureAlgorithm x509.SignatureAlgorithm
+	}{
+		{p384, x509.ECDSAWithSHA384},
+		{p521, x509.ECDSAWithSHA512},
+		{ed25519Key, x509.PureEd25519},
+	} {
+		cert, err := GenerateCertificate(tc.key)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.signatureAlgorithm, cert.x509Cert.SignatureAlgorithm)
+		assert.NoError(t, cert.x509Cert.CheckSignature(
+			cert.x509Cert.SignatureAlgorithm, cert.x509Cert.RawTBSCertificate, cert.x509Cert.Signature,
+		))
+		assert.True(t, cert.Equals(*cert))
+
+		pemString, err := cert.PEM()
+		assert.NoError(t, err)
+		decoded, err := CertificateFromPEM(pemString)
+		assert.NoError(t, err)
+		assert.True(t, cert.Equals(*decoded))
+	}
+
+	_, otherEd25519Key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	cert, err := GenerateCertificate(ed25519Key)
+	assert.NoError(t, err)
+	otherCert, err := GenerateCertificate(otherEd25519Key)
+	assert.NoError(t, err)
+	assert.False(t, cert.Equals(*otherCert))
+}
+
+func TestGenerateCertificateKeyTypes_Handshake(t *testing.T) {
+	lim := test.TimeOut(time.Second * 10)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	offerCertificate, err := GenerateCertificate(ed25519Key)
+	assert.NoError(t, err)
+
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	assert.NoError(t, err)
+	answerCertificate, err := GenerateCertificate(p384)
+	assert.NoError(t, err)
+
+	offerPC, err := NewPeerConnection(Configuration{Certificates: []Certificate{*offerCertificate}})
+	assert.NoError(t, err)
+	answerPC, err := NewPeerConnection(Configuration{Certificates: []Certificate{*answerCertificate}})
+	assert.NoError(t, err)
+	_, err = offerPC.CreateDataChannel("data", nil)
+	assert.NoError(t, err)
+
+	connected := untilConnectionState(PeerConnectionStateConnected, offerPC, answerPC)
+	assert.NoError(t, signalPair(offerPC, answerPC))
+	connected.Wait()
+
+	fingerprints, err := offerCertificate.GetFingerprints()
+	assert.NoError(t, err)
+	assert.Len(t, fingerprints, 3)
+	for _, fingerprint := range fingerprints {
+		sdpFingerprint := "a=fingerprint:" + fingerprint.Algorithm + " " + strings.ToUpper(fingerprint.Value)
+		assert.Contains(t, offerPC.CurrentLocalDescription().SDP, sdpFingerprint)
+	}
+	assert.Equal(t, offerCertificate.x509Cert.Raw, answerPC.SCTP().Transport().GetRemoteCertificate())
+	assert.Equal(t, answerCertificate.x509Cert.Raw, offerPC.SCTP().Transport().GetRemoteCertificate())
+
+	closePairNow(t, offerPC, answerPC)
+}
+
 func TestGenerateCertificateEqual(t *testing.T) {
 	sk1, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	assert.Nil(t, err)
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package webrtc
+
+import (
+	"errors"
+)
+
+// comfortNoiseMaxLevel is the lowest noise level that can be signaled, in -dBov.
+const comfortNoiseMaxLevel = 127
+
+var errComfortNoisePayloadEmpty = errors.New("comfort noise payload is empty")
+
+// ComfortNoise is a RFC 3389 comfort noise payload. It describes the background
+// noise a receiver should generate while the sender is silent.
+type ComfortNoise struct {
+	// Level is the noise level in -dBov, from 0 to 127.
+	Level uint8
+
+	// ReflectionCoefficients optionally describe the spectrum of the noise, see
+	// RFC 3389 Section 3. Empty means white noise.
+	ReflectionCoefficients []uint8
+
+	// Timestamp is the RTP timestamp of a received comfort noise packet.
+	// It is ignored when sending.
+	Timestamp uint32
+}
+
+func (c ComfortNoise) marshal() []byte {
+	payload := make([]byte, 1+len(c.ReflectionCoefficients))
+	payload[0] = min(c.Level, comfortNoiseMaxLevel)
+	copy(payload[1:], c.ReflectionCoefficients)
+
+	return payload
+}
+
+func (c *ComfortNoise) unmarshal(payload []byte) error {
+	if len(payload) == 0 {
+		return errComfortNoisePayloadEmpty
+	}
+
+	c.Level = payload[0] & comfortNoiseMaxLevel
+	c.ReflectionCoefficients = nil
+	if len(payload) > 1 {
+		c.ReflectionCoefficients = append([]uint8{}, payload[1:]...)
+	}
+
+	return nil
+}
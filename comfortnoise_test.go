@@ -0,0 +1,24 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package webrtc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComfortNoise_Marshal(t *testing.T) {
+	assert.Equal(t, []byte{64}, ComfortNoise{Level: 64}.marshal())
+	assert.Equal(t, []byte{127, 1, 2}, ComfortNoise{Level: 200, ReflectionCoefficients: []uint8{1, 2}}.marshal())
+
+	var noise ComfortNoise
+	assert.NoError(t, noise.unmarshal([]byte{0xc0, 3}))
+	assert.Equal(t, ComfortNoise{Level: 64, ReflectionCoefficients: []uint8{3}}, noise)
+
+	assert.NoError(t, noise.unmarshal([]byte{50}))
+	assert.Equal(t, ComfortNoise{Level: 50}, noise)
+
+	assert.ErrorIs(t, noise.unmarshal(nil), errComfortNoisePayloadEmpty)
+}
@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package webrtc
+
+// ConnectionPhase is a phase of establishing a PeerConnection.
+type ConnectionPhase int
+
+const (
+	// ConnectionPhaseUnknown is the enum's zero-value.
+	ConnectionPhaseUnknown ConnectionPhase = iota
+
+	// ConnectionPhaseGathering is the gathering of local ICE candidates.
+	ConnectionPhaseGathering
+
+	// ConnectionPhaseICE is the ICE connectivity checks, until a candidate
+	// pair is selected.
+	ConnectionPhaseICE
+
+	// ConnectionPhaseDTLS is the DTLS handshake.
+	ConnectionPhaseDTLS
+
+	// ConnectionPhaseSCTP is the establishment of the SCTP association.
+	ConnectionPhaseSCTP
+)
+
+// This is done this way because of a linter.
+const (
+	connectionPhaseGatheringStr = "gathering"
+	connectionPhaseICEStr       = "ice"
+	connectionPhaseDTLSStr      = "dtls"
+	connectionPhaseSCTPStr      = "sctp"
+)
+
+func (p ConnectionPhase) String() string {
+	switch p {
+	case ConnectionPhaseGathering:
+		return connectionPhaseGatheringStr
+	case ConnectionPhaseICE:
+		return connectionPhaseICEStr
+	case ConnectionPhaseDTLS:
+		return connectionPhaseDTLSStr
+	case ConnectionPhaseSCTP:
+		return connectionPhaseSCTPStr
+	default:
+		return ErrUnknownType.Error()
+	}
+}
@@ -42,6 +42,8 @@ const (
 
 	rtpPayloadTypeBitmask = 0x7F
 
+	rtpHeaderExtensionBit = 0x10
+
 	incomingUnhandledRTPSsrc = "Incoming unhandled RTP ssrc(%d), OnTrack will not be fired. %v"
 
 	useReadSimulcast = "Use ReadSimulcast(rid) instead of Read() when multiple tracks are present"
@@ -6,6 +6,7 @@
 package webrtc
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/pion/datachannel"
 	"github.com/pion/logging"
+	"github.com/pion/sctp"
 	"github.com/pion/webrtc/v4/pkg/rtcerr"
 )
 
@@ -34,12 +36,19 @@ type DataChannel struct {
 	protocol                   string
 	negotiated                 bool
 	id                         *uint16
+	compression                *DataChannelCompression
+	fragmentation              *DataChannelFragmentation
+	priority                   DataChannelPriority
 	readyState                 atomic.Value // DataChannelState
 	bufferedAmountLowThreshold uint64
 	detachCalled               bool
 	readLoopActive             chan struct{}
 	isGracefulClosed           bool
 
+	// writeLock is held while a message is written, SendContext only sets the
+	// write deadline of the stream while it holds it.
+	writeLock chan struct{}
+
 	// The binaryType represents attribute MUST, on getting, return the value to
 	// which it was last set. On setting, if the new value is either the string
 	// "blob" or the string "arraybuffer", then set the IDL attribute to this
@@ -53,12 +62,62 @@ type DataChannel struct {
 	onOpenHandler       func()
 	dialHandlerOnce     sync.Once
 	onDialHandler       func()
+	onClosingHandler    func()
 	onCloseHandler      func()
 	onBufferedAmountLow func()
 	onErrorHandler      func(error)
 
 	sctpTransport *SCTPTransport
 	dataChannel   *datachannel.DataChannel
+	sctpStats     dataChannelSCTPStats
+
+	// openTimer closes the DataChannel if the remote peer doesn't acknowledge
+	// its DATA_CHANNEL_OPEN message, see SettingEngine.SetDataChannelOpenTimeout.
+	openTimer    *time.Timer
+	openTimedOut bool
+
+	// awaitingAck is true from the DATA_CHANNEL_OPEN message until the remote
+	// peer acknowledged it, OnOpen handlers set in between wait for it.
+	awaitingAck bool
+
+	// scheduledBytes are the bytes of the messages queued by the scheduler of
+	// the SCTPTransport, see SettingEngine.SetDataChannelScheduling, and by the
+	// rate limiter, see SetMaxSendRate.
+	scheduledBytes atomic.Int64
+	rateLimiter    dataChannelRateLimiter
+
+	// bufferedAmountMu guards the amounts the stream reports its buffered
+	// amount at, see checkBufferedAmount. bufferedAmountHigh is set while the
+	// BufferedAmount is above the BufferedAmountLowThreshold, transportLevel is
+	// the share of the excess of the SCTPTransport over its threshold.
+	bufferedAmountMu     sync.Mutex
+	bufferedAmountHigh   bool
+	transportLevel       uint64
+	transportLevelActive bool
+
+	// extensionMu is held while a message of a DataChannel with extensions is
+	// sent, so the start of the extensions is sent before the first message
+	// using them, see dataChannelExtensionState. The read loop only
+	// reassembles and decompresses messages once receivingExtensions is set.
+	extensionMu         sync.Mutex
+	extensionsRequested bool
+	extensionState      dataChannelExtensionState
+	receivingExtensions bool
+
+	// The read loop reassembles the received fragments in reassembly.
+	reassembly   []byte
+	reassembling bool
+
+	// streamOptionsMu is held exclusively by SendWithOptions while it changes
+	// the ordering of the stream, the stream is looked up on the first use.
+	streamOptionsMu  sync.RWMutex
+	stream           *sctp.Stream
+	ppidMessagesSent atomic.Uint32
+	ppidBytesSent    atomic.Uint64
+
+	// receiveQueue holds the received messages for the OnMessage handler, see
+	// SettingEngine.SetDataChannelReceiveQueueLimits.
+	receiveQueue dataChannelReceiveQueue
 
 	// A reference to the associated api object used by this datachannel
 	api *API
@@ -94,18 +153,32 @@ func (api *API) newDataChannel(
 		return nil, &rtcerr.TypeError{Err: ErrStringSizeLimit}
 	}
 
+	if params.Fragmentation != nil &&
+		(!params.Ordered || params.MaxPacketLifeTime != nil || params.MaxRetransmits != nil) {
+		return nil, &rtcerr.TypeError{Err: errFragmentationUnreliable}
+	}
+
+	if params.Compression != nil && !params.Negotiated && !params.Ordered {
+		return nil, &rtcerr.TypeError{Err: errCompressionUnordered}
+	}
+
 	dataChannel := &DataChannel{
-		sctpTransport:     sctpTransport,
-		statsID:           fmt.Sprintf("DataChannel-%d", time.Now().UnixNano()),
-		label:             params.Label,
-		protocol:          params.Protocol,
-		negotiated:        params.Negotiated,
-		id:                params.ID,
-		ordered:           params.Ordered,
-		maxPacketLifeTime: params.MaxPacketLifeTime,
-		maxRetransmits:    params.MaxRetransmits,
-		api:               api,
-		log:               log,
+		sctpTransport:       sctpTransport,
+		statsID:             fmt.Sprintf("DataChannel-%d", time.Now().UnixNano()),
+		label:               params.Label,
+		protocol:            params.Protocol,
+		negotiated:          params.Negotiated,
+		id:                  params.ID,
+		ordered:             params.Ordered,
+		maxPacketLifeTime:   params.MaxPacketLifeTime,
+		maxRetransmits:      params.MaxRetransmits,
+		compression:         params.Compression,
+		fragmentation:       params.Fragmentation,
+		extensionsRequested: params.Compression != nil || params.Fragmentation != nil,
+		priority:            params.Priority,
+		api:                 api,
+		log:                 log,
+		writeLock:           make(chan struct{}, 1),
 	}
 
 	dataChannel.setReadyState(DataChannelStateConnecting)
@@ -119,6 +192,7 @@ func (d *DataChannel) open(sctpTransport *SCTPTransport) error { //nolint:cyclop
 	if association == nil {
 		return errSCTPNotEstablished
 	}
+	maxChannels := sctpTransport.MaxChannels()
 
 	d.mu.Lock()
 	if d.sctpTransport != nil { // already open
@@ -156,10 +230,10 @@ func (d *DataChannel) open(sctpTransport *SCTPTransport) error { //nolint:cyclop
 
 	cfg := &datachannel.Config{
 		ChannelType:          channelType,
-		Priority:             datachannel.ChannelPriorityNormal,
+		Priority:             d.priority.dcepPriority(),
 		ReliabilityParameter: reliabilityParameter,
 		Label:                d.label,
-		Protocol:             d.protocol,
+		Protocol:             d.wireProtocol(),
 		Negotiated:           d.negotiated,
 		LoggerFactory:        d.api.settingEngine.LoggerFactory,
 	}
@@ -174,6 +248,13 @@ func (d *DataChannel) open(sctpTransport *SCTPTransport) error { //nolint:cyclop
 		}
 		d.mu.Lock()
 		d.id = dcID
+	} else if *d.id >= maxChannels {
+		id := *d.id
+		d.mu.Unlock()
+
+		return &rtcerr.OperationError{
+			Err: fmt.Errorf("%w: %d, %d streams", ErrDataChannelIDExceedsStreams, id, maxChannels),
+		}
 	}
 	dc, err := datachannel.Dial(association, *d.id, cfg)
 	if err != nil {
@@ -184,7 +265,7 @@ func (d *DataChannel) open(sctpTransport *SCTPTransport) error { //nolint:cyclop
 
 	// bufferedAmountLowThreshold and onBufferedAmountLow might be set earlier
 	dc.SetBufferedAmountLowThreshold(d.bufferedAmountLowThreshold)
-	dc.OnBufferedAmountLow(d.onBufferedAmountLow)
+	dc.OnBufferedAmountLow(d.handleBufferedAmountLow)
 	d.mu.Unlock()
 
 	d.onDial()
@@ -218,9 +299,10 @@ func (d *DataChannel) OnOpen(f func()) {
 	d.mu.Lock()
 	d.openHandlerOnce = sync.Once{}
 	d.onOpenHandler = f
+	awaitingAck := d.awaitingAck
 	d.mu.Unlock()
 
-	if d.ReadyState() == DataChannelStateOpen {
+	if d.ReadyState() == DataChannelStateOpen && !awaitingAck {
 		// If the data channel is already open, call the handler immediately.
 		go d.openHandlerOnce.Do(func() {
 			f()
@@ -276,6 +358,26 @@ func (d *DataChannel) onDial() {
 	}
 }
 
+// OnClosing sets an event handler which is invoked when the DataChannel
+// starts closing, because Close was called or the remote peer reset its
+// stream. Messages received until the stream is reset in both directions are
+// still delivered, OnClose is invoked once it is.
+func (d *DataChannel) OnClosing(f func()) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.onClosingHandler = f
+}
+
+func (d *DataChannel) onClosing() {
+	d.mu.RLock()
+	handler := d.onClosingHandler
+	d.mu.RUnlock()
+
+	if handler != nil {
+		go handler()
+	}
+}
+
 // OnClose sets an event handler which is invoked when
 // the underlying data transport has been closed.
 // Note: Due to backwards compatibility, there is a chance that
@@ -303,7 +405,8 @@ func (d *DataChannel) onClose() {
 // OnMessage can currently receive messages up to 16384 bytes
 // in size. Check out the detach API if you want to use larger
 // message sizes. Note that browser support for larger messages
-// is also limited.
+// is also limited. With SettingEngine.EnableDataChannelReadBufferReuse
+// the handler must not keep msg.Data after it returns.
 func (d *DataChannel) OnMessage(f func(msg DataChannelMessage)) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
@@ -333,28 +436,38 @@ func (d *DataChannel) handleOpen(dc *datachannel.DataChannel, isRemote, isAlread
 		if err := dc.Close(); err != nil {
 			d.log.Errorf("Failed to close DataChannel that was closed during connecting state %v", err.Error())
 		}
+		d.setReadyState(DataChannelStateClosed)
 		d.onClose()
 
 		return
 	}
 	d.dataChannel = dc
 	bufferedAmountLowThreshold := d.bufferedAmountLowThreshold
-	onBufferedAmountLow := d.onBufferedAmountLow
-	d.mu.Unlock()
-	d.setReadyState(DataChannelStateOpen)
 
 	// Fire the OnOpen handler immediately not using pion/datachannel
 	// * detached datachannels have no read loop, the user needs to read and query themselves
 	// * remote datachannels should fire OnOpened. This isn't spec compliant, but we can't break behavior yet
 	// * already negotiated datachannels should fire OnOpened
-	if d.api.settingEngine.detach.DataChannels || isRemote || isAlreadyNegotiated {
+	openImmediately := d.api.settingEngine.detach.DataChannels || isRemote || isAlreadyNegotiated
+	d.awaitingAck = !openImmediately
+	d.mu.Unlock()
+
+	if err := d.startExtensions(isRemote); err != nil {
+		d.log.Errorf("Failed to confirm DataChannel extensions: %v", err)
+	}
+	d.setReadyState(DataChannelStateOpen)
+
+	if openImmediately {
 		// bufferedAmountLowThreshold and onBufferedAmountLow might be set earlier
 		d.dataChannel.SetBufferedAmountLowThreshold(bufferedAmountLowThreshold)
-		d.dataChannel.OnBufferedAmountLow(onBufferedAmountLow)
+		d.dataChannel.OnBufferedAmountLow(d.handleBufferedAmountLow)
 		d.onOpen()
 	} else {
+		d.startOpenTimer()
 		dc.OnOpen(func() {
-			d.onOpen()
+			if d.openAcknowledged() {
+				d.onOpen()
+			}
 		})
 	}
 
@@ -402,6 +515,10 @@ func (d *DataChannel) readLoop() {
 		defer close(readLoopActive)
 	}()
 
+	// The SCTP association reassembles a message completely before it can be
+	// read, and the read fails with io.ErrShortBuffer without consuming it if the
+	// buffer is too small. pion/sctp has no partial delivery API, so large
+	// messages can't be streamed to the application until it gains one.
 	buffer := make([]byte, sctpMaxMessageSizeUnsetValue)
 	for {
 		n, isString, err := d.dataChannel.ReadDataChannel(buffer)
@@ -419,8 +536,14 @@ func (d *DataChannel) readLoop() {
 				)
 			}
 
+			// The remote peer reset its stream, all of its messages were read. If
+			// the DataChannel wasn't closed locally, it is closing now.
+			closedLocally := !errors.Is(err, io.EOF) || !d.setClosing()
+			d.receiveQueue.wait()
 			d.setReadyState(DataChannelStateClosed)
-			if !errors.Is(err, io.EOF) {
+			if errors.Is(err, io.EOF) {
+				d.releaseID(closedLocally)
+			} else {
 				d.onError(err)
 			}
 			d.onClose()
@@ -428,37 +551,271 @@ func (d *DataChannel) readLoop() {
 			return
 		}
 
-		d.onMessage(DataChannelMessage{
-			Data:     append([]byte{}, buffer[:n]...),
+		data := buffer[:n]
+		if !d.api.settingEngine.sctp.reuseReadBuffer {
+			data = append([]byte{}, data...)
+		}
+		if d.extensionsRequested && d.handleExtensionMessage(data, isString) {
+			continue
+		}
+		if d.receivingExtensions && d.fragmentation != nil {
+			var complete bool
+			if data, complete, err = d.reassembleMessage(data); err != nil {
+				d.log.Errorf("Failed to reassemble DataChannel message: %v", err)
+
+				continue
+			} else if !complete {
+				continue
+			}
+		}
+		if d.receivingExtensions && d.compression != nil {
+			if data, err = d.decompressMessage(data); err != nil {
+				d.log.Errorf("Failed to decompress DataChannel message: %v", err)
+
+				continue
+			}
+		}
+
+		d.receive(DataChannelMessage{
+			Data:     data,
 			IsString: isString,
 		})
 	}
 }
 
-// Send sends the binary message to the DataChannel peer.
+// releaseID returns the ID to the SCTPTransport once the stream was reset in
+// both directions. The remote peer reset its outgoing stream, which ended the
+// read loop. If the DataChannel was closed locally, the remote peer did so in
+// response to the reset of our outgoing stream, which it performed already.
+// Otherwise the datachannel package resets our outgoing stream only now, see
+// dataChannelIDReleaseDelay.
+func (d *DataChannel) releaseID(closedLocally bool) {
+	d.mu.RLock()
+	id, transport := d.id, d.sctpTransport
+	d.mu.RUnlock()
+
+	if id == nil || transport == nil {
+		return
+	}
+
+	if closedLocally {
+		transport.releaseDataChannelID(*id)
+	} else {
+		transport.idReleases.schedule(*id, transport.releaseDataChannelID)
+	}
+}
+
+// Send sends the binary message to the DataChannel peer. An empty message is
+// sent with the WebRTC Binary Empty PPID, see RFC 8831 Section 6.6.
 func (d *DataChannel) Send(data []byte) error {
 	err := d.ensureOpen()
 	if err != nil {
 		return err
 	}
 
-	_, err = d.dataChannel.WriteDataChannel(data, false)
-
-	return err
+	return d.sendWithExtensions(data, false, d.send)
 }
 
-// SendText sends the text message to the DataChannel peer.
+// SendText sends the text message to the DataChannel peer. An empty message is
+// sent with the WebRTC String Empty PPID.
 func (d *DataChannel) SendText(s string) error {
 	err := d.ensureOpen()
 	if err != nil {
 		return err
 	}
 
-	_, err = d.dataChannel.WriteDataChannel([]byte(s), true)
+	data := []byte(s)
+	return d.sendWithExtensions(data, true, d.send)
+}
+
+// send queues the message while the DataChannel exceeds its maximum send rate,
+// see sendUnlimited for what happens to it afterwards.
+func (d *DataChannel) send(data []byte, isString bool) error {
+	message := dataChannelScheduledMessage{dataChannel: d, data: data, isString: isString}
+	if d.rateLimiter.enqueue(message) {
+		d.checkBufferedAmount(false)
+
+		return nil
+	}
+
+	return d.sendUnlimited(message)
+}
+
+// sendUnlimited passes the message to the scheduler of the SCTPTransport, if
+// scheduling is enabled, or writes it.
+func (d *DataChannel) sendUnlimited(message dataChannelScheduledMessage) error {
+	if scheduler := d.scheduler(); scheduler != nil {
+		return scheduler.send(message)
+	}
+
+	return d.writeScheduled(message)
+}
+
+func (d *DataChannel) scheduler() *dataChannelScheduler {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.sctpTransport == nil {
+		return nil
+	}
+
+	return d.sctpTransport.scheduler
+}
+
+// writeScheduled writes the message with its context and passes the result to
+// SendContext.
+func (d *DataChannel) writeScheduled(message dataChannelScheduledMessage) error {
+	ctx := message.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// SendWithOptions changes the ordering of the stream while it writes.
+	d.streamOptionsMu.RLock()
+	err := d.writeMessageContext(ctx, message.data, message.isString, 0)
+	d.streamOptionsMu.RUnlock()
+
+	message.finish(err)
 
 	return err
 }
 
+// writeMessage writes the message with the PPID, zero selects the one of the
+// message type. The caller holds streamOptionsMu.
+func (d *DataChannel) writeMessage(data []byte, isString bool, ppid sctp.PayloadProtocolIdentifier) error {
+	return d.writeMessageContext(context.Background(), data, isString, ppid)
+}
+
+// writeMessageContext writes the message once the other writes of the
+// DataChannel returned. Once the context is done the write deadline of the
+// stream is set, which no other write can hit then, the datachannel package
+// has no write taking a context. The caller holds streamOptionsMu.
+func (d *DataChannel) writeMessageContext(
+	ctx context.Context,
+	data []byte,
+	isString bool,
+	ppid sctp.PayloadProtocolIdentifier,
+) error {
+	select {
+	case d.writeLock <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() {
+		<-d.writeLock
+	}()
+
+	if ctx.Done() == nil {
+		return d.writeMessageLocked(data, isString, ppid)
+	}
+
+	deadlineSet := make(chan struct{})
+	stop := context.AfterFunc(ctx, func() {
+		_ = d.dataChannel.SetWriteDeadline(time.Now())
+		close(deadlineSet)
+	})
+
+	err := d.writeMessageLocked(data, isString, ppid)
+
+	if !stop() {
+		<-deadlineSet
+		_ = d.dataChannel.SetWriteDeadline(time.Time{})
+		if err != nil {
+			return ctx.Err()
+		}
+	}
+
+	return err
+}
+
+// writeMessageLocked writes the message, the caller holds writeLock.
+func (d *DataChannel) writeMessageLocked(data []byte, isString bool, ppid sctp.PayloadProtocolIdentifier) error {
+	d.observeOutstandingBytes()
+	var n int
+	var err error
+	if ppid == 0 {
+		n, err = d.dataChannel.WriteDataChannel(data, isString)
+	} else {
+		n, err = d.writeStream(data, ppid)
+	}
+	d.sctpStats.sent(n)
+	if err == nil {
+		d.api.settingEngine.instrumentation.dataChannelMessageSent()
+	}
+
+	d.checkBufferedAmount(false)
+	d.mu.RLock()
+	transport := d.sctpTransport
+	d.mu.RUnlock()
+	if transport != nil {
+		transport.checkBufferedAmount(false)
+	}
+
+	return err
+}
+
+// SendContext is like Send, except that it returns the error of the context
+// once it is done. Send blocks while the SCTP send buffer is full if
+// SettingEngine.EnableDataChannelBlockWrite is used, the context stops
+// waiting. Messages queued by SetMaxSendRate or
+// SettingEngine.SetDataChannelScheduling are waited for, and dropped if the
+// context is done before they are passed to the SCTP association. The other
+// writes of the DataChannel wait for the one of SendContext to return, they
+// don't fail with it. SendContext can't be used once the DataChannel is
+// detached.
+func (d *DataChannel) SendContext(ctx context.Context, data []byte) error {
+	return d.sendContext(ctx, data, false)
+}
+
+// SendTextContext is like SendText, except that it returns the error of the
+// context once it is done, see SendContext.
+func (d *DataChannel) SendTextContext(ctx context.Context, s string) error {
+	return d.sendContext(ctx, []byte(s), true)
+}
+
+func (d *DataChannel) sendContext(ctx context.Context, data []byte, isString bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	err := d.ensureOpen()
+	if err != nil {
+		return err
+	}
+
+	d.mu.RLock()
+	detached := d.detachCalled
+	d.mu.RUnlock()
+	if detached {
+		return errSendContextDetached
+	}
+
+	return d.sendWithExtensions(data, isString, func(data []byte, isString bool) error {
+		return d.writeContext(ctx, data, isString)
+	})
+}
+
+// writeContext queues or writes the message like send, and waits until a
+// queued message is written or its context is done.
+func (d *DataChannel) writeContext(ctx context.Context, data []byte, isString bool) error {
+	message := dataChannelScheduledMessage{
+		dataChannel: d,
+		data:        data,
+		isString:    isString,
+		ctx:         ctx,
+		sent:        make(chan error, 1),
+	}
+	if !d.rateLimiter.enqueue(message) {
+		if err := d.sendUnlimited(message); err != nil {
+			return err
+		}
+	} else {
+		d.checkBufferedAmount(false)
+	}
+
+	return message.wait()
+}
+
 func (d *DataChannel) ensureOpen() error {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
@@ -480,12 +837,23 @@ func (d *DataChannel) ensureOpen() error {
 // Please refer to the data-channels-detach example and the
 // pion/datachannel documentation for the correct way to handle the
 // resulting DataChannel object.
+//
+// Writes pass the buffer to the SCTP association without copying it, the
+// association copies it once into the chunks it sends. A buffer may be
+// reused as soon as Write returns, there is no API to lend it to the
+// association until the message is acknowledged.
+//
+// Use DetachWithDeadline for read and write deadlines, or the Conn of
+// pkg/dcnet for code expecting a net.Conn.
 func (d *DataChannel) Detach() (datachannel.ReadWriteCloser, error) {
 	return d.DetachWithDeadline()
 }
 
 // DetachWithDeadline allows you to detach the underlying datachannel.
-// It is the same as Detach but returns a ReadWriteCloserDeadliner.
+// It is the same as Detach but returns a ReadWriteCloserDeadliner, its
+// SetReadDeadline and SetWriteDeadline set the deadlines of the SCTP stream
+// like the ones of a net.Conn. Writes only block, and so only time out, with
+// SettingEngine.EnableDataChannelBlockWrite.
 func (d *DataChannel) DetachWithDeadline() (datachannel.ReadWriteCloserDeadliner, error) {
 	d.mu.Lock()
 
@@ -501,6 +869,18 @@ func (d *DataChannel) DetachWithDeadline() (datachannel.ReadWriteCloserDeadliner
 		return nil, errDetachBeforeOpened
 	}
 
+	if d.compression != nil {
+		d.mu.Unlock()
+
+		return nil, errDetachCompressed
+	}
+
+	if d.fragmentation != nil {
+		d.mu.Unlock()
+
+		return nil, errDetachFragmented
+	}
+
 	d.detachCalled = true
 
 	dataChannel := d.dataChannel
@@ -550,6 +930,7 @@ func (d *DataChannel) GracefulClose() error {
 func (d *DataChannel) close(shouldGracefullyClose bool) error {
 	d.mu.Lock()
 	d.isGracefulClosed = true
+	d.stopOpenTimer()
 	readLoopActive := d.readLoopActive
 	if shouldGracefullyClose && readLoopActive != nil {
 		defer func() {
@@ -559,11 +940,10 @@ func (d *DataChannel) close(shouldGracefullyClose bool) error {
 	haveSctpTransport := d.dataChannel != nil
 	d.mu.Unlock()
 
-	if d.ReadyState() == DataChannelStateClosed {
+	if !d.setClosing() {
 		return nil
 	}
 
-	d.setReadyState(DataChannelStateClosing)
 	if !haveSctpTransport {
 		return nil
 	}
@@ -626,6 +1006,19 @@ func (d *DataChannel) Negotiated() bool {
 	return d.negotiated
 }
 
+// Priority returns the priority of the DataChannel, for DataChannels of the
+// remote peer the one it announced.
+func (d *DataChannel) Priority() DataChannelPriority {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.priority == DataChannelPriorityUnknown {
+		return DataChannelPriorityLow
+	}
+
+	return d.priority
+}
+
 // ID represents the ID for this DataChannel. The value is initially
 // null, which is what will be returned if the ID was not provided at
 // channel creation time, and the DTLS role of the SCTP transport has not
@@ -666,7 +1059,7 @@ func (d *DataChannel) BufferedAmount() uint64 {
 		return 0
 	}
 
-	return d.dataChannel.BufferedAmount()
+	return d.dataChannel.BufferedAmount() + uint64(d.scheduledBytes.Load()) //nolint:gosec // G115
 }
 
 // BufferedAmountLowThreshold represents the threshold at which the
@@ -679,24 +1072,17 @@ func (d *DataChannel) BufferedAmountLowThreshold() uint64 {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
 
-	if d.dataChannel == nil {
-		return d.bufferedAmountLowThreshold
-	}
-
-	return d.dataChannel.BufferedAmountLowThreshold()
+	return d.bufferedAmountLowThreshold
 }
 
 // SetBufferedAmountLowThreshold is used to update the threshold.
 // See BufferedAmountLowThreshold().
 func (d *DataChannel) SetBufferedAmountLowThreshold(th uint64) {
 	d.mu.Lock()
-	defer d.mu.Unlock()
-
 	d.bufferedAmountLowThreshold = th
+	d.mu.Unlock()
 
-	if d.dataChannel != nil {
-		d.dataChannel.SetBufferedAmountLowThreshold(th)
-	}
+	d.checkBufferedAmount(false)
 }
 
 // OnBufferedAmountLow sets an event handler which is invoked when
@@ -706,12 +1092,115 @@ func (d *DataChannel) OnBufferedAmountLow(f func()) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	onBufferedAmountLow := d.makeBufferedAmountLowHandler(f)
-	d.onBufferedAmountLow = onBufferedAmountLow
+	d.onBufferedAmountLow = d.makeBufferedAmountLowHandler(f)
+}
 
-	if d.dataChannel != nil {
-		d.dataChannel.OnBufferedAmountLow(onBufferedAmountLow)
+// handleBufferedAmountLow is invoked by the stream from the read loop of the
+// association when its buffered amount decreases to the threshold of the
+// stream, see checkBufferedAmount.
+func (d *DataChannel) handleBufferedAmountLow() {
+	d.checkBufferedAmount(true)
+}
+
+// checkBufferedAmount invokes the handler of OnBufferedAmountLow once the
+// BufferedAmount decreased to the BufferedAmountLowThreshold, and the
+// SCTPTransport once the stream released its share of the excess of the
+// transport. The stream only reports decreasing to a single threshold, which
+// is set to the next of these amounts. Messages queued by the scheduler and the
+// rate limiter count towards the BufferedAmount, so they call it as well once
+// they pass a message to the stream. The stream released bytes down to its
+// threshold if released is set.
+func (d *DataChannel) checkBufferedAmount(released bool) {
+	d.mu.RLock()
+	dataChannel, handler, transport := d.dataChannel, d.onBufferedAmountLow, d.sctpTransport
+	threshold := d.bufferedAmountLowThreshold
+	d.mu.RUnlock()
+
+	if dataChannel == nil {
+		return
+	}
+
+	low, transportLow := false, false
+	d.bufferedAmountMu.Lock()
+	for {
+		level := dataChannel.BufferedAmountLowThreshold()
+		buffered := dataChannel.BufferedAmount()
+		scheduled := uint64(max(d.scheduledBytes.Load(), 0)) //nolint:gosec // G115
+
+		// A detached DataChannel doesn't notice its writes, the BufferedAmount
+		// was above the threshold if the stream released bytes down to it.
+		switch {
+		case buffered+scheduled > threshold:
+			d.bufferedAmountHigh = true
+		case d.bufferedAmountHigh || released && level+scheduled >= threshold:
+			d.bufferedAmountHigh = false
+			low = true
+		}
+		released = false
+
+		if d.transportLevelActive && buffered <= d.transportLevel {
+			d.transportLevelActive = false
+			transportLow = true
+		}
+
+		next, pending := d.nextBufferedAmountLevel(threshold, scheduled)
+		dataChannel.SetBufferedAmountLowThreshold(next)
+
+		// The stream only reports decreasing to its threshold, not being below
+		// it already.
+		if !pending || dataChannel.BufferedAmount() > next {
+			break
+		}
+	}
+	d.bufferedAmountMu.Unlock()
+
+	if low && handler != nil {
+		handler()
+	}
+	if transportLow && transport != nil {
+		// The SCTPTransport takes its lock, the read loop of the association
+		// invokes this.
+		go transport.checkBufferedAmount(true)
+	}
+}
+
+// nextBufferedAmountLevel returns the threshold of the stream, the highest of
+// the amounts that have to be reported, and whether one has to be. The caller
+// holds bufferedAmountMu.
+func (d *DataChannel) nextBufferedAmountLevel(threshold, scheduled uint64) (uint64, bool) {
+	level, pending := threshold, false
+	if d.bufferedAmountHigh && threshold >= scheduled {
+		level, pending = threshold-scheduled, true
+	}
+	if d.transportLevelActive && (!pending || d.transportLevel > level) {
+		level, pending = d.transportLevel, true
+	}
+
+	return level, pending
+}
+
+// streamBufferedAmount returns the buffered amount of the stream, without the
+// messages queued by the scheduler and the rate limiter.
+func (d *DataChannel) streamBufferedAmount() uint64 {
+	d.mu.RLock()
+	dataChannel := d.dataChannel
+	d.mu.RUnlock()
+
+	if dataChannel == nil {
+		return 0
 	}
+
+	return dataChannel.BufferedAmount()
+}
+
+// setTransportBufferedAmountLevel makes the stream report once its buffered
+// amount decreased to the level, see SCTPTransport.checkBufferedAmount.
+func (d *DataChannel) setTransportBufferedAmountLevel(level uint64) {
+	d.bufferedAmountMu.Lock()
+	d.transportLevel, d.transportLevelActive = level, true
+	d.bufferedAmountMu.Unlock()
+
+	d.checkBufferedAmount(false)
 }
 
 func (d *DataChannel) makeBufferedAmountLowHandler(f func()) func() {
@@ -754,11 +1243,12 @@ func (d *DataChannel) collectStats(collector *statsReportCollector) {
 	}
 
 	if d.dataChannel != nil {
-		stats.MessagesSent = d.dataChannel.MessagesSent()
-		stats.BytesSent = d.dataChannel.BytesSent()
+		stats.MessagesSent = d.dataChannel.MessagesSent() + d.ppidMessagesSent.Load()
+		stats.BytesSent = d.dataChannel.BytesSent() + d.ppidBytesSent.Load()
 		stats.MessagesReceived = d.dataChannel.MessagesReceived()
 		stats.BytesReceived = d.dataChannel.BytesReceived()
 	}
+	stats.MessagesDropped = d.receiveQueue.dropped.Load()
 
 	collector.Collect(stats.ID, stats)
 }
@@ -766,3 +1256,21 @@ func (d *DataChannel) collectStats(collector *statsReportCollector) {
 func (d *DataChannel) setReadyState(r DataChannelState) {
 	d.readyState.Store(r)
 }
+
+// setClosing changes the ready state to closing and invokes OnClosing, unless
+// the DataChannel is closing or closed already. It returns whether it did.
+func (d *DataChannel) setClosing() bool {
+	d.mu.Lock()
+	state := d.ReadyState()
+	closing := state != DataChannelStateClosing && state != DataChannelStateClosed
+	if closing {
+		d.setReadyState(DataChannelStateClosing)
+	}
+	d.mu.Unlock()
+
+	if closing {
+		d.onClosing()
+	}
+
+	return closing
+}
@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"bytes"
+	"compress/flate"
+	"io"
+	"strings"
+	"sync"
+)
+
+const (
+	dataChannelCompressionExtension        = "permessage-deflate"
+	dataChannelCompressionSeparator        = "; "
+	defaultDataChannelCompressionThreshold = 1024
+
+	// Every message of a DataChannel with compression starts with a flag.
+	compressionFlagNone    = 0
+	compressionFlagDeflate = 1
+)
+
+//nolint:gochecknoglobals
+var flateWriterPool = sync.Pool{
+	New: func() any {
+		writer, _ := flate.NewWriter(nil, flate.DefaultCompression)
+
+		return writer
+	},
+}
+
+// parseCompressionExtension removes the compression extension from the
+// protocol of an ordered DataChannel the remote peer opened if this peer
+// accepts it, see SettingEngine.AcceptDataChannelCompression.
+func parseCompressionExtension(
+	protocol string,
+	ordered bool,
+	accept *DataChannelCompression,
+) (string, *DataChannelCompression) {
+	if accept == nil || !ordered {
+		return protocol, nil
+	}
+
+	// The fragmentation extension is kept at the end if it isn't accepted.
+	base, fragmented := cutProtocolExtension(protocol, dataChannelFragmentationExtension)
+	trimmed, ok := cutProtocolExtension(base, dataChannelCompressionExtension)
+	if !ok {
+		return protocol, nil
+	}
+	if fragmented {
+		trimmed = appendProtocolExtension(trimmed, dataChannelFragmentationExtension)
+	}
+	compression := *accept
+
+	return trimmed, &compression
+}
+
+// cutProtocolExtension removes the extension from the end of the protocol.
+func cutProtocolExtension(protocol, extension string) (string, bool) {
+	if protocol == extension {
+		return "", true
+	}
+
+	return strings.CutSuffix(protocol, dataChannelCompressionSeparator+extension)
+}
+
+// appendProtocolExtension appends the extension to the protocol.
+func appendProtocolExtension(protocol, extension string) string {
+	if protocol == "" {
+		return extension
+	}
+
+	return protocol + dataChannelCompressionSeparator + extension
+}
+
+// Compressed returns true if the messages of the DataChannel are compressed,
+// see DataChannelCompression. It is false until the remote peer confirmed the
+// compression of a DataChannel opened by this peer.
+func (d *DataChannel) Compressed() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.compression != nil && d.extensionState >= dataChannelExtensionsConfirmed
+}
+
+// wireProtocol returns the protocol announced to the remote peer. The remote
+// peer removes the extensions from the end, fragmentation first.
+func (d *DataChannel) wireProtocol() string {
+	if d.negotiated {
+		return d.protocol
+	}
+
+	protocol := d.protocol
+	if d.compression != nil {
+		protocol = appendProtocolExtension(protocol, dataChannelCompressionExtension)
+	}
+	if d.fragmentation != nil {
+		protocol = appendProtocolExtension(protocol, dataChannelFragmentationExtension)
+	}
+
+	return protocol
+}
+
+func (d *DataChannel) compressMessage(data []byte) []byte {
+	threshold := d.compression.Threshold
+	if threshold == 0 {
+		threshold = defaultDataChannelCompressionThreshold
+	}
+
+	if len(data) >= threshold {
+		compressed := bytes.NewBuffer(make([]byte, 0, len(data)))
+		compressed.WriteByte(compressionFlagDeflate)
+
+		writer, _ := flateWriterPool.Get().(*flate.Writer)
+		writer.Reset(compressed)
+		_, writeErr := writer.Write(data)
+		closeErr := writer.Close()
+		flateWriterPool.Put(writer)
+
+		if writeErr == nil && closeErr == nil && compressed.Len() < len(data)+1 {
+			return compressed.Bytes()
+		}
+	}
+
+	return append([]byte{compressionFlagNone}, data...)
+}
+
+func (d *DataChannel) decompressMessage(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return data, nil
+	}
+
+	switch data[0] {
+	case compressionFlagNone:
+		return data[1:], nil
+	case compressionFlagDeflate:
+	default:
+		return nil, errInvalidCompressedMessage
+	}
+
+	maxMessageSize := int64(d.maxReceivedMessageSize())
+	reader := flate.NewReader(bytes.NewReader(data[1:]))
+	decompressed, err := io.ReadAll(io.LimitReader(reader, maxMessageSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(decompressed)) > maxMessageSize {
+		return nil, errDecompressedMessageTooLarge
+	}
+
+	return decompressed, reader.Close()
+}
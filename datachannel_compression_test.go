@@ -0,0 +1,249 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCompressionExtension(t *testing.T) {
+	accept := &DataChannelCompression{Threshold: 16}
+	for protocol, expected := range map[string]string{
+		"permessage-deflate":       "",
+		"chat; permessage-deflate": "chat",
+	} {
+		parsed, compression := parseCompressionExtension(protocol, true, accept)
+		assert.Equal(t, expected, parsed)
+		assert.Equal(t, accept, compression)
+	}
+
+	parsed, compression := parseCompressionExtension("chat", true, accept)
+	assert.Equal(t, "chat", parsed)
+	assert.Nil(t, compression)
+
+	// The extension is kept if it isn't accepted.
+	parsed, compression = parseCompressionExtension("chat; permessage-deflate", true, nil)
+	assert.Equal(t, "chat; permessage-deflate", parsed)
+	assert.Nil(t, compression)
+
+	parsed, compression = parseCompressionExtension("chat; permessage-deflate", false, accept)
+	assert.Equal(t, "chat; permessage-deflate", parsed)
+	assert.Nil(t, compression)
+
+	parsed, compression = parseCompressionExtension("chat; permessage-deflate; pion-fragmentation", true, accept)
+	assert.Equal(t, "chat; pion-fragmentation", parsed)
+	assert.Equal(t, accept, compression)
+}
+
+func TestDataChannel_Compression(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	settingEngine := SettingEngine{}
+	settingEngine.AcceptDataChannelCompression(&DataChannelCompression{Threshold: 16})
+	pcOffer, pcAnswer, err := NewAPI(WithSettingEngine(settingEngine)).newPair(Configuration{})
+	require.NoError(t, err)
+
+	protocol := "chat"
+	offerChannel, err := pcOffer.CreateDataChannel("compressed", &DataChannelInit{
+		Protocol:    &protocol,
+		Compression: &DataChannelCompression{Threshold: 16},
+	})
+	require.NoError(t, err)
+	assert.False(t, offerChannel.Compressed(), "compressed before the remote peer confirmed it")
+
+	offerReceived := make(chan DataChannelMessage, 3)
+	offerChannel.OnMessage(func(msg DataChannelMessage) {
+		offerReceived <- msg
+	})
+	offerOpened := make(chan struct{})
+	offerChannel.OnOpen(func() {
+		close(offerOpened)
+	})
+
+	answerReceived := make(chan DataChannelMessage, 3)
+	answerChannels := make(chan *DataChannel, 1)
+	pcAnswer.OnDataChannel(func(d *DataChannel) {
+		if d.Label() != "compressed" {
+			return
+		}
+		d.OnMessage(func(msg DataChannelMessage) {
+			answerReceived <- msg
+		})
+		answerChannels <- d
+	})
+
+	require.NoError(t, signalPair(pcOffer, pcAnswer))
+	answerChannel := <-answerChannels
+	assert.Equal(t, "chat", answerChannel.Protocol())
+	assert.Eventually(t, answerChannel.Compressed, 5*time.Second, 10*time.Millisecond)
+
+	<-offerOpened
+	assert.Eventually(t, offerChannel.Compressed, 5*time.Second, 10*time.Millisecond)
+
+	large := strings.Repeat("compressible text ", 1000)
+	require.NoError(t, offerChannel.SendText(large))
+	require.NoError(t, offerChannel.Send([]byte("small")))
+	require.NoError(t, offerChannel.Send(nil))
+
+	assert.Equal(t, DataChannelMessage{IsString: true, Data: []byte(large)}, <-answerReceived)
+	assert.Equal(t, DataChannelMessage{Data: []byte("small")}, <-answerReceived)
+	assert.Equal(t, DataChannelMessage{Data: []byte{}}, <-answerReceived)
+	assert.Less(t, offerChannel.dataChannel.BytesSent(), uint64(len(large)/10))
+
+	// The answerer compresses its messages as well.
+	require.NoError(t, answerChannel.Send([]byte(large)))
+	assert.Equal(t, DataChannelMessage{Data: []byte(large)}, <-offerReceived)
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
+
+func TestDataChannel_CompressionNotAccepted(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	// Only the offerer enables compression, like a remote peer that doesn't
+	// know the extension.
+	pcOffer, pcAnswer, err := newPair()
+	require.NoError(t, err)
+
+	protocol := "chat"
+	offerChannel, err := pcOffer.CreateDataChannel("compressed", &DataChannelInit{
+		Protocol:    &protocol,
+		Compression: &DataChannelCompression{Threshold: 16},
+	})
+	require.NoError(t, err)
+
+	offerReceived := make(chan DataChannelMessage, 1)
+	offerChannel.OnMessage(func(msg DataChannelMessage) {
+		offerReceived <- msg
+	})
+	offerOpened := make(chan struct{})
+	offerChannel.OnOpen(func() {
+		close(offerOpened)
+	})
+
+	answerReceived := make(chan DataChannelMessage, 2)
+	answerChannels := make(chan *DataChannel, 1)
+	pcAnswer.OnDataChannel(func(d *DataChannel) {
+		if d.Label() != "compressed" {
+			return
+		}
+		d.OnMessage(func(msg DataChannelMessage) {
+			answerReceived <- msg
+		})
+		answerChannels <- d
+	})
+
+	require.NoError(t, signalPair(pcOffer, pcAnswer))
+	answerChannel := <-answerChannels
+	assert.Equal(t, "chat; permessage-deflate", answerChannel.Protocol())
+	assert.False(t, answerChannel.Compressed())
+	<-offerOpened
+
+	// Messages are sent as they are, without the flag byte.
+	large := strings.Repeat("compressible text ", 1000)
+	require.NoError(t, offerChannel.SendText(large))
+	assert.Equal(t, DataChannelMessage{IsString: true, Data: []byte(large)}, <-answerReceived)
+
+	require.NoError(t, answerChannel.Send([]byte("reply")))
+	assert.Equal(t, DataChannelMessage{Data: []byte("reply")}, <-offerReceived)
+	assert.False(t, offerChannel.Compressed())
+
+	require.NoError(t, offerChannel.Send([]byte(large)))
+	assert.Equal(t, DataChannelMessage{Data: []byte(large)}, <-answerReceived)
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
+
+func TestDataChannel_CompressionUnordered(t *testing.T) {
+	pc, err := NewPeerConnection(Configuration{})
+	require.NoError(t, err)
+
+	ordered := false
+	_, err = pc.CreateDataChannel("unordered", &DataChannelInit{
+		Ordered:     &ordered,
+		Compression: &DataChannelCompression{},
+	})
+	assert.ErrorIs(t, err, errCompressionUnordered)
+
+	// Negotiated DataChannels don't confirm the compression in-band.
+	negotiated, id := true, uint16(1)
+	_, err = pc.CreateDataChannel("negotiated", &DataChannelInit{
+		Ordered:     &ordered,
+		Negotiated:  &negotiated,
+		ID:          &id,
+		Compression: &DataChannelCompression{},
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, pc.Close())
+}
+
+func TestDataChannel_CompressionDetach(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	settingEngine := SettingEngine{}
+	settingEngine.DetachDataChannels()
+	pcOffer, pcAnswer, err := NewAPI(WithSettingEngine(settingEngine)).newPair(Configuration{})
+	require.NoError(t, err)
+
+	offerChannel, err := pcOffer.CreateDataChannel("compressed", &DataChannelInit{
+		Compression: &DataChannelCompression{},
+	})
+	require.NoError(t, err)
+
+	detached := make(chan error, 1)
+	offerChannel.OnOpen(func() {
+		_, detachErr := offerChannel.Detach()
+		detached <- detachErr
+	})
+
+	require.NoError(t, signalPair(pcOffer, pcAnswer))
+	assert.ErrorIs(t, <-detached, errDetachCompressed)
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
+
+func TestDataChannel_compressMessage(t *testing.T) {
+	channel := &DataChannel{
+		api:         NewAPI(),
+		compression: &DataChannelCompression{Threshold: 8},
+	}
+
+	large := []byte(strings.Repeat("a", 4096))
+	compressed := channel.compressMessage(large)
+	assert.Less(t, len(compressed), len(large)/10)
+	decompressed, err := channel.decompressMessage(compressed)
+	require.NoError(t, err)
+	assert.Equal(t, large, decompressed)
+
+	// Messages below the threshold are only prefixed.
+	assert.Equal(t, []byte{compressionFlagNone, 's'}, channel.compressMessage([]byte("s")))
+
+	_, err = channel.decompressMessage([]byte{0xFF, 0x00})
+	assert.ErrorIs(t, err, errInvalidCompressedMessage)
+
+	tooLarge := channel.compressMessage(make([]byte, channel.api.settingEngine.getSCTPMaxMessageSize()+1))
+	_, err = channel.decompressMessage(tooLarge)
+	assert.ErrorIs(t, err, errDecompressedMessageTooLarge)
+}
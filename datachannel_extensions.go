@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+)
+
+// dataChannelExtensionState is the state of the negotiation of the extensions
+// announced in the protocol of a DATA_CHANNEL_OPEN message, like compression
+// and fragmentation.
+// The accepting peer confirms the extensions it uses with the first message it
+// sends, the opening peer starts using them with a message of its own. Messages
+// sent before are passed through unchanged, so a peer that doesn't confirm the
+// extensions never receives a message that uses them. Negotiated DataChannels
+// use the extensions both peers enabled from the start.
+type dataChannelExtensionState int
+
+const (
+	// dataChannelExtensionsNone is the state of DataChannels without
+	// extensions, or whose remote peer didn't confirm them.
+	dataChannelExtensionsNone dataChannelExtensionState = iota
+
+	// dataChannelExtensionsOffered is the state of DataChannels opened with
+	// extensions until the first message of the remote peer is read.
+	dataChannelExtensionsOffered
+
+	// dataChannelExtensionsConfirmed is the state of DataChannels whose remote
+	// peer confirmed the extensions until the start is sent with the next
+	// message.
+	dataChannelExtensionsConfirmed
+
+	// dataChannelExtensionsActive is the state of DataChannels that send their
+	// messages with the extensions.
+	dataChannelExtensionsActive
+)
+
+const (
+	// dataChannelExtensionsAccept is followed by the extensions the accepting
+	// peer uses, separated by dataChannelExtensionsSeparator.
+	dataChannelExtensionsAccept    = "\x00pion-extensions-accept:"
+	dataChannelExtensionsStart     = "\x00pion-extensions-start"
+	dataChannelExtensionsSeparator = ","
+)
+
+// offeredExtensions returns the extensions of the DataChannel, the ones it
+// announced when opening it or accepted when the remote peer opened it.
+func (d *DataChannel) offeredExtensions() []string {
+	var extensions []string
+	if d.compression != nil {
+		extensions = append(extensions, dataChannelCompressionExtension)
+	}
+	if d.fragmentation != nil {
+		extensions = append(extensions, dataChannelFragmentationExtension)
+	}
+
+	return extensions
+}
+
+// startExtensions is called once the DataChannel is opened, before it is open.
+// The accepting peer confirms the extensions with its first message.
+func (d *DataChannel) startExtensions(isRemote bool) error {
+	if !d.extensionsRequested {
+		return nil
+	}
+
+	state, receiving := dataChannelExtensionsOffered, false
+	switch {
+	case d.negotiated:
+		state, receiving = dataChannelExtensionsActive, true
+	case isRemote:
+		accept := dataChannelExtensionsAccept + strings.Join(d.offeredExtensions(), dataChannelExtensionsSeparator)
+		if _, err := d.dataChannel.WriteDataChannel([]byte(accept), false); err != nil {
+			return err
+		}
+		state = dataChannelExtensionsActive
+	}
+
+	d.extensionMu.Lock()
+	defer d.extensionMu.Unlock()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.extensionState = state
+	d.receivingExtensions = receiving
+
+	return nil
+}
+
+// sendWithExtensions passes the message to send, compressed and fragmented
+// once the remote peer confirmed the extensions. The start of the extensions
+// is sent before the first message using them, the fragments of other
+// messages aren't sent in between.
+func (d *DataChannel) sendWithExtensions(data []byte, isString bool, send func([]byte, bool) error) error {
+	if !d.extensionsRequested {
+		return send(data, isString)
+	}
+
+	d.extensionMu.Lock()
+	defer d.extensionMu.Unlock()
+
+	if d.extensionState == dataChannelExtensionsConfirmed {
+		if err := d.send([]byte(dataChannelExtensionsStart), false); err != nil {
+			return err
+		}
+
+		d.mu.Lock()
+		d.extensionState = dataChannelExtensionsActive
+		d.mu.Unlock()
+	}
+
+	if d.extensionState != dataChannelExtensionsActive {
+		return send(data, isString)
+	}
+
+	if d.compression != nil {
+		data = d.compressMessage(data)
+	}
+	if d.fragmentation != nil {
+		return d.sendFragmented(data, isString, send)
+	}
+
+	return send(data, isString)
+}
+
+// handleExtensionMessage is called from the read loop with every message of a
+// DataChannel with extensions, it returns true for the messages of the
+// negotiation.
+func (d *DataChannel) handleExtensionMessage(data []byte, isString bool) bool {
+	d.mu.RLock()
+	state := d.extensionState
+	d.mu.RUnlock()
+
+	switch {
+	case state == dataChannelExtensionsOffered:
+		accepted, ok := bytes.CutPrefix(data, []byte(dataChannelExtensionsAccept))
+		extensions := strings.Split(string(accepted), dataChannelExtensionsSeparator)
+
+		d.extensionMu.Lock()
+		defer d.extensionMu.Unlock()
+		d.mu.Lock()
+		defer d.mu.Unlock()
+
+		if !ok || isString || !slices.Contains(extensions, dataChannelCompressionExtension) {
+			d.compression = nil
+		}
+		if !ok || isString || !slices.Contains(extensions, dataChannelFragmentationExtension) {
+			d.fragmentation = nil
+		}
+		if d.compression == nil && d.fragmentation == nil {
+			d.extensionState = dataChannelExtensionsNone
+
+			return ok && !isString
+		}
+		d.extensionState = dataChannelExtensionsConfirmed
+		d.receivingExtensions = true
+
+		return true
+	case !d.receivingExtensions && !isString && string(data) == dataChannelExtensionsStart:
+		d.receivingExtensions = true
+
+		return true
+	default:
+		return false
+	}
+}
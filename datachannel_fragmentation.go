@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+const (
+	dataChannelFragmentationExtension = "pion-fragmentation"
+
+	defaultDataChannelFragmentSize       = 16384
+	defaultDataChannelReassembledMaxSize = 16 << 20
+
+	// Every message of a DataChannel with fragmentation starts with flags, a
+	// message that fits into one fragment has both. A fragment with the discard
+	// flag and nothing else drops the message sent before it was completed.
+	fragmentFlagFirst   = 1 << 0
+	fragmentFlagLast    = 1 << 1
+	fragmentFlagDiscard = 1 << 2
+)
+
+// parseFragmentationExtension removes the fragmentation extension from the
+// protocol of a reliable and ordered DataChannel the remote peer opened if this
+// peer accepts it, see SettingEngine.AcceptDataChannelFragmentation.
+func parseFragmentationExtension(
+	protocol string,
+	reliable bool,
+	accept *DataChannelFragmentation,
+) (string, *DataChannelFragmentation) {
+	if accept == nil || !reliable {
+		return protocol, nil
+	}
+	if trimmed, ok := cutProtocolExtension(protocol, dataChannelFragmentationExtension); ok {
+		fragmentation := *accept
+
+		return trimmed, &fragmentation
+	}
+
+	return protocol, nil
+}
+
+// Fragmented returns true if the messages of the DataChannel are fragmented,
+// see DataChannelFragmentation. It is false until the remote peer confirmed the
+// fragmentation of a DataChannel opened by this peer.
+func (d *DataChannel) Fragmented() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.fragmentation != nil && d.extensionState >= dataChannelExtensionsConfirmed
+}
+
+// fragmentPayloadSize returns the number of message bytes per fragment.
+func (d *DataChannel) fragmentPayloadSize() int {
+	size := d.fragmentation.FragmentSize
+	if size <= 0 {
+		size = defaultDataChannelFragmentSize
+	}
+
+	if transport := d.Transport(); transport != nil {
+		if maxMessageSize := transport.GetCapabilities().MaxMessageSize; maxMessageSize != 0 {
+			size = min(size, int(maxMessageSize))
+		}
+	}
+
+	return max(size-1, 1)
+}
+
+// sendFragmented sends the message in fragments with send. If sending a
+// fragment fails, like because the context of SendContext is done, after the
+// first one was sent, a discard fragment is sent so the remote peer drops the
+// incomplete message.
+func (d *DataChannel) sendFragmented(data []byte, isString bool, send func([]byte, bool) error) error {
+	size := d.fragmentPayloadSize()
+
+	for offset := 0; ; {
+		end := min(offset+size, len(data))
+
+		var flags byte
+		if offset == 0 {
+			flags |= fragmentFlagFirst
+		}
+		if end == len(data) {
+			flags |= fragmentFlagLast
+		}
+
+		fragment := make([]byte, 0, end-offset+1)
+		fragment = append(fragment, flags)
+		fragment = append(fragment, data[offset:end]...)
+		if err := send(fragment, isString); err != nil {
+			if offset != 0 {
+				if discardErr := d.send([]byte{fragmentFlagDiscard}, isString); discardErr != nil {
+					d.log.Warnf("Failed to discard incomplete DataChannel message: %v", discardErr)
+				}
+			}
+
+			return err
+		}
+
+		if end == len(data) {
+			return nil
+		}
+		offset = end
+	}
+}
+
+// maxReceivedMessageSize returns the size of the largest message accepted, the
+// maximum message size of the SCTP transport unless messages are fragmented.
+func (d *DataChannel) maxReceivedMessageSize() int {
+	if d.fragmentation == nil {
+		return int(d.api.settingEngine.getSCTPMaxMessageSize())
+	}
+	if d.fragmentation.MaxMessageSize <= 0 {
+		return defaultDataChannelReassembledMaxSize
+	}
+
+	return d.fragmentation.MaxMessageSize
+}
+
+// reassembleMessage is called from the read loop with every fragment, it
+// returns the message once its last fragment is read. A message that wasn't
+// completed, like because sending it failed, is dropped by a discard fragment
+// or the first fragment of the next one.
+func (d *DataChannel) reassembleMessage(fragment []byte) ([]byte, bool, error) {
+	if len(fragment) == 0 {
+		return nil, false, errInvalidFragment
+	}
+
+	flags, payload := fragment[0], fragment[1:]
+	switch {
+	case flags == fragmentFlagDiscard && len(payload) == 0:
+		d.reassembly = nil
+		d.reassembling = false
+
+		return nil, false, nil
+	case flags&fragmentFlagFirst != 0:
+		d.reassembly = d.reassembly[:0]
+		d.reassembling = true
+	case !d.reassembling:
+		return nil, false, errInvalidFragment
+	}
+
+	if flags&fragmentFlagLast != 0 && len(d.reassembly) == 0 {
+		d.reassembling = false
+
+		return payload, true, nil
+	}
+
+	if len(d.reassembly)+len(payload) > d.maxReceivedMessageSize() {
+		d.reassembly = nil
+		d.reassembling = false
+
+		return nil, false, errFragmentedMessageTooLarge
+	}
+
+	d.reassembly = append(d.reassembly, payload...)
+	if flags&fragmentFlagLast == 0 {
+		return nil, false, nil
+	}
+
+	message := d.reassembly
+	d.reassembly = nil
+	d.reassembling = false
+
+	return message, true, nil
+}
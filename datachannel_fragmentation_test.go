@@ -0,0 +1,297 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFragmentationExtension(t *testing.T) {
+	accept := &DataChannelFragmentation{FragmentSize: 1024}
+	for protocol, expected := range map[string]string{
+		"pion-fragmentation":                           "",
+		"chat; pion-fragmentation":                     "chat",
+		"chat; permessage-deflate; pion-fragmentation": "chat; permessage-deflate",
+	} {
+		parsed, fragmentation := parseFragmentationExtension(protocol, true, accept)
+		assert.Equal(t, expected, parsed)
+		assert.Equal(t, accept, fragmentation)
+	}
+
+	parsed, fragmentation := parseFragmentationExtension("chat; permessage-deflate", true, accept)
+	assert.Equal(t, "chat; permessage-deflate", parsed)
+	assert.Nil(t, fragmentation)
+
+	// The extension is kept if it isn't accepted.
+	parsed, fragmentation = parseFragmentationExtension("chat; pion-fragmentation", true, nil)
+	assert.Equal(t, "chat; pion-fragmentation", parsed)
+	assert.Nil(t, fragmentation)
+
+	parsed, fragmentation = parseFragmentationExtension("chat; pion-fragmentation", false, accept)
+	assert.Equal(t, "chat; pion-fragmentation", parsed)
+	assert.Nil(t, fragmentation)
+}
+
+func TestDataChannel_Fragmentation(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	// The answerer accepts messages of at most 4KiB.
+	settingEngine := SettingEngine{}
+	settingEngine.SetSCTPMaxMessageSize(4096)
+	settingEngine.AcceptDataChannelCompression(&DataChannelCompression{})
+	settingEngine.AcceptDataChannelFragmentation(&DataChannelFragmentation{})
+	pcOffer, err := NewPeerConnection(Configuration{})
+	require.NoError(t, err)
+	pcAnswer, err := NewAPI(WithSettingEngine(settingEngine)).NewPeerConnection(Configuration{})
+	require.NoError(t, err)
+
+	protocol := "chat"
+	offerChannel, err := pcOffer.CreateDataChannel("fragmented", &DataChannelInit{
+		Protocol:      &protocol,
+		Compression:   &DataChannelCompression{},
+		Fragmentation: &DataChannelFragmentation{},
+	})
+	require.NoError(t, err)
+	assert.False(t, offerChannel.Fragmented(), "fragmented before the remote peer confirmed it")
+
+	offerReceived := make(chan DataChannelMessage, 1)
+	offerChannel.OnMessage(func(msg DataChannelMessage) {
+		offerReceived <- msg
+	})
+	offerOpened := make(chan struct{})
+	offerChannel.OnOpen(func() {
+		close(offerOpened)
+	})
+
+	answerReceived := make(chan DataChannelMessage, 3)
+	answerChannels := make(chan *DataChannel, 1)
+	pcAnswer.OnDataChannel(func(d *DataChannel) {
+		d.OnMessage(func(msg DataChannelMessage) {
+			answerReceived <- msg
+		})
+		answerChannels <- d
+	})
+
+	require.NoError(t, signalPair(pcOffer, pcAnswer))
+	answerChannel := <-answerChannels
+	assert.Equal(t, "chat", answerChannel.Protocol())
+	assert.Eventually(t, answerChannel.Compressed, 5*time.Second, 10*time.Millisecond)
+	assert.True(t, answerChannel.Fragmented())
+
+	<-offerOpened
+	assert.Eventually(t, offerChannel.Fragmented, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 4095, offerChannel.fragmentPayloadSize())
+
+	large := bytes.Repeat([]byte{0, 1, 2, 3, 4, 5, 6, 7}, 32*1024)
+	text := strings.Repeat("fragmented text ", 1000)
+	require.NoError(t, offerChannel.Send(large))
+	require.NoError(t, offerChannel.SendText(text))
+	require.NoError(t, offerChannel.Send(nil))
+
+	assert.Equal(t, DataChannelMessage{Data: large}, <-answerReceived)
+	assert.Equal(t, DataChannelMessage{IsString: true, Data: []byte(text)}, <-answerReceived)
+	assert.Equal(t, DataChannelMessage{Data: []byte{}}, <-answerReceived)
+
+	// The answerer fragments its messages as well.
+	require.NoError(t, answerChannel.SendContext(t.Context(), large))
+	assert.Equal(t, DataChannelMessage{Data: large}, <-offerReceived)
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
+
+func TestDataChannel_FragmentationNotAccepted(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	// Only the offerer enables fragmentation, the answerer accepts compression.
+	settingEngine := SettingEngine{}
+	settingEngine.AcceptDataChannelCompression(&DataChannelCompression{})
+	pcOffer, pcAnswer, err := NewAPI(WithSettingEngine(settingEngine)).newPair(Configuration{})
+	require.NoError(t, err)
+
+	offerChannel, err := pcOffer.CreateDataChannel("fragmented", &DataChannelInit{
+		Compression:   &DataChannelCompression{Threshold: 16},
+		Fragmentation: &DataChannelFragmentation{FragmentSize: 64},
+	})
+	require.NoError(t, err)
+
+	offerOpened := make(chan struct{})
+	offerChannel.OnOpen(func() {
+		close(offerOpened)
+	})
+
+	answerReceived := make(chan DataChannelMessage, 1)
+	answerChannels := make(chan *DataChannel, 1)
+	pcAnswer.OnDataChannel(func(d *DataChannel) {
+		if d.Label() != "fragmented" {
+			return
+		}
+		d.OnMessage(func(msg DataChannelMessage) {
+			answerReceived <- msg
+		})
+		answerChannels <- d
+	})
+
+	require.NoError(t, signalPair(pcOffer, pcAnswer))
+	answerChannel := <-answerChannels
+	assert.Equal(t, "pion-fragmentation", answerChannel.Protocol())
+	assert.False(t, answerChannel.Fragmented())
+
+	<-offerOpened
+	assert.Eventually(t, offerChannel.Compressed, 5*time.Second, 10*time.Millisecond)
+	assert.False(t, offerChannel.Fragmented())
+
+	// The message is compressed, but sent in one piece.
+	text := strings.Repeat("compressible text ", 100)
+	require.NoError(t, offerChannel.SendText(text))
+	assert.Equal(t, DataChannelMessage{IsString: true, Data: []byte(text)}, <-answerReceived)
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
+
+func TestDataChannel_FragmentationDiscard(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	settingEngine := SettingEngine{}
+	settingEngine.AcceptDataChannelFragmentation(&DataChannelFragmentation{})
+	pcOffer, pcAnswer, err := NewAPI(WithSettingEngine(settingEngine)).newPair(Configuration{})
+	require.NoError(t, err)
+
+	offerChannel, err := pcOffer.CreateDataChannel("fragmented", &DataChannelInit{
+		Fragmentation: &DataChannelFragmentation{FragmentSize: 4},
+	})
+	require.NoError(t, err)
+
+	offerOpened := make(chan struct{})
+	offerChannel.OnOpen(func() {
+		close(offerOpened)
+	})
+
+	answerReceived := make(chan DataChannelMessage, 2)
+	pcAnswer.OnDataChannel(func(d *DataChannel) {
+		if d.Label() != "fragmented" {
+			return
+		}
+		d.OnMessage(func(msg DataChannelMessage) {
+			answerReceived <- msg
+		})
+	})
+
+	require.NoError(t, signalPair(pcOffer, pcAnswer))
+	<-offerOpened
+	assert.Eventually(t, offerChannel.Fragmented, 5*time.Second, 10*time.Millisecond)
+
+	// Sending fails after the first fragment, like a SendContext whose context
+	// is done, then the remote peer drops the incomplete message.
+	errSend := errors.New("send failed") //nolint:err113
+	sent := 0
+	err = offerChannel.sendWithExtensions([]byte("incomplete"), false, func(fragment []byte, isString bool) error {
+		if sent++; sent > 1 {
+			return errSend
+		}
+
+		return offerChannel.send(fragment, isString)
+	})
+	assert.ErrorIs(t, err, errSend)
+
+	require.NoError(t, offerChannel.sendWithExtensions([]byte("complete"), false, offerChannel.send))
+	assert.Equal(t, DataChannelMessage{Data: []byte("complete")}, <-answerReceived)
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
+
+func TestDataChannel_FragmentationUnreliable(t *testing.T) {
+	pc, err := NewPeerConnection(Configuration{})
+	require.NoError(t, err)
+
+	ordered := false
+	_, err = pc.CreateDataChannel("unordered", &DataChannelInit{
+		Ordered:       &ordered,
+		Fragmentation: &DataChannelFragmentation{},
+	})
+	assert.ErrorIs(t, err, errFragmentationUnreliable)
+
+	maxRetransmits := uint16(0)
+	_, err = pc.CreateDataChannel("unreliable", &DataChannelInit{
+		MaxRetransmits: &maxRetransmits,
+		Fragmentation:  &DataChannelFragmentation{},
+	})
+	assert.ErrorIs(t, err, errFragmentationUnreliable)
+
+	assert.NoError(t, pc.Close())
+}
+
+func TestDataChannel_reassembleMessage(t *testing.T) {
+	channel := &DataChannel{fragmentation: &DataChannelFragmentation{MaxMessageSize: 8}}
+
+	var fragments [][]byte
+	channel.fragmentation.FragmentSize = 4
+	require.NoError(t, channel.sendFragmented([]byte("abcdefg"), false, func(fragment []byte, _ bool) error {
+		fragments = append(fragments, fragment)
+
+		return nil
+	}))
+	assert.Equal(t, [][]byte{
+		append([]byte{fragmentFlagFirst}, "abc"...),
+		append([]byte{0}, "def"...),
+		append([]byte{fragmentFlagLast}, "g"...),
+	}, fragments)
+
+	for i, fragment := range fragments {
+		message, complete, err := channel.reassembleMessage(fragment)
+		require.NoError(t, err)
+		assert.Equal(t, i == len(fragments)-1, complete)
+		if complete {
+			assert.Equal(t, []byte("abcdefg"), message)
+		}
+	}
+
+	// An incomplete message is dropped by the next one.
+	_, _, err := channel.reassembleMessage(fragments[0])
+	require.NoError(t, err)
+	message, complete, err := channel.reassembleMessage([]byte{fragmentFlagFirst | fragmentFlagLast, 'z'})
+	require.NoError(t, err)
+	assert.True(t, complete)
+	assert.Equal(t, []byte("z"), message)
+
+	_, _, err = channel.reassembleMessage([]byte{fragmentFlagLast, 'z'})
+	assert.ErrorIs(t, err, errInvalidFragment)
+
+	// A discard fragment drops the incomplete message.
+	_, _, err = channel.reassembleMessage(fragments[0])
+	require.NoError(t, err)
+	_, complete, err = channel.reassembleMessage([]byte{fragmentFlagDiscard})
+	require.NoError(t, err)
+	assert.False(t, complete)
+	_, _, err = channel.reassembleMessage(fragments[2])
+	assert.ErrorIs(t, err, errInvalidFragment)
+	_, _, err = channel.reassembleMessage(nil)
+	assert.ErrorIs(t, err, errInvalidFragment)
+
+	_, _, err = channel.reassembleMessage(append([]byte{fragmentFlagFirst}, "abcde"...))
+	require.NoError(t, err)
+	_, _, err = channel.reassembleMessage(append([]byte{fragmentFlagLast}, "fghi"...))
+	assert.ErrorIs(t, err, errFragmentedMessageTooLarge)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/pion/logging"
 	"github.com/pion/transport/v4/test"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDataChannel_EventHandlers(t *testing.T) {
@@ -900,3 +901,514 @@ func TestOnBufferedAmountLowRespectsReadyState(t *testing.T) {
 		}
 	})
 }
+
+func TestDataChannel_QueueLimits(t *testing.T) {
+	lim := test.TimeOut(time.Second * 20)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	s := SettingEngine{}
+	s.SetDataChannelQueueLimits(3, 8)
+	offerPC, answerPC, err := NewAPI(WithSettingEngine(s)).newPair(Configuration{})
+	assert.NoError(t, err)
+
+	var queued []*DataChannel
+	for _, label := range []string{"a", "b", "c"} {
+		dc, createErr := offerPC.CreateDataChannel(label, nil)
+		assert.NoError(t, createErr)
+		queued = append(queued, dc)
+	}
+
+	_, err = offerPC.CreateDataChannel("d", nil)
+	assert.ErrorIs(t, err, ErrDataChannelQueueChannelsExceeded)
+
+	// A closed DataChannel is no longer queued.
+	assert.NoError(t, queued[2].Close())
+	queued = queued[:2]
+
+	_, err = offerPC.CreateDataChannel("too long", nil)
+	assert.ErrorIs(t, err, ErrDataChannelQueueBytesExceeded)
+
+	dc, err := offerPC.CreateDataChannel("c", nil)
+	assert.NoError(t, err)
+	queued = append(queued, dc)
+
+	var opened sync.WaitGroup
+	opened.Add(len(queued))
+	for _, dc := range queued {
+		dc.OnOpen(opened.Done)
+	}
+
+	assert.NoError(t, signalPairWithOptions(offerPC, answerPC, withDisableInitialDataChannel(true)))
+	opened.Wait()
+
+	// Queued DataChannels are opened in the order they were created.
+	for i := 1; i < len(queued); i++ {
+		assert.Less(t, *queued[i-1].ID(), *queued[i].ID())
+	}
+
+	// The limits only apply until the SCTP association is established.
+	for _, label := range []string{"d", "too long"} {
+		_, err = offerPC.CreateDataChannel(label, nil)
+		assert.NoError(t, err)
+	}
+
+	closePairNow(t, offerPC, answerPC)
+}
+
+func TestDataChannel_ReleaseIDOnClose(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	offerPC, answerPC, err := newPair()
+	require.NoError(t, err)
+	offerPC.sctpTransport.idReleases.delay = 100 * time.Millisecond
+
+	remoteChannels := make(chan *DataChannel, 1)
+	answerPC.OnDataChannel(func(d *DataChannel) {
+		if d.Label() == "churn" {
+			remoteChannels <- d
+		}
+	})
+	require.NoError(t, signalPair(offerPC, answerPC))
+
+	var firstID uint16
+	idReleased := func() bool {
+		offerPC.sctpTransport.lock.RLock()
+		defer offerPC.sctpTransport.lock.RUnlock()
+		_, used := offerPC.sctpTransport.dataChannelIDsUsed[firstID]
+
+		return !used
+	}
+
+	for i := 0; i < 3; i++ {
+		local, createErr := offerPC.CreateDataChannel("churn", nil)
+		require.NoError(t, createErr)
+
+		opened := make(chan struct{})
+		local.OnOpen(func() { close(opened) })
+		<-opened
+		remote := <-remoteChannels
+
+		require.NotNil(t, local.ID())
+		if i == 0 {
+			firstID = *local.ID()
+		}
+		assert.Equal(t, firstID, *local.ID(), "the ID of the closed DataChannel is not reused")
+
+		// Both peers reset the stream, whichever closes the DataChannel. The ID of
+		// a DataChannel closed by the remote peer is released with a delay.
+		localClosed, remoteClosed := make(chan struct{}), make(chan struct{})
+		local.OnClose(func() { close(localClosed) })
+		remote.OnClose(func() { close(remoteClosed) })
+		if i%2 == 0 {
+			require.NoError(t, local.Close())
+			<-localClosed
+			assert.True(t, idReleased())
+		} else {
+			require.NoError(t, remote.Close())
+			<-localClosed
+			assert.False(t, idReleased(), "the ID was released before the delay")
+			assert.Eventually(t, idReleased, time.Second, 10*time.Millisecond)
+		}
+		<-remoteClosed
+	}
+
+	closePairNow(t, offerPC, answerPC)
+}
+
+func TestDataChannel_OnClosing(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	offerPC, answerPC, err := newPair()
+	require.NoError(t, err)
+	offerPC.sctpTransport.idReleases.delay = 100 * time.Millisecond
+
+	remoteChannels := make(chan *DataChannel, 1)
+	answerPC.OnDataChannel(func(d *DataChannel) {
+		if d.Label() == "closing" {
+			remoteChannels <- d
+		}
+	})
+	require.NoError(t, signalPair(offerPC, answerPC))
+
+	for _, closedLocally := range []bool{true, false} {
+		local, createErr := offerPC.CreateDataChannel("closing", nil)
+		require.NoError(t, createErr)
+
+		opened := make(chan struct{})
+		local.OnOpen(func() { close(opened) })
+		<-opened
+		remote := <-remoteChannels
+
+		// Both peers pass through closing, whichever closes the DataChannel.
+		var closings atomic.Int32
+		closed := make(chan struct{}, 2)
+		for _, d := range []*DataChannel{local, remote} {
+			d.OnClosing(func() {
+				closings.Add(1)
+				assert.NotEqual(t, DataChannelStateOpen, d.ReadyState())
+			})
+			d.OnClose(func() { closed <- struct{}{} })
+		}
+
+		closer := remote
+		if closedLocally {
+			closer = local
+		}
+		require.NoError(t, closer.Close())
+		assert.Contains(t, []DataChannelState{DataChannelStateClosing, DataChannelStateClosed}, closer.ReadyState())
+		require.NoError(t, closer.Close())
+
+		<-closed
+		<-closed
+		assert.Equal(t, DataChannelStateClosed, local.ReadyState())
+		assert.Equal(t, DataChannelStateClosed, remote.ReadyState())
+		assert.Eventually(t, func() bool { return closings.Load() == 2 }, time.Second, 10*time.Millisecond)
+	}
+
+	closePairNow(t, offerPC, answerPC)
+}
+
+func TestDataChannel_OpenTimeout(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	s := SettingEngine{}
+	s.SetDataChannelOpenTimeout(200 * time.Millisecond)
+
+	offerPC, answerPC, err := NewAPI(WithSettingEngine(s)).newPair(Configuration{})
+	require.NoError(t, err)
+
+	// The negotiated DataChannel of the answer receives the DATA_CHANNEL_OPEN
+	// message of the offer and doesn't acknowledge it.
+	negotiated, id := true, uint16(10)
+	_, err = answerPC.CreateDataChannel("negotiated", &DataChannelInit{Negotiated: &negotiated, ID: &id})
+	require.NoError(t, err)
+	require.NoError(t, signalPair(offerPC, answerPC))
+
+	acknowledged, err := offerPC.CreateDataChannel("acknowledged", nil)
+	require.NoError(t, err)
+	opened := make(chan struct{})
+	acknowledged.OnOpen(func() { close(opened) })
+	<-opened
+
+	unacknowledged, err := offerPC.CreateDataChannel("unacknowledged", &DataChannelInit{ID: &id})
+	require.NoError(t, err)
+	unacknowledged.OnOpen(func() {
+		assert.Fail(t, "unacknowledged DataChannel opened")
+	})
+	errs := make(chan error, 1)
+	unacknowledged.OnError(func(err error) { errs <- err })
+	closed := make(chan struct{})
+	unacknowledged.OnClose(func() { close(closed) })
+
+	assert.ErrorIs(t, <-errs, ErrDataChannelOpenTimeout)
+	<-closed
+	assert.Equal(t, DataChannelStateClosed, unacknowledged.ReadyState())
+	assert.Equal(t, DataChannelStateOpen, acknowledged.ReadyState())
+
+	closePairNow(t, offerPC, answerPC)
+}
+
+func TestDataChannel_ReadBufferReuse(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	s := SettingEngine{}
+	s.EnableDataChannelReadBufferReuse(true)
+
+	offerPC, answerPC, err := NewAPI(WithSettingEngine(s)).newPair(Configuration{})
+	require.NoError(t, err)
+
+	messages := []string{"first", "second", "third"}
+	received := make(chan struct{})
+	var buffers []*byte
+	answerPC.OnDataChannel(func(d *DataChannel) {
+		d.OnMessage(func(msg DataChannelMessage) {
+			assert.Equal(t, messages[len(buffers)], string(msg.Data))
+			buffers = append(buffers, &msg.Data[0])
+			if len(buffers) == len(messages) {
+				close(received)
+			}
+		})
+	})
+
+	dc, err := offerPC.CreateDataChannel("reuse", nil)
+	require.NoError(t, err)
+	dc.OnOpen(func() {
+		for _, message := range messages {
+			assert.NoError(t, dc.SendText(message))
+		}
+	})
+	require.NoError(t, signalPair(offerPC, answerPC))
+
+	<-received
+	for _, buffer := range buffers {
+		assert.Same(t, buffers[0], buffer, "messages weren't read into the same buffer")
+	}
+
+	closePairNow(t, offerPC, answerPC)
+}
+
+func TestDataChannel_SendContext(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	// The answer doesn't read the DataChannel without detaching it, so Send blocks
+	// once the receive buffer is full.
+	s := SettingEngine{}
+	s.DetachDataChannels()
+	s.EnableDataChannelBlockWrite(true)
+	s.SetSCTPMaxReceiveBufferSize(1500)
+
+	offer, answer, err := NewAPI(WithSettingEngine(s)).newPair(Configuration{})
+	require.NoError(t, err)
+
+	dc, err := offer.CreateDataChannel("data", nil)
+	require.NoError(t, err)
+	opened, openedDone := context.WithCancel(context.Background())
+	dc.OnOpen(openedDone)
+
+	require.NoError(t, signalPair(offer, answer))
+	<-opened.Done()
+	assert.NoError(t, dc.SendTextContext(context.Background(), "message"))
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, dc.SendTextContext(canceled, "message"), context.Canceled)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	buf := make([]byte, 1000)
+	for range 10 {
+		if err = dc.SendContext(ctx, buf); err != nil {
+			break
+		}
+	}
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	closePairNow(t, offer, answer)
+}
+
+func TestDataChannel_SendContextConcurrentSend(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	s := SettingEngine{}
+	s.DetachDataChannels()
+	s.EnableDataChannelBlockWrite(true)
+	s.SetSCTPMaxReceiveBufferSize(1500)
+
+	offer, answer, err := NewAPI(WithSettingEngine(s)).newPair(Configuration{})
+	require.NoError(t, err)
+
+	dc, err := offer.CreateDataChannel("data", nil)
+	require.NoError(t, err)
+	opened, openedDone := context.WithCancel(context.Background())
+	dc.OnOpen(openedDone)
+
+	detached := make(chan datachannel.ReadWriteCloser, 1)
+	answer.OnDataChannel(func(d *DataChannel) {
+		if d.Label() != "data" {
+			return
+		}
+		d.OnOpen(func() {
+			raw, detachErr := d.Detach()
+			assert.NoError(t, detachErr)
+			detached <- raw
+		})
+	})
+
+	require.NoError(t, signalPair(offer, answer))
+	<-opened.Done()
+	raw := <-detached
+
+	// Fill the receive buffer of the answer, which doesn't read yet.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	buf := make([]byte, 1000)
+	for err == nil {
+		err = dc.SendContext(ctx, buf)
+	}
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// A Send blocked while the context of a SendContext is done doesn't fail.
+	sent := make(chan error, 1)
+	go func() {
+		sent <- dc.Send([]byte("plain"))
+	}()
+	time.Sleep(100 * time.Millisecond)
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, dc.SendContext(ctx, buf), context.DeadlineExceeded)
+
+	read := make([]byte, 1500)
+	for {
+		n, readErr := raw.Read(read)
+		require.NoError(t, readErr)
+		if string(read[:n]) == "plain" {
+			break
+		}
+	}
+	assert.NoError(t, <-sent)
+
+	closePairNow(t, offer, answer)
+}
+
+func TestDataChannel_SendContextRateLimited(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	offer, answer, err := newPair()
+	require.NoError(t, err)
+
+	dc, err := offer.CreateDataChannel("data", nil)
+	require.NoError(t, err)
+	opened, openedDone := context.WithCancel(context.Background())
+	dc.OnOpen(openedDone)
+
+	received := make(chan DataChannelMessage, 3)
+	answer.OnDataChannel(func(d *DataChannel) {
+		if d.Label() != "data" {
+			return
+		}
+		d.OnMessage(func(msg DataChannelMessage) {
+			received <- msg
+		})
+	})
+
+	require.NoError(t, signalPair(offer, answer))
+	<-opened.Done()
+
+	// 1000 bytes per second, the first message takes most of a second to
+	// refill the bucket.
+	dc.SetMaxSendRate(8000)
+	require.NoError(t, dc.Send(make([]byte, 1000)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, dc.SendTextContext(ctx, "dropped"), context.DeadlineExceeded)
+	require.NoError(t, dc.SendText("queued"))
+
+	assert.Equal(t, DataChannelMessage{Data: make([]byte, 1000)}, <-received)
+	assert.Equal(t, DataChannelMessage{IsString: true, Data: []byte("queued")}, <-received)
+
+	closePairNow(t, offer, answer)
+}
+
+func TestDataChannel_EmptyMessages(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	expected := []DataChannelMessage{
+		{IsString: true, Data: []byte{}},
+		{Data: []byte{}},
+		{Data: []byte("binary")},
+		{IsString: true, Data: []byte{}},
+		{Data: []byte{}},
+		{IsString: true, Data: []byte("text")},
+	}
+	send := func(dc *DataChannel) {
+		assert.NoError(t, dc.SendText(""))
+		assert.NoError(t, dc.Send(nil))
+		assert.NoError(t, dc.Send([]byte("binary")))
+		assert.NoError(t, dc.SendTextContext(t.Context(), ""))
+		assert.NoError(t, dc.SendContext(t.Context(), []byte{}))
+		assert.NoError(t, dc.SendText("text"))
+	}
+
+	for name, init := range map[string]*DataChannelInit{
+		"Plain":         nil,
+		"Compression":   {Compression: &DataChannelCompression{}},
+		"Fragmentation": {Fragmentation: &DataChannelFragmentation{}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			offerPC, answerPC, err := newPair()
+			require.NoError(t, err)
+
+			received := make(chan DataChannelMessage, len(expected))
+			answerPC.OnDataChannel(func(d *DataChannel) {
+				d.OnMessage(func(msg DataChannelMessage) {
+					received <- msg
+				})
+			})
+
+			dc, err := offerPC.CreateDataChannel("empty", init)
+			require.NoError(t, err)
+			dc.OnOpen(func() {
+				send(dc)
+			})
+			require.NoError(t, signalPair(offerPC, answerPC))
+
+			for _, message := range expected {
+				assert.Equal(t, message, <-received)
+			}
+
+			closePairNow(t, offerPC, answerPC)
+		})
+	}
+
+	t.Run("Detach", func(t *testing.T) {
+		s := SettingEngine{}
+		s.DetachDataChannels()
+		offerPC, answerPC, err := NewAPI(WithSettingEngine(s)).newPair(Configuration{})
+		require.NoError(t, err)
+
+		detached := make(chan datachannel.ReadWriteCloser, 1)
+		answerPC.OnDataChannel(func(d *DataChannel) {
+			if d.Label() != "empty" {
+				return
+			}
+			d.OnOpen(func() {
+				rwc, detachErr := d.Detach()
+				assert.NoError(t, detachErr)
+				detached <- rwc
+			})
+		})
+
+		dc, err := offerPC.CreateDataChannel("empty", nil)
+		require.NoError(t, err)
+		dc.OnOpen(func() {
+			send(dc)
+		})
+		require.NoError(t, signalPair(offerPC, answerPC))
+
+		rwc := <-detached
+		buffer := make([]byte, 16)
+		for _, message := range expected {
+			n, isString, readErr := rwc.ReadDataChannel(buffer)
+			require.NoError(t, readErr)
+			assert.Equal(t, message, DataChannelMessage{IsString: isString, Data: buffer[:n]})
+		}
+
+		closePairNow(t, offerPC, answerPC)
+	})
+}
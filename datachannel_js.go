@@ -7,6 +7,7 @@
 package webrtc
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"syscall/js"
@@ -71,8 +72,8 @@ func (d *DataChannel) OnClose(f func()) {
 	d.underlying.Set("onclose", onCloseHandler)
 }
 
-// FYI `OnClosing` is not implemented in the non-JS version of Pion.
-
+// OnClosing sets an event handler which is invoked when the DataChannel
+// starts closing.
 func (d *DataChannel) OnClosing(f func()) {
 	if d.onClosingHandler != nil {
 		oldHandler := d.onClosingHandler
@@ -151,6 +152,24 @@ func (d *DataChannel) SendText(s string) (err error) {
 	return nil
 }
 
+// SendContext is like Send, the browser buffers the message without blocking.
+// It returns the error of the context if it is done already.
+func (d *DataChannel) SendContext(ctx context.Context, data []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return d.Send(data)
+}
+
+// SendTextContext is like SendText, the browser buffers the message without
+// blocking. It returns the error of the context if it is done already.
+func (d *DataChannel) SendTextContext(ctx context.Context, s string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return d.SendText(s)
+}
+
 // Detach allows you to detach the underlying datachannel. This provides
 // an idiomatic API to work with, however it disables the OnMessage callback.
 // Before calling Detach you have to enable this behavior by calling
@@ -249,6 +268,15 @@ func (d *DataChannel) Negotiated() bool {
 	return d.underlying.Get("negotiated").Bool()
 }
 
+// Priority returns the priority of the DataChannel.
+func (d *DataChannel) Priority() DataChannelPriority {
+	if priority := d.underlying.Get("priority"); priority.Type() == js.TypeString {
+		return newDataChannelPriority(priority.String())
+	}
+
+	return DataChannelPriorityLow
+}
+
 // ID represents the ID for this DataChannel. The value is initially
 // null, which is what will be returned if the ID was not provided at
 // channel creation time. Otherwise, it will return the ID that was either
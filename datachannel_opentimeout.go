@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"fmt"
+	"time"
+)
+
+// startOpenTimer starts waiting for the DATA_CHANNEL_ACK message, if
+// SettingEngine.SetDataChannelOpenTimeout is used.
+func (d *DataChannel) startOpenTimer() {
+	timeout := d.api.settingEngine.sctp.dataChannelOpenTimeout
+	if timeout <= 0 {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.isGracefulClosed {
+		return
+	}
+	d.openTimer = time.AfterFunc(timeout, func() {
+		d.openTimeout(timeout)
+	})
+}
+
+// stopOpenTimer stops waiting for the DATA_CHANNEL_ACK message. The caller
+// holds the lock.
+func (d *DataChannel) stopOpenTimer() {
+	if d.openTimer != nil {
+		d.openTimer.Stop()
+		d.openTimer = nil
+	}
+}
+
+// openAcknowledged is called when the DATA_CHANNEL_ACK message is received, it
+// returns false if the DataChannel timed out before.
+func (d *DataChannel) openAcknowledged() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.stopOpenTimer()
+	if d.openTimedOut {
+		return false
+	}
+	d.awaitingAck = false
+
+	return true
+}
+
+func (d *DataChannel) openTimeout(timeout time.Duration) {
+	d.mu.Lock()
+	if d.openTimer == nil {
+		d.mu.Unlock()
+
+		return
+	}
+	d.openTimer = nil
+	d.openTimedOut = true
+	d.mu.Unlock()
+
+	d.log.Warnf("DataChannel %s wasn't acknowledged within %s, closing it", d.label, timeout)
+	d.onError(fmt.Errorf("%w: %s", ErrDataChannelOpenTimeout, timeout))
+	if err := d.Close(); err != nil {
+		d.log.Warnf("Failed to close DataChannel %s after the open timeout: %v", d.label, err)
+	}
+}
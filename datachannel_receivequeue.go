@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// DataChannelReceiveQueuePolicy decides what happens to a message received
+// while the receive queue of a DataChannel is full, see
+// SettingEngine.SetDataChannelReceiveQueueLimits.
+type DataChannelReceiveQueuePolicy int
+
+const (
+	// DataChannelReceiveQueuePolicyDropNew drops the received message, the
+	// queued ones are kept. This is the default.
+	DataChannelReceiveQueuePolicyDropNew DataChannelReceiveQueuePolicy = iota
+
+	// DataChannelReceiveQueuePolicyDropOld drops the oldest queued messages
+	// until the received message fits into the queue.
+	DataChannelReceiveQueuePolicyDropOld
+
+	// DataChannelReceiveQueuePolicyClose closes the DataChannel, its OnError
+	// handler is invoked with ErrDataChannelReceiveQueueFull.
+	DataChannelReceiveQueuePolicyClose
+)
+
+// This is done this way because of a linter.
+const (
+	dataChannelReceiveQueuePolicyDropNewStr = "drop-new"
+	dataChannelReceiveQueuePolicyDropOldStr = "drop-old"
+	dataChannelReceiveQueuePolicyCloseStr   = "close"
+)
+
+func (p DataChannelReceiveQueuePolicy) String() string {
+	switch p {
+	case DataChannelReceiveQueuePolicyDropNew:
+		return dataChannelReceiveQueuePolicyDropNewStr
+	case DataChannelReceiveQueuePolicyDropOld:
+		return dataChannelReceiveQueuePolicyDropOldStr
+	case DataChannelReceiveQueuePolicyClose:
+		return dataChannelReceiveQueuePolicyCloseStr
+	default:
+		return ErrUnknownType.Error()
+	}
+}
+
+// dataChannelReceiveQueue passes the received messages of a DataChannel to its
+// OnMessage handler in a goroutine of its own, so a slow handler doesn't stall
+// the read loop.
+type dataChannelReceiveQueue struct {
+	mu       sync.Mutex
+	messages []DataChannelMessage
+	bytes    int
+
+	// delivered is closed once the goroutine delivering the queued messages
+	// returns, it is nil while no goroutine runs.
+	delivered chan struct{}
+
+	dropped atomic.Uint32
+}
+
+// receiveQueueEnabled returns true if SettingEngine.SetDataChannelReceiveQueueLimits
+// is used.
+func (d *DataChannel) receiveQueueEnabled() bool {
+	settings := &d.api.settingEngine.sctp
+
+	return settings.receiveQueueMaxMessages > 0 || settings.receiveQueueMaxBytes > 0
+}
+
+// receive passes the message to the OnMessage handler, through the receive
+// queue if it is enabled.
+func (d *DataChannel) receive(msg DataChannelMessage) {
+	d.api.settingEngine.instrumentation.dataChannelMessageReceived()
+
+	if !d.receiveQueueEnabled() {
+		d.onMessage(msg)
+
+		return
+	}
+
+	// The read buffer is reused for the next message while this one is queued.
+	if d.api.settingEngine.sctp.reuseReadBuffer {
+		msg.Data = append([]byte{}, msg.Data...)
+	}
+
+	if d.receiveQueue.enqueue(d, msg) {
+		return
+	}
+
+	d.log.Warnf("Receive queue of DataChannel %s is full, closing it", d.label)
+	d.onError(ErrDataChannelReceiveQueueFull)
+	if err := d.Close(); err != nil {
+		d.log.Warnf("Failed to close DataChannel %s after its receive queue was full: %v", d.label, err)
+	}
+}
+
+// enqueue queues the message, or drops messages while the queue is full. It
+// returns false if the DataChannel has to be closed instead.
+func (q *dataChannelReceiveQueue) enqueue(d *DataChannel, msg DataChannelMessage) bool {
+	settings := &d.api.settingEngine.sctp
+	exceeded := func() bool {
+		return (settings.receiveQueueMaxMessages > 0 && len(q.messages) >= settings.receiveQueueMaxMessages) ||
+			(settings.receiveQueueMaxBytes > 0 && q.bytes+len(msg.Data) > settings.receiveQueueMaxBytes)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for exceeded() {
+		switch {
+		case settings.receiveQueuePolicy == DataChannelReceiveQueuePolicyClose:
+			return false
+		case settings.receiveQueuePolicy == DataChannelReceiveQueuePolicyDropOld && len(q.messages) != 0:
+			q.bytes -= len(q.messages[0].Data)
+			q.messages[0] = DataChannelMessage{}
+			q.messages = q.messages[1:]
+			q.dropped.Add(1)
+		default:
+			// The message doesn't fit into the queue, even if it is empty.
+			q.dropped.Add(1)
+
+			return true
+		}
+	}
+
+	q.messages = append(q.messages, msg)
+	q.bytes += len(msg.Data)
+
+	if q.delivered == nil {
+		q.delivered = make(chan struct{})
+		go q.deliver(d, q.delivered)
+	}
+
+	return true
+}
+
+// deliver passes the queued messages to the OnMessage handler until the queue
+// is empty.
+func (q *dataChannelReceiveQueue) deliver(d *DataChannel, delivered chan struct{}) {
+	defer close(delivered)
+
+	for {
+		q.mu.Lock()
+		if len(q.messages) == 0 {
+			q.delivered = nil
+			q.mu.Unlock()
+
+			return
+		}
+		msg := q.messages[0]
+		q.messages[0] = DataChannelMessage{}
+		q.messages = q.messages[1:]
+		q.bytes -= len(msg.Data)
+		q.mu.Unlock()
+
+		d.onMessage(msg)
+	}
+}
+
+// wait returns once the queued messages are delivered, OnClose is invoked
+// after the OnMessage handler returned for the last message.
+func (q *dataChannelReceiveQueue) wait() {
+	q.mu.Lock()
+	delivered := q.delivered
+	q.mu.Unlock()
+
+	if delivered != nil {
+		<-delivered
+	}
+}
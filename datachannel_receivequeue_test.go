@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/logging"
+	"github.com/pion/transport/v4/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataChannelReceiveQueue_Policies(t *testing.T) {
+	report := test.CheckRoutines(t)
+	defer report()
+
+	for _, tc := range []struct {
+		policy    DataChannelReceiveQueuePolicy
+		delivered []string
+	}{
+		{DataChannelReceiveQueuePolicyDropNew, []string{"0", "1", "2"}},
+		{DataChannelReceiveQueuePolicyDropOld, []string{"0", "3", "4"}},
+	} {
+		t.Run(tc.policy.String(), func(t *testing.T) {
+			s := SettingEngine{}
+			s.SetDataChannelReceiveQueueLimits(2, 0, tc.policy)
+			d := &DataChannel{api: NewAPI(WithSettingEngine(s)), log: logging.NewDefaultLoggerFactory().NewLogger("test")}
+
+			// The handler blocks on the first message until all are received.
+			received := make(chan string, 5)
+			unblock := make(chan struct{})
+			d.OnMessage(func(msg DataChannelMessage) {
+				received <- string(msg.Data)
+				<-unblock
+			})
+
+			d.receive(DataChannelMessage{Data: []byte("0")})
+			assert.Equal(t, "0", <-received)
+			for _, message := range []string{"1", "2", "3", "4"} {
+				d.receive(DataChannelMessage{Data: []byte(message)})
+			}
+			close(unblock)
+
+			d.receiveQueue.wait()
+			close(received)
+			delivered := []string{"0"}
+			for message := range received {
+				delivered = append(delivered, message)
+			}
+			assert.Equal(t, tc.delivered, delivered)
+			assert.Equal(t, uint32(2), d.receiveQueue.dropped.Load())
+		})
+	}
+
+	t.Run("MaxBytes", func(t *testing.T) {
+		s := SettingEngine{}
+		s.SetDataChannelReceiveQueueLimits(0, 4, DataChannelReceiveQueuePolicyDropOld)
+		d := &DataChannel{api: NewAPI(WithSettingEngine(s)), log: logging.NewDefaultLoggerFactory().NewLogger("test")}
+
+		received := make(chan struct{}, 1)
+		unblock := make(chan struct{})
+		d.OnMessage(func(DataChannelMessage) {
+			received <- struct{}{}
+			<-unblock
+		})
+
+		d.receive(DataChannelMessage{Data: []byte("0")})
+		<-received
+		d.receive(DataChannelMessage{Data: []byte("abc")})
+		d.receive(DataChannelMessage{Data: []byte("de")})
+		assert.Equal(t, uint32(1), d.receiveQueue.dropped.Load())
+		d.receive(DataChannelMessage{Data: []byte("fghij")})
+		assert.Equal(t, uint32(3), d.receiveQueue.dropped.Load(), "a message larger than the limit is dropped")
+		close(unblock)
+		d.receiveQueue.wait()
+		assert.Empty(t, d.receiveQueue.messages)
+	})
+}
+
+func TestDataChannel_ReceiveQueueClose(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	s := SettingEngine{}
+	s.SetDataChannelReceiveQueueLimits(2, 0, DataChannelReceiveQueuePolicyClose)
+	offerPC, answerPC, err := NewAPI(WithSettingEngine(s)).newPair(Configuration{})
+	require.NoError(t, err)
+
+	unblock := make(chan struct{})
+	errs := make(chan error, 1)
+	closed := make(chan struct{})
+	answerPC.OnDataChannel(func(d *DataChannel) {
+		if d.Label() != "queue" {
+			return
+		}
+		d.OnMessage(func(DataChannelMessage) {
+			<-unblock
+		})
+		d.OnError(func(err error) {
+			errs <- err
+		})
+		d.OnClose(func() {
+			close(closed)
+		})
+	})
+
+	dc, err := offerPC.CreateDataChannel("queue", nil)
+	require.NoError(t, err)
+	dc.OnOpen(func() {
+		for i := 0; i < 4; i++ {
+			assert.NoError(t, dc.SendText("message"))
+		}
+	})
+	require.NoError(t, signalPair(offerPC, answerPC))
+
+	assert.ErrorIs(t, <-errs, ErrDataChannelReceiveQueueFull)
+	close(unblock)
+	<-closed
+
+	closePairNow(t, offerPC, answerPC)
+}
@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"sync"
+	"time"
+)
+
+// SCTPStreamStats are the statistics of the SCTP stream of a DataChannel. All
+// DataChannels share one SCTP association, these tell which of them is stalling
+// it.
+type SCTPStreamStats struct {
+	// StreamIdentifier is the SCTP stream of the DataChannel, its ID.
+	StreamIdentifier uint16
+
+	// OutstandingBytes is the number of bytes sent or queued that the remote peer
+	// didn't acknowledge yet.
+	OutstandingBytes uint64
+
+	// RetransmissionTimeouts is the number of retransmission timeouts of the
+	// association while the stream had outstanding bytes. The association
+	// retransmits all chunks in flight after a timeout.
+	RetransmissionTimeouts uint64
+
+	// Resetting is true while the stream is reset after the DataChannel was
+	// closed, until the remote peer reset it as well.
+	Resetting bool
+
+	// LastAckAge is the time since bytes of the stream were last acknowledged,
+	// zero when no bytes are outstanding. Acknowledgements are noticed when
+	// messages are sent with Send or SendText, on retransmission timeouts and
+	// when the statistics are read.
+	LastAckAge time.Duration
+}
+
+type dataChannelSCTPStats struct {
+	mu                     sync.Mutex
+	outstandingBytes       uint64
+	lastAck                time.Time
+	retransmissionTimeouts uint64
+}
+
+// observe updates the outstanding bytes, bytes were acknowledged when there are
+// fewer than expected.
+func (s *dataChannelSCTPStats) observe(outstandingBytes uint64, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if outstandingBytes < s.outstandingBytes || s.lastAck.IsZero() {
+		s.lastAck = now
+	}
+	s.outstandingBytes = outstandingBytes
+}
+
+func (s *dataChannelSCTPStats) sent(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.outstandingBytes += uint64(n) //nolint:gosec // G115
+}
+
+func (s *dataChannelSCTPStats) retransmissionTimeout(outstandingBytes uint64, now time.Time) {
+	s.observe(outstandingBytes, now)
+	if outstandingBytes == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.retransmissionTimeouts++
+}
+
+// SCTPStreamStats returns the statistics of the SCTP stream of the DataChannel.
+// It returns false if the DataChannel isn't open yet.
+func (d *DataChannel) SCTPStreamStats() (SCTPStreamStats, bool) {
+	d.mu.RLock()
+	dataChannel := d.dataChannel
+	d.mu.RUnlock()
+	if dataChannel == nil {
+		return SCTPStreamStats{}, false
+	}
+
+	now := time.Now()
+	outstandingBytes := dataChannel.BufferedAmount()
+	d.sctpStats.observe(outstandingBytes, now)
+
+	d.sctpStats.mu.Lock()
+	defer d.sctpStats.mu.Unlock()
+
+	stats := SCTPStreamStats{
+		StreamIdentifier:       dataChannel.StreamIdentifier(),
+		OutstandingBytes:       outstandingBytes,
+		RetransmissionTimeouts: d.sctpStats.retransmissionTimeouts,
+		Resetting:              d.ReadyState() == DataChannelStateClosing,
+	}
+	if outstandingBytes != 0 {
+		stats.LastAckAge = now.Sub(d.sctpStats.lastAck)
+	}
+
+	return stats, true
+}
+
+// observeOutstandingBytes notices the acknowledgements since the last message
+// was sent.
+func (d *DataChannel) observeOutstandingBytes() {
+	d.sctpStats.observe(d.BufferedAmount(), time.Now())
+}
+
+// onRetransmissionTimeout counts a retransmission timeout of the association
+// for the DataChannels with outstanding bytes.
+func (r *SCTPTransport) onRetransmissionTimeout() {
+	r.lock.RLock()
+	dataChannels := append([]*DataChannel(nil), r.dataChannels...)
+	r.lock.RUnlock()
+
+	now := time.Now()
+	for _, d := range dataChannels {
+		d.sctpStats.retransmissionTimeout(d.BufferedAmount(), now)
+	}
+}
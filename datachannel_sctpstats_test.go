@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataChannel_SCTPStreamStats(t *testing.T) {
+	lim := test.TimeOut(time.Second * 20)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	offerPC, answerPC, err := newPair()
+	require.NoError(t, err)
+
+	dc, err := offerPC.CreateDataChannel("stats", nil)
+	require.NoError(t, err)
+
+	_, ok := dc.SCTPStreamStats()
+	assert.False(t, ok)
+
+	opened := make(chan struct{})
+	dc.OnOpen(func() {
+		close(opened)
+	})
+	received := make(chan struct{}, 1)
+	answerPC.OnDataChannel(func(d *DataChannel) {
+		d.OnMessage(func(DataChannelMessage) {
+			select {
+			case received <- struct{}{}:
+			default:
+			}
+		})
+	})
+
+	require.NoError(t, signalPair(offerPC, answerPC))
+	<-opened
+
+	require.NoError(t, dc.SendText("ping"))
+	<-received
+
+	stats, ok := dc.SCTPStreamStats()
+	require.True(t, ok)
+	require.NotNil(t, dc.ID())
+	assert.Equal(t, *dc.ID(), stats.StreamIdentifier)
+	assert.False(t, stats.Resetting)
+	assert.Zero(t, stats.RetransmissionTimeouts)
+	if stats.OutstandingBytes == 0 {
+		assert.Zero(t, stats.LastAckAge)
+	}
+
+	closePairNow(t, offerPC, answerPC)
+}
+
+func TestDataChannelSCTPStats(t *testing.T) {
+	var stats dataChannelSCTPStats
+	start := time.Now()
+
+	stats.observe(0, start)
+	stats.sent(100)
+	stats.observe(100, start.Add(time.Second))
+	assert.Equal(t, start, stats.lastAck)
+
+	stats.retransmissionTimeout(100, start.Add(2*time.Second))
+	assert.Equal(t, uint64(1), stats.retransmissionTimeouts)
+	assert.Equal(t, start, stats.lastAck)
+
+	stats.sent(50)
+	stats.observe(120, start.Add(3*time.Second))
+	assert.Equal(t, start.Add(3*time.Second), stats.lastAck)
+	assert.Equal(t, uint64(120), stats.outstandingBytes)
+
+	stats.retransmissionTimeout(0, start.Add(4*time.Second))
+	assert.Equal(t, uint64(1), stats.retransmissionTimeouts)
+}
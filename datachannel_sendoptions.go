@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"io"
+
+	"github.com/pion/datachannel"
+	"github.com/pion/sctp"
+)
+
+// DataChannelSendOptions are the options of a message sent with
+// DataChannel.SendWithOptions, they apply to this message only.
+type DataChannelSendOptions struct {
+	// IsString sends the message as a text message instead of a binary one.
+	IsString bool
+
+	// Ordered overrides the ordering of the DataChannel for the message, an
+	// unordered message may overtake the ordered ones sent before it. The
+	// ordering of the DataChannel is kept if it is nil. Ordered messages of an
+	// unordered DataChannel are delivered in order among themselves.
+	Ordered *bool
+
+	// PayloadProtocolIdentifier is the SCTP PPID of the message, the PPID of a
+	// WebRTC String or Binary message is used if it is 0. The remote peer
+	// receives messages with other PPIDs as binary messages, see RFC 8831
+	// Section 6.6. The DCEP PPID is reserved for the DataChannel protocol and
+	// empty messages can't use a PPID of their own.
+	PayloadProtocolIdentifier uint32
+}
+
+// SendWithOptions sends the message to the DataChannel peer with the ordering
+// and SCTP PPID of the options, like to mix ordered control messages and
+// unordered bulk messages on one DataChannel. The message is written to the
+// SCTP association directly, it can't be combined with SetMaxSendRate or
+// SettingEngine.SetDataChannelScheduling. Messages of a DataChannel with
+// fragmentation have to be ordered, as do the ones of a DataChannel that
+// negotiates compression with the remote peer, see DataChannelCompression.
+func (d *DataChannel) SendWithOptions(data []byte, options DataChannelSendOptions) error {
+	if err := d.ensureOpen(); err != nil {
+		return err
+	}
+
+	ppid := sctp.PayloadProtocolIdentifier(options.PayloadProtocolIdentifier)
+	switch {
+	case ppid == sctp.PayloadTypeWebRTCDCEP:
+		return errSendOptionsReservedPPID
+	case ppid != 0 && len(data) == 0:
+		return errSendOptionsEmptyPPID
+	case options.Ordered != nil && !*options.Ordered && d.extensionsRequested && !d.negotiated:
+		return errSendOptionsUnorderedExtensions
+	case options.Ordered != nil && !*options.Ordered && d.fragmentation != nil:
+		return errSendOptionsUnorderedFragments
+	case d.rateLimiter.active() || d.scheduler() != nil:
+		return errSendOptionsQueued
+	}
+
+	return d.sendWithExtensions(data, options.IsString, func(message []byte, isString bool) error {
+		return d.writeWithOptions(message, isString, options.Ordered, ppid)
+	})
+}
+
+// writeWithOptions writes the message with the ordering and PPID, holding
+// streamOptionsMu while the ordering of the stream is changed.
+func (d *DataChannel) writeWithOptions(
+	data []byte,
+	isString bool,
+	ordered *bool,
+	ppid sctp.PayloadProtocolIdentifier,
+) error {
+	d.streamOptionsMu.Lock()
+	defer d.streamOptionsMu.Unlock()
+
+	if ordered != nil && *ordered != d.Ordered() {
+		stream, err := d.sctpStream()
+		if err != nil {
+			return err
+		}
+		unordered, relType, relVal := d.reliabilityParams()
+		stream.SetReliabilityParams(!*ordered, relType, relVal)
+		defer stream.SetReliabilityParams(unordered, relType, relVal)
+	}
+
+	return d.writeMessage(data, isString, ppid)
+}
+
+// writeStream writes the message to the SCTP stream with the PPID, the
+// counters of the datachannel package only count the messages it writes.
+func (d *DataChannel) writeStream(data []byte, ppid sctp.PayloadProtocolIdentifier) (int, error) {
+	stream, err := d.sctpStream()
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := stream.WriteSCTP(data, ppid)
+	if err == nil {
+		d.ppidMessagesSent.Add(1)
+		d.ppidBytesSent.Add(uint64(n)) //nolint:gosec // G115
+	}
+
+	return n, err
+}
+
+// sctpStream returns the SCTP stream of the DataChannel, the association
+// returns the stream the datachannel package opened.
+func (d *DataChannel) sctpStream() (*sctp.Stream, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.stream != nil {
+		return d.stream, nil
+	}
+
+	association := d.sctpTransport.association()
+	if association == nil || d.id == nil {
+		return nil, io.ErrClosedPipe
+	}
+	stream, err := association.OpenStream(*d.id, sctp.PayloadTypeWebRTCBinary)
+	if err != nil {
+		return nil, err
+	}
+	d.stream = stream
+
+	return stream, nil
+}
+
+// reliabilityParams returns the SCTP reliability parameters of the channel
+// type of the DataChannel.
+func (d *DataChannel) reliabilityParams() (unordered bool, relType byte, relVal uint32) {
+	config := d.dataChannel.Config
+	switch config.ChannelType {
+	case datachannel.ChannelTypeReliableUnordered:
+		unordered = true
+	case datachannel.ChannelTypePartialReliableRexmit:
+		relType = sctp.ReliabilityTypeRexmit
+	case datachannel.ChannelTypePartialReliableRexmitUnordered:
+		unordered, relType = true, sctp.ReliabilityTypeRexmit
+	case datachannel.ChannelTypePartialReliableTimed:
+		relType = sctp.ReliabilityTypeTimed
+	case datachannel.ChannelTypePartialReliableTimedUnordered:
+		unordered, relType = true, sctp.ReliabilityTypeTimed
+	default:
+		relType = sctp.ReliabilityTypeReliable
+	}
+
+	return unordered, relType, config.ReliabilityParameter
+}
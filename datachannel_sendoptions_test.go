@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/sctp"
+	"github.com/pion/transport/v4/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataChannel_SendWithOptions(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	pcOffer, pcAnswer, err := newPair()
+	require.NoError(t, err)
+
+	offerChannel, err := pcOffer.CreateDataChannel("options", nil)
+	require.NoError(t, err)
+
+	opened := make(chan struct{})
+	offerChannel.OnOpen(func() {
+		close(opened)
+	})
+
+	answerReceived := make(chan DataChannelMessage, 4)
+	pcAnswer.OnDataChannel(func(d *DataChannel) {
+		d.OnMessage(func(msg DataChannelMessage) {
+			answerReceived <- msg
+		})
+	})
+
+	require.NoError(t, signalPair(pcOffer, pcAnswer))
+	<-opened
+
+	unordered := false
+	require.NoError(t, offerChannel.SendWithOptions([]byte("bulk"), DataChannelSendOptions{Ordered: &unordered}))
+	assert.Equal(t, DataChannelMessage{Data: []byte("bulk")}, <-answerReceived)
+
+	require.NoError(t, offerChannel.SendWithOptions([]byte("control"), DataChannelSendOptions{IsString: true}))
+	assert.Equal(t, DataChannelMessage{IsString: true, Data: []byte("control")}, <-answerReceived)
+
+	// Messages with a PPID of their own are received as binary messages.
+	require.NoError(t, offerChannel.SendWithOptions([]byte("custom"), DataChannelSendOptions{
+		IsString:                  true,
+		Ordered:                   &unordered,
+		PayloadProtocolIdentifier: 0x1234,
+	}))
+	assert.Equal(t, DataChannelMessage{Data: []byte("custom")}, <-answerReceived)
+
+	// The ordering of the DataChannel is restored afterwards.
+	require.NoError(t, offerChannel.SendText("ordered"))
+	assert.Equal(t, DataChannelMessage{IsString: true, Data: []byte("ordered")}, <-answerReceived)
+
+	assert.ErrorIs(t, offerChannel.SendWithOptions([]byte("dcep"), DataChannelSendOptions{
+		PayloadProtocolIdentifier: uint32(sctp.PayloadTypeWebRTCDCEP),
+	}), errSendOptionsReservedPPID)
+	assert.ErrorIs(t, offerChannel.SendWithOptions(nil, DataChannelSendOptions{
+		PayloadProtocolIdentifier: 0x1234,
+	}), errSendOptionsEmptyPPID)
+
+	offerChannel.SetMaxSendRate(1 << 20)
+	assert.ErrorIs(t, offerChannel.SendWithOptions([]byte("limited"), DataChannelSendOptions{}), errSendOptionsQueued)
+
+	stats := getDataChannelStats(t, pcOffer.GetStats(), offerChannel)
+	assert.Equal(t, uint32(4), stats.MessagesSent)
+	assert.Equal(t, uint64(len("bulkcontrolcustomordered")), stats.BytesSent)
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
+
+func TestDataChannel_SendWithOptionsFragmented(t *testing.T) {
+	pc, err := NewPeerConnection(Configuration{})
+	require.NoError(t, err)
+
+	channel, err := pc.CreateDataChannel("fragmented", &DataChannelInit{
+		Fragmentation: &DataChannelFragmentation{},
+	})
+	require.NoError(t, err)
+	channel.setReadyState(DataChannelStateOpen)
+
+	unordered := false
+	assert.ErrorIs(t, channel.SendWithOptions([]byte("bulk"), DataChannelSendOptions{
+		Ordered: &unordered,
+	}), errSendOptionsUnorderedExtensions)
+
+	negotiated, id := true, uint16(1)
+	channel, err = pc.CreateDataChannel("negotiated", &DataChannelInit{
+		Negotiated:    &negotiated,
+		ID:            &id,
+		Fragmentation: &DataChannelFragmentation{},
+	})
+	require.NoError(t, err)
+	channel.setReadyState(DataChannelStateOpen)
+
+	assert.ErrorIs(t, channel.SendWithOptions([]byte("bulk"), DataChannelSendOptions{
+		Ordered: &unordered,
+	}), errSendOptionsUnorderedFragments)
+
+	assert.NoError(t, pc.Close())
+}
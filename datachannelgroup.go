@@ -0,0 +1,332 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package webrtc
+
+import (
+	"errors"
+	"sync"
+)
+
+// BackpressurePolicy decides what a DataChannelGroup does with a message for
+// a member whose buffered amount exceeds its limit.
+type BackpressurePolicy int
+
+const (
+	// BackpressurePolicyDrop skips the message for the member.
+	BackpressurePolicyDrop BackpressurePolicy = iota
+
+	// BackpressurePolicyClose closes the member's DataChannel and removes it
+	// from the group.
+	BackpressurePolicyClose
+
+	// BackpressurePolicyQueue queues the message regardless of the buffered
+	// amount.
+	BackpressurePolicyQueue
+)
+
+// This is done this way because of a linter.
+const (
+	backpressurePolicyDropStr  = "drop"
+	backpressurePolicyCloseStr = "close"
+	backpressurePolicyQueueStr = "queue"
+)
+
+func (p BackpressurePolicy) String() string {
+	switch p {
+	case BackpressurePolicyDrop:
+		return backpressurePolicyDropStr
+	case BackpressurePolicyClose:
+		return backpressurePolicyCloseStr
+	case BackpressurePolicyQueue:
+		return backpressurePolicyQueueStr
+	default:
+		return ErrUnknownType.Error()
+	}
+}
+
+// defaultDataChannelGroupMaxBufferedAmount is the buffered amount limit of
+// members without DataChannelGroupMemberOptions.MaxBufferedAmount.
+const defaultDataChannelGroupMaxBufferedAmount = 1024 * 1024
+
+// DataChannelGroupMemberOptions configures a member of a DataChannelGroup.
+type DataChannelGroupMemberOptions struct {
+	// Backpressure is applied to broadcast messages while the member's
+	// buffered amount exceeds MaxBufferedAmount.
+	Backpressure BackpressurePolicy
+
+	// MaxBufferedAmount is the member's buffered amount limit in bytes, zero
+	// selects 1MiB.
+	MaxBufferedAmount uint64
+}
+
+type dataChannelGroupMember struct {
+	options DataChannelGroupMemberOptions
+	joined  bool
+	dropped uint64
+}
+
+// DataChannelGroup manages the DataChannels with the same label across many
+// PeerConnections, like a chat room or the state updates of a game server.
+// Messages are broadcast to all members, while the backpressure policy of
+// every member decides how a slow peer is handled, so it doesn't hold up the
+// others.
+//
+// The group takes over the OnOpen, OnClose and OnMessage handlers of its
+// members, use the group's handlers instead.
+type DataChannelGroup struct {
+	label string
+	init  *DataChannelInit
+
+	mu        sync.RWMutex
+	members   map[*DataChannel]*dataChannelGroupMember
+	closed    bool
+	onJoin    func(*DataChannel)
+	onLeave   func(*DataChannel)
+	onMessage func(*DataChannel, DataChannelMessage)
+}
+
+// NewDataChannelGroup creates a DataChannelGroup for the DataChannels with the
+// label. init configures the DataChannels created by AddPeerConnection.
+func NewDataChannelGroup(label string, init *DataChannelInit) *DataChannelGroup {
+	return &DataChannelGroup{
+		label:   label,
+		init:    init,
+		members: map[*DataChannel]*dataChannelGroupMember{},
+	}
+}
+
+// Label returns the label of the group's DataChannels.
+func (g *DataChannelGroup) Label() string {
+	return g.label
+}
+
+// AddPeerConnection creates the group's DataChannel on the PeerConnection and
+// adds it to the group.
+func (g *DataChannelGroup) AddPeerConnection(
+	pc *PeerConnection, options DataChannelGroupMemberOptions,
+) (*DataChannel, error) {
+	dataChannel, err := pc.CreateDataChannel(g.label, g.init)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = g.Add(dataChannel, options); err != nil {
+		_ = dataChannel.Close()
+
+		return nil, err
+	}
+
+	return dataChannel, nil
+}
+
+// Add adds a DataChannel with the group's label, like one the remote peer
+// created and OnDataChannel passed. The member joins once it is open, or
+// immediately if it already is.
+func (g *DataChannelGroup) Add(dataChannel *DataChannel, options DataChannelGroupMemberOptions) error {
+	if dataChannel.Label() != g.label {
+		return errDataChannelGroupLabelMismatch
+	}
+
+	if options.MaxBufferedAmount == 0 {
+		options.MaxBufferedAmount = defaultDataChannelGroupMaxBufferedAmount
+	}
+
+	g.mu.Lock()
+	if g.closed {
+		g.mu.Unlock()
+
+		return errDataChannelGroupClosed
+	}
+	g.members[dataChannel] = &dataChannelGroupMember{options: options}
+	g.mu.Unlock()
+
+	if dataChannel.ReadyState() == DataChannelStateOpen {
+		g.join(dataChannel)
+	} else {
+		dataChannel.OnOpen(func() {
+			g.join(dataChannel)
+		})
+	}
+	dataChannel.OnClose(func() {
+		g.leave(dataChannel)
+	})
+	dataChannel.OnMessage(func(msg DataChannelMessage) {
+		g.mu.RLock()
+		onMessage := g.onMessage
+		_, isMember := g.members[dataChannel]
+		g.mu.RUnlock()
+
+		if isMember && onMessage != nil {
+			onMessage(dataChannel, msg)
+		}
+	})
+
+	return nil
+}
+
+// Remove removes a DataChannel from the group without closing it.
+func (g *DataChannelGroup) Remove(dataChannel *DataChannel) {
+	g.leave(dataChannel)
+}
+
+func (g *DataChannelGroup) join(dataChannel *DataChannel) {
+	g.mu.Lock()
+	member, ok := g.members[dataChannel]
+	if !ok || member.joined {
+		g.mu.Unlock()
+
+		return
+	}
+	member.joined = true
+	onJoin := g.onJoin
+	g.mu.Unlock()
+
+	if onJoin != nil {
+		onJoin(dataChannel)
+	}
+}
+
+func (g *DataChannelGroup) leave(dataChannel *DataChannel) {
+	g.mu.Lock()
+	member, ok := g.members[dataChannel]
+	delete(g.members, dataChannel)
+	onLeave := g.onLeave
+	g.mu.Unlock()
+
+	if ok && member.joined && onLeave != nil {
+		onLeave(dataChannel)
+	}
+}
+
+// Members returns the open DataChannels of the group.
+func (g *DataChannelGroup) Members() []*DataChannel {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	members := make([]*DataChannel, 0, len(g.members))
+	for dataChannel, member := range g.members {
+		if member.joined {
+			members = append(members, dataChannel)
+		}
+	}
+
+	return members
+}
+
+// DroppedMessages returns how many broadcast messages were dropped for a
+// member by BackpressurePolicyDrop.
+func (g *DataChannelGroup) DroppedMessages(dataChannel *DataChannel) uint64 {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if member, ok := g.members[dataChannel]; ok {
+		return member.dropped
+	}
+
+	return 0
+}
+
+// OnJoin sets an event handler which is invoked when a member's DataChannel
+// opens.
+func (g *DataChannelGroup) OnJoin(f func(*DataChannel)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.onJoin = f
+}
+
+// OnLeave sets an event handler which is invoked when a member that joined
+// closes or is removed.
+func (g *DataChannelGroup) OnLeave(f func(*DataChannel)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.onLeave = f
+}
+
+// OnMessage sets an event handler which is invoked on a message arrival from
+// a member.
+func (g *DataChannelGroup) OnMessage(f func(*DataChannel, DataChannelMessage)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.onMessage = f
+}
+
+// Broadcast sends binary data to all members, see BroadcastMessage.
+func (g *DataChannelGroup) Broadcast(data []byte) error {
+	return g.BroadcastMessage(DataChannelMessage{Data: data}, nil)
+}
+
+// BroadcastText sends text to all members, see BroadcastMessage.
+func (g *DataChannelGroup) BroadcastText(s string) error {
+	return g.BroadcastMessage(DataChannelMessage{IsString: true, Data: []byte(s)}, nil)
+}
+
+// BroadcastMessage sends the message to all members but except, which may be
+// nil, like the member that sent a chat message. It applies the members'
+// backpressure policies and returns the errors of the members the message
+// couldn't be sent to.
+func (g *DataChannelGroup) BroadcastMessage(msg DataChannelMessage, except *DataChannel) error {
+	type target struct {
+		dataChannel *DataChannel
+		member      *dataChannelGroupMember
+	}
+
+	g.mu.RLock()
+	targets := make([]target, 0, len(g.members))
+	for dataChannel, member := range g.members {
+		if member.joined && dataChannel != except {
+			targets = append(targets, target{dataChannel, member})
+		}
+	}
+	g.mu.RUnlock()
+
+	var errs []error
+	for _, target := range targets {
+		if target.dataChannel.BufferedAmount() > target.member.options.MaxBufferedAmount {
+			switch target.member.options.Backpressure {
+			case BackpressurePolicyDrop:
+				g.mu.Lock()
+				target.member.dropped++
+				g.mu.Unlock()
+
+				continue
+			case BackpressurePolicyClose:
+				g.leave(target.dataChannel)
+				errs = append(errs, target.dataChannel.Close())
+
+				continue
+			case BackpressurePolicyQueue:
+			}
+		}
+
+		var err error
+		if msg.IsString {
+			err = target.dataChannel.SendText(string(msg.Data))
+		} else {
+			err = target.dataChannel.Send(msg.Data)
+		}
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Close closes the DataChannels of all members. Members can't be added
+// afterwards.
+func (g *DataChannelGroup) Close() error {
+	g.mu.Lock()
+	g.closed = true
+	dataChannels := make([]*DataChannel, 0, len(g.members))
+	for dataChannel := range g.members {
+		dataChannels = append(dataChannels, dataChannel)
+	}
+	g.mu.Unlock()
+
+	var errs []error
+	for _, dataChannel := range dataChannels {
+		g.leave(dataChannel)
+		errs = append(errs, dataChannel.Close())
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackpressurePolicy_String(t *testing.T) {
+	for policy, expected := range map[BackpressurePolicy]string{
+		BackpressurePolicyDrop:  "drop",
+		BackpressurePolicyClose: "close",
+		BackpressurePolicyQueue: "queue",
+		BackpressurePolicy(42):  ErrUnknownType.Error(),
+	} {
+		assert.Equal(t, expected, policy.String())
+	}
+}
+
+func TestDataChannelGroup(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	group := NewDataChannelGroup("chat", nil)
+	joined := make(chan *DataChannel, 2)
+	left := make(chan *DataChannel, 2)
+	group.OnJoin(func(d *DataChannel) { joined <- d })
+	group.OnLeave(func(d *DataChannel) { left <- d })
+	group.OnMessage(func(from *DataChannel, msg DataChannelMessage) {
+		assert.NoError(t, group.BroadcastMessage(msg, from))
+	})
+
+	type client struct {
+		server, peer        *PeerConnection
+		member, peerChannel *DataChannel
+		messages            chan string
+	}
+
+	clients := make([]*client, 2)
+	for i := range clients {
+		server, peer, err := newPair()
+		require.NoError(t, err)
+
+		member, err := group.AddPeerConnection(server, DataChannelGroupMemberOptions{})
+		require.NoError(t, err)
+
+		c := &client{server: server, peer: peer, member: member, messages: make(chan string, 1)}
+		peerChannels := make(chan *DataChannel, 1)
+		peer.OnDataChannel(func(d *DataChannel) {
+			d.OnMessage(func(msg DataChannelMessage) {
+				select {
+				case c.messages <- string(msg.Data):
+				default:
+				}
+			})
+			peerChannels <- d
+		})
+
+		require.NoError(t, signalPair(server, peer))
+		c.peerChannel = <-peerChannels
+		clients[i] = c
+	}
+
+	assert.ElementsMatch(t, []*DataChannel{clients[0].member, clients[1].member}, []*DataChannel{<-joined, <-joined})
+	assert.Len(t, group.Members(), 2)
+
+	require.NoError(t, group.BroadcastText("hello"))
+	assert.Equal(t, "hello", <-clients[0].messages)
+	assert.Equal(t, "hello", <-clients[1].messages)
+
+	// A message of a member is forwarded to the others.
+	require.NoError(t, clients[0].peerChannel.SendText("hi"))
+	assert.Equal(t, "hi", <-clients[1].messages)
+	assert.Empty(t, clients[0].messages)
+
+	assert.ErrorIs(
+		t, NewDataChannelGroup("other", nil).Add(clients[0].member, DataChannelGroupMemberOptions{}),
+		errDataChannelGroupLabelMismatch,
+	)
+
+	// Rejoin with a buffered amount limit that large messages exceed.
+	slow := clients[1].member
+	rejoin := func(policy BackpressurePolicy) {
+		group.Remove(slow)
+		assert.Equal(t, slow, <-left)
+		require.NoError(t, group.Add(slow, DataChannelGroupMemberOptions{Backpressure: policy, MaxBufferedAmount: 1}))
+		assert.Equal(t, slow, <-joined)
+	}
+	large := make([]byte, 60000)
+
+	rejoin(BackpressurePolicyDrop)
+	require.Eventually(t, func() bool {
+		assert.NoError(t, group.Broadcast(large))
+
+		return group.DroppedMessages(slow) > 0
+	}, 5*time.Second, time.Millisecond)
+
+	rejoin(BackpressurePolicyClose)
+	require.Eventually(t, func() bool {
+		_ = group.Broadcast(large)
+
+		return len(group.Members()) == 1
+	}, 5*time.Second, time.Millisecond)
+	assert.Equal(t, slow, <-left)
+	assert.Equal(t, []*DataChannel{clients[0].member}, group.Members())
+
+	require.NoError(t, group.Close())
+	assert.Equal(t, clients[0].member, <-left)
+	assert.Empty(t, group.Members())
+	assert.ErrorIs(t, group.Add(clients[0].member, DataChannelGroupMemberOptions{}), errDataChannelGroupClosed)
+
+	for _, c := range clients {
+		closePairNow(t, c.server, c.peer)
+	}
+}
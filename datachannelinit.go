@@ -33,4 +33,64 @@ type DataChannelInit struct {
 
 	// ID overrides the default selection of ID for this channel.
 	ID *uint16
+
+	// Compression enables the compression of messages, see
+	// DataChannelCompression. Not supported with WASM (js).
+	Compression *DataChannelCompression
+
+	// Fragmentation enables sending messages larger than the maximum message
+	// size of the remote peer, see DataChannelFragmentation. Not supported with
+	// WASM (js).
+	Fragmentation *DataChannelFragmentation
+
+	// Priority is announced to the remote peer and used to schedule the
+	// messages of the channel, see SettingEngine.SetDataChannelScheduling. The
+	// default value is DataChannelPriorityLow.
+	Priority *DataChannelPriority
+}
+
+// DataChannelCompression configures the compression of a DataChannel's
+// messages with DEFLATE (RFC 1951), like permessage-deflate of WebSockets.
+//
+// Compression is a pion extension, the remote peer has to use this package as
+// well. It is announced by appending "; permessage-deflate" to the protocol
+// of the DataChannel. A remote peer that accepts it, see
+// SettingEngine.AcceptDataChannelCompression, confirms it with its first
+// message, compresses its messages as well and reports the protocol without
+// the extension. Messages are sent uncompressed until the remote peer
+// confirmed the compression, and for good if it doesn't. Only ordered
+// DataChannels can announce compression. Negotiated DataChannels have to
+// enable compression on both peers.
+type DataChannelCompression struct {
+	// Threshold is the size in bytes from which messages are compressed, zero
+	// selects 1024. Smaller messages and messages that don't shrink are sent
+	// uncompressed.
+	Threshold int
+}
+
+// DataChannelFragmentation configures the fragmentation of a DataChannel's
+// messages, so messages of any size can be sent regardless of the maximum
+// message size the remote peer accepts, see SCTPCapabilities.MaxMessageSize.
+// Every message is split into fragments that start with a header, the remote
+// peer reassembles them before they are passed to its OnMessage handler.
+//
+// Fragmentation is a pion extension, the remote peer has to use this package as
+// well. It is announced by appending "; pion-fragmentation" to the protocol of
+// the DataChannel and confirmed like DataChannelCompression, see
+// SettingEngine.AcceptDataChannelFragmentation. Messages aren't fragmented
+// until the remote peer confirmed the fragmentation, and for good if it
+// doesn't, so they have to fit into its maximum message size until then.
+// Negotiated DataChannels have to enable fragmentation on both peers. Only
+// reliable and ordered DataChannels can be fragmented, and they can't be
+// detached.
+type DataChannelFragmentation struct {
+	// FragmentSize is the largest fragment sent in bytes, including the header,
+	// zero selects 16384. Fragments are never larger than the maximum message
+	// size of the remote peer.
+	FragmentSize int
+
+	// MaxMessageSize is the largest message accepted in bytes once it is
+	// reassembled and decompressed, zero selects 16 MiB. Larger messages are
+	// dropped.
+	MaxMessageSize int
 }
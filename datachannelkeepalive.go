@@ -0,0 +1,240 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"encoding/binary"
+	"sync"
+	"time"
+)
+
+// KeepaliveProtocol is the DataChannel protocol of the channels created by
+// CreateKeepalive. The remote peer attaches a Keepalive to them with
+// OnDataChannelProtocol and NewKeepalive.
+const KeepaliveProtocol = "pion-keepalive"
+
+const (
+	defaultKeepaliveInterval = time.Second
+	defaultKeepaliveTimeout  = 5 * time.Second
+
+	keepaliveMessagePing = 1
+	keepaliveMessagePong = 2
+	keepaliveMessageSize = 9
+)
+
+// KeepaliveOptions controls how often a Keepalive pings the remote peer and
+// when it is considered unresponsive.
+type KeepaliveOptions struct {
+	// Interval between two pings, one second when zero.
+	Interval time.Duration
+
+	// Timeout after which the remote peer is considered unresponsive if
+	// nothing was received from it, five seconds when zero.
+	Timeout time.Duration
+}
+
+// Keepalive pings the remote application over a dedicated DataChannel and
+// measures the round trip time of its answers. Unlike ICE consent checks,
+// which are answered by the remote ICE agent, the answers are sent by the
+// remote Keepalive, so a hung remote application is detected even while the
+// PeerConnection stays connected. Both peers have to use a Keepalive on the
+// DataChannel.
+type Keepalive struct {
+	dataChannel *DataChannel
+	interval    time.Duration
+	timeout     time.Duration
+	start       time.Time
+
+	mu               sync.Mutex
+	rtt              time.Duration
+	lastReceived     time.Time
+	alive            bool
+	closed           bool
+	stop             chan struct{}
+	onRTT            func(time.Duration)
+	onLivenessChange func(alive bool)
+}
+
+// CreateKeepalive creates a DataChannel with the KeepaliveProtocol and returns
+// a Keepalive using it.
+func (pc *PeerConnection) CreateKeepalive(options KeepaliveOptions) (*Keepalive, error) {
+	protocol := KeepaliveProtocol
+	dataChannel, err := pc.CreateDataChannel(KeepaliveProtocol, &DataChannelInit{Protocol: &protocol})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKeepalive(dataChannel, options), nil
+}
+
+// NewKeepalive returns a Keepalive using a DataChannel, like one the remote peer
+// created with CreateKeepalive. The Keepalive takes over the OnOpen, OnMessage
+// and OnClose handlers of the DataChannel and starts pinging once it is open.
+func NewKeepalive(dataChannel *DataChannel, options KeepaliveOptions) *Keepalive {
+	keepalive := &Keepalive{
+		dataChannel: dataChannel,
+		interval:    options.Interval,
+		timeout:     options.Timeout,
+		start:       time.Now(),
+		stop:        make(chan struct{}),
+	}
+	if keepalive.interval <= 0 {
+		keepalive.interval = defaultKeepaliveInterval
+	}
+	if keepalive.timeout <= 0 {
+		keepalive.timeout = defaultKeepaliveTimeout
+	}
+
+	dataChannel.OnMessage(keepalive.handleMessage)
+	dataChannel.OnClose(func() {
+		_ = keepalive.Close()
+	})
+	dataChannel.OnOpen(func() {
+		go keepalive.run()
+	})
+
+	return keepalive
+}
+
+// DataChannel returns the DataChannel of the Keepalive.
+func (k *Keepalive) DataChannel() *DataChannel {
+	return k.dataChannel
+}
+
+// OnRTT sets an event handler which is invoked with the round trip time of
+// every answered ping.
+func (k *Keepalive) OnRTT(f func(rtt time.Duration)) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.onRTT = f
+}
+
+// OnLivenessChange sets an event handler which is invoked with false when
+// nothing was received from the remote peer within the timeout, and with true
+// when it becomes responsive again.
+func (k *Keepalive) OnLivenessChange(f func(alive bool)) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.onLivenessChange = f
+}
+
+// RTT returns the round trip time of the last answered ping, or zero if no ping
+// was answered yet.
+func (k *Keepalive) RTT() time.Duration {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	return k.rtt
+}
+
+// Alive returns false before the DataChannel is open and once nothing was
+// received from the remote peer within the timeout.
+func (k *Keepalive) Alive() bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	return k.alive
+}
+
+// Close stops pinging the remote peer. The DataChannel isn't closed.
+func (k *Keepalive) Close() error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if !k.closed {
+		k.closed = true
+		close(k.stop)
+	}
+
+	return nil
+}
+
+func (k *Keepalive) run() {
+	k.mu.Lock()
+	if k.closed {
+		k.mu.Unlock()
+
+		return
+	}
+	k.lastReceived = time.Now()
+	k.alive = true
+	k.mu.Unlock()
+
+	ticker := time.NewTicker(k.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := k.send(keepaliveMessagePing, k.now()); err != nil {
+			return
+		}
+
+		select {
+		case <-k.stop:
+			return
+		case <-ticker.C:
+		}
+
+		k.mu.Lock()
+		expired := k.alive && time.Since(k.lastReceived) > k.timeout
+		if expired {
+			k.alive = false
+		}
+		handler := k.onLivenessChange
+		k.mu.Unlock()
+
+		if expired && handler != nil {
+			handler(false)
+		}
+	}
+}
+
+func (k *Keepalive) handleMessage(msg DataChannelMessage) {
+	if msg.IsString || len(msg.Data) != keepaliveMessageSize {
+		return
+	}
+
+	sent := time.Duration(binary.BigEndian.Uint64(msg.Data[1:])) //nolint:gosec // G115
+	if msg.Data[0] == keepaliveMessagePing {
+		_ = k.send(keepaliveMessagePong, sent)
+	}
+
+	k.mu.Lock()
+	revived := !k.alive && !k.lastReceived.IsZero()
+	k.lastReceived = time.Now()
+	k.alive = true
+	onLivenessChange := k.onLivenessChange
+
+	var onRTT func(time.Duration)
+	rtt := k.now() - sent
+	if msg.Data[0] == keepaliveMessagePong && rtt >= 0 {
+		k.rtt = rtt
+		onRTT = k.onRTT
+	}
+	k.mu.Unlock()
+
+	if revived && onLivenessChange != nil {
+		onLivenessChange(true)
+	}
+	if onRTT != nil {
+		onRTT(rtt)
+	}
+}
+
+// send sends a ping or pong, carrying the time a ping was sent relative to the
+// start of the Keepalive that sent it.
+func (k *Keepalive) send(messageType byte, sent time.Duration) error {
+	msg := make([]byte, keepaliveMessageSize)
+	msg[0] = messageType
+	binary.BigEndian.PutUint64(msg[1:], uint64(sent)) //nolint:gosec // G115
+
+	return k.dataChannel.Send(msg)
+}
+
+func (k *Keepalive) now() time.Duration {
+	return time.Since(k.start)
+}
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeepalive(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	pcOffer, pcAnswer, err := newPair()
+	require.NoError(t, err)
+
+	options := KeepaliveOptions{Interval: 20 * time.Millisecond, Timeout: time.Second}
+	offerKeepalive, err := pcOffer.CreateKeepalive(options)
+	require.NoError(t, err)
+	assert.False(t, offerKeepalive.Alive())
+
+	rtts := make(chan time.Duration, 1)
+	offerKeepalive.OnRTT(func(rtt time.Duration) {
+		select {
+		case rtts <- rtt:
+		default:
+		}
+	})
+
+	answerKeepalives := make(chan *Keepalive, 1)
+	pcAnswer.OnDataChannelProtocol(KeepaliveProtocol, func(d *DataChannel) {
+		answerKeepalives <- NewKeepalive(d, options)
+	})
+
+	require.NoError(t, signalPair(pcOffer, pcAnswer))
+
+	assert.Greater(t, <-rtts, time.Duration(0))
+	assert.Greater(t, offerKeepalive.RTT(), time.Duration(0))
+	assert.True(t, offerKeepalive.Alive())
+
+	answerKeepalive := <-answerKeepalives
+	assert.Eventually(t, func() bool {
+		return answerKeepalive.RTT() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
+
+func TestKeepalive_Unresponsive(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	pcOffer, pcAnswer, err := newPair()
+	require.NoError(t, err)
+
+	keepalive, err := pcOffer.CreateKeepalive(KeepaliveOptions{
+		Interval: 10 * time.Millisecond,
+		Timeout:  50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	liveness := make(chan bool, 2)
+	keepalive.OnLivenessChange(func(alive bool) {
+		select {
+		case liveness <- alive:
+		default:
+		}
+	})
+
+	// The remote application doesn't answer until it attaches a Keepalive.
+	answerChannels := make(chan *DataChannel, 1)
+	pcAnswer.OnDataChannel(func(d *DataChannel) {
+		answerChannels <- d
+	})
+
+	require.NoError(t, signalPair(pcOffer, pcAnswer))
+	answerChannel := <-answerChannels
+
+	assert.False(t, <-liveness)
+	assert.False(t, keepalive.Alive())
+	assert.Zero(t, keepalive.RTT())
+
+	answerKeepalive := NewKeepalive(answerChannel, KeepaliveOptions{})
+	assert.True(t, <-liveness)
+	assert.True(t, keepalive.Alive())
+
+	assert.NoError(t, answerKeepalive.Close())
+	assert.NoError(t, keepalive.Close())
+	closePairNow(t, pcOffer, pcAnswer)
+}
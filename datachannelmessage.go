@@ -6,7 +6,8 @@ package webrtc
 // DataChannelMessage represents a message received from the
 // data channel. IsString will be set to true if the incoming
 // message is of the string type. Otherwise the message is of
-// a binary type.
+// a binary type. Empty messages keep their type, their Data is empty but not
+// nil.
 type DataChannelMessage struct {
 	IsString bool
 	Data     []byte
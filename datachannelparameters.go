@@ -12,4 +12,12 @@ type DataChannelParameters struct {
 	MaxPacketLifeTime *uint16 `json:"maxPacketLifeTime"`
 	MaxRetransmits    *uint16 `json:"maxRetransmits"`
 	Negotiated        bool    `json:"negotiated"`
+
+	// Compression is a pion extension, see DataChannelCompression.
+	Compression *DataChannelCompression `json:"compression,omitempty"`
+
+	// Fragmentation is a pion extension, see DataChannelFragmentation.
+	Fragmentation *DataChannelFragmentation `json:"fragmentation,omitempty"`
+
+	Priority DataChannelPriority `json:"priority,omitempty"`
 }
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package webrtc
+
+// DataChannelPriority is the priority of a DataChannel, the RTCPriorityType of
+// the W3C WebRTC specification. It is announced to the remote peer when the
+// DataChannel is opened, see SettingEngine.SetDataChannelScheduling for how
+// it affects sending.
+type DataChannelPriority int
+
+const (
+	// DataChannelPriorityUnknown is the enum's zero-value.
+	DataChannelPriorityUnknown DataChannelPriority = iota
+
+	// DataChannelPriorityVeryLow is for bulk transfers that may be starved by
+	// all other DataChannels.
+	DataChannelPriorityVeryLow
+
+	// DataChannelPriorityLow is the default priority of DataChannels.
+	DataChannelPriorityLow
+
+	// DataChannelPriorityMedium is above the default priority.
+	DataChannelPriorityMedium
+
+	// DataChannelPriorityHigh is for latency sensitive messages, like control
+	// messages.
+	DataChannelPriorityHigh
+)
+
+// This is done this way because of a linter.
+const (
+	dataChannelPriorityVeryLowStr = "very-low"
+	dataChannelPriorityLowStr     = "low"
+	dataChannelPriorityMediumStr  = "medium"
+	dataChannelPriorityHighStr    = "high"
+)
+
+func newDataChannelPriority(raw string) DataChannelPriority {
+	switch raw {
+	case dataChannelPriorityVeryLowStr:
+		return DataChannelPriorityVeryLow
+	case dataChannelPriorityLowStr:
+		return DataChannelPriorityLow
+	case dataChannelPriorityMediumStr:
+		return DataChannelPriorityMedium
+	case dataChannelPriorityHighStr:
+		return DataChannelPriorityHigh
+	default:
+		return DataChannelPriorityUnknown
+	}
+}
+
+func (p DataChannelPriority) String() string {
+	switch p {
+	case DataChannelPriorityVeryLow:
+		return dataChannelPriorityVeryLowStr
+	case DataChannelPriorityLow:
+		return dataChannelPriorityLowStr
+	case DataChannelPriorityMedium:
+		return dataChannelPriorityMediumStr
+	case DataChannelPriorityHigh:
+		return dataChannelPriorityHighStr
+	default:
+		return ErrUnknownType.Error()
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (p DataChannelPriority) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *DataChannelPriority) UnmarshalText(b []byte) error {
+	*p = newDataChannelPriority(string(b))
+
+	return nil
+}
@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package webrtc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataChannelPriority_String(t *testing.T) {
+	testCases := []struct {
+		priority       DataChannelPriority
+		expectedString string
+	}{
+		{DataChannelPriorityUnknown, ErrUnknownType.Error()},
+		{DataChannelPriorityVeryLow, "very-low"},
+		{DataChannelPriorityLow, "low"},
+		{DataChannelPriorityMedium, "medium"},
+		{DataChannelPriorityHigh, "high"},
+	}
+
+	for i, testCase := range testCases {
+		assert.Equal(t,
+			testCase.expectedString,
+			testCase.priority.String(),
+			"testCase: %d %v", i, testCase,
+		)
+		assert.Equal(t,
+			testCase.priority,
+			newDataChannelPriority(testCase.expectedString),
+			"testCase: %d %v", i, testCase,
+		)
+	}
+}
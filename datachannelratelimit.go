@@ -0,0 +1,202 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	// dataChannelRateLimitBurst is how long a DataChannel may send at its
+	// maximum send rate before it is throttled, after it didn't send for a while.
+	dataChannelRateLimitBurst = 100 * time.Millisecond
+
+	// dataChannelRateLimitInterval is how often the queued messages of a rate
+	// limited DataChannel are checked.
+	dataChannelRateLimitInterval = 5 * time.Millisecond
+)
+
+// dataChannelRateLimiter is a token bucket of bytes. Messages are queued while
+// the bucket is empty, a message larger than the tokens in the bucket is sent
+// as long as some are left and the bucket has to refill the debt.
+type dataChannelRateLimiter struct {
+	mu sync.Mutex
+
+	// rate is in bytes per second, 0 is unlimited.
+	rate    float64
+	tokens  float64
+	updated time.Time
+
+	queue   []dataChannelScheduledMessage
+	running bool
+	sending bool
+}
+
+// SetMaxSendRate limits the rate Send and SendText pass messages to the SCTP
+// association to bitsPerSecond, a bulk transfer doesn't fill the congestion
+// window shared with the other DataChannels then. Messages sent faster are
+// queued and count towards BufferedAmount. After the DataChannel didn't send
+// for a while it may send 100ms worth of messages at once. 0 removes the limit,
+// which is the default. Messages sent on a detached DataChannel aren't limited.
+func (d *DataChannel) SetMaxSendRate(bitsPerSecond uint64) {
+	d.rateLimiter.setRate(float64(bitsPerSecond) / 8)
+}
+
+// MaxSendRate returns the maximum send rate in bits per second, see
+// SetMaxSendRate.
+func (d *DataChannel) MaxSendRate() uint64 {
+	d.rateLimiter.mu.Lock()
+	defer d.rateLimiter.mu.Unlock()
+
+	return uint64(d.rateLimiter.rate * 8)
+}
+
+func (l *dataChannelRateLimiter) setRate(rate float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// A DataChannel that wasn't limited starts with a full bucket.
+	now := time.Now()
+	if l.rate == 0 {
+		l.tokens = rate * dataChannelRateLimitBurst.Seconds()
+	} else {
+		l.refill(now)
+	}
+	l.rate = rate
+	l.tokens = min(l.tokens, l.capacity())
+	l.updated = now
+}
+
+// capacity returns the tokens the bucket holds at most. The caller holds the
+// lock.
+func (l *dataChannelRateLimiter) capacity() float64 {
+	return l.rate * dataChannelRateLimitBurst.Seconds()
+}
+
+// refill adds the tokens since the last update. The caller holds the lock.
+func (l *dataChannelRateLimiter) refill(now time.Time) {
+	l.tokens = min(l.tokens+now.Sub(l.updated).Seconds()*l.rate, l.capacity())
+	l.updated = now
+}
+
+// active returns true if the rate is limited or messages are still queued.
+func (l *dataChannelRateLimiter) active() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.rate != 0 || len(l.queue) != 0 || l.sending
+}
+
+// enqueue queues the message and returns true if the DataChannel has to wait
+// before sending it, otherwise the caller sends it right away.
+func (l *dataChannelRateLimiter) enqueue(message dataChannelScheduledMessage) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	d, data := message.dataChannel, message.data
+	if l.rate == 0 && len(l.queue) == 0 && !l.sending {
+		return false
+	}
+
+	l.refill(time.Now())
+	if len(l.queue) == 0 && !l.sending && l.tokens > 0 {
+		l.tokens -= float64(len(data))
+
+		return false
+	}
+
+	l.queue = append(l.queue, message)
+	d.scheduledBytes.Add(int64(len(data)))
+
+	if !l.running {
+		l.running = true
+		go l.run(d)
+	}
+
+	return true
+}
+
+// next removes the message to send next once the bucket has tokens. It returns
+// false and stops the limiter when no message is queued. Until next is called
+// again the message is being sent, so new messages can't overtake it.
+func (l *dataChannelRateLimiter) next() (message dataChannelScheduledMessage, ready, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sending = false
+	if len(l.queue) == 0 {
+		l.running = false
+
+		return message, false, false
+	}
+
+	if l.rate != 0 {
+		if l.refill(time.Now()); l.tokens <= 0 {
+			return message, false, true
+		}
+		l.tokens -= float64(len(l.queue[0].data))
+	}
+
+	message = l.queue[0]
+	l.queue[0] = dataChannelScheduledMessage{}
+	l.queue = l.queue[1:]
+	l.sending = true
+
+	return message, true, true
+}
+
+// clear drops the queued messages once the DataChannel isn't open anymore.
+func (l *dataChannelRateLimiter) clear(d *DataChannel) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, message := range l.queue {
+		d.scheduledBytes.Add(-int64(len(message.data)))
+		message.finish(io.ErrClosedPipe)
+	}
+	l.queue = nil
+	l.running = false
+	l.sending = false
+}
+
+func (l *dataChannelRateLimiter) run(d *DataChannel) {
+	ticker := time.NewTicker(dataChannelRateLimitInterval)
+	defer ticker.Stop()
+
+	for {
+		if d.ReadyState() != DataChannelStateOpen {
+			l.clear(d)
+
+			return
+		}
+
+		message, ready, ok := l.next()
+		if !ok {
+			return
+		}
+		if !ready {
+			<-ticker.C
+
+			continue
+		}
+
+		if message.canceled() {
+			message.finish(message.ctx.Err())
+		} else if err := d.sendUnlimited(message); err != nil && message.sent == nil {
+			// The errors of SendContext are returned to its caller.
+			d.log.Warnf("Failed to send rate limited message on DataChannel %s: %v", d.label, err)
+			d.onError(err)
+		}
+		d.scheduledBytes.Add(-int64(len(message.data)))
+
+		// The stream of the DataChannel stays almost empty while the messages
+		// wait for the rate limit, it doesn't report the BufferedAmount
+		// decreasing to the threshold.
+		d.checkBufferedAmount(false)
+	}
+}
@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataChannelRateLimiter_Debt(t *testing.T) {
+	l := dataChannelRateLimiter{}
+	l.setRate(1000)
+
+	assert.Equal(t, float64(100), l.tokens)
+	now := l.updated
+
+	// A message larger than the bucket is sent, the bucket refills the debt.
+	l.tokens -= 300
+	l.refill(now.Add(100 * time.Millisecond))
+	assert.InDelta(t, -100, l.tokens, 0.001)
+	l.refill(now.Add(time.Second))
+	assert.InDelta(t, 100, l.tokens, 0.001)
+}
+
+func TestDataChannel_SetMaxSendRate(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	const (
+		messages = 20
+		rate     = 800 * 1000 // 100 KB/s
+	)
+
+	offerPC, answerPC, err := newPair()
+	require.NoError(t, err)
+
+	var (
+		mu       sync.Mutex
+		received = map[string]int{}
+		finished = map[string]time.Time{}
+	)
+	allReceived, allReceivedDone := context.WithCancel(context.Background())
+	answerPC.OnDataChannel(func(dc *DataChannel) {
+		label := dc.Label()
+		dc.OnMessage(func(DataChannelMessage) {
+			mu.Lock()
+			defer mu.Unlock()
+			if received[label]++; received[label] == messages {
+				finished[label] = time.Now()
+				if len(finished) == 2 {
+					allReceivedDone()
+				}
+			}
+		})
+	})
+
+	var opened sync.WaitGroup
+	newDataChannel := func(label string) *DataChannel {
+		dc, createErr := offerPC.CreateDataChannel(label, nil)
+		require.NoError(t, createErr)
+		opened.Add(1)
+		dc.OnOpen(opened.Done)
+
+		return dc
+	}
+	limited := newDataChannel("limited")
+	unlimited := newDataChannel("unlimited")
+
+	require.NoError(t, signalPair(offerPC, answerPC))
+	opened.Wait()
+
+	limited.SetMaxSendRate(rate)
+	assert.Equal(t, uint64(rate), limited.MaxSendRate())
+	assert.Zero(t, unlimited.MaxSendRate())
+
+	payload := make([]byte, 10*1024)
+	start := time.Now()
+	for i := 0; i < messages; i++ {
+		require.NoError(t, limited.Send(payload))
+		require.NoError(t, unlimited.Send(payload))
+	}
+	// The messages beyond the burst are queued by the rate limiter.
+	assert.Greater(t, limited.BufferedAmount(), uint64(messages/2*len(payload)))
+
+	<-allReceived.Done()
+
+	mu.Lock()
+	// 200 KB at 100 KB/s, minus the burst and the debt of the last message.
+	assert.Greater(t, finished["limited"].Sub(start), 1500*time.Millisecond)
+	assert.True(t, finished["unlimited"].Before(finished["limited"]))
+	mu.Unlock()
+	assert.Eventually(t, func() bool {
+		return limited.BufferedAmount() == 0
+	}, time.Second, 10*time.Millisecond)
+
+	closePairNow(t, offerPC, answerPC)
+}
+
+func TestDataChannel_SetMaxSendRateBufferedAmountLow(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	const threshold = 64 * 1024
+
+	offerPC, answerPC, err := newPair()
+	require.NoError(t, err)
+
+	dc, err := offerPC.CreateDataChannel("limited", nil)
+	require.NoError(t, err)
+	opened := make(chan struct{})
+	dc.OnOpen(func() {
+		close(opened)
+	})
+
+	require.NoError(t, signalPair(offerPC, answerPC))
+	<-opened
+
+	// The messages wait for the rate limit, the stream stays almost empty.
+	dc.SetMaxSendRate(8 * 1000 * 1000)
+	bufferedAmounts := make(chan uint64, 1)
+	dc.SetBufferedAmountLowThreshold(threshold)
+	dc.OnBufferedAmountLow(func() {
+		select {
+		case bufferedAmounts <- dc.BufferedAmount():
+		default:
+		}
+	})
+
+	payload := make([]byte, 16*1024)
+	for i := 0; i < 16; i++ {
+		require.NoError(t, dc.Send(payload))
+	}
+	assert.Greater(t, dc.BufferedAmount(), uint64(threshold))
+
+	select {
+	case bufferedAmount := <-bufferedAmounts:
+		assert.LessOrEqual(t, bufferedAmount, uint64(threshold))
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "timed out waiting for OnBufferedAmountLow")
+	}
+
+	closePairNow(t, offerPC, answerPC)
+}
@@ -0,0 +1,360 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"context"
+	"io"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pion/datachannel"
+)
+
+const (
+	// defaultDataChannelSchedulingMaxBufferedAmount is the buffered amount of the
+	// SCTP association above which messages are scheduled, when
+	// SettingEngine.SetDataChannelScheduling is called without one.
+	defaultDataChannelSchedulingMaxBufferedAmount = 64 * 1024
+
+	// dataChannelSchedulerInterval is how often the scheduler checks whether the
+	// SCTP association accepts the next message.
+	dataChannelSchedulerInterval = 5 * time.Millisecond
+
+	dataChannelPriorities = int(DataChannelPriorityHigh)
+)
+
+// DataChannelSchedulingPolicy decides which DataChannel sends next when the
+// SCTP association is congested, see SettingEngine.SetDataChannelScheduling.
+type DataChannelSchedulingPolicy int
+
+const (
+	// DataChannelSchedulingPolicyFIFO passes the messages of all DataChannels to
+	// the SCTP association in the order they are sent. This is the default.
+	DataChannelSchedulingPolicyFIFO DataChannelSchedulingPolicy = iota
+
+	// DataChannelSchedulingPolicyStrict sends the messages of the DataChannels
+	// with the highest priority first. DataChannels with a lower priority only
+	// send when no DataChannel with a higher priority has messages queued.
+	DataChannelSchedulingPolicyStrict
+
+	// DataChannelSchedulingPolicyWeighted shares the SCTP association between
+	// the priorities in proportion to their weights, the DCEP priorities of RFC
+	// 8832. A high priority DataChannel sends eight times the bytes of a very-low
+	// priority one, but none of them is starved.
+	DataChannelSchedulingPolicyWeighted
+)
+
+// This is done this way because of a linter.
+const (
+	dataChannelSchedulingPolicyFIFOStr     = "fifo"
+	dataChannelSchedulingPolicyStrictStr   = "strict"
+	dataChannelSchedulingPolicyWeightedStr = "weighted"
+)
+
+func (p DataChannelSchedulingPolicy) String() string {
+	switch p {
+	case DataChannelSchedulingPolicyFIFO:
+		return dataChannelSchedulingPolicyFIFOStr
+	case DataChannelSchedulingPolicyStrict:
+		return dataChannelSchedulingPolicyStrictStr
+	case DataChannelSchedulingPolicyWeighted:
+		return dataChannelSchedulingPolicyWeightedStr
+	default:
+		return ErrUnknownType.Error()
+	}
+}
+
+// dcepPriority returns the priority of the DATA_CHANNEL_OPEN message, see
+// RFC 8832 section 6.4.
+func (p DataChannelPriority) dcepPriority() uint16 {
+	switch p {
+	case DataChannelPriorityVeryLow:
+		return datachannel.ChannelPriorityBelowNormal
+	case DataChannelPriorityMedium:
+		return datachannel.ChannelPriorityHigh
+	case DataChannelPriorityHigh:
+		return datachannel.ChannelPriorityExtraHigh
+	default:
+		return datachannel.ChannelPriorityNormal
+	}
+}
+
+// dataChannelPriorityFromDCEP returns the priority of a DATA_CHANNEL_OPEN
+// message. Priorities between the ones of RFC 8832 are rounded up.
+func dataChannelPriorityFromDCEP(priority uint16) DataChannelPriority {
+	switch {
+	case priority <= datachannel.ChannelPriorityBelowNormal:
+		return DataChannelPriorityVeryLow
+	case priority <= datachannel.ChannelPriorityNormal:
+		return DataChannelPriorityLow
+	case priority <= datachannel.ChannelPriorityHigh:
+		return DataChannelPriorityMedium
+	default:
+		return DataChannelPriorityHigh
+	}
+}
+
+type dataChannelScheduledMessage struct {
+	dataChannel *DataChannel
+	data        []byte
+	isString    bool
+
+	// ctx is the context of a message sent with SendContext, which waits for
+	// the result of the write on sent. The message is dropped if the context is
+	// done before it is written.
+	ctx  context.Context //nolint:containedctx
+	sent chan error
+}
+
+// canceled returns true if the context of the message is done.
+func (m dataChannelScheduledMessage) canceled() bool {
+	return m.ctx != nil && m.ctx.Err() != nil
+}
+
+// finish passes the result of the write to SendContext.
+func (m dataChannelScheduledMessage) finish(err error) {
+	if m.sent != nil {
+		m.sent <- err
+	}
+}
+
+// wait returns the result of the write once the message was written or
+// dropped, or the error of its context once it is done.
+func (m dataChannelScheduledMessage) wait() error {
+	if m.sent == nil {
+		return nil
+	}
+
+	select {
+	case err := <-m.sent:
+		return err
+	case <-m.ctx.Done():
+		return m.ctx.Err()
+	}
+}
+
+// dataChannelScheduler queues the messages of the DataChannels of an
+// SCTPTransport while the buffered amount of its association exceeds the
+// limit, and passes them to the association by priority once it drains.
+type dataChannelScheduler struct {
+	transport         *SCTPTransport
+	policy            DataChannelSchedulingPolicy
+	maxBufferedAmount int
+
+	mu          sync.Mutex
+	queues      [dataChannelPriorities][]dataChannelScheduledMessage
+	queued      int
+	queuedBytes int
+	running     bool
+	sending     bool
+
+	// virtualTimes are the bytes sent by each priority divided by its weight,
+	// the weighted policy sends from the queue with the lowest one.
+	virtualTimes [dataChannelPriorities]uint64
+}
+
+func newDataChannelScheduler(transport *SCTPTransport) *dataChannelScheduler {
+	settings := transport.api.settingEngine.sctp
+	if settings.schedulingPolicy == DataChannelSchedulingPolicyFIFO {
+		return nil
+	}
+
+	maxBufferedAmount := settings.schedulingMaxBufferedAmount
+	if maxBufferedAmount == 0 {
+		maxBufferedAmount = defaultDataChannelSchedulingMaxBufferedAmount
+	}
+
+	return &dataChannelScheduler{
+		transport:         transport,
+		policy:            settings.schedulingPolicy,
+		maxBufferedAmount: maxBufferedAmount,
+	}
+}
+
+func priorityQueueIndex(priority DataChannelPriority) int {
+	if priority == DataChannelPriorityUnknown {
+		priority = DataChannelPriorityLow
+	}
+
+	return int(priority) - 1
+}
+
+func priorityWeight(index int) uint64 {
+	return uint64(DataChannelPriority(index + 1).dcepPriority())
+}
+
+// send writes the message to the DataChannel if neither the association is
+// congested nor messages are queued, otherwise it queues the message.
+func (s *dataChannelScheduler) send(message dataChannelScheduledMessage) error {
+	association := s.transport.association()
+	if association == nil {
+		message.finish(errSCTPNotEstablished)
+
+		return errSCTPNotEstablished
+	}
+
+	d, data := message.dataChannel, message.data
+	s.mu.Lock()
+	if s.queued == 0 && !s.sending && association.BufferedAmount() < s.maxBufferedAmount {
+		s.mu.Unlock()
+
+		return d.writeScheduled(message)
+	}
+
+	index := priorityQueueIndex(d.Priority())
+	if len(s.queues[index]) == 0 {
+		s.activate(index)
+	}
+	s.queues[index] = append(s.queues[index], message)
+	s.queued++
+	s.queuedBytes += len(data)
+	d.scheduledBytes.Add(int64(len(data)))
+
+	if !s.running {
+		s.running = true
+		go s.run()
+	}
+	s.mu.Unlock()
+	d.checkBufferedAmount(false)
+
+	return nil
+}
+
+// activate keeps a priority that didn't send for a while from catching up on
+// the others, its virtual time starts at the lowest one of the queued
+// priorities. The caller holds the lock.
+func (s *dataChannelScheduler) activate(index int) {
+	if s.queued == 0 {
+		s.virtualTimes = [dataChannelPriorities]uint64{}
+
+		return
+	}
+
+	minVirtualTime := uint64(math.MaxUint64)
+	for i, queue := range s.queues {
+		if len(queue) != 0 {
+			minVirtualTime = min(minVirtualTime, s.virtualTimes[i])
+		}
+	}
+	s.virtualTimes[index] = max(s.virtualTimes[index], minVirtualTime)
+}
+
+// next removes the message to send next, it returns false and stops the
+// scheduler when no message is queued. Until next is called again the message
+// is being sent, so new messages of its DataChannel can't overtake it.
+func (s *dataChannelScheduler) next() (dataChannelScheduledMessage, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sending = false
+
+	index := -1
+	for i := len(s.queues) - 1; i >= 0; i-- {
+		if len(s.queues[i]) == 0 {
+			continue
+		}
+		if index == -1 {
+			index = i
+		} else if s.policy == DataChannelSchedulingPolicyWeighted && s.virtualTimes[i] < s.virtualTimes[index] {
+			index = i
+		}
+		if s.policy == DataChannelSchedulingPolicyStrict {
+			break
+		}
+	}
+	if index == -1 {
+		s.running = false
+
+		return dataChannelScheduledMessage{}, false
+	}
+
+	message := s.queues[index][0]
+	s.queues[index][0] = dataChannelScheduledMessage{}
+	s.queues[index] = s.queues[index][1:]
+	s.queued--
+	s.queuedBytes -= len(message.data)
+	s.sending = true
+	s.virtualTimes[index] += uint64(len(message.data)) * priorityWeight(dataChannelPriorities-1) / priorityWeight(index)
+
+	return message, true
+}
+
+// queuedAmount returns the bytes of the queued messages, the scheduler may be
+// nil.
+func (s *dataChannelScheduler) queuedAmount() int {
+	if s == nil {
+		return 0
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.queuedBytes
+}
+
+// clear drops the queued messages once the association is closed.
+func (s *dataChannelScheduler) clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, queue := range s.queues {
+		for _, message := range queue {
+			message.dataChannel.scheduledBytes.Add(-int64(len(message.data)))
+			message.finish(io.ErrClosedPipe)
+		}
+		s.queues[i] = nil
+	}
+	s.queued = 0
+	s.queuedBytes = 0
+	s.running = false
+	s.sending = false
+}
+
+func (s *dataChannelScheduler) run() {
+	ticker := time.NewTicker(dataChannelSchedulerInterval)
+	defer ticker.Stop()
+
+	for {
+		association := s.transport.association()
+		if association == nil {
+			s.clear()
+
+			return
+		}
+		if association.BufferedAmount() >= s.maxBufferedAmount {
+			<-ticker.C
+
+			continue
+		}
+
+		message, ok := s.next()
+		if !ok {
+			return
+		}
+
+		d := message.dataChannel
+		switch {
+		case d.ReadyState() != DataChannelStateOpen:
+			message.finish(io.ErrClosedPipe)
+		case message.canceled():
+			message.finish(message.ctx.Err())
+		default:
+			// The errors of SendContext are returned to its caller.
+			if err := d.writeScheduled(message); err != nil && message.sent == nil {
+				d.log.Warnf("Failed to send scheduled message on DataChannel %s: %v", d.label, err)
+				d.onError(err)
+			}
+		}
+		d.scheduledBytes.Add(-int64(len(message.data)))
+
+		// The stream of the DataChannel stays below the buffered amount of the
+		// association, it doesn't report the BufferedAmount decreasing to a
+		// threshold above it.
+		d.checkBufferedAmount(false)
+	}
+}
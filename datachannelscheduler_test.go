@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pion/datachannel"
+	"github.com/pion/transport/v4/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataChannelPriorityDCEP(t *testing.T) {
+	for _, priority := range []DataChannelPriority{
+		DataChannelPriorityVeryLow,
+		DataChannelPriorityLow,
+		DataChannelPriorityMedium,
+		DataChannelPriorityHigh,
+	} {
+		assert.Equal(t, priority, dataChannelPriorityFromDCEP(priority.dcepPriority()))
+	}
+
+	assert.Equal(t, datachannel.ChannelPriorityNormal, DataChannelPriorityUnknown.dcepPriority())
+	assert.Equal(t, DataChannelPriorityMedium, dataChannelPriorityFromDCEP(300))
+	assert.Equal(t, DataChannelPriorityHigh, dataChannelPriorityFromDCEP(2048))
+}
+
+func TestDataChannelScheduler_Next(t *testing.T) {
+	veryLow := &DataChannel{priority: DataChannelPriorityVeryLow}
+	high := &DataChannel{priority: DataChannelPriorityHigh}
+
+	schedule := func(policy DataChannelSchedulingPolicy, picks int) map[*DataChannel]int {
+		s := &dataChannelScheduler{policy: policy}
+		for i := 0; i < 100; i++ {
+			for _, d := range []*DataChannel{veryLow, high} {
+				index := priorityQueueIndex(d.priority)
+				if len(s.queues[index]) == 0 {
+					s.activate(index)
+				}
+				s.queues[index] = append(s.queues[index], dataChannelScheduledMessage{
+					dataChannel: d,
+					data:        make([]byte, 1000),
+				})
+				s.queued++
+			}
+		}
+
+		sent := map[*DataChannel]int{}
+		for i := 0; i < picks; i++ {
+			message, ok := s.next()
+			require.True(t, ok)
+			sent[message.dataChannel]++
+		}
+
+		return sent
+	}
+
+	sent := schedule(DataChannelSchedulingPolicyStrict, 100)
+	assert.Equal(t, 100, sent[high])
+	assert.Zero(t, sent[veryLow])
+
+	sent = schedule(DataChannelSchedulingPolicyWeighted, 90)
+	assert.Equal(t, 80, sent[high])
+	assert.Equal(t, 10, sent[veryLow])
+}
+
+func TestDataChannelScheduling(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	const bulkMessages = 128
+
+	s := SettingEngine{}
+	s.SetDataChannelScheduling(DataChannelSchedulingPolicyStrict, 16*1024)
+	offerPC, answerPC, err := NewAPI(WithSettingEngine(s)).newPair(Configuration{})
+	require.NoError(t, err)
+
+	var (
+		mu               sync.Mutex
+		bulkReceived     int
+		bulkAtControl    = -1
+		announcedControl DataChannelPriority
+	)
+	received, receivedDone := context.WithCancel(context.Background())
+	answerPC.OnDataChannel(func(dc *DataChannel) {
+		switch dc.Label() {
+		case "bulk":
+			dc.OnMessage(func(DataChannelMessage) {
+				mu.Lock()
+				defer mu.Unlock()
+				if bulkReceived++; bulkReceived == bulkMessages && bulkAtControl != -1 {
+					receivedDone()
+				}
+			})
+		case "control":
+			mu.Lock()
+			announcedControl = dc.Priority()
+			mu.Unlock()
+			dc.OnMessage(func(DataChannelMessage) {
+				mu.Lock()
+				defer mu.Unlock()
+				if bulkAtControl = bulkReceived; bulkReceived == bulkMessages {
+					receivedDone()
+				}
+			})
+		}
+	})
+
+	var opened sync.WaitGroup
+	newDataChannel := func(label string, priority DataChannelPriority) *DataChannel {
+		dc, createErr := offerPC.CreateDataChannel(label, &DataChannelInit{Priority: &priority})
+		require.NoError(t, createErr)
+		opened.Add(1)
+		dc.OnOpen(opened.Done)
+
+		return dc
+	}
+	bulk := newDataChannel("bulk", DataChannelPriorityVeryLow)
+	control := newDataChannel("control", DataChannelPriorityHigh)
+
+	require.NoError(t, signalPair(offerPC, answerPC))
+	opened.Wait()
+
+	payload := make([]byte, 16*1024)
+	for i := 0; i < bulkMessages; i++ {
+		require.NoError(t, bulk.Send(payload))
+	}
+	assert.Greater(t, bulk.BufferedAmount(), uint64(len(payload)))
+	require.NoError(t, control.SendText("control"))
+
+	<-received.Done()
+
+	mu.Lock()
+	assert.Equal(t, DataChannelPriorityHigh, announcedControl)
+	assert.Less(t, bulkAtControl, bulkMessages/2, "the control message waited for the bulk messages")
+	mu.Unlock()
+
+	closePairNow(t, offerPC, answerPC)
+}
+
+func TestDataChannelScheduling_BufferedAmountLow(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	const threshold = 512 * 1024
+
+	s := SettingEngine{}
+	s.SetDataChannelScheduling(DataChannelSchedulingPolicyStrict, 16*1024)
+	offerPC, answerPC, err := NewAPI(WithSettingEngine(s)).newPair(Configuration{})
+	require.NoError(t, err)
+
+	dc, err := offerPC.CreateDataChannel("bulk", nil)
+	require.NoError(t, err)
+	opened := make(chan struct{})
+	dc.OnOpen(func() {
+		close(opened)
+	})
+
+	require.NoError(t, signalPair(offerPC, answerPC))
+	<-opened
+
+	// The scheduler keeps the stream below the threshold, most of the
+	// BufferedAmount is queued.
+	bufferedAmounts := make(chan uint64, 1)
+	dc.SetBufferedAmountLowThreshold(threshold)
+	dc.OnBufferedAmountLow(func() {
+		select {
+		case bufferedAmounts <- dc.BufferedAmount():
+		default:
+		}
+	})
+
+	payload := make([]byte, 16*1024)
+	for dc.BufferedAmount() <= 2*threshold {
+		require.NoError(t, dc.Send(payload))
+	}
+
+	select {
+	case bufferedAmount := <-bufferedAmounts:
+		assert.LessOrEqual(t, bufferedAmount, uint64(threshold))
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "timed out waiting for OnBufferedAmountLow")
+	}
+
+	closePairNow(t, offerPC, answerPC)
+}
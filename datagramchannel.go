@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// defaultDatagramMaxBufferedAmount is the buffered amount above which
+// datagrams are dropped, unless SetMaxBufferedAmount changed it.
+const defaultDatagramMaxBufferedAmount = 64 * 1024
+
+// DatagramStats are the counters of a DatagramChannel.
+type DatagramStats struct {
+	DatagramsSent     uint64
+	DatagramsReceived uint64
+	BytesSent         uint64
+	BytesReceived     uint64
+
+	// DatagramsDropped counts the datagrams that weren't sent because the
+	// buffered amount exceeded its maximum. Datagrams the network loses aren't
+	// counted.
+	DatagramsDropped uint64
+}
+
+// DatagramChannel sends and receives datagrams over an unordered DataChannel
+// without retransmissions, like a UDP socket. Datagrams may be lost or
+// reordered, they are dropped instead of queued while the DataChannel's
+// buffered amount is high, so stale data doesn't delay fresh data.
+//
+// Datagrams larger than about 1200 bytes span several SCTP packets and are
+// lost if any of them is lost.
+type DatagramChannel struct {
+	dataChannel       *DataChannel
+	maxBufferedAmount atomic.Uint64
+
+	mu         sync.RWMutex
+	onDatagram func([]byte)
+
+	datagramsSent     atomic.Uint64
+	datagramsReceived atomic.Uint64
+	datagramsDropped  atomic.Uint64
+	bytesSent         atomic.Uint64
+	bytesReceived     atomic.Uint64
+}
+
+// CreateDatagramChannel creates an unordered DataChannel without
+// retransmissions and returns a DatagramChannel using it.
+func (pc *PeerConnection) CreateDatagramChannel(label string) (*DatagramChannel, error) {
+	ordered := false
+	maxRetransmits := uint16(0)
+
+	dataChannel, err := pc.CreateDataChannel(label, &DataChannelInit{Ordered: &ordered, MaxRetransmits: &maxRetransmits})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDatagramChannel(dataChannel)
+}
+
+// NewDatagramChannel returns a DatagramChannel using a DataChannel, like one the
+// remote peer created with CreateDatagramChannel. The DataChannel has to be
+// unordered without retransmissions. The DatagramChannel takes over its
+// OnMessage handler.
+func NewDatagramChannel(dataChannel *DataChannel) (*DatagramChannel, error) {
+	maxRetransmits := dataChannel.MaxRetransmits()
+	if dataChannel.Ordered() || maxRetransmits == nil || *maxRetransmits != 0 {
+		return nil, errDatagramChannelReliable
+	}
+
+	datagramChannel := &DatagramChannel{dataChannel: dataChannel}
+	datagramChannel.maxBufferedAmount.Store(defaultDatagramMaxBufferedAmount)
+	dataChannel.OnMessage(datagramChannel.handleMessage)
+
+	return datagramChannel, nil
+}
+
+// DataChannel returns the DataChannel of the DatagramChannel.
+func (c *DatagramChannel) DataChannel() *DataChannel {
+	return c.dataChannel
+}
+
+// MaxDatagramSize returns the largest datagram the remote peer accepts, or zero
+// while the SCTP transport isn't connected.
+func (c *DatagramChannel) MaxDatagramSize() int {
+	sctpTransport := c.dataChannel.Transport()
+	if sctpTransport == nil {
+		return 0
+	}
+
+	return int(sctpTransport.GetCapabilities().MaxMessageSize)
+}
+
+// SetMaxBufferedAmount sets the buffered amount above which datagrams are
+// dropped, 64KiB by default.
+func (c *DatagramChannel) SetMaxBufferedAmount(maxBufferedAmount uint64) {
+	c.maxBufferedAmount.Store(maxBufferedAmount)
+}
+
+// Send sends a datagram. It returns ErrDatagramTooLarge for datagrams larger
+// than MaxDatagramSize, while datagrams that are dropped because of a high
+// buffered amount are only counted in the DatagramStats.
+func (c *DatagramChannel) Send(datagram []byte) error {
+	if maxSize := c.MaxDatagramSize(); maxSize != 0 && len(datagram) > maxSize {
+		return ErrDatagramTooLarge
+	}
+
+	if c.dataChannel.BufferedAmount() > c.maxBufferedAmount.Load() {
+		c.datagramsDropped.Add(1)
+
+		return nil
+	}
+
+	if err := c.dataChannel.Send(datagram); err != nil {
+		return err
+	}
+
+	c.datagramsSent.Add(1)
+	c.bytesSent.Add(uint64(len(datagram)))
+
+	return nil
+}
+
+// OnDatagram sets an event handler which is invoked on a datagram arrival.
+func (c *DatagramChannel) OnDatagram(f func(datagram []byte)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onDatagram = f
+}
+
+func (c *DatagramChannel) handleMessage(msg DataChannelMessage) {
+	c.datagramsReceived.Add(1)
+	c.bytesReceived.Add(uint64(len(msg.Data)))
+
+	c.mu.RLock()
+	onDatagram := c.onDatagram
+	c.mu.RUnlock()
+
+	if onDatagram != nil {
+		onDatagram(msg.Data)
+	}
+}
+
+// Stats returns the counters of the DatagramChannel.
+func (c *DatagramChannel) Stats() DatagramStats {
+	return DatagramStats{
+		DatagramsSent:     c.datagramsSent.Load(),
+		DatagramsReceived: c.datagramsReceived.Load(),
+		BytesSent:         c.bytesSent.Load(),
+		BytesReceived:     c.bytesReceived.Load(),
+		DatagramsDropped:  c.datagramsDropped.Load(),
+	}
+}
+
+// Close closes the DataChannel.
+func (c *DatagramChannel) Close() error {
+	return c.dataChannel.Close()
+}
@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatagramChannel(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	pcOffer, pcAnswer, err := newPair()
+	require.NoError(t, err)
+
+	sender, err := pcOffer.CreateDatagramChannel("game")
+	require.NoError(t, err)
+	assert.Equal(t, 0, sender.MaxDatagramSize())
+
+	opened := make(chan struct{})
+	sender.DataChannel().OnOpen(func() {
+		close(opened)
+	})
+
+	received := make(chan []byte, 1)
+	receivers := make(chan *DatagramChannel, 1)
+	pcAnswer.OnDataChannel(func(d *DataChannel) {
+		if d.Label() != "game" {
+			return
+		}
+
+		receiver, receiverErr := NewDatagramChannel(d)
+		if !assert.NoError(t, receiverErr) {
+			return
+		}
+
+		receiver.OnDatagram(func(datagram []byte) {
+			select {
+			case received <- datagram:
+			default:
+			}
+		})
+		receivers <- receiver
+	})
+
+	require.NoError(t, signalPair(pcOffer, pcAnswer))
+	receiver := <-receivers
+	<-opened
+
+	require.NoError(t, sender.Send([]byte("state")))
+	assert.Equal(t, []byte("state"), <-received)
+	assert.Eventually(t, func() bool {
+		return receiver.Stats() == DatagramStats{DatagramsReceived: 1, BytesReceived: 5}
+	}, time.Second, time.Millisecond)
+
+	assert.Greater(t, sender.MaxDatagramSize(), 0)
+	assert.ErrorIs(t, sender.Send(make([]byte, sender.MaxDatagramSize()+1)), ErrDatagramTooLarge)
+
+	// Datagrams are dropped as soon as anything is buffered.
+	sender.SetMaxBufferedAmount(0)
+	require.Eventually(t, func() bool {
+		assert.NoError(t, sender.Send(make([]byte, 60000)))
+
+		return sender.Stats().DatagramsDropped > 0
+	}, 5*time.Second, time.Millisecond)
+
+	reliable, err := pcOffer.CreateDataChannel("reliable", nil)
+	require.NoError(t, err)
+	_, err = NewDatagramChannel(reliable)
+	assert.ErrorIs(t, err, errDatagramChannelReliable)
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
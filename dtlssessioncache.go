@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pion/dtls/v3"
+)
+
+// maxDTLSSessionTickets bounds the tickets of a DTLSSessionCache, the oldest are
+// evicted first.
+const maxDTLSSessionTickets = 256
+
+// DTLSSessionTicket is a DTLS session that can be resumed, exported from a
+// DTLSSessionCache. It contains the master secret of the session, so it has to be
+// kept as confidential as the private key of the certificate.
+type DTLSSessionTicket struct {
+	// RemoteFingerprint is the fingerprint of the remote certificate the session
+	// was established with. The session is only resumed with peers that announce
+	// this fingerprint.
+	RemoteFingerprint DTLSFingerprint `json:"remoteFingerprint"`
+
+	// Role is the local DTLS role in the session, clients resume sessions and
+	// servers accept resumed sessions.
+	Role DTLSRole `json:"role"`
+
+	ID                []byte `json:"id"`
+	Secret            []byte `json:"secret"`
+	RemoteCertificate []byte `json:"remoteCertificate"`
+}
+
+func (t DTLSSessionTicket) matches(fingerprints []DTLSFingerprint) bool {
+	return slices.ContainsFunc(fingerprints, func(fp DTLSFingerprint) bool {
+		return strings.EqualFold(fp.Algorithm, t.RemoteFingerprint.Algorithm) &&
+			strings.EqualFold(fp.Value, t.RemoteFingerprint.Value)
+	})
+}
+
+func (t DTLSSessionTicket) clone() DTLSSessionTicket {
+	t.ID = slices.Clone(t.ID)
+	t.Secret = slices.Clone(t.Secret)
+	t.RemoteCertificate = slices.Clone(t.RemoteCertificate)
+
+	return t
+}
+
+// DTLSSessionCache stores the DTLS sessions of DTLSTransports, see
+// SettingEngine.SetDTLSSessionCache. It is safe for concurrent use.
+type DTLSSessionCache struct {
+	mu      sync.Mutex
+	tickets []DTLSSessionTicket
+}
+
+// NewDTLSSessionCache creates an empty DTLSSessionCache.
+func NewDTLSSessionCache() *DTLSSessionCache {
+	return &DTLSSessionCache{}
+}
+
+// Export returns the sessions of the cache, like to persist them across restarts.
+func (c *DTLSSessionCache) Export() []DTLSSessionTicket {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	tickets := make([]DTLSSessionTicket, 0, len(c.tickets))
+	for _, ticket := range c.tickets {
+		tickets = append(tickets, ticket.clone())
+	}
+
+	return tickets
+}
+
+// Import adds exported sessions to the cache.
+func (c *DTLSSessionCache) Import(tickets ...DTLSSessionTicket) {
+	for _, ticket := range tickets {
+		c.set(ticket.clone())
+	}
+}
+
+// Forget removes the sessions with the remote peer of the fingerprint, so the
+// next connection to it does a full handshake.
+func (c *DTLSSessionCache) Forget(fingerprint DTLSFingerprint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.tickets = slices.DeleteFunc(c.tickets, func(t DTLSSessionTicket) bool {
+		return t.matches([]DTLSFingerprint{fingerprint})
+	})
+}
+
+// set adds a ticket, replacing the session of a client with the same peer and
+// sessions with the same ID.
+func (c *DTLSSessionCache) set(ticket DTLSSessionTicket) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.tickets = slices.DeleteFunc(c.tickets, func(t DTLSSessionTicket) bool {
+		if t.Role != ticket.Role {
+			return false
+		}
+		if ticket.Role == DTLSRoleClient {
+			return t.matches([]DTLSFingerprint{ticket.RemoteFingerprint})
+		}
+
+		return bytes.Equal(t.ID, ticket.ID)
+	})
+	c.tickets = append(c.tickets, ticket)
+	if len(c.tickets) > maxDTLSSessionTickets {
+		c.tickets = slices.Delete(c.tickets, 0, len(c.tickets)-maxDTLSSessionTickets)
+	}
+}
+
+// find returns the ticket of the role for the remote fingerprints, servers look
+// sessions up by their ID.
+func (c *DTLSSessionCache) find(role DTLSRole, id []byte, fingerprints []DTLSFingerprint) (DTLSSessionTicket, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := len(c.tickets) - 1; i >= 0; i-- {
+		ticket := c.tickets[i]
+		if ticket.Role != role || !ticket.matches(fingerprints) {
+			continue
+		}
+		if role == DTLSRoleServer && !bytes.Equal(ticket.ID, id) {
+			continue
+		}
+
+		return ticket.clone(), true
+	}
+
+	return DTLSSessionTicket{}, false
+}
+
+func (c *DTLSSessionCache) remove(role DTLSRole, id []byte, fingerprints []DTLSFingerprint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.tickets = slices.DeleteFunc(c.tickets, func(t DTLSSessionTicket) bool {
+		if t.Role != role || !t.matches(fingerprints) {
+			return false
+		}
+
+		return role == DTLSRoleClient || bytes.Equal(t.ID, id)
+	})
+}
+
+// dtlsSessionStore is the dtls.SessionStore of a DTLSTransport. It binds the
+// sessions to the remote fingerprints instead of the keys of the DTLS
+// connection, which are the remote address for clients.
+type dtlsSessionStore struct {
+	cache     *DTLSSessionCache
+	transport *DTLSTransport
+	role      DTLSRole
+}
+
+func newDTLSSessionStore(cache *DTLSSessionCache, transport *DTLSTransport, role DTLSRole) *dtlsSessionStore {
+	return &dtlsSessionStore{cache: cache, transport: transport, role: role}
+}
+
+func (s *dtlsSessionStore) Set(key []byte, session dtls.Session) error {
+	s.transport.lock.RLock()
+	fingerprint := s.transport.verifiedFingerprint
+	remoteCertificate := s.transport.remoteCertificate
+	if fingerprint == (DTLSFingerprint{}) && len(s.transport.remoteParameters.Fingerprints) > 0 {
+		// The remote certificate isn't verified when verification is disabled or,
+		// for servers, the client isn't asked for a certificate. The session is as
+		// trustworthy as the signaling then.
+		fingerprint = s.transport.remoteParameters.Fingerprints[0]
+	}
+	s.transport.lock.RUnlock()
+
+	if fingerprint == (DTLSFingerprint{}) {
+		return nil
+	}
+
+	ticket := DTLSSessionTicket{
+		RemoteFingerprint: fingerprint,
+		Role:              s.role,
+		ID:                session.ID,
+		Secret:            session.Secret,
+		RemoteCertificate: remoteCertificate,
+	}
+	if s.role == DTLSRoleServer {
+		ticket.ID = key
+	}
+	s.cache.set(ticket.clone())
+
+	return nil
+}
+
+func (s *dtlsSessionStore) Get(key []byte) (dtls.Session, error) {
+	s.transport.lock.Lock()
+	defer s.transport.lock.Unlock()
+
+	ticket, ok := s.cache.find(s.role, key, s.transport.remoteParameters.Fingerprints)
+	if !ok {
+		return dtls.Session{}, nil
+	}
+
+	// The certificate isn't sent again when the session is resumed.
+	if s.transport.remoteCertificate == nil {
+		s.transport.remoteCertificate = ticket.RemoteCertificate
+		s.transport.verifiedFingerprint = ticket.RemoteFingerprint
+	}
+
+	return dtls.Session{ID: ticket.ID, Secret: ticket.Secret}, nil
+}
+
+func (s *dtlsSessionStore) Del(key []byte) error {
+	s.transport.lock.RLock()
+	fingerprints := s.transport.remoteParameters.Fingerprints
+	s.transport.lock.RUnlock()
+
+	s.cache.remove(s.role, key, fingerprints)
+
+	return nil
+}
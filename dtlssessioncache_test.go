@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/pion/dtls/v3"
+	"github.com/pion/transport/v4/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDTLSSessionCache_Resumption(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	newCertificate := func() Certificate {
+		sk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		certificate, err := GenerateCertificate(sk)
+		require.NoError(t, err)
+
+		return *certificate
+	}
+	offerCertificate, answerCertificate := newCertificate(), newCertificate()
+	offerCache, answerCache := NewDTLSSessionCache(), NewDTLSSessionCache()
+
+	connect := func(offerCertificate, answerCertificate Certificate) {
+		newPC := func(cache *DTLSSessionCache, certificate Certificate) *PeerConnection {
+			s := SettingEngine{}
+			s.SetDTLSSessionCache(cache)
+			s.SetDTLSClientAuth(dtls.NoClientCert)
+			pc, err := NewAPI(WithSettingEngine(s)).NewPeerConnection(Configuration{
+				Certificates: []Certificate{certificate},
+			})
+			require.NoError(t, err)
+
+			return pc
+		}
+		offerPC, answerPC := newPC(offerCache, offerCertificate), newPC(answerCache, answerCertificate)
+
+		connected := untilConnectionState(PeerConnectionStateConnected, offerPC, answerPC)
+		require.NoError(t, signalPair(offerPC, answerPC))
+		connected.Wait()
+
+		// The answer is the DTLS client, it knows the certificate of the server
+		// when the session is resumed too.
+		require.Equal(t, DTLSRoleClient, answerPC.SCTP().Transport().Role())
+		assert.Equal(t, offerCertificate.x509Cert.Raw, answerPC.SCTP().Transport().GetRemoteCertificate())
+
+		closePairNow(t, offerPC, answerPC)
+	}
+
+	connect(offerCertificate, answerCertificate)
+	offerTickets, answerTickets := offerCache.Export(), answerCache.Export()
+	require.Len(t, offerTickets, 1)
+	require.Len(t, answerTickets, 1)
+	assert.Equal(t, DTLSRoleServer, offerTickets[0].Role)
+	assert.Equal(t, DTLSRoleClient, answerTickets[0].Role)
+	assert.Equal(t, offerTickets[0].ID, answerTickets[0].ID)
+
+	// The session is resumed, so its ID doesn't change.
+	connect(offerCertificate, answerCertificate)
+	assert.Equal(t, offerTickets, offerCache.Export())
+	assert.Equal(t, answerTickets, answerCache.Export())
+
+	// Servers with another certificate do a full handshake.
+	connect(newCertificate(), answerCertificate)
+	assert.Len(t, offerCache.Export(), 2)
+	assert.Len(t, answerCache.Export(), 2)
+}
+
+func TestDTLSSessionCache_ExportImport(t *testing.T) {
+	fingerprint := DTLSFingerprint{Algorithm: "sha-256", Value: "AB:CD"}
+	ticket := DTLSSessionTicket{
+		RemoteFingerprint: fingerprint,
+		Role:              DTLSRoleClient,
+		ID:                []byte{1},
+		Secret:            []byte{2},
+	}
+
+	cache := NewDTLSSessionCache()
+	cache.Import(ticket)
+	exported := cache.Export()
+	assert.Equal(t, []DTLSSessionTicket{ticket}, exported)
+
+	exported[0].Secret[0] = 3
+	assert.Equal(t, []DTLSSessionTicket{ticket}, cache.Export())
+
+	found, ok := cache.find(DTLSRoleClient, nil, []DTLSFingerprint{{Algorithm: "SHA-256", Value: "ab:cd"}})
+	assert.True(t, ok)
+	assert.Equal(t, ticket, found)
+
+	_, ok = cache.find(DTLSRoleServer, ticket.ID, []DTLSFingerprint{fingerprint})
+	assert.False(t, ok)
+
+	// A newer session with the same peer replaces the older one.
+	newer := ticket
+	newer.ID = []byte{4}
+	cache.Import(newer)
+	assert.Equal(t, []DTLSSessionTicket{newer}, cache.Export())
+
+	cache.Forget(fingerprint)
+	assert.Empty(t, cache.Export())
+}
@@ -6,13 +6,13 @@
 package webrtc
 
 import (
-	"crypto/ecdsa"
-	"crypto/elliptic"
-	"crypto/rand"
+	"context"
+	"crypto"
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
 	"fmt"
+	"net"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -20,6 +20,7 @@ import (
 
 	"github.com/pion/dtls/v3"
 	"github.com/pion/dtls/v3/pkg/crypto/fingerprint"
+	"github.com/pion/dtls/v3/pkg/protocol"
 	"github.com/pion/interceptor"
 	"github.com/pion/logging"
 	"github.com/pion/rtcp"
@@ -29,6 +30,10 @@ import (
 	"github.com/pion/webrtc/v4/pkg/rtcerr"
 )
 
+// dtlsExtractorLabelPrefix is the prefix of the exporter labels of the keys of
+// DTLS-SRTP, RFC 5764 Section 4.2.
+const dtlsExtractorLabelPrefix = "EXTRACTOR-"
+
 // DTLSTransport allows an application access to information about the DTLS
 // transport over which RTP and RTCP packets are sent and received by
 // RTPSender and RTPReceiver, as well other data such as SCTP packets sent
@@ -40,8 +45,14 @@ type DTLSTransport struct {
 	certificates          []Certificate
 	remoteParameters      DTLSParameters
 	remoteCertificate     []byte
+	remoteCertificates    [][]byte
+	verifiedFingerprint   DTLSFingerprint
 	state                 DTLSTransportState
+	startErr              error
+	negotiatedRole        DTLSRole
 	srtpProtectionProfile srtp.ProtectionProfile
+	handshakeDuration     time.Duration
+	cryptex               bool
 
 	onStateChangeHandler   func(DTLSTransportState)
 	internalOnCloseHandler func()
@@ -53,6 +64,8 @@ type DTLSTransport struct {
 	simulcastStreams            []simulcastStreamPair
 	srtpReady                   chan struct{}
 
+	rtpFilterPacketsDropped, rtpFilterBytesDropped atomic.Uint64
+
 	dtlsMatcher mux.MatchFunc
 
 	api *API
@@ -84,6 +97,9 @@ func (api *API) NewDTLSTransport(transport *ICETransport, certificates []Certifi
 		log:          api.settingEngine.LoggerFactory.NewLogger("DTLSTransport"),
 	}
 
+	if len(certificates) == 0 {
+		certificates = api.settingEngine.dtls.sharedCertificates
+	}
 	if len(certificates) > 0 {
 		now := time.Now()
 		for _, x509Cert := range certificates {
@@ -93,11 +109,7 @@ func (api *API) NewDTLSTransport(transport *ICETransport, certificates []Certifi
 			trans.certificates = append(trans.certificates, x509Cert)
 		}
 	} else {
-		sk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
-		if err != nil {
-			return nil, &rtcerr.UnknownError{Err: err}
-		}
-		certificate, err := GenerateCertificate(sk)
+		certificate, err := api.generateCertificate()
 		if err != nil {
 			return nil, err
 		}
@@ -141,6 +153,14 @@ func (t *DTLSTransport) State() DTLSTransportState {
 	return t.state
 }
 
+// startError returns the error that failed the DTLS handshake.
+func (t *DTLSTransport) startError() error {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.startErr
+}
+
 // WriteRTCP sends a user provided RTCP packet to the connected peer. If no peer is connected the
 // packet is discarded.
 func (t *DTLSTransport) WriteRTCP(pkts []rtcp.Packet) (int, error) {
@@ -168,7 +188,7 @@ func (t *DTLSTransport) GetLocalParameters() (DTLSParameters, error) {
 	fingerprints := []DTLSFingerprint{}
 
 	for _, c := range t.certificates {
-		prints, err := c.GetFingerprints()
+		prints, err := c.getFingerprints(t.api.settingEngine.getDTLSFingerprintAlgorithms())
 		if err != nil {
 			return DTLSParameters{}, err
 		}
@@ -182,6 +202,15 @@ func (t *DTLSTransport) GetLocalParameters() (DTLSParameters, error) {
 	}, nil
 }
 
+// Role returns the DTLS role this transport selected when it was started.
+// DTLSRoleUnknown is returned if Start has not been called yet.
+func (t *DTLSTransport) Role() DTLSRole {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.negotiatedRole
+}
+
 // GetRemoteCertificate returns the certificate chain in use by the remote side
 // returns an empty list prior to selection of the remote certificate.
 func (t *DTLSTransport) GetRemoteCertificate() []byte {
@@ -191,12 +220,66 @@ func (t *DTLSTransport) GetRemoteCertificate() []byte {
 	return t.remoteCertificate
 }
 
+// GetRemoteCertificateChain returns the DER encoded certificates the remote
+// peer sent in the DTLS handshake, its certificate first and then the
+// intermediates. It is nil before the handshake and for resumed sessions, which
+// don't exchange certificates.
+func (t *DTLSTransport) GetRemoteCertificateChain() [][]byte {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.remoteCertificates
+}
+
+// ExportKeyingMaterial derives length bytes of keying material from the DTLS
+// session as described in RFC 5705, both peers derive the same bytes for the
+// same label. An error is returned before the DTLS handshake completed.
+//
+// pion/dtls doesn't support a context, it has to be empty. The labels TLS uses
+// itself are reserved, and so are the labels starting with "EXTRACTOR-", like
+// "EXTRACTOR-dtls_srtp" of the SRTP master keys, which aren't exported to the
+// application. Applications should use labels of their own, RFC 5705 Section 4.
+func (t *DTLSTransport) ExportKeyingMaterial(label string, context []byte, length int) ([]byte, error) {
+	if strings.HasPrefix(label, dtlsExtractorLabelPrefix) {
+		return nil, fmt.Errorf("%w: %s", errDtlsExporterLabelReserved, label)
+	}
+
+	t.lock.RLock()
+	conn := t.conn
+	t.lock.RUnlock()
+
+	if conn == nil {
+		return nil, errDtlsTransportNotStarted
+	}
+
+	state, ok := conn.ConnectionState()
+	if !ok {
+		return nil, errDtlsTransportNotStarted
+	}
+
+	return state.ExportKeyingMaterial(label, context, length)
+}
+
+// setCryptex sets whether both peers signaled Cryptex, RFC 9335, so the SRTP
+// session encrypts the header extensions and CSRCs of RTP packets.
+func (t *DTLSTransport) setCryptex(cryptex bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.cryptex = cryptex
+}
+
 func (t *DTLSTransport) startSRTP() error {
 	srtpConfig := &srtp.Config{
 		Profile:       t.srtpProtectionProfile,
 		BufferFactory: t.api.settingEngine.BufferFactory,
 		LoggerFactory: t.api.settingEngine.LoggerFactory,
 	}
+	if t.cryptex {
+		srtpConfig.LocalOptions = append(srtpConfig.LocalOptions, srtp.Cryptex(srtp.CryptexModeEnabled))
+		srtpConfig.RemoteOptions = append(srtpConfig.RemoteOptions, srtp.Cryptex(srtp.CryptexModeEnabled))
+	}
+
 	if t.api.settingEngine.replayProtection.SRTP != nil {
 		srtpConfig.RemoteOptions = append(
 			srtpConfig.RemoteOptions,
@@ -237,7 +320,12 @@ func (t *DTLSTransport) startSRTP() error {
 		return fmt.Errorf("%w: %v", errDtlsKeyExtractionFailed, err)
 	}
 
-	srtpSession, err := srtp.NewSessionSRTP(t.srtpEndpoint, srtpConfig)
+	var srtpConn net.Conn = t.srtpEndpoint
+	if filter := t.api.settingEngine.inboundRTPPacketFilter; filter != nil {
+		srtpConn = &rtpFilterConn{Conn: t.srtpEndpoint, filter: filter, transport: t}
+	}
+
+	srtpSession, err := srtp.NewSessionSRTP(srtpConn, srtpConfig)
 	if err != nil {
 		// nolint
 		return fmt.Errorf("%w: %v", errFailedToStartSRTP, err)
@@ -319,6 +407,7 @@ func (t *DTLSTransport) Start(remoteParameters DTLSParameters) error {
 		return t.failStart(err)
 	}
 
+	handshakeStart := time.Now()
 	if err = t.handshakeDTLS(dtlsConn); err != nil {
 		dtlsEndpoint.SetOnClose(nil)
 		_ = dtlsConn.Close()
@@ -326,6 +415,10 @@ func (t *DTLSTransport) Start(remoteParameters DTLSParameters) error {
 		return t.failStart(err)
 	}
 
+	t.lock.Lock()
+	t.handshakeDuration = time.Since(handshakeStart)
+	t.lock.Unlock()
+
 	if err = t.completeStart(dtlsConn); err != nil {
 		dtlsEndpoint.SetOnClose(nil)
 		_ = dtlsConn.Close()
@@ -355,10 +448,11 @@ func (t *DTLSTransport) prepareStart(remoteParameters DTLSParameters) (DTLSRole,
 	t.remoteParameters = remoteParameters
 
 	cert := t.certificates[0]
+	t.negotiatedRole = t.role()
 	t.onStateChange(DTLSTransportStateConnecting)
 
-	return t.role(), tls.Certificate{
-		Certificate: [][]byte{cert.x509Cert.Raw},
+	return t.negotiatedRole, tls.Certificate{
+		Certificate: cert.chain(),
 		PrivateKey:  cert.privateKey,
 	}, nil
 }
@@ -387,6 +481,14 @@ func (t *DTLSTransport) dtlsSharedOptions(certificate tls.Certificate) []dtls.Op
 		)
 	}
 
+	if t.api.settingEngine.dtls.disableRetransmitBackoff {
+		sharedOpts = append(sharedOpts, dtls.WithDisableRetransmitBackoff(true))
+	}
+
+	if t.api.settingEngine.dtls.mtu > 0 {
+		sharedOpts = append(sharedOpts, dtls.WithMTU(t.api.settingEngine.dtls.mtu))
+	}
+
 	if t.api.settingEngine.replayProtection.DTLS != nil {
 		sharedOpts = append(
 			sharedOpts,
@@ -416,6 +518,13 @@ func (t *DTLSTransport) dtlsSharedOptions(certificate tls.Certificate) []dtls.Op
 		sharedOpts = append(sharedOpts, dtls.WithKeyLogWriter(t.api.settingEngine.dtls.keyLogWriter))
 	}
 
+	if t.api.settingEngine.dtls.connectionIDGenerator != nil {
+		sharedOpts = append(
+			sharedOpts,
+			dtls.WithConnectionIDGenerator(t.api.settingEngine.dtls.connectionIDGenerator),
+		)
+	}
+
 	if len(t.api.settingEngine.dtls.supportedProtocols) > 0 {
 		sharedOpts = append(
 			sharedOpts,
@@ -435,28 +544,42 @@ func (t *DTLSTransport) srtpProtectionProfiles() []dtls.SRTPProtectionProfile {
 }
 
 func (t *DTLSTransport) verifyPeerCertificateFunc() func([][]byte, [][]*x509.Certificate) error {
-	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
 		if len(rawCerts) == 0 {
 			return errNoRemoteCertificate
 		}
 
-		t.lock.Lock()
-		defer t.lock.Unlock()
-		t.remoteCertificate = rawCerts[0]
-
-		if t.api.settingEngine.disableCertificateFingerprintVerification {
-			return nil
+		if err := t.verifyRemoteFingerprint(rawCerts); err != nil {
+			return err
 		}
 
-		parsedRemoteCert, err := x509.ParseCertificate(t.remoteCertificate)
-		if err != nil {
-			return err
+		// The callback is invoked without the lock, it may read the transport.
+		if verify := t.api.settingEngine.dtls.verifyPeerCertificate; verify != nil {
+			return verify(rawCerts, verifiedChains)
 		}
 
-		return t.validateFingerPrint(parsedRemoteCert)
+		return nil
 	}
 }
 
+func (t *DTLSTransport) verifyRemoteFingerprint(rawCerts [][]byte) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.remoteCertificate = rawCerts[0]
+	t.remoteCertificates = rawCerts
+
+	if t.api.settingEngine.disableCertificateFingerprintVerification {
+		return nil
+	}
+
+	parsedRemoteCert, err := x509.ParseCertificate(t.remoteCertificate)
+	if err != nil {
+		return err
+	}
+
+	return t.validateFingerPrint(parsedRemoteCert)
+}
+
 func (t *DTLSTransport) connectDTLS(
 	dtlsEndpoint *mux.Endpoint,
 	role DTLSRole,
@@ -492,6 +615,10 @@ func (t *DTLSTransport) toDTLSServerOptions(sharedOpts []dtls.Option) []dtls.Ser
 		clientAuth = *t.api.settingEngine.dtls.clientAuth
 	}
 
+	if cache := t.api.settingEngine.dtls.sessionCache; cache != nil {
+		serverOpts = append(serverOpts, dtls.WithSessionStore(newDTLSSessionStore(cache, t, DTLSRoleServer)))
+	}
+
 	serverOpts = append(serverOpts,
 		dtls.WithClientAuth(clientAuth),
 		dtls.WithClientCAs(t.api.settingEngine.dtls.clientCAs),
@@ -521,6 +648,10 @@ func (t *DTLSTransport) toDTLSClientOptions(sharedOpts []dtls.Option) []dtls.Cli
 		clientOpts = append(clientOpts, opt)
 	}
 
+	if cache := t.api.settingEngine.dtls.sessionCache; cache != nil {
+		clientOpts = append(clientOpts, dtls.WithSessionStore(newDTLSSessionStore(cache, t, DTLSRoleClient)))
+	}
+
 	if t.api.settingEngine.dtls.clientHelloMessageHook != nil {
 		clientOpts = append(
 			clientOpts,
@@ -532,12 +663,23 @@ func (t *DTLSTransport) toDTLSClientOptions(sharedOpts []dtls.Option) []dtls.Cli
 }
 
 func (t *DTLSTransport) handshakeDTLS(dtlsConn *dtls.Conn) error {
-	if t.api.settingEngine.dtls.connectContextMaker == nil {
+	handshakeTimeout := t.api.settingEngine.dtls.handshakeTimeout
+	if t.api.settingEngine.dtls.connectContextMaker == nil && handshakeTimeout <= 0 {
 		return dtlsConn.Handshake()
 	}
 
-	handshakeCtx, cancel := t.api.settingEngine.dtls.connectContextMaker()
-	if cancel != nil {
+	handshakeCtx := context.Background()
+	if t.api.settingEngine.dtls.connectContextMaker != nil {
+		var cancel func()
+		handshakeCtx, cancel = t.api.settingEngine.dtls.connectContextMaker()
+		if cancel != nil {
+			defer cancel()
+		}
+	}
+
+	if handshakeTimeout > 0 {
+		var cancel context.CancelFunc
+		handshakeCtx, cancel = context.WithTimeout(handshakeCtx, handshakeTimeout)
 		defer cancel()
 	}
 
@@ -558,14 +700,34 @@ func (t *DTLSTransport) completeStart(dtlsConn *dtls.Conn) error {
 
 	t.srtpProtectionProfile = srtpProtectionProfile
 	t.conn = dtlsConn
+	t.api.settingEngine.instrumentation.dtlsHandshake()
 	t.onStateChange(DTLSTransportStateConnected)
 
 	return t.startSRTP()
 }
 
+// populateTransportStats adds the DTLS metrics to the stats of the transport.
+func (t *DTLSTransport) populateTransportStats(stats *TransportStats) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	stats.DTLSState = t.state
+	stats.DTLSHandshakeDuration = t.handshakeDuration.Seconds()
+	if t.conn == nil {
+		return
+	}
+	// The State of pion/dtls doesn't carry the version, its handshake only
+	// completes with DTLS 1.2. There is no DTLS 1.3 handshake and no setting to
+	// limit the versions yet.
+	if _, ok := t.conn.ConnectionState(); ok {
+		stats.TLSVersion = fmt.Sprintf("%02X%02X", protocol.Version1_2.Major, protocol.Version1_2.Minor)
+	}
+}
+
 func (t *DTLSTransport) failStart(err error) error {
 	t.lock.Lock()
 	defer t.lock.Unlock()
+	t.startErr = err
 	t.onStateChange(DTLSTransportStateFailed)
 
 	return err
@@ -627,19 +789,38 @@ func (t *DTLSTransport) Stop() error {
 	return util.FlattenErrs(closeErrs)
 }
 
+// validateFingerPrint verifies the certificate against the remote fingerprints
+// of the strongest hash algorithm both peers support, RFC 8122 Section 5, so a
+// fingerprint of a weaker algorithm can't be used to downgrade the verification.
 func (t *DTLSTransport) validateFingerPrint(remoteCert *x509.Certificate) error {
+	var strongest crypto.Hash
 	for _, fp := range t.remoteParameters.Fingerprints {
 		hashAlgo, err := fingerprint.HashFromString(fp.Algorithm)
 		if err != nil {
-			return err
+			// Another fingerprint may use a supported hash algorithm.
+			t.log.Debugf("Ignoring remote fingerprint: %s", err)
+
+			continue
+		}
+		if strongest == 0 || hashAlgo.Size() > strongest.Size() {
+			strongest = hashAlgo
 		}
+	}
 
-		remoteValue, err := fingerprint.Fingerprint(remoteCert, hashAlgo)
+	for _, fp := range t.remoteParameters.Fingerprints {
+		// The remote peer may have several certificates.
+		if hashAlgo, err := fingerprint.HashFromString(fp.Algorithm); err != nil || hashAlgo != strongest {
+			continue
+		}
+
+		remoteValue, err := fingerprint.Fingerprint(remoteCert, strongest)
 		if err != nil {
 			return err
 		}
 
 		if strings.EqualFold(remoteValue, fp.Value) {
+			t.verifiedFingerprint = fp
+
 			return nil
 		}
 	}
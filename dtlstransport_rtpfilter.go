@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"encoding/binary"
+	"net"
+)
+
+// rtpHeaderMinLength is the length of an RTP header without CSRCs, the SSRC
+// is its last field.
+const rtpHeaderMinLength = 12
+
+// RTPPacketFilterStats are the counters of the inbound RTP packet filter, see
+// SettingEngine.SetInboundRTPPacketFilter.
+type RTPPacketFilterStats struct {
+	// PacketsDropped is the number of RTP packets dropped by the filter.
+	PacketsDropped uint64
+
+	// BytesDropped is the number of bytes of the dropped RTP packets, including
+	// their headers and SRTP authentication tags.
+	BytesDropped uint64
+}
+
+// RTPPacketFilterStats returns the counters of the inbound RTP packet filter.
+// They are zero if no filter is set.
+func (t *DTLSTransport) RTPPacketFilterStats() RTPPacketFilterStats {
+	return RTPPacketFilterStats{
+		PacketsDropped: t.rtpFilterPacketsDropped.Load(),
+		BytesDropped:   t.rtpFilterBytesDropped.Load(),
+	}
+}
+
+// rtpFilterConn sits between the SRTP endpoint and the SRTP session and drops
+// the packets rejected by the filter before they are decrypted.
+type rtpFilterConn struct {
+	net.Conn
+
+	filter    func(ssrc SSRC, payloadType PayloadType, size int) (keep bool)
+	transport *DTLSTransport
+}
+
+func (c *rtpFilterConn) Read(b []byte) (int, error) {
+	for {
+		n, err := c.Conn.Read(b)
+		if err != nil || c.keep(b[:n]) {
+			return n, err
+		}
+
+		c.transport.rtpFilterPacketsDropped.Add(1)
+		c.transport.rtpFilterBytesDropped.Add(uint64(n)) //nolint:gosec // G115
+	}
+}
+
+// keep reads the SSRC and payload type from the header, which SRTP doesn't
+// encrypt. Packets too short for a header are left to the SRTP session.
+func (c *rtpFilterConn) keep(packet []byte) bool {
+	if len(packet) < rtpHeaderMinLength {
+		return true
+	}
+
+	return c.filter(
+		SSRC(binary.BigEndian.Uint32(packet[8:12])),
+		PayloadType(packet[1]&0x7f),
+		len(packet),
+	)
+}
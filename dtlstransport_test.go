@@ -8,19 +8,31 @@ package webrtc
 import (
 	"bytes"
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/tls"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"errors"
 	"io"
+	"math/big"
 	"net"
 	"reflect"
 	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/pion/dtls/v3"
 	dtlsElliptic "github.com/pion/dtls/v3/pkg/crypto/elliptic"
+	"github.com/pion/dtls/v3/pkg/crypto/fingerprint"
+	"github.com/pion/dtls/v3/pkg/protocol/extension"
 	"github.com/pion/dtls/v3/pkg/protocol/handshake"
+	"github.com/pion/logging"
 	"github.com/pion/srtp/v3"
 	"github.com/pion/transport/v4/test"
 	"github.com/pion/webrtc/v4/internal/mux"
@@ -92,11 +104,12 @@ func TestInvalidFingerprintCausesFailed(t *testing.T) { //nolint:cyclop
 
 	select {
 	case offer := <-offerChan:
-		// Replace with invalid fingerprint
-		re := regexp.MustCompile(`sha-256 (.*?)\r`)
+		// Replace every fingerprint with an invalid one
+		re := regexp.MustCompile(`a=fingerprint:(\S+) (.*?)\r`)
+		assert.Len(t, re.FindAllString(offer.SDP, -1), 3)
 		offer.SDP = re.ReplaceAllString(
 			offer.SDP,
-			"sha-256 AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA\r",
+			"a=fingerprint:$1 AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA\r",
 		)
 
 		assert.NoError(t, pcAnswer.SetRemoteDescription(offer))
@@ -107,7 +120,7 @@ func TestInvalidFingerprintCausesFailed(t *testing.T) { //nolint:cyclop
 
 		answer.SDP = re.ReplaceAllString(
 			answer.SDP,
-			"sha-256 AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA\r",
+			"a=fingerprint:$1 AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA:AA\r",
 		)
 
 		assert.NoError(t, pcOffer.SetRemoteDescription(answer))
@@ -160,8 +173,15 @@ func TestPeerConnection_DTLSRoleSettingEngine(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NoError(t, signalPair(offerPC, answerPC))
 
-		connectionComplete := untilConnectionState(PeerConnectionStateConnected, answerPC)
+		connectionComplete := untilConnectionState(PeerConnectionStateConnected, offerPC, answerPC)
 		connectionComplete.Wait()
+
+		assert.Equal(t, r, answerPC.dtlsTransport.Role())
+		if r == DTLSRoleClient {
+			assert.Equal(t, DTLSRoleServer, offerPC.dtlsTransport.Role())
+		} else {
+			assert.Equal(t, DTLSRoleClient, offerPC.dtlsTransport.Role())
+		}
 		closePairNow(t, offerPC, answerPC)
 	}
 
@@ -177,6 +197,110 @@ func TestPeerConnection_DTLSRoleSettingEngine(t *testing.T) {
 	})
 }
 
+func TestPeerConnection_DTLSCertificateChain(t *testing.T) { //nolint:cyclop
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	newCertificate := func(template, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (
+		*x509.Certificate, *ecdsa.PrivateKey,
+	) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NoError(t, err)
+		if parent == nil {
+			parent, parentKey = template, key
+		}
+		template.NotBefore = time.Now().Add(-time.Hour)
+		template.NotAfter = time.Now().Add(time.Hour)
+		raw, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+		assert.NoError(t, err)
+		cert, err := x509.ParseCertificate(raw)
+		assert.NoError(t, err)
+
+		return cert, key
+	}
+
+	root, rootKey := newCertificate(&x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "root"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, nil)
+	intermediate, intermediateKey := newCertificate(&x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: "intermediate"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, root, rootKey)
+	leaf, leafKey := newCertificate(&x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "offer"},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}, intermediate, intermediateKey)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(root)
+	verifyChain := func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		intermediates := x509.NewCertPool()
+		certs := make([]*x509.Certificate, 0, len(rawCerts))
+		for _, raw := range rawCerts {
+			cert, err := x509.ParseCertificate(raw)
+			if err != nil {
+				return err
+			}
+			intermediates.AddCert(cert)
+			certs = append(certs, cert)
+		}
+		_, err := certs[0].Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		})
+
+		return err
+	}
+
+	runTest := func(certificate Certificate, expected DTLSTransportState) {
+		s := SettingEngine{}
+		s.SetDTLSVerifyPeerCertificate(verifyChain)
+
+		offerPC, err := NewPeerConnection(Configuration{Certificates: []Certificate{certificate}})
+		assert.NoError(t, err)
+		answerPC, err := NewAPI(WithSettingEngine(s)).NewPeerConnection(Configuration{})
+		assert.NoError(t, err)
+
+		done := make(chan struct{})
+		var once sync.Once
+		answerPC.SCTP().Transport().OnStateChange(func(state DTLSTransportState) {
+			if state == DTLSTransportStateConnected || state == DTLSTransportStateFailed {
+				assert.Equal(t, expected, state)
+				once.Do(func() { close(done) })
+			}
+		})
+		assert.NoError(t, signalPair(offerPC, answerPC))
+		<-done
+
+		if expected == DTLSTransportStateConnected {
+			assert.Equal(t, [][]byte{leaf.Raw, intermediate.Raw}, answerPC.dtlsTransport.GetRemoteCertificateChain())
+			assert.Equal(t, leaf.Raw, answerPC.dtlsTransport.GetRemoteCertificate())
+		}
+		closePairNow(t, offerPC, answerPC)
+	}
+
+	t.Run("Verified", func(*testing.T) {
+		runTest(CertificateFromX509Chain(leafKey, leaf, intermediate), DTLSTransportStateConnected)
+	})
+
+	t.Run("Missing Intermediate", func(*testing.T) {
+		runTest(CertificateFromX509(leafKey, leaf), DTLSTransportStateFailed)
+	})
+}
+
 type errConn struct {
 	localAddr  net.Addr
 	remoteAddr net.Addr
@@ -221,6 +345,7 @@ func TestDTLSTransport_Start_ErrICEConnectionNotStarted(t *testing.T) {
 	err := transport.Start(DTLSParameters{Role: DTLSRoleServer})
 	assert.ErrorIs(t, err, errICEConnectionNotStarted)
 	assert.Equal(t, DTLSTransportStateNew, transport.State())
+	assert.Equal(t, DTLSRoleUnknown, transport.Role())
 }
 
 func TestDTLSTransport_Start_ConnectErrorFailsTransport(t *testing.T) {
@@ -321,6 +446,20 @@ func TestDTLSTransport_dtlsSharedOptions_IncludesOptionalOptions(t *testing.T) {
 			},
 			wantExtra: 1,
 		},
+		{
+			name: "DisableRetransmitBackoff",
+			configure: func(se *SettingEngine) {
+				se.dtls.disableRetransmitBackoff = true
+			},
+			wantExtra: 1,
+		},
+		{
+			name: "MTU",
+			configure: func(se *SettingEngine) {
+				se.dtls.mtu = 1000
+			},
+			wantExtra: 1,
+		},
 		{
 			name: "ReplayProtectionWindow",
 			configure: func(se *SettingEngine) {
@@ -436,6 +575,66 @@ func TestDTLSTransport_verifyPeerCertificateFunc_ParseError(t *testing.T) {
 	assert.Equal(t, rawCert, transport.GetRemoteCertificate())
 }
 
+func TestDTLSTransport_validateFingerPrint_StrongestAlgorithm(t *testing.T) {
+	sk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	certificate, err := GenerateCertificate(sk)
+	assert.NoError(t, err)
+	remoteCert := certificate.x509Cert
+
+	sha256Value, err := fingerprint.Fingerprint(remoteCert, crypto.SHA256)
+	assert.NoError(t, err)
+	sha512Value, err := fingerprint.Fingerprint(remoteCert, crypto.SHA512)
+	assert.NoError(t, err)
+	otherValue := strings.Repeat("00:", 63) + "00"
+
+	for _, tc := range []struct {
+		name         string
+		fingerprints []DTLSFingerprint
+		verified     DTLSFingerprint
+	}{
+		{
+			name: "strongest matches",
+			fingerprints: []DTLSFingerprint{
+				{Algorithm: "sha-3", Value: otherValue},
+				{Algorithm: "sha-256", Value: otherValue},
+				{Algorithm: "sha-512", Value: sha512Value},
+			},
+			verified: DTLSFingerprint{Algorithm: "sha-512", Value: sha512Value},
+		},
+		{
+			name: "weaker matches",
+			fingerprints: []DTLSFingerprint{
+				{Algorithm: "sha-256", Value: sha256Value},
+				{Algorithm: "sha-512", Value: otherValue},
+			},
+		},
+		{
+			name: "one of several certificates matches",
+			fingerprints: []DTLSFingerprint{
+				{Algorithm: "sha-512", Value: otherValue},
+				{Algorithm: "sha-512", Value: strings.ToUpper(sha512Value)},
+			},
+			verified: DTLSFingerprint{Algorithm: "sha-512", Value: strings.ToUpper(sha512Value)},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			transport := &DTLSTransport{
+				log:              logging.NewDefaultLoggerFactory().NewLogger("test"),
+				remoteParameters: DTLSParameters{Fingerprints: tc.fingerprints},
+			}
+
+			err := transport.validateFingerPrint(remoteCert)
+			if tc.verified == (DTLSFingerprint{}) {
+				assert.ErrorIs(t, err, errNoMatchingCertificateFingerprint)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.verified, transport.verifiedFingerprint)
+		})
+	}
+}
+
 func TestDTLSTransport_toDTLSServerOptions_IncludesOptionalOptions(t *testing.T) {
 	baseAPI := NewAPI()
 	baseTransport := &DTLSTransport{api: baseAPI}
@@ -526,6 +725,135 @@ func TestDTLSTransport_handshakeDTLS_DeferredCancel(t *testing.T) {
 	assert.True(t, cancelCalled)
 }
 
+func TestDTLSTransport_handshakeDTLS_Timeout(t *testing.T) {
+	lim := test.TimeOut(time.Second * 5)
+	defer lim.Stop()
+
+	api := NewAPI()
+	api.settingEngine.SetDTLSHandshakeTimeout(200 * time.Millisecond)
+	transport := &DTLSTransport{api: api}
+
+	// The remote peer never answers the flights.
+	remote, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer func() { _ = remote.Close() }()
+	local, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	dtlsConn, err := dtls.ClientWithOptions(local, remote.LocalAddr())
+	assert.NoError(t, err)
+	defer func() { _ = dtlsConn.Close() }()
+
+	start := time.Now()
+	err = transport.handshakeDTLS(dtlsConn)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+func TestDTLSTransport_HandshakeDurationStats(t *testing.T) {
+	lim := test.TimeOut(time.Second * 10)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	offerPC, answerPC, err := newPair()
+	assert.NoError(t, err)
+
+	connected := untilConnectionState(PeerConnectionStateConnected, offerPC, answerPC)
+	assert.NoError(t, signalPair(offerPC, answerPC))
+	connected.Wait()
+
+	stats := getTransportStats(t, offerPC.GetStats(), "iceTransport")
+	assert.Equal(t, DTLSTransportStateConnected, stats.DTLSState)
+	assert.Greater(t, stats.DTLSHandshakeDuration, 0.0)
+	assert.Less(t, stats.DTLSHandshakeDuration, 10.0)
+	assert.Equal(t, "FEFD", stats.TLSVersion)
+
+	closePairNow(t, offerPC, answerPC)
+}
+
+func TestDTLSTransport_ConnectionID(t *testing.T) {
+	lim := test.TimeOut(time.Second * 10)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	// The ServerHello only has the extension if both peers support it.
+	var negotiated atomic.Bool
+	s := SettingEngine{}
+	s.SetDTLSConnectionIDGenerator(dtls.RandomCIDGenerator(8))
+	s.SetDTLSServerHelloMessageHook(func(hello handshake.MessageServerHello) handshake.Message {
+		for _, ext := range hello.Extensions {
+			if _, ok := ext.(*extension.ConnectionID); ok {
+				negotiated.Store(true)
+			}
+		}
+
+		return &hello
+	})
+
+	offerPC, answerPC, err := NewAPI(WithSettingEngine(s)).newPair(Configuration{})
+	assert.NoError(t, err)
+
+	received := make(chan string, 1)
+	answerPC.OnDataChannel(func(d *DataChannel) {
+		d.OnMessage(func(msg DataChannelMessage) {
+			received <- string(msg.Data)
+		})
+	})
+	dc, err := offerPC.CreateDataChannel("cid", nil)
+	assert.NoError(t, err)
+	dc.OnOpen(func() {
+		assert.NoError(t, dc.SendText("hello"))
+	})
+
+	assert.NoError(t, signalPair(offerPC, answerPC))
+	assert.Equal(t, "hello", <-received)
+	assert.True(t, negotiated.Load())
+
+	closePairNow(t, offerPC, answerPC)
+}
+
+func TestDTLSTransport_ExportKeyingMaterial(t *testing.T) {
+	lim := test.TimeOut(time.Second * 10)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	offerPC, answerPC, err := newPair()
+	assert.NoError(t, err)
+
+	_, err = offerPC.SCTP().Transport().ExportKeyingMaterial("EXPORTER-test", nil, 32)
+	assert.ErrorIs(t, err, errDtlsTransportNotStarted)
+
+	connected := untilConnectionState(PeerConnectionStateConnected, offerPC, answerPC)
+	assert.NoError(t, signalPair(offerPC, answerPC))
+	connected.Wait()
+
+	offerMaterial, err := offerPC.SCTP().Transport().ExportKeyingMaterial("EXPORTER-test", nil, 32)
+	assert.NoError(t, err)
+	assert.Len(t, offerMaterial, 32)
+	answerMaterial, err := answerPC.SCTP().Transport().ExportKeyingMaterial("EXPORTER-test", nil, 32)
+	assert.NoError(t, err)
+	assert.Equal(t, offerMaterial, answerMaterial)
+
+	otherMaterial, err := offerPC.SCTP().Transport().ExportKeyingMaterial("EXPORTER-other", nil, 32)
+	assert.NoError(t, err)
+	assert.NotEqual(t, offerMaterial, otherMaterial)
+
+	_, err = offerPC.SCTP().Transport().ExportKeyingMaterial("EXPORTER-test", []byte{1}, 32)
+	assert.Error(t, err)
+	_, err = offerPC.SCTP().Transport().ExportKeyingMaterial("master secret", nil, 32)
+	assert.Error(t, err)
+	_, err = offerPC.SCTP().Transport().ExportKeyingMaterial("EXTRACTOR-dtls_srtp", nil, 32)
+	assert.ErrorIs(t, err, errDtlsExporterLabelReserved, "the SRTP master keys were exported")
+
+	closePairNow(t, offerPC, answerPC)
+}
+
 func TestSRTPProtectionProfileFromDTLS(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -574,3 +902,69 @@ func TestSRTPProtectionProfileFromDTLS(t *testing.T) {
 		})
 	}
 }
+
+func TestDTLSTransport_InboundRTPPacketFilter(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	var droppedSSRC atomic.Uint32
+	settingEngine := SettingEngine{}
+	settingEngine.SetInboundRTPPacketFilter(func(ssrc SSRC, payloadType PayloadType, size int) bool {
+		assert.NotZero(t, payloadType)
+		assert.Greater(t, size, rtpHeaderMinLength)
+
+		return uint32(ssrc) != droppedSSRC.Load()
+	})
+
+	pcOffer, err := NewPeerConnection(Configuration{})
+	assert.NoError(t, err)
+	pcAnswer, err := NewAPI(WithSettingEngine(settingEngine)).NewPeerConnection(Configuration{})
+	assert.NoError(t, err)
+
+	keptTrack, err := NewTrackLocalStaticSample(RTPCodecCapability{MimeType: MimeTypeVP8}, "kept", "pion")
+	assert.NoError(t, err)
+	_, err = pcOffer.AddTrack(keptTrack)
+	assert.NoError(t, err)
+
+	droppedTrack, err := NewTrackLocalStaticSample(RTPCodecCapability{MimeType: MimeTypeVP8}, "dropped", "pion")
+	assert.NoError(t, err)
+	droppedSender, err := pcOffer.AddTrack(droppedTrack)
+	assert.NoError(t, err)
+	droppedSSRC.Store(uint32(droppedSender.GetParameters().Encodings[0].SSRC))
+
+	var droppedTrackReceived atomic.Bool
+	keptTrackReceived := make(chan struct{})
+	pcAnswer.OnTrack(func(track *TrackRemote, _ *RTPReceiver) {
+		if track.ID() == droppedTrack.ID() {
+			droppedTrackReceived.Store(true)
+		} else {
+			close(keptTrackReceived)
+		}
+	})
+
+	assert.NoError(t, signalPair(pcOffer, pcAnswer))
+
+	done := make(chan struct{})
+	sendDone := make(chan struct{})
+	go func() {
+		defer close(sendDone)
+		sendVideoUntilDone(t, done, []*TrackLocalStaticSample{keptTrack, droppedTrack})
+	}()
+
+	<-keptTrackReceived
+	assert.Eventually(t, func() bool {
+		return pcAnswer.dtlsTransport.RTPPacketFilterStats().PacketsDropped >= 5
+	}, 5*time.Second, 10*time.Millisecond)
+	close(done)
+	<-sendDone
+
+	stats := pcAnswer.dtlsTransport.RTPPacketFilterStats()
+	assert.Greater(t, stats.BytesDropped, stats.PacketsDropped*rtpHeaderMinLength)
+	assert.False(t, droppedTrackReceived.Load())
+	assert.Zero(t, pcOffer.dtlsTransport.RTPPacketFilterStats().PacketsDropped)
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
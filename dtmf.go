@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package webrtc
+
+import (
+	"encoding/binary"
+	"errors"
+	"strings"
+)
+
+const (
+	// dtmfEventPayloadSize is the size of a RFC 4733 named event payload.
+	dtmfEventPayloadSize = 4
+
+	dtmfEventEndBit     = 0x80
+	dtmfEventVolumeMask = 0x3f
+
+	// dtmfTones are the tones that can be sent as telephone-events, the index is the event code.
+	dtmfTones = "0123456789*#ABCD"
+
+	// dtmfPauseTone is not sent, it pauses the tone buffer, see the W3C RTCDTMFSender.
+	dtmfPauseTone = ','
+)
+
+var errDTMFEventPayloadTooShort = errors.New("telephone-event payload is too short")
+
+// dtmfEvent is a RFC 4733 Section 2.3 named event payload.
+type dtmfEvent struct {
+	event    uint8
+	end      bool
+	volume   uint8
+	duration uint16
+}
+
+func (e dtmfEvent) marshalTo(b []byte) {
+	b[0] = e.event
+	b[1] = e.volume & dtmfEventVolumeMask
+	if e.end {
+		b[1] |= dtmfEventEndBit
+	}
+	binary.BigEndian.PutUint16(b[2:], e.duration)
+}
+
+func (e *dtmfEvent) unmarshal(b []byte) error {
+	if len(b) < dtmfEventPayloadSize {
+		return errDTMFEventPayloadTooShort
+	}
+
+	e.event = b[0]
+	e.end = b[1]&dtmfEventEndBit != 0
+	e.volume = b[1] & dtmfEventVolumeMask
+	e.duration = binary.BigEndian.Uint16(b[2:])
+
+	return nil
+}
+
+// dtmfToneToEvent returns the telephone-event code of a tone. Letters are case-insensitive.
+func dtmfToneToEvent(tone byte) (uint8, bool) {
+	idx := strings.IndexByte(dtmfTones, tone)
+	if idx == -1 && tone >= 'a' && tone <= 'd' {
+		idx = strings.IndexByte(dtmfTones, tone-'a'+'A')
+	}
+
+	return uint8(idx), idx != -1 //nolint:gosec // G115
+}
+
+// dtmfEventToTone returns the tone of a telephone-event code, or false if the
+// event isn't a DTMF tone.
+func dtmfEventToTone(event uint8) (string, bool) {
+	if int(event) >= len(dtmfTones) {
+		return "", false
+	}
+
+	return dtmfTones[event : event+1], true
+}
+
+// normalizeDTMFTones upper-cases tones and validates them, see the W3C insertDTMF algorithm.
+func normalizeDTMFTones(tones string) (string, bool) {
+	tones = strings.ToUpper(tones)
+	for i := range len(tones) {
+		if _, ok := dtmfToneToEvent(tones[i]); !ok && tones[i] != dtmfPauseTone {
+			return "", false
+		}
+	}
+
+	return tones, true
+}
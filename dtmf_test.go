@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package webrtc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDTMFEvent_Marshal(t *testing.T) {
+	event := dtmfEvent{event: 11, end: true, volume: 10, duration: 800}
+
+	payload := make([]byte, dtmfEventPayloadSize)
+	event.marshalTo(payload)
+	assert.Equal(t, []byte{0x0b, 0x8a, 0x03, 0x20}, payload)
+
+	var parsed dtmfEvent
+	assert.NoError(t, parsed.unmarshal(payload))
+	assert.Equal(t, event, parsed)
+
+	assert.ErrorIs(t, parsed.unmarshal(payload[:3]), errDTMFEventPayloadTooShort)
+}
+
+func TestDTMFTones(t *testing.T) {
+	for tone, expected := range map[byte]uint8{'0': 0, '9': 9, '*': 10, '#': 11, 'A': 12, 'd': 15} {
+		event, ok := dtmfToneToEvent(tone)
+		assert.True(t, ok)
+		assert.Equal(t, expected, event)
+	}
+
+	_, ok := dtmfToneToEvent('E')
+	assert.False(t, ok)
+
+	tone, ok := dtmfEventToTone(11)
+	assert.True(t, ok)
+	assert.Equal(t, "#", tone)
+
+	_, ok = dtmfEventToTone(16)
+	assert.False(t, ok)
+
+	tones, ok := normalizeDTMFTones("12,ab#*")
+	assert.True(t, ok)
+	assert.Equal(t, "12,AB#*", tones)
+
+	_, ok = normalizeDTMFTones("12e")
+	assert.False(t, ok)
+}
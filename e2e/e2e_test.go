@@ -7,6 +7,7 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -199,19 +200,38 @@ func TestE2E_DataChannel(t *testing.T) {
 				_ = pc.Close()
 			}()
 
+			// Empty messages have to keep their type on the way to the browser and
+			// back.
+			expected := []webrtc.DataChannelMessage{
+				{IsString: true, Data: []byte("HELLO WORLD")},
+				{IsString: true, Data: []byte{}},
+				{IsString: false, Data: []byte{}},
+				{IsString: false, Data: []byte{0, 1, 2}},
+			}
 			chValid := make(chan struct{})
 			pc.OnDataChannel(func(dc *webrtc.DataChannel) {
 				dc.OnOpen(func() {
 					// Ping
-					if err := dc.SendText("hello world"); err != nil {
-						t.Errorf("Failed to send data: %v", err)
+					for _, err := range []error{
+						dc.SendText("hello world"),
+						dc.SendText(""),
+						dc.Send(nil),
+						dc.Send([]byte{0, 1, 2}),
+					} {
+						if err != nil {
+							t.Errorf("Failed to send data: %v", err)
+						}
 					}
 				})
+				var received int
 				dc.OnMessage(func(msg webrtc.DataChannelMessage) {
 					// Pong
-					if string(msg.Data) != "HELLO WORLD" {
-						t.Errorf("expected message from browser: HELLO WORLD, got: %s", string(msg.Data))
-					} else {
+					want := expected[received]
+					if msg.IsString != want.IsString || !bytes.Equal(msg.Data, want.Data) {
+						t.Errorf("expected message %d from browser: %v %q, got: %v %q",
+							received, want.IsString, want.Data, msg.IsString, msg.Data)
+					}
+					if received++; received == len(expected) {
 						chValid <- struct{}{}
 					}
 				})
@@ -65,6 +65,11 @@ var (
 	// specified for a data channel has been exceeded.
 	ErrMaxDataChannelID = errors.New("maximum number ID for datachannel specified")
 
+	// ErrDataChannelIDExceedsStreams indicates that the ID of a data channel is
+	// beyond the streams negotiated for the SCTP association, because the remote
+	// peer advertised fewer of them.
+	ErrDataChannelIDExceedsStreams = errors.New("data channel id exceeds the negotiated SCTP streams")
+
 	// ErrNegotiatedWithoutID indicates that an attempt to create a data channel
 	// was made while setting the negotiated option to true without providing
 	// the negotiated channel ID.
@@ -152,6 +157,12 @@ var (
 	// ErrCodecAlreadyRegistered indicates that a codec has already been registered for the same payload type.
 	ErrCodecAlreadyRegistered = errors.New("codec already registered for same payload type")
 
+	// ErrRTCPFeedbackTypeAlreadyRegistered indicates that a RTCPFeedbackType has already been registered
+	// for the same packet type and format.
+	ErrRTCPFeedbackTypeAlreadyRegistered = errors.New(
+		"RTCP feedback type already registered for same packet type and format",
+	)
+
 	// ErrRTPSenderNewTrackHasIncorrectKind indicates that the new track is of a different kind than the previous/original.
 	ErrRTPSenderNewTrackHasIncorrectKind = errors.New("new track must be of the same kind as previous")
 
@@ -159,12 +170,20 @@ var (
 	//  than the previous/original.
 	ErrRTPSenderNewTrackHasIncorrectEnvelope = errors.New("new track must have the same envelope as previous")
 
+	// ErrTransceiverPoolExhausted indicates that a track couldn't be bound to a
+	// transceiver pool, because all of its negotiated transceivers of the kind
+	// of the track are bound already.
+	ErrTransceiverPoolExhausted = errors.New("no negotiated transceiver of the pool is free")
+
 	// ErrUnbindFailed indicates that a TrackLocal was not able to be unbind.
 	ErrUnbindFailed = errors.New("failed to unbind TrackLocal from PeerConnection")
 
 	// ErrNoPayloaderForCodec indicates that the requested codec does not have a payloader.
 	ErrNoPayloaderForCodec = errors.New("the requested codec does not have a payloader")
 
+	// ErrNoDepacketizerForCodec indicates that the requested codec does not have a depacketizer.
+	ErrNoDepacketizerForCodec = errors.New("the requested codec does not have a depacketizer")
+
 	// ErrRegisterHeaderExtensionInvalidDirection indicates that a extension was
 	// registered with a direction besides `sendonly` or `recvonly`.
 	ErrRegisterHeaderExtensionInvalidDirection = errors.New(
@@ -178,10 +197,85 @@ var (
 	// ErrSDPUnmarshalling indicates that the SDP could not be unmarshalled.
 	ErrSDPUnmarshalling = errors.New("failed to unmarshal SDP")
 
+	// ErrDatagramTooLarge indicates a datagram larger than the DatagramChannel's MaxDatagramSize.
+	ErrDatagramTooLarge = errors.New("datagram exceeds the maximum datagram size")
+
+	// ErrBatchedMessageTooLarge indicates a message that a MessageBatcher can't frame.
+	ErrBatchedMessageTooLarge = errors.New("message is too large to be batched")
+
+	// ErrInvalidMessageBatch indicates a received message that isn't a valid batch.
+	ErrInvalidMessageBatch = errors.New("invalid message batch")
+
+	// ErrConnectionPhaseTimeout indicates that a phase of establishing a PeerConnection
+	// exceeded its deadline, see PhaseTimeoutError.
+	ErrConnectionPhaseTimeout = errors.New("connection phase timed out")
+
+	// ErrReconnectFailed indicates that a Reconnector gave up restarting ICE.
+	ErrReconnectFailed = errors.New("peer connection did not reconnect")
+
+	// ErrDataChannelQueueChannelsExceeded indicates that CreateDataChannel was called
+	// before the SCTP association was established with as many DataChannels queued
+	// as allowed by SettingEngine.SetDataChannelQueueLimits.
+	ErrDataChannelQueueChannelsExceeded = errors.New("too many datachannels queued before SCTP is established")
+
+	// ErrDataChannelQueueBytesExceeded indicates that CreateDataChannel was called
+	// before the SCTP association was established and the labels and protocols of
+	// the queued DataChannels would exceed SettingEngine.SetDataChannelQueueLimits.
+	ErrDataChannelQueueBytesExceeded = errors.New("too many datachannel bytes queued before SCTP is established")
+
+	// ErrDataChannelOpenTimeout indicates that the remote peer didn't acknowledge
+	// the opening of a data channel in time, see
+	// SettingEngine.SetDataChannelOpenTimeout.
+	ErrDataChannelOpenTimeout = errors.New("data channel open was not acknowledged in time")
+
+	// ErrDataChannelReceiveQueueFull indicates that a data channel was closed
+	// because its receive queue was full, see
+	// SettingEngine.SetDataChannelReceiveQueueLimits.
+	ErrDataChannelReceiveQueueFull = errors.New("data channel receive queue is full")
+
+	// ErrICEServerNoResponse indicates that a STUN or TURN server didn't respond
+	// during the candidate gathering, see ICEServerGatheringEvent.
+	ErrICEServerNoResponse = errors.New("ICE server did not respond")
+
+	// ErrICEServerNoCandidate indicates that a STUN or TURN server responded but
+	// no candidate was gathered from it, like when a TURN server refused the
+	// credentials, see ICEServerGatheringEvent.
+	ErrICEServerNoCandidate = errors.New("ICE server did not provide a candidate")
+
+	// ErrSampleExceedsMaxPtime indicates that a sample is longer than the maxptime
+	// the remote peer signaled for the media section of the track.
+	ErrSampleExceedsMaxPtime = errors.New("sample duration exceeds the negotiated maxptime")
+
 	errDetachNotEnabled                 = errors.New("enable detaching by calling webrtc.DetachDataChannels()")
 	errDetachBeforeOpened               = errors.New("datachannel not opened yet, try calling Detach from OnOpen")
+	errDetachCompressed                 = errors.New("datachannels with compression can't be detached")
+	errCompressionUnordered             = errors.New("datachannels negotiating compression have to be ordered")
+	errDecompressedMessageTooLarge      = errors.New("decompressed datachannel message exceeds the max message size")
+	errInvalidCompressedMessage         = errors.New("datachannel message has an invalid compression flag")
+	errDetachFragmented                 = errors.New("datachannels with fragmentation can't be detached")
+	errFragmentationUnreliable          = errors.New("fragmented datachannels have to be reliable and ordered")
+	errFragmentedMessageTooLarge        = errors.New("reassembled datachannel message exceeds the max message size")
+	errInvalidFragment                  = errors.New("datachannel message fragment is invalid")
+	errSendOptionsQueued                = errors.New("datachannel messages with send options can't be queued")
+	errSendContextDetached              = errors.New("SendContext can't be used on a detached datachannel")
+	errSendOptionsUnorderedFragments    = errors.New("fragmented datachannel messages have to be ordered")
+	errSendOptionsUnorderedExtensions   = errors.New("datachannel messages negotiating extensions have to be ordered")
+	errSendOptionsReservedPPID          = errors.New("datachannel messages can't use the DCEP payload protocol identifier")
+	errSendOptionsEmptyPPID             = errors.New("empty datachannel messages can't use a payload protocol identifier")
+	errDataChannelGroupLabelMismatch    = errors.New("datachannel label doesn't match the DataChannelGroup")
+	errDataChannelGroupClosed           = errors.New("the DataChannelGroup is closed")
+	errDatagramChannelReliable          = errors.New("datagram channels have to be unordered without retransmissions")
+	errReconnectorNoSignal              = errors.New("the Reconnector requires a Signal function")
+	errReconnectorInstalled             = errors.New("the PeerConnection already has a Reconnector")
+	errSessionParametersTooLarge        = errors.New("the session parameters are too large")
+	errSessionICERoleConflict           = errors.New("the ICE username fragments of both sessions are equal")
+	errSampleReaderStarted              = errors.New("the samples of the track are read already")
+	errPipeNoSignal                     = errors.New("the PeerConnectionPipe requires a Signal function")
+	errTrackNoClockRate                 = errors.New("the clock rate of the track is unknown")
+	errReconnectorNegotiating           = errors.New("an offer of the remote peer is being negotiated")
 	errDtlsTransportNotStarted          = errors.New("the DTLS transport has not started yet")
 	errDtlsKeyExtractionFailed          = errors.New("failed extracting keys from DTLS for SRTP")
+	errDtlsExporterLabelReserved        = errors.New("the exporter label is reserved for the keys of DTLS-SRTP")
 	errFailedToStartSRTP                = errors.New("failed to start SRTP")
 	errFailedToStartSRTCP               = errors.New("failed to start SRTCP")
 	errInvalidDTLSStart                 = errors.New("attempted to start DTLSTransport that is not in new state")
@@ -232,9 +326,14 @@ var (
 	errPeerConnAddTransceiverFromTrackSupport = errors.New(
 		"AddTransceiverFromTrack currently only supports sendonly and sendrecv",
 	)
+	errPeerConnTransceiverPoolDirection = errors.New(
+		"AddTransceiverPool only supports sendonly and recvonly",
+	)
+	errPeerConnTransceiverPoolSender             = errors.New("RTPSender is not bound to a transceiver pool")
 	errPeerConnSetIdentityProviderNotImplemented = errors.New("TODO SetIdentityProvider")
 	errPeerConnWriteRTCPOpenWriteStream          = errors.New("WriteRTCP failed to open WriteStream")
 	errPeerConnTranscieverMidNil                 = errors.New("cannot find transceiver with mid")
+	errMidGeneratorNotUnique                     = errors.New("the mid generator only returned mids already used")
 	errPeerConnEarlyMediaWithoutAnswer           = errors.New(
 		"cannot process early media without SDP answer," +
 			"use SettingEngine.SetHandleUndeclaredSSRCWithoutAnswer(true) to process without answer",
@@ -256,6 +355,11 @@ var (
 	errRTPSenderBaseEncodingMismatch = errors.New("Sender cannot add encoding as provided track does not match base track")
 	errRTPSenderRIDCollision         = errors.New("Sender cannot encoding due to RID collision")
 	errRTPSenderNoTrackForRID        = errors.New("Sender does not have track for RID")
+	errRTPSenderDTMFNotAudio         = errors.New("Sender cannot insert DTMF as it is not sending audio")
+	errRTPSenderDTMFNotNegotiated    = errors.New("Sender cannot insert DTMF as telephone-event was not negotiated")
+	errRTPSenderDTMFInvalidTone      = errors.New("Sender cannot insert DTMF as tones contain an invalid character")
+	errRTPSenderCNNotAudio           = errors.New("Sender cannot send comfort noise as it is not sending audio")
+	errRTPSenderCNNotNegotiated      = errors.New("Sender cannot send comfort noise as CN was not negotiated")
 
 	errRTPTransceiverCannotChangeMid        = errors.New("cannot change transceiver mid")
 	errRTPTransceiverSetSendingInvalidState = errors.New("invalid state change in RTPTransceiver.setSending")
@@ -263,6 +367,9 @@ var (
 
 	errSCTPTransportDTLS = errors.New("DTLS not established")
 
+	errRTCPFeedbackTypeNoUnmarshal   = errors.New("RTCP feedback type requires an Unmarshal function")
+	errRTCPFeedbackTypeInvalidFormat = errors.New("RTCP feedback type format must fit into 5 bits")
+
 	errSDPZeroTransceivers                 = errors.New("addTransceiverSDP() called with 0 transceivers")
 	errSDPMediaSectionMediaDataChanInvalid = errors.New("invalid Media Section. Media + DataChannel both enabled")
 	errSDPMediaSectionMultipleTrackInvalid = errors.New(
@@ -270,6 +377,9 @@ var (
 	)
 
 	errSettingEngineSetAnsweringDTLSRole = errors.New("SetAnsweringDTLSRole must DTLSRoleClient or DTLSRoleServer")
+	errSharedCredentialsUsernameFragment = errors.New("the ICE username fragment must be 4 to 256 ice-chars")
+	errSharedCredentialsPassword         = errors.New("the ICE password must be 22 to 256 ice-chars")
+	errSharedCredentialsNoCertificate    = errors.New("shared credentials need at least one certificate")
 
 	errSignalingStateCannotRollback            = errors.New("can't rollback from stable state")
 	errSignalingStateProposedTransitionInvalid = errors.New("invalid proposed signaling state transition")
@@ -293,4 +403,6 @@ var (
 	errRTPTooShort = errors.New("not long enough to be a RTP Packet")
 
 	errExcessiveRetries = errors.New("excessive retries in CreateOffer")
+
+	errRTCEventLogDisabled = errors.New("RTC event log is not enabled in the SettingEngine")
 )
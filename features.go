@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package webrtc
+
+import (
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// FeatureSet describes the optional subsystems of this build of Pion WebRTC and
+// the defaults of its SettingEngine, see Features. It can be marshaled to JSON
+// and attached to bug reports.
+type FeatureSet struct {
+	// GoVersion is the version of Go the program was built with.
+	GoVersion string `json:"goVersion"`
+
+	// Modules are the versions of the Pion modules the program was built with,
+	// keyed by their path. It is empty when the build information isn't
+	// available, like for programs built without module support.
+	Modules map[string]string `json:"modules,omitempty"`
+
+	// WASM is true when built for WebAssembly, where the WebRTC implementation
+	// of the browser is used.
+	WASM bool `json:"wasm"`
+
+	// VNet is true when the virtual network of pion/transport can be used,
+	// see SettingEngine.SetNet.
+	VNet bool `json:"vnet"`
+
+	// MDNS is true when mDNS candidates can be gathered and resolved, see
+	// SettingEngine.SetICEMulticastDNSMode.
+	MDNS bool `json:"mdns"`
+
+	// ICETCP is true when ICE-TCP candidates can be used, see
+	// SettingEngine.SetICETCPMux.
+	ICETCP bool `json:"iceTcp"`
+
+	// Simulcast is true when simulcast streams can be sent and received.
+	Simulcast bool `json:"simulcast"`
+
+	// Defaults are the settings used when the SettingEngine doesn't change them.
+	Defaults FeatureDefaults `json:"defaults"`
+}
+
+// FeatureDefaults are the defaults of a SettingEngine, see FeatureSet.
+type FeatureDefaults struct {
+	// ICENetworkTypes are the network types candidates are gathered for.
+	ICENetworkTypes []string `json:"iceNetworkTypes,omitempty"`
+
+	// MulticastDNSMode is the handling of mDNS candidates, one of disabled,
+	// query-only and query-and-gather.
+	MulticastDNSMode string `json:"multicastDnsMode,omitempty"`
+
+	// ReceiveMTU is the size of the buffers incoming packets are read into.
+	ReceiveMTU uint `json:"receiveMtu,omitempty"`
+
+	// SCTPMaxMessageSize is the largest DataChannel message that is accepted.
+	SCTPMaxMessageSize uint32 `json:"sctpMaxMessageSize,omitempty"`
+}
+
+// Features returns the optional subsystems of this build and the defaults of
+// the SettingEngine, so they can be included in support and bug reports.
+func Features() FeatureSet {
+	features := FeatureSet{
+		GoVersion: runtime.Version(),
+		WASM:      runtime.GOOS == "js" && runtime.GOARCH == "wasm",
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		modules := map[string]string{}
+		for _, module := range append([]*debug.Module{&info.Main}, info.Deps...) {
+			if module.Replace != nil {
+				module = module.Replace
+			}
+			if strings.HasPrefix(module.Path, "github.com/pion/") {
+				modules[module.Path] = module.Version
+			}
+		}
+		if len(modules) != 0 {
+			features.Modules = modules
+		}
+	}
+
+	addBuildFeatures(&features)
+
+	return features
+}
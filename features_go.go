@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+func addBuildFeatures(features *FeatureSet) {
+	features.VNet = true
+	features.MDNS = true
+	features.ICETCP = true
+	features.Simulcast = true
+
+	settingEngine := &SettingEngine{}
+	for _, networkType := range supportedNetworkTypes() {
+		features.Defaults.ICENetworkTypes = append(features.Defaults.ICENetworkTypes, networkType.String())
+	}
+	// Remote mDNS candidates are resolved, but local ones aren't gathered,
+	// see ICEGatherer.sanitizedMDNSMode.
+	features.Defaults.MulticastDNSMode = "query-only"
+	features.Defaults.ReceiveMTU = settingEngine.getReceiveMTU()
+	features.Defaults.SCTPMaxMessageSize = settingEngine.getSCTPMaxMessageSize()
+}
@@ -0,0 +1,10 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build js && wasm
+// +build js,wasm
+
+package webrtc
+
+// addBuildFeatures leaves the subsystems disabled, the browser provides them.
+func addBuildFeatures(*FeatureSet) {}
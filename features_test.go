@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"encoding/json"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeatures(t *testing.T) {
+	features := Features()
+
+	assert.Equal(t, runtime.Version(), features.GoVersion)
+	assert.Contains(t, features.Modules, "github.com/pion/ice/v4")
+	assert.False(t, features.WASM)
+	assert.True(t, features.VNet)
+	assert.True(t, features.MDNS)
+	assert.True(t, features.ICETCP)
+	assert.True(t, features.Simulcast)
+	assert.Equal(t, FeatureDefaults{
+		ICENetworkTypes:    []string{"udp4", "udp6"},
+		MulticastDNSMode:   "query-only",
+		ReceiveMTU:         receiveMTU,
+		SCTPMaxMessageSize: defaultMaxSCTPMessageSize,
+	}, features.Defaults)
+
+	b, err := json.Marshal(features)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"iceTcp":true`)
+	assert.Contains(t, string(b), `"multicastDnsMode":"query-only"`)
+}
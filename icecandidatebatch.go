@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"sync"
+	"time"
+)
+
+// iceCandidateBatcher groups trickled candidates that are found within a window
+// so they can be signaled together.
+type iceCandidateBatcher struct {
+	// deliverMu serializes handler invocations so batches are delivered in order
+	// and the end-of-candidates batch is always the last one.
+	deliverMu sync.Mutex
+
+	mu      sync.Mutex
+	window  time.Duration
+	pending []ICECandidate
+	timer   *time.Timer
+
+	handler func(candidates []ICECandidate, endOfCandidates bool)
+}
+
+func newICECandidateBatcher(
+	window time.Duration, handler func(candidates []ICECandidate, endOfCandidates bool),
+) *iceCandidateBatcher {
+	return &iceCandidateBatcher{
+		window:  window,
+		handler: handler,
+	}
+}
+
+// onCandidate is installed as the ICEGatherer's local candidate handler. A nil
+// candidate flushes everything that is pending together with end-of-candidates.
+func (b *iceCandidateBatcher) onCandidate(candidate *ICECandidate) {
+	if candidate == nil {
+		b.flush(true)
+
+		return
+	}
+
+	b.mu.Lock()
+	b.pending = append(b.pending, *candidate)
+	if b.window <= 0 {
+		b.mu.Unlock()
+		b.flush(false)
+
+		return
+	}
+
+	if b.timer == nil {
+		b.timer = time.AfterFunc(b.window, func() {
+			b.flush(false)
+		})
+	}
+	b.mu.Unlock()
+}
+
+func (b *iceCandidateBatcher) flush(endOfCandidates bool) {
+	b.deliverMu.Lock()
+	defer b.deliverMu.Unlock()
+
+	b.mu.Lock()
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	candidates := b.pending
+	b.pending = nil
+	b.mu.Unlock()
+
+	if len(candidates) == 0 && !endOfCandidates {
+		return
+	}
+
+	b.handler(candidates, endOfCandidates)
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/stretchr/testify/assert"
+)
+
+type iceCandidateBatch struct {
+	candidates      []ICECandidate
+	endOfCandidates bool
+}
+
+func TestICECandidateBatcher(t *testing.T) {
+	t.Run("GroupsWithinWindow", func(t *testing.T) {
+		batches := make(chan iceCandidateBatch, 4)
+		batcher := newICECandidateBatcher(50*time.Millisecond, func(c []ICECandidate, end bool) {
+			batches <- iceCandidateBatch{c, end}
+		})
+
+		batcher.onCandidate(&ICECandidate{Port: 1})
+		batcher.onCandidate(&ICECandidate{Port: 2})
+
+		batch := <-batches
+		assert.False(t, batch.endOfCandidates)
+		assert.Len(t, batch.candidates, 2)
+
+		batcher.onCandidate(&ICECandidate{Port: 3})
+		batcher.onCandidate(nil)
+
+		batch = <-batches
+		assert.True(t, batch.endOfCandidates)
+		assert.Equal(t, []ICECandidate{{Port: 3}}, batch.candidates)
+
+		select {
+		case batch = <-batches:
+			assert.Fail(t, "unexpected batch after end-of-candidates", batch)
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
+	t.Run("ZeroWindow", func(t *testing.T) {
+		var batches []iceCandidateBatch
+		batcher := newICECandidateBatcher(0, func(c []ICECandidate, end bool) {
+			batches = append(batches, iceCandidateBatch{c, end})
+		})
+
+		batcher.onCandidate(&ICECandidate{Port: 1})
+		batcher.onCandidate(&ICECandidate{Port: 2})
+		batcher.onCandidate(nil)
+
+		assert.Equal(t, []iceCandidateBatch{
+			{[]ICECandidate{{Port: 1}}, false},
+			{[]ICECandidate{{Port: 2}}, false},
+			{nil, true},
+		}, batches)
+	})
+}
+
+func TestPeerConnection_OnICECandidateBatch(t *testing.T) {
+	lim := test.TimeOut(time.Second * 10)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	pc, err := NewPeerConnection(Configuration{})
+	assert.NoError(t, err)
+
+	done := make(chan struct{})
+	var candidates []ICECandidate
+	pc.OnICECandidateBatch(20*time.Millisecond, func(batch []ICECandidate, endOfCandidates bool) {
+		candidates = append(candidates, batch...)
+		if endOfCandidates {
+			close(done)
+		}
+	})
+
+	_, err = pc.CreateDataChannel("batch", nil)
+	assert.NoError(t, err)
+
+	offer, err := pc.CreateOffer(nil)
+	assert.NoError(t, err)
+	assert.NoError(t, pc.SetLocalDescription(offer))
+
+	<-done
+	assert.NotEmpty(t, candidates)
+	assert.NoError(t, pc.Close())
+}
@@ -3,6 +3,14 @@
 
 package webrtc
 
+import (
+	"strings"
+)
+
+// endOfCandidatesAttribute is the SDP attribute defined in RFC 8840 that some
+// signaling implementations send verbatim instead of an empty candidate.
+const endOfCandidatesAttribute = "end-of-candidates"
+
 // ICECandidateInit is used to serialize ice candidates.
 type ICECandidateInit struct {
 	Candidate        string  `json:"candidate"`
@@ -10,3 +18,20 @@ type ICECandidateInit struct {
 	SDPMLineIndex    *uint16 `json:"sdpMLineIndex"`
 	UsernameFragment *string `json:"usernameFragment"`
 }
+
+// NewEndOfCandidatesInit returns an ICECandidateInit that tells the remote peer
+// no further candidates will be trickled. This matches the empty candidate that
+// browsers emit when gathering has completed.
+func NewEndOfCandidatesInit() ICECandidateInit {
+	return ICECandidateInit{}
+}
+
+// IsEndOfCandidates reports if the ICECandidateInit signals end-of-candidates.
+// Both the empty candidate used by browsers and the RFC 8840 attribute
+// (with or without the "a=" prefix) are accepted.
+func (c ICECandidateInit) IsEndOfCandidates() bool {
+	value := strings.TrimPrefix(strings.TrimSpace(c.Candidate), "a=")
+	value = strings.TrimPrefix(value, "candidate:")
+
+	return value == "" || value == endOfCandidatesAttribute
+}
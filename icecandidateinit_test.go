@@ -46,3 +46,20 @@ func refString(s string) *string {
 func refUint16(i uint16) *uint16 {
 	return &i
 }
+
+func TestICECandidateInit_IsEndOfCandidates(t *testing.T) {
+	for _, tc := range []struct {
+		candidate string
+		expected  bool
+	}{
+		{"", true},
+		{"candidate:", true},
+		{"end-of-candidates", true},
+		{"a=end-of-candidates", true},
+		{"candidate:abc123", false},
+	} {
+		assert.Equal(t, tc.expected, ICECandidateInit{Candidate: tc.candidate}.IsEndOfCandidates(), tc.candidate)
+	}
+
+	assert.True(t, NewEndOfCandidatesInit().IsEndOfCandidates())
+}
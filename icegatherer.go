@@ -15,6 +15,7 @@ import (
 	"github.com/pion/ice/v4"
 	"github.com/pion/logging"
 	"github.com/pion/stun/v3"
+	"github.com/pion/transport/v4"
 )
 
 // ICEGatherer gathers local host, server reflexive and relay
@@ -34,6 +35,9 @@ type ICEGatherer struct {
 	onLocalCandidateHandler atomic.Value // func(candidate *ICECandidate)
 	onStateChangeHandler    atomic.Value // func(state ICEGathererState)
 
+	onServerGatheringEventHandler atomic.Value // func(ICEServerGatheringEvent)
+	serverTracker                 *iceServerTracker
+
 	// Used for GatheringCompletePromise
 	onGatheringCompleteHandler atomic.Value // func()
 
@@ -163,6 +167,10 @@ func (g *ICEGatherer) createAgent() error {
 		return nil
 	}
 
+	if len(g.validatedServers) > 0 {
+		g.serverTracker = &iceServerTracker{gatherer: g}
+	}
+
 	options, err := g.buildAgentOptions()
 	if err != nil {
 		return err
@@ -183,7 +191,12 @@ func (g *ICEGatherer) buildAgentOptions() ([]ice.AgentOption, error) {
 	nat1To1CandiTyp := g.resolveNAT1To1CandidateType()
 	mDNSMode := g.sanitizedMDNSMode()
 
-	options := g.baseAgentOptions(mDNSMode)
+	agentNet, err := g.serverTracker.wrapNet(g.api.settingEngine.net)
+	if err != nil {
+		return nil, err
+	}
+
+	options := g.baseAgentOptions(mDNSMode, agentNet)
 	if len(candidateTypes) > 0 {
 		options = append(options, ice.WithCandidateTypes(candidateTypes))
 	}
@@ -243,7 +256,7 @@ func (g *ICEGatherer) sanitizedMDNSMode() ice.MulticastDNSMode {
 	return ice.MulticastDNSModeQueryOnly
 }
 
-func (g *ICEGatherer) baseAgentOptions(mDNSMode ice.MulticastDNSMode) []ice.AgentOption {
+func (g *ICEGatherer) baseAgentOptions(mDNSMode ice.MulticastDNSMode, agentNet transport.Net) []ice.AgentOption {
 	return []ice.AgentOption{
 		ice.WithICELite(g.api.settingEngine.candidates.ICELite),
 		ice.WithUrls(g.validatedServers),
@@ -252,7 +265,7 @@ func (g *ICEGatherer) baseAgentOptions(mDNSMode ice.MulticastDNSMode) []ice.Agen
 		ice.WithInterfaceFilter(g.api.settingEngine.candidates.InterfaceFilter),
 		ice.WithIPFilter(g.api.settingEngine.candidates.IPFilter),
 		ice.WithRemoteIPFilter(g.api.settingEngine.candidates.RemoteIPFilter),
-		ice.WithNet(g.api.settingEngine.net),
+		ice.WithNet(agentNet),
 		ice.WithMulticastDNSMode(mDNSMode),
 		ice.WithTCPMux(g.api.settingEngine.iceTCPMux),
 		ice.WithUDPMux(g.api.settingEngine.iceUDPMux),
@@ -262,15 +275,12 @@ func (g *ICEGatherer) baseAgentOptions(mDNSMode ice.MulticastDNSMode) []ice.Agen
 }
 
 func (g *ICEGatherer) credentialOptions() []ice.AgentOption {
-	ufrag := g.api.settingEngine.candidates.UsernameFragment
-	pass := g.api.settingEngine.candidates.Password
+	ufrag, pass := g.api.settingEngine.iceCredentials()
 	if ufrag == "" && pass == "" {
 		return nil
 	}
 
-	return []ice.AgentOption{
-		ice.WithLocalCredentials(g.api.settingEngine.candidates.UsernameFragment, g.api.settingEngine.candidates.Password),
-	}
+	return []ice.AgentOption{ice.WithLocalCredentials(ufrag, pass)}
 }
 
 func (g *ICEGatherer) addressRewriteOptions(candidateType ice.CandidateType) ([]ice.AgentOption, error) {
@@ -301,11 +311,16 @@ func (g *ICEGatherer) addressRewriteOptions(candidateType ice.CandidateType) ([]
 func (g *ICEGatherer) timeoutOptions() []ice.AgentOption {
 	opts := make([]ice.AgentOption, 0, 8)
 
-	if g.api.settingEngine.timeout.ICEDisconnectedTimeout != nil {
-		opts = append(opts, ice.WithDisconnectedTimeout(*g.api.settingEngine.timeout.ICEDisconnectedTimeout))
-	}
-	if g.api.settingEngine.timeout.ICEFailedTimeout != nil {
-		opts = append(opts, ice.WithFailedTimeout(*g.api.settingEngine.timeout.ICEFailedTimeout))
+	if g.api.settingEngine.hasPairTimeouts() {
+		timeouts := g.api.settingEngine.maxPairTimeouts()
+		opts = append(opts, ice.WithDisconnectedTimeout(timeouts.disconnected), ice.WithFailedTimeout(timeouts.failed))
+	} else {
+		if g.api.settingEngine.timeout.ICEDisconnectedTimeout != nil {
+			opts = append(opts, ice.WithDisconnectedTimeout(*g.api.settingEngine.timeout.ICEDisconnectedTimeout))
+		}
+		if g.api.settingEngine.timeout.ICEFailedTimeout != nil {
+			opts = append(opts, ice.WithFailedTimeout(*g.api.settingEngine.timeout.ICEFailedTimeout))
+		}
 	}
 	if g.api.settingEngine.timeout.ICEKeepaliveInterval != nil {
 		opts = append(opts, ice.WithKeepaliveInterval(*g.api.settingEngine.timeout.ICEKeepaliveInterval))
@@ -420,6 +435,11 @@ func (g *ICEGatherer) Gather() error { //nolint:cyclop
 		return fmt.Errorf("%w: unable to gather", errICEAgentNotExist)
 	}
 
+	g.lock.RLock()
+	tracker := g.serverTracker
+	tracker.start(g.validatedServers)
+	g.lock.RUnlock()
+
 	g.setState(ICEGathererStateGathering)
 	if err := agent.OnCandidate(func(candidate ice.Candidate) {
 		onLocalCandidateHandler := func(*ICECandidate) {}
@@ -441,6 +461,8 @@ func (g *ICEGatherer) Gather() error { //nolint:cyclop
 		sdpMLineIndex := uint16(g.sdpMLineIndex.Load()) //nolint:gosec // G115
 
 		if candidate != nil {
+			tracker.candidateGathered(candidate, sdpMid, sdpMLineIndex)
+
 			g.candidatePoolLock.Lock()
 			if g.iceCandidatePoolSize > 0 && g.candidatePool != nil {
 				g.candidatePool = append(g.candidatePool, candidate)
@@ -458,6 +480,7 @@ func (g *ICEGatherer) Gather() error { //nolint:cyclop
 			}
 			onLocalCandidateHandler(&c)
 		} else {
+			tracker.finish()
 			g.setState(ICEGathererStateComplete)
 			onGatheringCompleteHandler()
 
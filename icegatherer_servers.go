@@ -0,0 +1,530 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pion/ice/v4"
+	"github.com/pion/stun/v3"
+	"github.com/pion/transport/v4"
+	"github.com/pion/transport/v4/stdnet"
+)
+
+// ICEServerGatheringEventType is the type of an ICEServerGatheringEvent.
+type ICEServerGatheringEventType int
+
+const (
+	// ICEServerGatheringEventTypeUnknown is the enum's zero-value.
+	ICEServerGatheringEventTypeUnknown ICEServerGatheringEventType = iota
+
+	// ICEServerGatheringEventTypeStarted indicates that the first request was
+	// sent to the server.
+	ICEServerGatheringEventTypeStarted
+
+	// ICEServerGatheringEventTypeSucceeded indicates that the first candidate
+	// was gathered from the server.
+	ICEServerGatheringEventTypeSucceeded
+
+	// ICEServerGatheringEventTypeFailed indicates that the gathering completed
+	// without a candidate from the server.
+	ICEServerGatheringEventTypeFailed
+)
+
+// This is done this way because of a linter.
+const (
+	iceServerGatheringEventTypeStartedStr   = "started"
+	iceServerGatheringEventTypeSucceededStr = "succeeded"
+	iceServerGatheringEventTypeFailedStr    = "failed"
+)
+
+func (t ICEServerGatheringEventType) String() string {
+	switch t {
+	case ICEServerGatheringEventTypeStarted:
+		return iceServerGatheringEventTypeStartedStr
+	case ICEServerGatheringEventTypeSucceeded:
+		return iceServerGatheringEventTypeSucceededStr
+	case ICEServerGatheringEventTypeFailed:
+		return iceServerGatheringEventTypeFailedStr
+	default:
+		return ErrUnknownType.Error()
+	}
+}
+
+// ICEServerGatheringEvent reports the progress of the candidate gathering from
+// one of the STUN and TURN servers, see ICEGatherer.OnServerGatheringEvent.
+type ICEServerGatheringEvent struct {
+	Type ICEServerGatheringEventType
+
+	// URL is the URL of the server without its credentials, like
+	// "turn:turn.example.com:3478?transport=udp".
+	URL string
+
+	// RTT is the time between the first request to the server and its response,
+	// it is zero if the server didn't respond. It is set for Succeeded and Failed
+	// events.
+	RTT time.Duration
+
+	// Candidate is the first candidate gathered from the server, it is set for
+	// Succeeded events.
+	Candidate *ICECandidate
+
+	// Err is the reason no candidate was gathered from the server, it is set for
+	// Failed events. It is ErrICEServerNoResponse, ErrICEServerNoCandidate or the
+	// error resolving the address of the server.
+	Err error
+}
+
+// OnServerGatheringEvent sets an event handler which is invoked when the
+// gathering from one of the STUN and TURN servers starts, succeeds or fails.
+// Applications can use it to find the servers that don't contribute candidates.
+// Every server has at most one event of each type per gathering, servers that
+// aren't contacted, like STUN servers with ICETransportPolicyRelay, have none.
+//
+// The events are collected by watching the traffic to the servers on the
+// sockets opened while gathering, which is only possible for gatherers created
+// with ICE servers. TURN servers reached through SettingEngine.SetICEProxyDialer
+// aren't watched.
+func (g *ICEGatherer) OnServerGatheringEvent(f func(ICEServerGatheringEvent)) {
+	g.onServerGatheringEventHandler.Store(f)
+}
+
+func (g *ICEGatherer) onServerGatheringEvent(event ICEServerGatheringEvent) {
+	g.log.Debugf("ICE server %s gathering %s", event.URL, event.Type)
+	if handler, ok := g.onServerGatheringEventHandler.Load().(func(ICEServerGatheringEvent)); ok && handler != nil {
+		handler(event)
+	}
+}
+
+// OnICEServerGatheringEvent sets an event handler which is invoked when the
+// gathering from one of the ICE servers of the Configuration starts, succeeds or
+// fails, see ICEGatherer.OnServerGatheringEvent.
+func (pc *PeerConnection) OnICEServerGatheringEvent(f func(ICEServerGatheringEvent)) {
+	pc.iceGatherer.OnServerGatheringEvent(f)
+}
+
+// iceServerTracker watches the connections the ICE agent opens to gather from
+// the servers, the agent doesn't report which server a candidate came from.
+type iceServerTracker struct {
+	gatherer  *ICEGatherer
+	gathering atomic.Bool
+
+	mu      sync.Mutex
+	servers []*iceServerProbe
+}
+
+// iceServerProbe is the gathering from one server URL.
+type iceServerProbe struct {
+	url      string
+	hostPort string
+	relay    bool
+	tcp      bool
+
+	// addresses are the resolved addresses of the server.
+	addresses map[string]struct{}
+	attempts  []*iceServerAttempt
+	resolvErr error
+
+	started   bool
+	succeeded bool
+}
+
+// iceServerAttempt is the gathering from a server on one local socket.
+type iceServerAttempt struct {
+	server    string
+	localPort int
+	sent      time.Time
+	rtt       time.Duration
+	responded bool
+}
+
+func (p *iceServerProbe) rtt() time.Duration {
+	for _, attempt := range p.attempts {
+		if attempt.responded {
+			return attempt.rtt
+		}
+	}
+
+	return 0
+}
+
+// start begins tracking a gathering from the servers.
+func (t *iceServerTracker) start(urls []*stun.URI) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.servers = t.servers[:0]
+	for _, url := range urls {
+		t.servers = append(t.servers, &iceServerProbe{
+			url:       url.String(),
+			hostPort:  net.JoinHostPort(url.Host, strconv.Itoa(url.Port)),
+			relay:     url.Scheme == stun.SchemeTypeTURN || url.Scheme == stun.SchemeTypeTURNS,
+			tcp:       url.Proto == stun.ProtoTypeTCP,
+			addresses: map[string]struct{}{},
+		})
+	}
+	t.gathering.Store(true)
+}
+
+// finish reports the servers no candidate was gathered from.
+func (t *iceServerTracker) finish() {
+	if t == nil || !t.gathering.Swap(false) {
+		return
+	}
+
+	var events []ICEServerGatheringEvent
+	t.mu.Lock()
+	for _, server := range t.servers {
+		if server.succeeded || (!server.started && server.resolvErr == nil) {
+			continue
+		}
+
+		event := ICEServerGatheringEvent{
+			Type: ICEServerGatheringEventTypeFailed,
+			URL:  server.url,
+			RTT:  server.rtt(),
+			Err:  server.resolvErr,
+		}
+		switch {
+		case !server.started:
+		case event.RTT == 0:
+			event.Err = ErrICEServerNoResponse
+		default:
+			event.Err = ErrICEServerNoCandidate
+		}
+		events = append(events, event)
+	}
+	t.servers = nil
+	t.mu.Unlock()
+
+	for _, event := range events {
+		t.gatherer.onServerGatheringEvent(event)
+	}
+}
+
+// watches returns true while gathering from a server of the kind, the sockets
+// opened for other kinds or after the gathering aren't wrapped.
+func (t *iceServerTracker) watches(relay, tcp bool) bool {
+	if !t.gathering.Load() {
+		return false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, server := range t.servers {
+		if server.relay == relay && server.tcp == tcp {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *iceServerTracker) resolved(address string, tcp bool, addr net.Addr, err error) {
+	if !t.gathering.Load() {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, server := range t.servers {
+		if server.hostPort != address || server.tcp != tcp {
+			continue
+		}
+		if err != nil {
+			server.resolvErr = err
+		} else {
+			server.addresses[addr.String()] = struct{}{}
+		}
+	}
+}
+
+// sent is called for the packets sent on a socket, the first packet to a server
+// starts an attempt.
+func (t *iceServerTracker) sent(conn *iceServerConn, addr net.Addr) {
+	if !t.gathering.Load() || addr == nil {
+		return
+	}
+
+	t.mu.Lock()
+	if conn.attempt != nil {
+		t.mu.Unlock()
+
+		return
+	}
+
+	var probe *iceServerProbe
+	for _, server := range t.servers {
+		if _, ok := server.addresses[addr.String()]; ok && server.relay == conn.relay && server.tcp == conn.tcp {
+			probe = server
+
+			break
+		}
+	}
+	if probe == nil {
+		t.mu.Unlock()
+
+		return
+	}
+
+	conn.attempt = &iceServerAttempt{server: addr.String(), localPort: conn.localPort, sent: time.Now()}
+	probe.attempts = append(probe.attempts, conn.attempt)
+	first := !probe.started
+	probe.started = true
+	t.mu.Unlock()
+
+	if first {
+		t.gatherer.onServerGatheringEvent(ICEServerGatheringEvent{
+			Type: ICEServerGatheringEventTypeStarted,
+			URL:  probe.url,
+		})
+	}
+}
+
+func (t *iceServerTracker) received(conn *iceServerConn, addr net.Addr) {
+	if !t.gathering.Load() || addr == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if attempt := conn.attempt; attempt != nil && !attempt.responded && attempt.server == addr.String() {
+		attempt.rtt = max(time.Since(attempt.sent), time.Nanosecond)
+		attempt.responded = true
+	}
+}
+
+// candidateGathered attributes server reflexive and relay candidates to the
+// attempt on the socket of their related address.
+func (t *iceServerTracker) candidateGathered(candidate ice.Candidate, sdpMid string, sdpMLineIndex uint16) {
+	if t == nil || !t.gathering.Load() || candidate.RelatedAddress() == nil {
+		return
+	}
+
+	var relay bool
+	switch candidate.Type() {
+	case ice.CandidateTypeServerReflexive:
+	case ice.CandidateTypeRelay:
+		relay = true
+	default:
+		return
+	}
+
+	t.mu.Lock()
+	event := ICEServerGatheringEvent{Type: ICEServerGatheringEventTypeSucceeded}
+	for _, server := range t.servers {
+		if server.succeeded || server.relay != relay {
+			continue
+		}
+		for _, attempt := range server.attempts {
+			if attempt.localPort == candidate.RelatedAddress().Port {
+				server.succeeded = true
+				event.URL, event.RTT = server.url, attempt.rtt
+			}
+		}
+		if server.succeeded {
+			break
+		}
+	}
+	t.mu.Unlock()
+
+	if event.URL == "" {
+		return
+	}
+
+	c, err := newICECandidateFromICE(candidate, sdpMid, sdpMLineIndex)
+	if err != nil {
+		return
+	}
+	event.Candidate = &c
+	t.gatherer.onServerGatheringEvent(event)
+}
+
+// wrapNet returns the Net of the agent, which opens sockets that are watched
+// while gathering.
+func (t *iceServerTracker) wrapNet(n transport.Net) (transport.Net, error) {
+	if t == nil {
+		return n, nil
+	}
+	if n == nil {
+		var err error
+		if n, err = stdnet.NewNet(); err != nil {
+			return nil, err
+		}
+	}
+
+	return &iceServerNet{Net: n, tracker: t}, nil
+}
+
+type iceServerNet struct {
+	transport.Net
+	tracker *iceServerTracker
+}
+
+func (n *iceServerNet) ResolveUDPAddr(network, address string) (*net.UDPAddr, error) {
+	addr, err := n.Net.ResolveUDPAddr(network, address)
+	n.tracker.resolved(address, false, addr, err)
+
+	return addr, err
+}
+
+func (n *iceServerNet) ResolveTCPAddr(network, address string) (*net.TCPAddr, error) {
+	addr, err := n.Net.ResolveTCPAddr(network, address)
+	n.tracker.resolved(address, true, addr, err)
+
+	return addr, err
+}
+
+// ListenUDP opens the sockets of host and server reflexive candidates. The
+// multicast sockets of mDNS aren't wrapped, they have to be *net.UDPConn to join
+// the multicast groups.
+func (n *iceServerNet) ListenUDP(network string, locAddr *net.UDPAddr) (transport.UDPConn, error) {
+	conn, err := n.Net.ListenUDP(network, locAddr)
+	if err != nil || (locAddr != nil && locAddr.IP.IsMulticast()) || !n.tracker.watches(false, false) {
+		return conn, err
+	}
+
+	return &iceServerUDPConn{UDPConn: conn, iceServerConn: n.newConn(conn.LocalAddr(), nil, false, false)}, nil
+}
+
+// ListenPacket opens the sockets of TURN clients over UDP.
+func (n *iceServerNet) ListenPacket(network, address string) (net.PacketConn, error) {
+	conn, err := n.Net.ListenPacket(network, address)
+	if err != nil || !n.tracker.watches(true, false) {
+		return conn, err
+	}
+
+	return &iceServerPacketConn{PacketConn: conn, iceServerConn: n.newConn(conn.LocalAddr(), nil, true, false)}, nil
+}
+
+// DialUDP opens the sockets of TURN clients over DTLS.
+func (n *iceServerNet) DialUDP(network string, laddr, raddr *net.UDPAddr) (transport.UDPConn, error) {
+	conn, err := n.Net.DialUDP(network, laddr, raddr)
+	if err != nil || !n.tracker.watches(true, false) {
+		return conn, err
+	}
+
+	return &iceServerUDPConn{UDPConn: conn, iceServerConn: n.newConn(conn.LocalAddr(), raddr, true, false)}, nil
+}
+
+// DialTCP opens the connections of TURN clients over TCP and TLS.
+func (n *iceServerNet) DialTCP(network string, laddr, raddr *net.TCPAddr) (transport.TCPConn, error) {
+	conn, err := n.Net.DialTCP(network, laddr, raddr)
+	if err != nil || !n.tracker.watches(true, true) {
+		return conn, err
+	}
+
+	return &iceServerTCPConn{TCPConn: conn, iceServerConn: n.newConn(conn.LocalAddr(), raddr, true, true)}, nil
+}
+
+func (n *iceServerNet) newConn(local, remote net.Addr, relay, tcp bool) iceServerConn {
+	conn := iceServerConn{tracker: n.tracker, remote: remote, relay: relay, tcp: tcp}
+	switch addr := local.(type) {
+	case *net.UDPAddr:
+		conn.localPort = addr.Port
+	case *net.TCPAddr:
+		conn.localPort = addr.Port
+	}
+
+	return conn
+}
+
+// iceServerConn is the tracking state of a socket, remote is set for connected
+// sockets. attempt is protected by the lock of the tracker.
+type iceServerConn struct {
+	tracker   *iceServerTracker
+	remote    net.Addr
+	localPort int
+	relay     bool
+	tcp       bool
+	attempt   *iceServerAttempt
+}
+
+type iceServerUDPConn struct {
+	transport.UDPConn
+	iceServerConn
+}
+
+func (c *iceServerUDPConn) WriteTo(p []byte, addr net.Addr) (int, error) {
+	c.tracker.sent(&c.iceServerConn, addr)
+
+	return c.UDPConn.WriteTo(p, addr)
+}
+
+func (c *iceServerUDPConn) ReadFrom(p []byte) (int, net.Addr, error) {
+	n, addr, err := c.UDPConn.ReadFrom(p)
+	if err == nil {
+		c.tracker.received(&c.iceServerConn, addr)
+	}
+
+	return n, addr, err
+}
+
+func (c *iceServerUDPConn) Write(p []byte) (int, error) {
+	c.tracker.sent(&c.iceServerConn, c.remote)
+
+	return c.UDPConn.Write(p)
+}
+
+func (c *iceServerUDPConn) Read(p []byte) (int, error) {
+	n, err := c.UDPConn.Read(p)
+	if err == nil {
+		c.tracker.received(&c.iceServerConn, c.remote)
+	}
+
+	return n, err
+}
+
+type iceServerPacketConn struct {
+	net.PacketConn
+	iceServerConn
+}
+
+func (c *iceServerPacketConn) WriteTo(p []byte, addr net.Addr) (int, error) {
+	c.tracker.sent(&c.iceServerConn, addr)
+
+	return c.PacketConn.WriteTo(p, addr)
+}
+
+func (c *iceServerPacketConn) ReadFrom(p []byte) (int, net.Addr, error) {
+	n, addr, err := c.PacketConn.ReadFrom(p)
+	if err == nil {
+		c.tracker.received(&c.iceServerConn, addr)
+	}
+
+	return n, addr, err
+}
+
+type iceServerTCPConn struct {
+	transport.TCPConn
+	iceServerConn
+}
+
+func (c *iceServerTCPConn) Write(p []byte) (int, error) {
+	c.tracker.sent(&c.iceServerConn, c.remote)
+
+	return c.TCPConn.Write(p)
+}
+
+func (c *iceServerTCPConn) Read(p []byte) (int, error) {
+	n, err := c.TCPConn.Read(p)
+	if err == nil {
+		c.tracker.received(&c.iceServerConn, c.remote)
+	}
+
+	return n, err
+}
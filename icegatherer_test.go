@@ -1957,6 +1957,171 @@ func TestICEGatherer_STUNGatherTimeout(t *testing.T) {
 	assert.LessOrEqual(t, time.Since(start), timeout*10)
 }
 
+func TestICEGatherer_ServerGatheringEvents(t *testing.T) { //nolint:cyclop
+	lim := test.TimeOut(time.Second * 20)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	const (
+		serverIP       = "1.2.3.4"
+		rejectingIP    = "1.2.3.5"
+		deadIP         = "1.2.3.6"
+		serverPort     = 3478
+		gathererIP     = "10.0.0.1"
+		gathererWANIP  = "1.2.3.10"
+		externalSubnet = "1.2.3.0/24"
+	)
+
+	loggerFactory := logging.NewDefaultLoggerFactory()
+
+	wan, err := vnet.NewRouter(&vnet.RouterConfig{
+		CIDR:          externalSubnet,
+		LoggerFactory: loggerFactory,
+	})
+	require.NoError(t, err)
+
+	lan, err := vnet.NewRouter(&vnet.RouterConfig{
+		StaticIPs:     []string{fmt.Sprintf("%s/%s", gathererWANIP, gathererIP)},
+		CIDR:          "10.0.0.0/24",
+		NATType:       &vnet.NATType{Mode: vnet.NATModeNAT1To1},
+		LoggerFactory: loggerFactory,
+	})
+	require.NoError(t, err)
+
+	gathererNet, err := vnet.NewNet(&vnet.NetConfig{StaticIPs: []string{gathererIP}})
+	require.NoError(t, err)
+	require.NoError(t, lan.AddNet(gathererNet))
+	require.NoError(t, wan.AddRouter(lan))
+
+	authKey := turn.GenerateAuthKey("user", "pion.ly", "pass")
+	newTURNServer := func(ip string, accept bool) *turn.Server {
+		serverNet, netErr := vnet.NewNet(&vnet.NetConfig{StaticIPs: []string{ip}})
+		require.NoError(t, netErr)
+		require.NoError(t, wan.AddNet(serverNet))
+
+		listener, listenErr := serverNet.ListenPacket("udp4", net.JoinHostPort(ip, fmt.Sprintf("%d", serverPort)))
+		require.NoError(t, listenErr)
+
+		server, serverErr := turn.NewServer(turn.ServerConfig{
+			Realm: "pion.ly",
+			AuthHandler: func(string, string, net.Addr) ([]byte, bool) {
+				return authKey, accept
+			},
+			PacketConnConfigs: []turn.PacketConnConfig{
+				{
+					PacketConn: listener,
+					RelayAddressGenerator: &turn.RelayAddressGeneratorStatic{
+						RelayAddress: net.ParseIP(ip),
+						Address:      "0.0.0.0",
+						Net:          serverNet,
+					},
+				},
+			},
+			LoggerFactory: loggerFactory,
+		})
+		require.NoError(t, serverErr)
+
+		return server
+	}
+	server := newTURNServer(serverIP, true)
+	rejecting := newTURNServer(rejectingIP, false)
+
+	require.NoError(t, wan.Start())
+	defer func() {
+		assert.NoError(t, server.Close())
+		assert.NoError(t, rejecting.Close())
+		assert.NoError(t, wan.Stop())
+	}()
+
+	se := SettingEngine{}
+	se.SetICEMulticastDNSMode(ice.MulticastDNSModeDisabled)
+	se.SetNetworkTypes([]NetworkType{NetworkTypeUDP4})
+	se.SetSTUNGatherTimeout(300 * time.Millisecond)
+	se.SetNet(gathererNet)
+
+	stunURL := fmt.Sprintf("stun:%s:%d", serverIP, serverPort)
+	turnURL := fmt.Sprintf("turn:%s:%d?transport=udp", serverIP, serverPort)
+	rejectingURL := fmt.Sprintf("turn:%s:%d?transport=udp", rejectingIP, serverPort)
+	deadURL := fmt.Sprintf("stun:%s:%d", deadIP, serverPort)
+
+	gatherer, err := NewAPI(WithSettingEngine(se)).NewICEGatherer(ICEGatherOptions{
+		ICEServers: []ICEServer{
+			{URLs: []string{stunURL, deadURL}},
+			{URLs: []string{turnURL, rejectingURL}, Username: "user", Credential: "pass"},
+		},
+	})
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, gatherer.Close())
+	}()
+
+	var eventsMu sync.Mutex
+	events := map[string][]ICEServerGatheringEvent{}
+	gatherer.OnServerGatheringEvent(func(event ICEServerGatheringEvent) {
+		eventsMu.Lock()
+		defer eventsMu.Unlock()
+		events[event.URL] = append(events[event.URL], event)
+	})
+
+	gatheringDone := make(chan struct{})
+	gatherer.OnLocalCandidate(func(c *ICECandidate) {
+		if c == nil {
+			close(gatheringDone)
+		}
+	})
+	require.NoError(t, gatherer.Gather())
+	<-gatheringDone
+
+	// The tracking stops with the gathering, the sockets opened later aren't
+	// wrapped.
+	assert.False(t, gatherer.serverTracker.watches(false, false))
+	assert.False(t, gatherer.serverTracker.watches(true, false))
+	agentNet, err := gatherer.serverTracker.wrapNet(gathererNet)
+	require.NoError(t, err)
+	conn, err := agentNet.ListenUDP("udp4", &net.UDPAddr{IP: net.ParseIP(gathererIP)})
+	require.NoError(t, err)
+	_, isWatched := conn.(*iceServerUDPConn)
+	assert.False(t, isWatched)
+	assert.NoError(t, conn.Close())
+
+	eventsMu.Lock()
+	defer eventsMu.Unlock()
+
+	assertEvents := func(url string, result ICEServerGatheringEventType) ICEServerGatheringEvent {
+		if !assert.Len(t, events[url], 2, url) {
+			return ICEServerGatheringEvent{}
+		}
+		assert.Equal(t, ICEServerGatheringEventTypeStarted, events[url][0].Type, url)
+		assert.Equal(t, result, events[url][1].Type, url)
+
+		return events[url][1]
+	}
+
+	stunEvent := assertEvents(stunURL, ICEServerGatheringEventTypeSucceeded)
+	assert.Positive(t, stunEvent.RTT)
+	if assert.NotNil(t, stunEvent.Candidate) {
+		assert.Equal(t, ICECandidateTypeSrflx, stunEvent.Candidate.Typ)
+		assert.Equal(t, gathererWANIP, stunEvent.Candidate.Address)
+	}
+
+	turnEvent := assertEvents(turnURL, ICEServerGatheringEventTypeSucceeded)
+	assert.Positive(t, turnEvent.RTT)
+	if assert.NotNil(t, turnEvent.Candidate) {
+		assert.Equal(t, ICECandidateTypeRelay, turnEvent.Candidate.Typ)
+		assert.Equal(t, serverIP, turnEvent.Candidate.Address)
+	}
+
+	rejectingEvent := assertEvents(rejectingURL, ICEServerGatheringEventTypeFailed)
+	assert.ErrorIs(t, rejectingEvent.Err, ErrICEServerNoCandidate)
+	assert.Positive(t, rejectingEvent.RTT)
+
+	deadEvent := assertEvents(deadURL, ICEServerGatheringEventTypeFailed)
+	assert.ErrorIs(t, deadEvent.Err, ErrICEServerNoResponse)
+	assert.Zero(t, deadEvent.RTT)
+}
+
 func TestICEGatherer_RelayAcceptanceMinWait(t *testing.T) { //nolint:cyclop
 	lim := test.TimeOut(time.Second * 40)
 	defer lim.Stop()
@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/pion/ice/v4"
+)
+
+const (
+	// The defaults of the ICE agent.
+	defaultICEDisconnectedTimeout = 5 * time.Second
+	defaultICEFailedTimeout       = 25 * time.Second
+
+	iceTimeoutsCheckInterval = 200 * time.Millisecond
+
+	// libwebrtc advertises these network costs for 5G to 2G networks.
+	minCellularNetworkCost = 250
+	maxCellularNetworkCost = 980
+
+	iceNetworkCostExtension = "network-cost"
+)
+
+type iceTimeouts struct {
+	disconnected time.Duration
+	failed       time.Duration
+}
+
+// iceCandidatePairType is relay if a candidate of the pair is relayed, srflx if
+// one is reflexive and host otherwise.
+func iceCandidatePairType(local, remote ice.Candidate) ICECandidateType {
+	pairType := ICECandidateTypeHost
+	for _, candidate := range []ice.Candidate{local, remote} {
+		switch candidate.Type() {
+		case ice.CandidateTypeRelay:
+			return ICECandidateTypeRelay
+		case ice.CandidateTypeServerReflexive, ice.CandidateTypePeerReflexive:
+			pairType = ICECandidateTypeSrflx
+		default:
+		}
+	}
+
+	return pairType
+}
+
+func isCellularICECandidate(candidate ice.Candidate) bool {
+	extension, ok := candidate.GetExtension(iceNetworkCostExtension)
+	if !ok {
+		return false
+	}
+	cost, err := strconv.Atoi(extension.Value)
+
+	return err == nil && cost >= minCellularNetworkCost && cost <= maxCellularNetworkCost
+}
+
+// pairTimeouts returns the ICE timeouts for the selected pair, false if they
+// don't depend on the pair.
+func (e *SettingEngine) pairTimeouts(local, remote ice.Candidate) (iceTimeouts, bool) {
+	if e.timeout.ICEPairTimeouts == nil && e.timeout.ICECellularTimeouts == nil {
+		return iceTimeouts{}, false
+	}
+
+	if e.timeout.ICECellularTimeouts != nil && isCellularICECandidate(remote) {
+		return *e.timeout.ICECellularTimeouts, true
+	}
+	if timeouts, ok := e.timeout.ICEPairTimeouts[iceCandidatePairType(local, remote)]; ok {
+		return timeouts, true
+	}
+
+	return e.defaultICETimeouts(), true
+}
+
+// defaultICETimeouts returns the timeouts of SetICETimeouts, or the ones of the
+// agent.
+func (e *SettingEngine) defaultICETimeouts() iceTimeouts {
+	timeouts := iceTimeouts{disconnected: defaultICEDisconnectedTimeout, failed: defaultICEFailedTimeout}
+	if e.timeout.ICEDisconnectedTimeout != nil {
+		timeouts.disconnected = *e.timeout.ICEDisconnectedTimeout
+	}
+	if e.timeout.ICEFailedTimeout != nil {
+		timeouts.failed = *e.timeout.ICEFailedTimeout
+	}
+
+	return timeouts
+}
+
+func (e *SettingEngine) hasPairTimeouts() bool {
+	return e.timeout.ICEPairTimeouts != nil || e.timeout.ICECellularTimeouts != nil
+}
+
+// maxPairTimeouts returns the longest timeouts of all pairs. They are the
+// timeouts of the agent, which can't change them once it is created, and
+// monitorPairTimeouts detects the shorter ones.
+func (e *SettingEngine) maxPairTimeouts() iceTimeouts {
+	timeouts := e.defaultICETimeouts()
+	update := func(other iceTimeouts) {
+		timeouts.disconnected = max(timeouts.disconnected, other.disconnected)
+		timeouts.failed = max(timeouts.failed, other.failed)
+	}
+	for _, other := range e.timeout.ICEPairTimeouts {
+		update(other)
+	}
+	if e.timeout.ICECellularTimeouts != nil {
+		update(*e.timeout.ICECellularTimeouts)
+	}
+
+	return timeouts
+}
+
+// monitorPairTimeouts moves the ICETransport to Disconnected or Failed when the
+// selected pair received nothing within its timeouts, while the agent is
+// connected.
+func (t *ICETransport) monitorPairTimeouts(ctx context.Context, agent *ice.Agent, settingEngine *SettingEngine) {
+	ticker := time.NewTicker(iceTimeoutsCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		pair, err := agent.GetSelectedCandidatePair()
+		if err != nil || pair == nil {
+			continue
+		}
+		lastReceived, ok := remoteCandidateLastReceived(agent, pair.Remote)
+		if !ok {
+			continue
+		}
+
+		timeouts, _ := settingEngine.pairTimeouts(pair.Local, pair.Remote)
+		state := ICETransportStateConnected
+		switch elapsed := time.Since(lastReceived); {
+		case timeouts.failed != 0 && elapsed > timeouts.disconnected+timeouts.failed:
+			state = ICETransportStateFailed
+		case timeouts.disconnected != 0 && elapsed > timeouts.disconnected:
+			state = ICETransportStateDisconnected
+		}
+
+		t.stateMu.Lock()
+		changed := t.agentState == ICETransportStateConnected && t.State() != state
+		if changed {
+			t.pairTimeoutsState = state != ICETransportStateConnected
+			t.setState(state)
+		}
+		t.stateMu.Unlock()
+
+		if changed {
+			t.onConnectionStateChange(state)
+		}
+	}
+}
+
+// remoteCandidateLastReceived returns when the remote candidate last received
+// anything, the selected pair of the agent only has copies of its candidates.
+func remoteCandidateLastReceived(agent *ice.Agent, remote ice.Candidate) (time.Time, bool) {
+	candidates, err := agent.GetRemoteCandidates()
+	if err != nil {
+		return time.Time{}, false
+	}
+	for _, candidate := range candidates {
+		if candidate.Equal(remote) {
+			return candidate.LastReceived(), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// onAgentConnectionStateChange updates the state for the one of the agent. It
+// isn't reported again if monitorPairTimeouts did already.
+func (t *ICETransport) onAgentConnectionStateChange(state ICETransportState) {
+	t.stateMu.Lock()
+	t.agentState = state
+	reported := t.pairTimeoutsState && t.State() == state
+	t.pairTimeoutsState = false
+	t.setState(state)
+	t.stateMu.Unlock()
+
+	if !reported {
+		t.onConnectionStateChange(state)
+	}
+}
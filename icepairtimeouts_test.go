@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pion/ice/v4"
+	"github.com/pion/transport/v4/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettingEngine_pairTimeouts(t *testing.T) {
+	candidate := func(raw string) ice.Candidate {
+		c, err := ice.UnmarshalCandidate(raw)
+		require.NoError(t, err)
+
+		return c
+	}
+	host := candidate("1 1 udp 2130706431 10.0.0.1 5000 typ host")
+	cellular := candidate("1 1 udp 2130706431 10.0.0.2 5000 typ host network-cost 900")
+	srflx := candidate("1 1 udp 1694498815 1.2.3.4 5000 typ srflx raddr 10.0.0.1 rport 5000")
+	relay := candidate("1 1 udp 16777215 5.6.7.8 5000 typ relay raddr 1.2.3.4 rport 5000")
+
+	s := SettingEngine{}
+	_, ok := s.pairTimeouts(host, host)
+	assert.False(t, ok)
+
+	s.SetICETimeouts(time.Second, 2*time.Second, time.Second)
+	s.SetICETimeoutsForCandidateType(ICECandidateTypeRelay, 10*time.Second, 20*time.Second)
+	s.SetICETimeoutsForCandidateType(ICECandidateTypeSrflx, 3*time.Second, 4*time.Second)
+
+	for _, test := range []struct {
+		name          string
+		local, remote ice.Candidate
+		want          iceTimeouts
+	}{
+		{"host", host, host, iceTimeouts{disconnected: time.Second, failed: 2 * time.Second}},
+		{"srflx", srflx, host, iceTimeouts{disconnected: 3 * time.Second, failed: 4 * time.Second}},
+		{"relay", srflx, relay, iceTimeouts{disconnected: 10 * time.Second, failed: 20 * time.Second}},
+		{"cellular without timeouts", host, cellular, iceTimeouts{disconnected: time.Second, failed: 2 * time.Second}},
+	} {
+		timeouts, ok := s.pairTimeouts(test.local, test.remote)
+		assert.True(t, ok, test.name)
+		assert.Equal(t, test.want, timeouts, test.name)
+	}
+
+	s.SetICECellularTimeouts(30*time.Second, 40*time.Second)
+	timeouts, _ := s.pairTimeouts(relay, cellular)
+	assert.Equal(t, iceTimeouts{disconnected: 30 * time.Second, failed: 40 * time.Second}, timeouts)
+
+	// The ICE defaults apply for other pairs without SetICETimeouts.
+	s = SettingEngine{}
+	s.SetICECellularTimeouts(30*time.Second, 40*time.Second)
+	timeouts, _ = s.pairTimeouts(host, host)
+	assert.Equal(t, iceTimeouts{disconnected: defaultICEDisconnectedTimeout, failed: defaultICEFailedTimeout}, timeouts)
+}
+
+func TestICETransport_PairTimeouts(t *testing.T) {
+	lim := test.TimeOut(time.Second * 20)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	// The host timeouts detect the failure well before the default timeouts.
+	s := SettingEngine{}
+	s.SetICETimeouts(defaultICEDisconnectedTimeout, defaultICEFailedTimeout, 100*time.Millisecond)
+	s.SetICETimeoutsForCandidateType(ICECandidateTypeHost, time.Second, time.Second)
+	s.DisableCloseByDTLS(true)
+	pcOffer, err := NewAPI(WithSettingEngine(s)).NewPeerConnection(Configuration{})
+	require.NoError(t, err)
+	pcAnswer, err := NewPeerConnection(Configuration{})
+	require.NoError(t, err)
+
+	connected, connectedDone := context.WithCancel(context.Background())
+	failed, failedDone := context.WithCancel(context.Background())
+	pcOffer.OnConnectionStateChange(func(state PeerConnectionState) {
+		switch state { //nolint:exhaustive
+		case PeerConnectionStateConnected:
+			connectedDone()
+		case PeerConnectionStateFailed:
+			failedDone()
+		}
+	})
+	require.NoError(t, signalPair(pcOffer, pcAnswer))
+	<-connected.Done()
+
+	start := time.Now()
+	require.NoError(t, pcAnswer.Close())
+	<-failed.Done()
+	assert.Less(t, time.Since(start), defaultICEDisconnectedTimeout)
+
+	require.NoError(t, pcOffer.Close())
+}
@@ -28,9 +28,16 @@ type ICETransport struct {
 	onConnectionStateChangeHandler         atomic.Value // func(ICETransportState)
 	internalOnConnectionStateChangeHandler atomic.Value // func(ICETransportState)
 	onSelectedCandidatePairChangeHandler   atomic.Value // func(*ICECandidatePair)
+	internalOnSelectedCandidatePairChange  atomic.Value // func(*ICECandidatePair)
+	lastSelectedCandidatePair              atomic.Value // *ICECandidatePair
 
 	state atomic.Value // ICETransportState
 
+	// stateMu orders the state changes of the agent and monitorPairTimeouts.
+	stateMu           sync.Mutex
+	agentState        ICETransportState
+	pairTimeoutsState bool
+
 	gatherer *ICEGatherer
 	conn     *ice.Conn
 	mux      *mux.Mux
@@ -68,6 +75,41 @@ func (t *ICETransport) GetSelectedCandidatePair() (*ICECandidatePair, error) {
 	return NewICECandidatePair(&local, &remote), nil
 }
 
+// GetLocalCandidates returns the candidates gathered for the ICE transport so
+// far, see getLocalCandidates of the W3C RTCIceTransport. Candidates gathered
+// later are returned by the next call, or OnICECandidate reports them as they
+// are gathered.
+func (t *ICETransport) GetLocalCandidates() ([]ICECandidate, error) {
+	agent := t.gatherer.getAgent()
+	if agent == nil {
+		return []ICECandidate{}, nil
+	}
+
+	iceCandidates, err := agent.GetLocalCandidates()
+	if err != nil {
+		return nil, err
+	}
+
+	return newICECandidatesFromICE(iceCandidates, "", 0)
+}
+
+// GetRemoteCandidates returns the candidates received from the remote peer so
+// far, including the peer reflexive candidates discovered by connectivity
+// checks, see getRemoteCandidates of the W3C RTCIceTransport.
+func (t *ICETransport) GetRemoteCandidates() ([]ICECandidate, error) {
+	agent := t.gatherer.getAgent()
+	if agent == nil {
+		return []ICECandidate{}, nil
+	}
+
+	iceCandidates, err := agent.GetRemoteCandidates()
+	if err != nil {
+		return nil, err
+	}
+
+	return newICECandidatesFromICE(iceCandidates, "", 0)
+}
+
 // GetSelectedCandidatePairStats returns the selected candidate pair stats on which packets are sent
 // if there is no selected pair empty stats, false is returned to indicate stats not available.
 func (t *ICETransport) GetSelectedCandidatePairStats() (ICECandidatePairStats, bool) {
@@ -109,10 +151,7 @@ func (t *ICETransport) Start(gatherer *ICEGatherer, params ICEParameters, role *
 	}
 
 	if err := agent.OnConnectionStateChange(func(iceState ice.ConnectionState) {
-		state := newICETransportStateFromICE(iceState)
-
-		t.setState(state)
-		t.onConnectionStateChange(state)
+		t.onAgentConnectionStateChange(newICETransportStateFromICE(iceState))
 	}); err != nil {
 		return err
 	}
@@ -137,6 +176,10 @@ func (t *ICETransport) Start(gatherer *ICEGatherer, params ICEParameters, role *
 	ctx, ctxCancel := context.WithCancel(context.Background())
 	t.ctxCancel = ctxCancel
 
+	if settingEngine := t.gatherer.api.settingEngine; settingEngine.hasPairTimeouts() {
+		go t.monitorPairTimeouts(ctx, agent, settingEngine)
+	}
+
 	// Drop the lock here to allow ICE candidates to be
 	// added so that the agent can complete a connection
 	t.lock.Unlock()
@@ -191,10 +234,7 @@ func (t *ICETransport) restart() error {
 		return fmt.Errorf("%w: unable to restart ICETransport", errICEAgentNotExist)
 	}
 
-	if err := agent.Restart(
-		t.gatherer.api.settingEngine.candidates.UsernameFragment,
-		t.gatherer.api.settingEngine.candidates.Password,
-	); err != nil {
+	if err := agent.Restart(t.gatherer.api.settingEngine.iceCredentials()); err != nil {
 		return err
 	}
 
@@ -253,7 +293,19 @@ func (t *ICETransport) OnSelectedCandidatePairChange(f func(*ICECandidatePair))
 	t.onSelectedCandidatePairChangeHandler.Store(f)
 }
 
+// getLastSelectedCandidatePair returns the most recently selected candidate
+// pair, which is kept after the ICE transport failed or was restarted.
+func (t *ICETransport) getLastSelectedCandidatePair() *ICECandidatePair {
+	pair, _ := t.lastSelectedCandidatePair.Load().(*ICECandidatePair)
+
+	return pair
+}
+
 func (t *ICETransport) onSelectedCandidatePairChange(pair *ICECandidatePair) {
+	t.lastSelectedCandidatePair.Store(pair)
+	if handler, ok := t.internalOnSelectedCandidatePairChange.Load().(func(*ICECandidatePair)); ok {
+		handler(pair)
+	}
 	if handler, ok := t.onSelectedCandidatePairChangeHandler.Load().(func(*ICECandidatePair)); ok {
 		handler(pair)
 	}
@@ -297,7 +349,7 @@ func (t *ICETransport) SetRemoteCandidates(remoteCandidates []ICECandidate) erro
 	}
 
 	for _, c := range remoteCandidates {
-		i, err := c.ToICE()
+		i, err := t.remoteCandidateToICE(c)
 		if err != nil {
 			return err
 		}
@@ -325,7 +377,7 @@ func (t *ICETransport) AddRemoteCandidate(remoteCandidate *ICECandidate) error {
 	}
 
 	if remoteCandidate != nil {
-		if candidate, err = remoteCandidate.ToICE(); err != nil {
+		if candidate, err = t.remoteCandidateToICE(*remoteCandidate); err != nil {
 			return err
 		}
 	}
@@ -338,6 +390,18 @@ func (t *ICETransport) AddRemoteCandidate(remoteCandidate *ICECandidate) error {
 	return agent.AddRemoteCandidate(candidate)
 }
 
+// remoteCandidateToICE converts a remote candidate, applying the SettingEngine
+// priority hook if one is configured. The caller must hold the lock.
+func (t *ICETransport) remoteCandidateToICE(candidate ICECandidate) (ice.Candidate, error) {
+	if t.gatherer.api != nil {
+		if hook := t.gatherer.api.settingEngine.candidates.RemotePriorityHook; hook != nil {
+			candidate.Priority = hook(candidate)
+		}
+	}
+
+	return candidate.ToICE()
+}
+
 // State returns the current ice transport state.
 func (t *ICETransport) State() ICETransportState {
 	if v, ok := t.state.Load().(ICETransportState); ok {
@@ -418,12 +482,73 @@ func (t *ICETransport) Stats() TransportStats {
 		stats.BytesReceived = conn.BytesReceived()
 	}
 
+	if t.gatherer != nil {
+		if agent := t.gatherer.getAgent(); agent != nil {
+			collectTransportTrafficStats(agent, &stats)
+		}
+	}
+
 	return stats
 }
 
-func (t *ICETransport) collectStats(collector *statsReportCollector) {
+// collectTransportTrafficStats splits the traffic of every candidate pair by the
+// protocol it uses and attributes it to the pair.
+func collectTransportTrafficStats(agent *ice.Agent, stats *TransportStats) {
+	candidates := map[string]ice.Candidate{}
+	if local, err := agent.GetLocalCandidates(); err == nil {
+		for _, c := range local {
+			candidates[c.ID()] = c
+		}
+	}
+	if remote, err := agent.GetRemoteCandidates(); err == nil {
+		for _, c := range remote {
+			candidates[c.ID()] = c
+		}
+	}
+
+	for _, pairStats := range agent.GetCandidatePairsStats() {
+		traffic := TransportTrafficStats{
+			PacketsSent:     pairStats.PacketsSent,
+			PacketsReceived: pairStats.PacketsReceived,
+			BytesSent:       pairStats.BytesSent,
+			BytesReceived:   pairStats.BytesReceived,
+		}
+		if traffic == (TransportTrafficStats{}) {
+			continue
+		}
+
+		if stats.CandidatePairs == nil {
+			stats.CandidatePairs = map[string]TransportTrafficStats{}
+		}
+		stats.CandidatePairs[newICECandidatePairStatsID(pairStats.LocalCandidateID, pairStats.RemoteCandidateID)] = traffic
+		stats.PacketsSent += traffic.PacketsSent
+		stats.PacketsReceived += traffic.PacketsReceived
+
+		local, remote := candidates[pairStats.LocalCandidateID], candidates[pairStats.RemoteCandidateID]
+		switch {
+		case (local != nil && local.Type() == ice.CandidateTypeRelay) ||
+			(remote != nil && remote.Type() == ice.CandidateTypeRelay):
+			stats.Relay.add(traffic)
+		case local != nil && local.NetworkType().IsTCP() && remote != nil && remote.NetworkType().IsTCP():
+			stats.TCP.add(traffic)
+		default:
+			stats.UDP.add(traffic)
+		}
+	}
+
+	if pairStats, ok := agent.GetSelectedCandidatePairStats(); ok {
+		stats.SelectedCandidatePairID = newICECandidatePairStatsID(
+			pairStats.LocalCandidateID, pairStats.RemoteCandidateID,
+		)
+	}
+}
+
+func (t *ICETransport) collectStats(collector *statsReportCollector, dtlsTransport *DTLSTransport) {
 	collector.Collecting()
 	stats := t.Stats()
+	if dtlsTransport != nil {
+		dtlsTransport.populateTransportStats(&stats)
+	}
 	collector.Collect(stats.ID, stats)
 }
 
@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pion/ice/v4"
 	"github.com/pion/transport/v4/test"
 	"github.com/stretchr/testify/assert"
 )
@@ -125,6 +126,40 @@ func TestICETransport_GetSelectedCandidatePair(t *testing.T) {
 	closePairNow(t, offerer, answerer)
 }
 
+func TestICETransport_GetCandidates(t *testing.T) {
+	offerer, answerer, err := newPair()
+	assert.NoError(t, err)
+	assert.Same(t, offerer.SCTP().Transport().ICETransport(), offerer.ICETransport())
+
+	localCandidates, err := offerer.ICETransport().GetLocalCandidates()
+	assert.NoError(t, err)
+	assert.Empty(t, localCandidates)
+
+	peerConnectionConnected := untilConnectionState(PeerConnectionStateConnected, offerer, answerer)
+	assert.NoError(t, signalPair(offerer, answerer))
+	peerConnectionConnected.Wait()
+
+	localCandidates, err = offerer.ICETransport().GetLocalCandidates()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, localCandidates)
+
+	remoteCandidates, err := answerer.ICETransport().GetRemoteCandidates()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, remoteCandidates)
+
+	// The selected pair is made of the candidates the transport returns.
+	pair, err := offerer.ICETransport().GetSelectedCandidatePair()
+	assert.NoError(t, err)
+	assert.NotNil(t, pair)
+	found := false
+	for _, candidate := range localCandidates {
+		found = found || (candidate.Address == pair.Local.Address && candidate.Port == pair.Local.Port)
+	}
+	assert.True(t, found, "selected local candidate %s not returned", pair.Local)
+
+	closePairNow(t, offerer, answerer)
+}
+
 func TestICETransport_GetLocalAndRemoteParameters(t *testing.T) {
 	offerer, answerer, err := newPair()
 	assert.NoError(t, err)
@@ -156,3 +191,44 @@ func TestICETransport_GetLocalAndRemoteParameters(t *testing.T) {
 
 	closePairNow(t, offerer, answerer)
 }
+
+func TestICETransport_RemoteCandidatePriorityHook(t *testing.T) {
+	report := test.CheckRoutines(t)
+	defer report()
+
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	const hookPriority = 12345
+
+	var hookCalls atomic.Int32
+	settingEngine := SettingEngine{}
+	settingEngine.SetICERemoteCandidatePriorityHook(func(candidate ICECandidate) uint32 {
+		assert.NotZero(t, candidate.Priority)
+		hookCalls.Add(1)
+
+		return hookPriority
+	})
+
+	pcOffer, err := NewPeerConnection(Configuration{})
+	assert.NoError(t, err)
+
+	pcAnswer, err := NewAPI(WithSettingEngine(settingEngine)).NewPeerConnection(Configuration{})
+	assert.NoError(t, err)
+
+	connected := untilConnectionState(PeerConnectionStateConnected, pcOffer, pcAnswer)
+	assert.NoError(t, signalPair(pcOffer, pcAnswer))
+	connected.Wait()
+
+	assert.NotZero(t, hookCalls.Load())
+
+	remoteCandidates, err := pcAnswer.iceTransport.gatherer.getAgent().GetRemoteCandidates()
+	assert.NoError(t, err)
+	for _, candidate := range remoteCandidates {
+		if candidate.Type() != ice.CandidateTypePeerReflexive {
+			assert.Equal(t, uint32(hookPriority), candidate.Priority())
+		}
+	}
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
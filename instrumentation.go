@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"expvar"
+	"sync/atomic"
+	"time"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+)
+
+// Instrumentation counts the work the PeerConnections it is set on with
+// SettingEngine.SetInstrumentation do, per subsystem, for capacity planning
+// without a profiler. One Instrumentation can be shared by many
+// PeerConnections, the counters only grow.
+type Instrumentation struct {
+	rtpPacketsSent, rtpBytesSent         atomic.Uint64
+	rtpPacketsReceived, rtpBytesReceived atomic.Uint64
+	rtcpPacketsSent, rtcpPacketsReceived atomic.Uint64
+
+	dtlsHandshakes atomic.Uint64
+
+	dataChannelMessagesSent, dataChannelMessagesReceived atomic.Uint64
+
+	// The time spent in the interceptors is the time spent in the whole
+	// chain, minus the time spent in SRTP below it.
+	chainWriteNanos, transportWriteNanos atomic.Int64
+	chainReadNanos, transportReadNanos   atomic.Int64
+}
+
+// InstrumentationSnapshot holds the counters of an Instrumentation at one
+// point in time. Every RTP and RTCP packet is one SRTP operation, every DTLS
+// handshake one key exchange.
+type InstrumentationSnapshot struct {
+	RTPPacketsSent      uint64 `json:"rtpPacketsSent"`
+	RTPBytesSent        uint64 `json:"rtpBytesSent"`
+	RTPPacketsReceived  uint64 `json:"rtpPacketsReceived"`
+	RTPBytesReceived    uint64 `json:"rtpBytesReceived"`
+	RTCPPacketsSent     uint64 `json:"rtcpPacketsSent"`
+	RTCPPacketsReceived uint64 `json:"rtcpPacketsReceived"`
+
+	DTLSHandshakes uint64 `json:"dtlsHandshakes"`
+
+	DataChannelMessagesSent     uint64 `json:"dataChannelMessagesSent"`
+	DataChannelMessagesReceived uint64 `json:"dataChannelMessagesReceived"`
+
+	// InterceptorWriteTime and InterceptorReadTime are the time the
+	// interceptors spent on the RTP and RTCP packets sent and received.
+	// Interceptors that send packets from goroutines of their own, like
+	// pacers, aren't accounted for on the write side.
+	InterceptorWriteTime time.Duration `json:"interceptorWriteTime"`
+	InterceptorReadTime  time.Duration `json:"interceptorReadTime"`
+}
+
+// NewInstrumentation returns an Instrumentation with all counters zero.
+func NewInstrumentation() *Instrumentation {
+	return &Instrumentation{}
+}
+
+// Snapshot returns the current counters.
+func (i *Instrumentation) Snapshot() InstrumentationSnapshot {
+	return InstrumentationSnapshot{
+		RTPPacketsSent:              i.rtpPacketsSent.Load(),
+		RTPBytesSent:                i.rtpBytesSent.Load(),
+		RTPPacketsReceived:          i.rtpPacketsReceived.Load(),
+		RTPBytesReceived:            i.rtpBytesReceived.Load(),
+		RTCPPacketsSent:             i.rtcpPacketsSent.Load(),
+		RTCPPacketsReceived:         i.rtcpPacketsReceived.Load(),
+		DTLSHandshakes:              i.dtlsHandshakes.Load(),
+		DataChannelMessagesSent:     i.dataChannelMessagesSent.Load(),
+		DataChannelMessagesReceived: i.dataChannelMessagesReceived.Load(),
+		InterceptorWriteTime:        interceptorTime(i.chainWriteNanos.Load(), i.transportWriteNanos.Load()),
+		InterceptorReadTime:         interceptorTime(i.chainReadNanos.Load(), i.transportReadNanos.Load()),
+	}
+}
+
+// Expvar returns an expvar.Var of the Snapshot, to be published with
+// expvar.Publish.
+func (i *Instrumentation) Expvar() expvar.Var {
+	return expvar.Func(func() any {
+		return i.Snapshot()
+	})
+}
+
+func interceptorTime(chainNanos, transportNanos int64) time.Duration {
+	// The SRTP time of packets sent from goroutines of interceptors isn't part
+	// of the time of any chain.
+	return time.Duration(max(chainNanos-transportNanos, 0))
+}
+
+// interceptors returns the interceptors wrapping the chain of a PeerConnection.
+// The transport one is first in the chain, next to SRTP, and the application
+// one last.
+func (i *Instrumentation) interceptors() (transport, application interceptor.Interceptor) {
+	return &instrumentationInterceptor{instrumentation: i, transport: true},
+		&instrumentationInterceptor{instrumentation: i}
+}
+
+func (i *Instrumentation) dtlsHandshake() {
+	if i != nil {
+		i.dtlsHandshakes.Add(1)
+	}
+}
+
+func (i *Instrumentation) dataChannelMessageSent() {
+	if i != nil {
+		i.dataChannelMessagesSent.Add(1)
+	}
+}
+
+func (i *Instrumentation) dataChannelMessageReceived() {
+	if i != nil {
+		i.dataChannelMessagesReceived.Add(1)
+	}
+}
+
+// instrumentationInterceptor times the reads and writes of the interceptors
+// above it. The one next to SRTP also counts the packets.
+type instrumentationInterceptor struct {
+	interceptor.NoOp
+	instrumentation *Instrumentation
+	transport       bool
+}
+
+func (i *instrumentationInterceptor) readNanos() *atomic.Int64 {
+	if i.transport {
+		return &i.instrumentation.transportReadNanos
+	}
+
+	return &i.instrumentation.chainReadNanos
+}
+
+func (i *instrumentationInterceptor) writeNanos() *atomic.Int64 {
+	if i.transport {
+		return &i.instrumentation.transportWriteNanos
+	}
+
+	return &i.instrumentation.chainWriteNanos
+}
+
+func (i *instrumentationInterceptor) BindRTCPReader(reader interceptor.RTCPReader) interceptor.RTCPReader {
+	return interceptor.RTCPReaderFunc(func(b []byte, a interceptor.Attributes) (int, interceptor.Attributes, error) {
+		start := time.Now()
+		n, a, err := reader.Read(b, a)
+		i.readNanos().Add(int64(time.Since(start)))
+		if i.transport && err == nil {
+			i.instrumentation.rtcpPacketsReceived.Add(1)
+		}
+
+		return n, a, err
+	})
+}
+
+func (i *instrumentationInterceptor) BindRTCPWriter(writer interceptor.RTCPWriter) interceptor.RTCPWriter {
+	return interceptor.RTCPWriterFunc(func(pkts []rtcp.Packet, a interceptor.Attributes) (int, error) {
+		start := time.Now()
+		n, err := writer.Write(pkts, a)
+		i.writeNanos().Add(int64(time.Since(start)))
+		if i.transport && err == nil {
+			i.instrumentation.rtcpPacketsSent.Add(1)
+		}
+
+		return n, err
+	})
+}
+
+func (i *instrumentationInterceptor) BindLocalStream(
+	_ *interceptor.StreamInfo, writer interceptor.RTPWriter,
+) interceptor.RTPWriter {
+	return interceptor.RTPWriterFunc(func(header *rtp.Header, payload []byte, a interceptor.Attributes) (int, error) {
+		start := time.Now()
+		n, err := writer.Write(header, payload, a)
+		i.writeNanos().Add(int64(time.Since(start)))
+		if i.transport && err == nil {
+			i.instrumentation.rtpPacketsSent.Add(1)
+			i.instrumentation.rtpBytesSent.Add(uint64(n)) //nolint:gosec // G115
+		}
+
+		return n, err
+	})
+}
+
+func (i *instrumentationInterceptor) BindRemoteStream(
+	_ *interceptor.StreamInfo, reader interceptor.RTPReader,
+) interceptor.RTPReader {
+	return interceptor.RTPReaderFunc(func(b []byte, a interceptor.Attributes) (int, interceptor.Attributes, error) {
+		start := time.Now()
+		n, a, err := reader.Read(b, a)
+		i.readNanos().Add(int64(time.Since(start)))
+		if i.transport && err == nil {
+			i.instrumentation.rtpPacketsReceived.Add(1)
+			i.instrumentation.rtpBytesReceived.Add(uint64(n)) //nolint:gosec // G115
+		}
+
+		return n, a, err
+	})
+}
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/pion/webrtc/v4/pkg/media"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstrumentation(t *testing.T) {
+	lim := test.TimeOut(time.Second * 10)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	instrumentation := NewInstrumentation()
+	s := SettingEngine{}
+	s.SetInstrumentation(instrumentation)
+	pcOffer, pcAnswer, err := NewAPI(WithSettingEngine(s)).newPair(Configuration{})
+	assert.NoError(t, err)
+
+	track, err := NewTrackLocalStaticSample(RTPCodecCapability{MimeType: MimeTypeVP8}, "video", "pion")
+	assert.NoError(t, err)
+	_, err = pcOffer.AddTrack(track)
+	assert.NoError(t, err)
+
+	rtpReceived := make(chan struct{})
+	pcAnswer.OnTrack(func(track *TrackRemote, _ *RTPReceiver) {
+		_, _, readErr := track.ReadRTP()
+		assert.NoError(t, readErr)
+		close(rtpReceived)
+	})
+
+	messageReceived := make(chan struct{})
+	pcAnswer.OnDataChannel(func(d *DataChannel) {
+		if d.Label() != "instrumented" {
+			return
+		}
+		d.OnMessage(func(DataChannelMessage) {
+			close(messageReceived)
+		})
+	})
+	dataChannel, err := pcOffer.CreateDataChannel("instrumented", nil)
+	assert.NoError(t, err)
+	dataChannel.OnOpen(func() {
+		assert.NoError(t, dataChannel.SendText("hello"))
+	})
+
+	assert.NoError(t, signalPair(pcOffer, pcAnswer))
+	<-messageReceived
+
+	func() {
+		for {
+			select {
+			case <-rtpReceived:
+				return
+			case <-time.After(20 * time.Millisecond):
+				assert.NoError(t, track.WriteSample(media.Sample{Data: []byte{0x00}, Duration: time.Second}))
+			}
+		}
+	}()
+
+	snapshot := instrumentation.Snapshot()
+	assert.Equal(t, uint64(2), snapshot.DTLSHandshakes)
+	assert.Equal(t, uint64(1), snapshot.DataChannelMessagesSent)
+	assert.Equal(t, uint64(1), snapshot.DataChannelMessagesReceived)
+	assert.NotZero(t, snapshot.RTPPacketsSent)
+	assert.NotZero(t, snapshot.RTPBytesSent)
+	assert.NotZero(t, snapshot.RTPPacketsReceived)
+	assert.NotZero(t, snapshot.RTPBytesReceived)
+	assert.GreaterOrEqual(t, snapshot.RTPPacketsSent, snapshot.RTPPacketsReceived)
+	assert.NotZero(t, snapshot.InterceptorWriteTime)
+
+	var published InstrumentationSnapshot
+	assert.NoError(t, json.Unmarshal([]byte(instrumentation.Expvar().String()), &published))
+	assert.GreaterOrEqual(t, published.RTPPacketsSent, snapshot.RTPPacketsSent)
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
+
+func TestInstrumentation_InterceptorTime(t *testing.T) {
+	instrumentation := NewInstrumentation()
+	instrumentation.chainWriteNanos.Store(int64(3 * time.Millisecond))
+	instrumentation.transportWriteNanos.Store(int64(time.Millisecond))
+	instrumentation.chainReadNanos.Store(int64(time.Millisecond))
+	instrumentation.transportReadNanos.Store(int64(2 * time.Millisecond))
+
+	snapshot := instrumentation.Snapshot()
+	assert.Equal(t, 2*time.Millisecond, snapshot.InterceptorWriteTime)
+	assert.Zero(t, snapshot.InterceptorReadTime, "the time of packets sent by interceptors made it negative")
+}
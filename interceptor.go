@@ -228,6 +228,10 @@ func ConfigureTWCCSenderWithOptions(mediaEngine *MediaEngine, interceptorRegistr
 
 // ConfigureCongestionControlFeedback registers congestion control feedback as
 // defined in RFC 8888 (https://datatracker.ietf.org/doc/rfc8888/)
+//
+// The reports don't carry ECN marks, every packet is reported as Not-ECT. The
+// ICE transport reads packets without their IP header, so the ECN bits of RTP
+// packets aren't available, and they are sent with the ECN bits of the socket.
 func ConfigureCongestionControlFeedback(mediaEngine *MediaEngine, interceptorRegistry *interceptor.Registry) error {
 	return ConfigureCongestionControlFeedbackWithOptions(mediaEngine, interceptorRegistry)
 }
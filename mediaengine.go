@@ -8,6 +8,9 @@ package webrtc
 import (
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -40,6 +43,9 @@ type MediaEngine struct {
 	headerExtensions           []mediaEngineHeaderExtension
 	negotiatedHeaderExtensions map[int]mediaEngineHeaderExtension
 
+	rtcpFeedbackTypes       map[rtcpFeedbackTypeKey]RTCPFeedbackType
+	headerExtensionHandlers map[string]RTPHeaderExtensionHandler
+
 	mu sync.RWMutex
 }
 
@@ -387,6 +393,12 @@ func (m *MediaEngine) copy() *MediaEngine {
 	if len(m.headerExtensions) > 0 {
 		cloned.negotiatedHeaderExtensions = map[int]mediaEngineHeaderExtension{}
 	}
+	if len(m.rtcpFeedbackTypes) > 0 {
+		cloned.rtcpFeedbackTypes = maps.Clone(m.rtcpFeedbackTypes)
+	}
+	if len(m.headerExtensionHandlers) > 0 {
+		cloned.headerExtensionHandlers = maps.Clone(m.headerExtensionHandlers)
+	}
 
 	return cloned
 }
@@ -558,6 +570,52 @@ func (m *MediaEngine) updateHeaderExtensionFromMediaSection(media *sdp.MediaDesc
 	return nil
 }
 
+// updateRTCPFeedbackFromMediaSection adds the feedback of a media section to
+// the negotiated codecs. They are negotiated with the first media section of
+// their kind, the others may use more feedback, see RTPTransceiver.SetRTCPFeedback.
+func (m *MediaEngine) updateRTCPFeedbackFromMediaSection(media *sdp.MediaDescription, typ RTPCodecType) error {
+	negotiatedCodecs := m.negotiatedVideoCodecs
+	switch typ {
+	case RTPCodecTypeAudio:
+		negotiatedCodecs = m.negotiatedAudioCodecs
+	case RTPCodecTypeVideo:
+	default:
+		return nil
+	}
+
+	codecs, err := codecsFromMediaDescription(media)
+	if err != nil {
+		return err
+	}
+
+	for _, remoteCodec := range codecs {
+		idx := slices.IndexFunc(negotiatedCodecs, func(codec RTPCodecParameters) bool {
+			return codec.PayloadType == remoteCodec.PayloadType && strings.EqualFold(codec.MimeType, remoteCodec.MimeType)
+		})
+		if idx == -1 {
+			continue
+		}
+
+		localCodec, matchType, err := m.matchRemoteCodec(remoteCodec, typ, negotiatedCodecs, nil)
+		if err != nil {
+			return err
+		}
+		if matchType == codecMatchNone {
+			continue
+		}
+
+		feedback := slices.Clone(negotiatedCodecs[idx].RTCPFeedback)
+		for _, f := range rtcpFeedbackIntersection(localCodec.RTCPFeedback, remoteCodec.RTCPFeedback) {
+			if !slices.Contains(feedback, f) {
+				feedback = append(feedback, f)
+			}
+		}
+		negotiatedCodecs[idx].RTCPFeedback = feedback
+	}
+
+	return nil
+}
+
 // Look up a header extension and enable if it exists.
 func (m *MediaEngine) updateHeaderExtension(id int, extension string, typ RTPCodecType) error {
 	if m.negotiatedHeaderExtensions == nil {
@@ -630,6 +688,9 @@ func (m *MediaEngine) updateFromRemoteDescription(desc sdp.SessionDescription) e
 			if err := m.updateHeaderExtensionFromMediaSection(media); err != nil {
 				return err
 			}
+			if err := m.updateRTCPFeedbackFromMediaSection(media, typ); err != nil {
+				return err
+			}
 
 			if !m.negotiateMultiCodecs || (typ != RTPCodecTypeAudio && typ != RTPCodecTypeVideo) {
 				continue
@@ -787,6 +848,11 @@ func (m *MediaEngine) getRTPParametersByKind(typ RTPCodecType, directions []RTPT
 		}
 	}
 
+	// Sorted, so the order of the extmaps in descriptions is reproducible.
+	sort.Slice(headerExtensions, func(i, j int) bool {
+		return headerExtensions[i].ID < headerExtensions[j].ID
+	})
+
 	return RTPParameters{
 		HeaderExtensions: headerExtensions,
 		Codecs:           foundCodecs,
@@ -839,6 +905,25 @@ func payloaderForCodec(codec RTPCodecCapability) (rtp.Payloader, error) {
 	}
 }
 
+func depacketizerForCodec(codec RTPCodecCapability) (rtp.Depacketizer, error) {
+	switch strings.ToLower(codec.MimeType) {
+	case strings.ToLower(MimeTypeH264):
+		return &codecs.H264Packet{}, nil
+	case strings.ToLower(MimeTypeH265):
+		return &codecs.H265Depacketizer{}, nil
+	case strings.ToLower(MimeTypeOpus):
+		return &codecs.OpusPacket{}, nil
+	case strings.ToLower(MimeTypeVP8):
+		return &codecs.VP8Packet{}, nil
+	case strings.ToLower(MimeTypeVP9):
+		return &codecs.VP9Packet{}, nil
+	case strings.ToLower(MimeTypeAV1):
+		return &codecs.AV1Depacketizer{}, nil
+	default:
+		return nil, ErrNoDepacketizerForCodec
+	}
+}
+
 func (m *MediaEngine) isRTXEnabled(typ RTPCodecType, directions []RTPTransceiverDirection) bool {
 	for _, p := range m.getRTPParametersByKind(typ, directions).Codecs {
 		if strings.EqualFold(p.MimeType, MimeTypeRTX) {
@@ -0,0 +1,210 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"slices"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/rtp"
+)
+
+// RTPHeaderExtensionHandler adds and decodes a header extension registered with
+// MediaEngine.RegisterHeaderExtensionHandler.
+type RTPHeaderExtensionHandler struct {
+	// Marshal returns the payload of the extension for an outgoing packet. The
+	// extension is omitted if ok is false. It is only called for RTPSenders that
+	// negotiated the extension.
+	Marshal func(header *rtp.Header, payload []byte) (extension []byte, ok bool)
+
+	// Unmarshal decodes the payload of the extension of a received packet. The
+	// value is returned by ReceivedHeaderExtensionsFromAttributes for the packet,
+	// packets whose extension fails to decode are returned without it.
+	Unmarshal func(extension []byte) (any, error)
+}
+
+// ReceivedHeaderExtensions are the header extensions of a packet read from a
+// TrackRemote which have a RTPHeaderExtensionHandler or weren't negotiated.
+type ReceivedHeaderExtensions struct {
+	// Values maps the URI of header extensions to the value decoded by the
+	// Unmarshal function of their RTPHeaderExtensionHandler.
+	Values map[string]any
+
+	// Unknown maps the IDs of header extensions that weren't negotiated to their
+	// payload. They are only reported with SettingEngine.SetReportUnknownHeaderExtensions.
+	Unknown map[int][]byte
+}
+
+type receivedHeaderExtensionsKey struct{}
+
+// ReceivedHeaderExtensionsFromAttributes returns the ReceivedHeaderExtensions of
+// a packet from the attributes returned by TrackRemote.Read or ReadRTP.
+func ReceivedHeaderExtensionsFromAttributes(attributes interceptor.Attributes) (ReceivedHeaderExtensions, bool) {
+	extensions, ok := attributes.Get(receivedHeaderExtensionsKey{}).(ReceivedHeaderExtensions)
+
+	return extensions, ok
+}
+
+// RegisterHeaderExtensionHandler registers a header extension like
+// RegisterHeaderExtension, and a handler that adds it to sent packets and
+// decodes it from received packets. With allowedDirections the extension can
+// be limited to only be sent or only be received.
+func (m *MediaEngine) RegisterHeaderExtensionHandler(
+	extension RTPHeaderExtensionCapability,
+	handler RTPHeaderExtensionHandler,
+	typ RTPCodecType,
+	allowedDirections ...RTPTransceiverDirection,
+) error {
+	if err := m.RegisterHeaderExtension(extension, typ, allowedDirections...); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.headerExtensionHandlers == nil {
+		m.headerExtensionHandlers = map[string]RTPHeaderExtensionHandler{}
+	}
+	m.headerExtensionHandlers[extension.URI] = handler
+
+	return nil
+}
+
+// headerExtensionMarshalers returns the Marshal functions of the negotiated
+// header extensions by ID.
+func (m *MediaEngine) headerExtensionMarshalers(
+	negotiated []RTPHeaderExtensionParameter,
+) map[int]func(*rtp.Header, []byte) ([]byte, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var marshalers map[int]func(*rtp.Header, []byte) ([]byte, bool)
+	for _, extension := range negotiated {
+		if handler, ok := m.headerExtensionHandlers[extension.URI]; ok && handler.Marshal != nil {
+			if marshalers == nil {
+				marshalers = map[int]func(*rtp.Header, []byte) ([]byte, bool){}
+			}
+			marshalers[extension.ID] = handler.Marshal
+		}
+	}
+
+	return marshalers
+}
+
+// headerExtensionUnmarshaler returns the Unmarshal function for a header
+// extension URI, if it may be received.
+func (m *MediaEngine) headerExtensionUnmarshaler(uri string) func([]byte) (any, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	handler, ok := m.headerExtensionHandlers[uri]
+	if !ok {
+		return nil
+	}
+
+	for _, extension := range m.headerExtensions {
+		if extension.uri == uri && !slices.Contains(extension.allowedDirections, RTPTransceiverDirectionRecvonly) {
+			return nil
+		}
+	}
+
+	return handler.Unmarshal
+}
+
+func (m *MediaEngine) hasHeaderExtensionHandlers() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.headerExtensionHandlers) != 0
+}
+
+// setHeaderExtensions adds the extensions of the marshalers to a packet. The
+// header is copied if an extension is added, as it may be shared by the
+// PeerConnections a track is sent to.
+func setHeaderExtensions(
+	marshalers map[int]func(*rtp.Header, []byte) ([]byte, bool), header *rtp.Header, payload []byte,
+) (*rtp.Header, error) {
+	copied := false
+	for id, marshal := range marshalers {
+		extension, ok := marshal(header, payload)
+		if !ok {
+			continue
+		}
+
+		if !copied {
+			clone := header.Clone()
+			header = &clone
+			copied = true
+		}
+
+		if err := header.SetExtension(uint8(id), extension); err != nil { //nolint:gosec // G115
+			return nil, err
+		}
+	}
+
+	return header, nil
+}
+
+// receivedHeaderExtensions decodes the header extensions of a packet read from
+// the track. It returns false if the packet has no extension to report.
+func (t *TrackRemote) receivedHeaderExtensions(b []byte) (ReceivedHeaderExtensions, bool) {
+	if len(b) == 0 || b[0]&rtpHeaderExtensionBit == 0 || t.receiver == nil || t.receiver.api == nil {
+		return ReceivedHeaderExtensions{}, false
+	}
+
+	api := t.receiver.api
+	if !api.settingEngine.reportUnknownHeaderExtensions && !api.mediaEngine.hasHeaderExtensionHandlers() {
+		return ReceivedHeaderExtensions{}, false
+	}
+
+	header := &rtp.Header{}
+	if _, err := header.Unmarshal(b); err != nil {
+		return ReceivedHeaderExtensions{}, false
+	}
+
+	t.mu.RLock()
+	negotiated := t.params.HeaderExtensions
+	t.mu.RUnlock()
+
+	var received ReceivedHeaderExtensions
+	for _, id := range header.GetExtensionIDs() {
+		payload := header.GetExtension(id)
+
+		uri := ""
+		for _, extension := range negotiated {
+			if extension.ID == int(id) {
+				uri = extension.URI
+
+				break
+			}
+		}
+
+		switch {
+		case uri == "" && api.settingEngine.reportUnknownHeaderExtensions:
+			if received.Unknown == nil {
+				received.Unknown = map[int][]byte{}
+			}
+			received.Unknown[int(id)] = append([]byte{}, payload...)
+		case uri != "":
+			unmarshal := t.receiver.api.mediaEngine.headerExtensionUnmarshaler(uri)
+			if unmarshal == nil {
+				continue
+			}
+
+			value, err := unmarshal(payload)
+			if err != nil {
+				continue
+			}
+
+			if received.Values == nil {
+				received.Values = map[string]any{}
+			}
+			received.Values[uri] = value
+		}
+	}
+
+	return received, received.Values != nil || received.Unknown != nil
+}
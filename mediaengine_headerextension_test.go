@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/pion/transport/v4/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testHeaderExtensionURI = "urn:example:test-extension"
+
+var errTestHeaderExtensionEmpty = errors.New("empty test extension")
+
+func newTestHeaderExtensionAPI(t *testing.T, reportUnknown bool, directions ...RTPTransceiverDirection) *API {
+	t.Helper()
+
+	mediaEngine := &MediaEngine{}
+	require.NoError(t, mediaEngine.RegisterDefaultCodecs())
+	require.NoError(t, mediaEngine.RegisterHeaderExtensionHandler(
+		RTPHeaderExtensionCapability{URI: testHeaderExtensionURI},
+		RTPHeaderExtensionHandler{
+			Marshal: func(header *rtp.Header, _ []byte) ([]byte, bool) {
+				// Only every other packet carries the extension.
+				return []byte{byte(header.SequenceNumber)}, header.SequenceNumber%2 == 0
+			},
+			Unmarshal: func(extension []byte) (any, error) {
+				if len(extension) == 0 {
+					return nil, errTestHeaderExtensionEmpty
+				}
+
+				return int(extension[0]), nil
+			},
+		},
+		RTPCodecTypeVideo,
+		directions...,
+	))
+
+	settingEngine := SettingEngine{}
+	settingEngine.SetReportUnknownHeaderExtensions(reportUnknown)
+
+	return NewAPI(WithMediaEngine(mediaEngine), WithSettingEngine(settingEngine))
+}
+
+func TestMediaEngine_RegisterHeaderExtensionHandler(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	pcOffer, err := newTestHeaderExtensionAPI(t, false).NewPeerConnection(Configuration{})
+	require.NoError(t, err)
+	pcAnswer, err := newTestHeaderExtensionAPI(t, true).NewPeerConnection(Configuration{})
+	require.NoError(t, err)
+
+	track, err := NewTrackLocalStaticRTP(RTPCodecCapability{MimeType: MimeTypeVP8}, "video", "pion")
+	require.NoError(t, err)
+	_, err = pcOffer.AddTrack(track)
+	require.NoError(t, err)
+
+	received := make(chan ReceivedHeaderExtensions, 2)
+	pcAnswer.OnTrack(func(track *TrackRemote, _ *RTPReceiver) {
+		for {
+			packet, attributes, readErr := track.ReadRTP()
+			if readErr != nil {
+				return
+			}
+
+			extensions, ok := ReceivedHeaderExtensionsFromAttributes(attributes)
+			if !ok || packet.SequenceNumber > 1 {
+				continue
+			}
+
+			select {
+			case received <- extensions:
+			default:
+			}
+		}
+	})
+
+	require.NoError(t, signalPair(pcOffer, pcAnswer))
+
+	func() {
+		for seq := 0; ; seq++ {
+			time.Sleep(20 * time.Millisecond)
+
+			header := rtp.Header{Version: 2, SequenceNumber: uint16(seq % 2)} //nolint:gosec // G115
+			// An extension that wasn't negotiated.
+			require.NoError(t, header.SetExtension(14, []byte{0x01, 0x02}))
+			require.NoError(t, track.WriteRTP(&rtp.Packet{Header: header, Payload: []byte{0x00}}))
+
+			if len(received) == cap(received) {
+				return
+			}
+		}
+	}()
+
+	first, second := <-received, <-received
+	if _, ok := first.Values[testHeaderExtensionURI]; !ok {
+		first, second = second, first
+	}
+
+	assert.Equal(t, ReceivedHeaderExtensions{
+		Values:  map[string]any{testHeaderExtensionURI: 0},
+		Unknown: map[int][]byte{14: {0x01, 0x02}},
+	}, first)
+	assert.Equal(t, ReceivedHeaderExtensions{
+		Unknown: map[int][]byte{14: {0x01, 0x02}},
+	}, second)
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
+
+func TestTrackRemote_ReadPeekedHeaderExtensions(t *testing.T) {
+	mediaEngine := &MediaEngine{}
+	require.NoError(t, mediaEngine.RegisterCodec(RTPCodecParameters{
+		RTPCodecCapability: RTPCodecCapability{MimeType: MimeTypeVP8, ClockRate: 90000},
+		PayloadType:        96,
+	}, RTPCodecTypeVideo))
+
+	settingEngine := &SettingEngine{}
+	settingEngine.SetReportUnknownHeaderExtensions(true)
+	receiver := &RTPReceiver{api: &API{mediaEngine: mediaEngine, settingEngine: settingEngine}, kind: RTPCodecTypeVideo}
+	track := newTrackRemote(RTPCodecTypeVideo, 0, 0, "", receiver)
+
+	header := rtp.Header{Version: 2, PayloadType: 96}
+	require.NoError(t, header.SetExtension(14, []byte{0x01, 0x02}))
+	payload, err := (&rtp.Packet{Header: header, Payload: []byte{0x00}}).Marshal()
+	require.NoError(t, err)
+
+	// The first packets are peeked while the track is probed.
+	track.mu.Lock()
+	track.peekedPackets = []*peekedPacket{{payload: payload}}
+	track.mu.Unlock()
+
+	_, attributes, err := track.ReadRTP()
+	require.NoError(t, err)
+
+	extensions, ok := ReceivedHeaderExtensionsFromAttributes(attributes)
+	require.True(t, ok)
+	assert.Equal(t, ReceivedHeaderExtensions{Unknown: map[int][]byte{14: {0x01, 0x02}}}, extensions)
+}
+
+func TestMediaEngine_headerExtensionUnmarshaler(t *testing.T) {
+	handler := RTPHeaderExtensionHandler{Unmarshal: func([]byte) (any, error) { return nil, nil }}
+
+	mediaEngine := &MediaEngine{}
+	assert.False(t, mediaEngine.hasHeaderExtensionHandlers())
+	require.NoError(t, mediaEngine.RegisterHeaderExtensionHandler(
+		RTPHeaderExtensionCapability{URI: testHeaderExtensionURI}, handler, RTPCodecTypeVideo,
+		RTPTransceiverDirectionSendonly,
+	))
+	assert.True(t, mediaEngine.hasHeaderExtensionHandlers())
+	assert.Nil(t, mediaEngine.headerExtensionUnmarshaler(testHeaderExtensionURI), "sendonly extensions aren't decoded")
+	assert.Nil(t, mediaEngine.headerExtensionUnmarshaler("urn:example:unknown"))
+
+	require.NoError(t, mediaEngine.RegisterHeaderExtensionHandler(
+		RTPHeaderExtensionCapability{URI: testHeaderExtensionURI}, handler, RTPCodecTypeVideo,
+	))
+	assert.NotNil(t, mediaEngine.copy().headerExtensionUnmarshaler(testHeaderExtensionURI))
+
+	assert.ErrorIs(t, mediaEngine.RegisterHeaderExtensionHandler(
+		RTPHeaderExtensionCapability{URI: testHeaderExtensionURI}, handler, RTPCodecTypeVideo,
+		RTPTransceiverDirectionSendrecv,
+	), ErrRegisterHeaderExtensionInvalidDirection)
+}
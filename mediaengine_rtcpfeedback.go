@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"github.com/pion/rtcp"
+)
+
+// rtcpFormatMask is the largest value of the FMT field of the RTCP header.
+const rtcpFormatMask = 0x1F
+
+// RTCPFeedbackType describes a RTCP feedback message that isn't implemented by
+// pion/rtcp, like proprietary layer requests.
+type RTCPFeedbackType struct {
+	// Feedback is announced with a=rtcp-fb for the codecs of the media kind it is
+	// registered for. It may be empty for messages that aren't negotiated.
+	Feedback RTCPFeedback
+
+	// PacketType and Format are the PT and FMT fields of the RTCP header that
+	// identify the message, usually rtcp.TypeTransportSpecificFeedback or
+	// rtcp.TypePayloadSpecificFeedback with an unassigned FMT.
+	PacketType rtcp.PacketType
+	Format     uint8
+
+	// Unmarshal decodes a single RTCP packet of this type, including its header.
+	// The returned packet is returned by ReadRTCP of RTPSender and RTPReceiver, its
+	// Marshal method is used to send it with WriteRTCP.
+	//
+	// SRTP routes received RTCP by the destination SSRCs pion/rtcp decodes, which
+	// are unknown for custom messages. They have to be sent in a compound packet
+	// with a builtin packet for the same media SSRC, like a ReceiverReport.
+	Unmarshal func(rawPacket []byte) (rtcp.Packet, error)
+}
+
+type rtcpFeedbackTypeKey struct {
+	packetType rtcp.PacketType
+	format     uint8
+}
+
+// RegisterRTCPFeedbackType adds a custom RTCP feedback message to the MediaEngine.
+// Its Feedback is added to the codecs of typ that are already registered, like
+// RegisterFeedback does, and received packets of its PacketType and Format are
+// decoded with its Unmarshal function. A message registered for a PacketType and
+// Format implemented by pion/rtcp replaces the builtin decoding.
+func (m *MediaEngine) RegisterRTCPFeedbackType(feedbackType RTCPFeedbackType, typ RTPCodecType) error {
+	switch {
+	case feedbackType.Unmarshal == nil:
+		return errRTCPFeedbackTypeNoUnmarshal
+	case feedbackType.Format > rtcpFormatMask:
+		return errRTCPFeedbackTypeInvalidFormat
+	case typ != RTPCodecTypeAudio && typ != RTPCodecTypeVideo:
+		return ErrUnknownType
+	}
+
+	key := rtcpFeedbackTypeKey{packetType: feedbackType.PacketType, format: feedbackType.Format}
+
+	m.mu.Lock()
+	if _, ok := m.rtcpFeedbackTypes[key]; ok {
+		m.mu.Unlock()
+
+		return ErrRTCPFeedbackTypeAlreadyRegistered
+	}
+	if m.rtcpFeedbackTypes == nil {
+		m.rtcpFeedbackTypes = map[rtcpFeedbackTypeKey]RTCPFeedbackType{}
+	}
+	m.rtcpFeedbackTypes[key] = feedbackType
+	m.mu.Unlock()
+
+	if feedbackType.Feedback.Type != "" {
+		m.RegisterFeedback(feedbackType.Feedback, typ)
+	}
+
+	return nil
+}
+
+func (m *MediaEngine) getRTCPFeedbackType(header rtcp.Header) (RTCPFeedbackType, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	feedbackType, ok := m.rtcpFeedbackTypes[rtcpFeedbackTypeKey{packetType: header.Type, format: header.Count}]
+
+	return feedbackType, ok
+}
+
+func (m *MediaEngine) hasRTCPFeedbackTypes() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.rtcpFeedbackTypes) != 0
+}
+
+// unmarshalRTCP is rtcp.Unmarshal with the RTCPFeedbackTypes of the MediaEngine.
+func (m *MediaEngine) unmarshalRTCP(rawData []byte) ([]rtcp.Packet, error) {
+	if !m.hasRTCPFeedbackTypes() {
+		return rtcp.Unmarshal(rawData)
+	}
+
+	var packets []rtcp.Packet
+	for len(rawData) != 0 {
+		var header rtcp.Header
+		size := 0
+		if err := header.Unmarshal(rawData); err == nil {
+			size = int(header.Length+1) * 4
+		}
+		if size == 0 || size > len(rawData) {
+			// Let pion/rtcp report the malformed packet.
+			return rtcp.Unmarshal(rawData)
+		}
+
+		if feedbackType, ok := m.getRTCPFeedbackType(header); ok {
+			packet, err := feedbackType.Unmarshal(rawData[:size])
+			if err != nil {
+				return nil, err
+			}
+			packets = append(packets, packet)
+		} else {
+			builtin, err := rtcp.Unmarshal(rawData[:size])
+			if err != nil {
+				return nil, err
+			}
+			packets = append(packets, builtin...)
+		}
+
+		rawData = rawData[size:]
+	}
+
+	return packets, nil
+}
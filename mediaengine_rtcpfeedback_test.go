@@ -0,0 +1,202 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pion/rtcp"
+	"github.com/pion/transport/v4/test"
+	"github.com/pion/webrtc/v4/pkg/media"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testLayerRequestFormat = 20
+
+var errTestLayerRequestSize = errors.New("invalid layer request size")
+
+// testLayerRequest is a proprietary payload-specific feedback message.
+type testLayerRequest struct {
+	SenderSSRC, MediaSSRC uint32
+	Layer                 uint8
+}
+
+func (p *testLayerRequest) Marshal() ([]byte, error) {
+	header, err := rtcp.Header{
+		Count:  testLayerRequestFormat,
+		Type:   rtcp.TypePayloadSpecificFeedback,
+		Length: uint16(p.MarshalSize()/4 - 1), //nolint:gosec // G115
+	}.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, p.MarshalSize())
+	copy(b, header)
+	binary.BigEndian.PutUint32(b[4:], p.SenderSSRC)
+	binary.BigEndian.PutUint32(b[8:], p.MediaSSRC)
+	b[12] = p.Layer
+
+	return b, nil
+}
+
+func (p *testLayerRequest) Unmarshal(rawPacket []byte) error {
+	if len(rawPacket) != p.MarshalSize() {
+		return errTestLayerRequestSize
+	}
+
+	p.SenderSSRC = binary.BigEndian.Uint32(rawPacket[4:])
+	p.MediaSSRC = binary.BigEndian.Uint32(rawPacket[8:])
+	p.Layer = rawPacket[12]
+
+	return nil
+}
+
+func (p *testLayerRequest) DestinationSSRC() []uint32 {
+	return []uint32{p.MediaSSRC}
+}
+
+func (p *testLayerRequest) MarshalSize() int {
+	return 16
+}
+
+var testLayerRequestType = RTCPFeedbackType{ //nolint:gochecknoglobals
+	Feedback:   RTCPFeedback{Type: "x-layer"},
+	PacketType: rtcp.TypePayloadSpecificFeedback,
+	Format:     testLayerRequestFormat,
+	Unmarshal: func(rawPacket []byte) (rtcp.Packet, error) {
+		packet := &testLayerRequest{}
+
+		return packet, packet.Unmarshal(rawPacket)
+	},
+}
+
+func TestMediaEngine_RegisterRTCPFeedbackType(t *testing.T) {
+	mediaEngine := &MediaEngine{}
+	require.NoError(t, mediaEngine.RegisterDefaultCodecs())
+	require.NoError(t, mediaEngine.RegisterRTCPFeedbackType(testLayerRequestType, RTPCodecTypeVideo))
+
+	assert.ErrorIs(t,
+		mediaEngine.RegisterRTCPFeedbackType(testLayerRequestType, RTPCodecTypeVideo),
+		ErrRTCPFeedbackTypeAlreadyRegistered,
+	)
+	assert.ErrorIs(t,
+		mediaEngine.RegisterRTCPFeedbackType(RTCPFeedbackType{Format: 1}, RTPCodecTypeVideo),
+		errRTCPFeedbackTypeNoUnmarshal,
+	)
+	assert.ErrorIs(t,
+		mediaEngine.RegisterRTCPFeedbackType(RTCPFeedbackType{
+			Format: 32, Unmarshal: testLayerRequestType.Unmarshal,
+		}, RTPCodecTypeVideo),
+		errRTCPFeedbackTypeInvalidFormat,
+	)
+
+	for _, codec := range mediaEngine.videoCodecs {
+		if codec.MimeType != MimeTypeRTX {
+			assert.Contains(t, codec.RTCPFeedback, testLayerRequestType.Feedback)
+		}
+	}
+	for _, codec := range mediaEngine.audioCodecs {
+		assert.NotContains(t, codec.RTCPFeedback, testLayerRequestType.Feedback)
+	}
+
+	// Custom and builtin packets of a compound packet are decoded.
+	layerRequest, err := (&testLayerRequest{SenderSSRC: 1, MediaSSRC: 2, Layer: 3}).Marshal()
+	require.NoError(t, err)
+	pli, err := (&rtcp.PictureLossIndication{SenderSSRC: 1, MediaSSRC: 2}).Marshal()
+	require.NoError(t, err)
+
+	packets, err := mediaEngine.copy().unmarshalRTCP(append(pli, layerRequest...))
+	require.NoError(t, err)
+	assert.Equal(t, []rtcp.Packet{
+		&rtcp.PictureLossIndication{SenderSSRC: 1, MediaSSRC: 2},
+		&testLayerRequest{SenderSSRC: 1, MediaSSRC: 2, Layer: 3},
+	}, packets)
+
+	_, err = mediaEngine.unmarshalRTCP(layerRequest[:8])
+	assert.Error(t, err)
+	_, err = mediaEngine.unmarshalRTCP(append(layerRequest, 0x01))
+	assert.Error(t, err)
+}
+
+func TestRTPSender_ReadRTCP_CustomFeedback(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	mediaEngine := &MediaEngine{}
+	require.NoError(t, mediaEngine.RegisterDefaultCodecs())
+	require.NoError(t, mediaEngine.RegisterRTCPFeedbackType(testLayerRequestType, RTPCodecTypeVideo))
+
+	pcOffer, pcAnswer, err := NewAPI(WithMediaEngine(mediaEngine)).newPair(Configuration{})
+	require.NoError(t, err)
+
+	track, err := NewTrackLocalStaticSample(RTPCodecCapability{MimeType: MimeTypeVP8}, "video", "pion")
+	require.NoError(t, err)
+
+	sender, err := pcOffer.AddTrack(track)
+	require.NoError(t, err)
+
+	offer, err := pcOffer.CreateOffer(nil)
+	require.NoError(t, err)
+	assert.Contains(t, offer.SDP, "x-layer")
+
+	onTrack := make(chan struct{})
+	pcAnswer.OnTrack(func(*TrackRemote, *RTPReceiver) {
+		close(onTrack)
+	})
+
+	require.NoError(t, signalPair(pcOffer, pcAnswer))
+
+	received := make(chan *testLayerRequest, 1)
+	go func() {
+		for {
+			packets, _, readErr := sender.ReadRTCP()
+			if readErr != nil {
+				return
+			}
+
+			for _, packet := range packets {
+				if layerRequest, ok := packet.(*testLayerRequest); ok {
+					select {
+					case received <- layerRequest:
+					default:
+					}
+				}
+			}
+		}
+	}()
+
+	ssrc := uint32(sender.GetParameters().Encodings[0].SSRC)
+	func() {
+		for {
+			select {
+			case <-onTrack:
+				onTrack = nil
+			case layerRequest := <-received:
+				assert.Equal(t, &testLayerRequest{SenderSSRC: 5, MediaSSRC: ssrc, Layer: 2}, layerRequest)
+
+				return
+			case <-time.After(20 * time.Millisecond):
+				assert.NoError(t, track.WriteSample(media.Sample{Data: []byte{0x00}, Duration: time.Second}))
+				if onTrack == nil {
+					assert.NoError(t, pcAnswer.WriteRTCP([]rtcp.Packet{
+						&rtcp.ReceiverReport{SSRC: 5, Reports: []rtcp.ReceptionReport{{SSRC: ssrc}}},
+						&testLayerRequest{SenderSSRC: 5, MediaSSRC: ssrc, Layer: 2},
+					}))
+				}
+			}
+		}
+	}()
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
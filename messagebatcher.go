@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package webrtc
+
+import (
+	"encoding/binary"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBatchInterval is the tick of a MessageBatcher created without
+	// interval, a frame at 60Hz.
+	DefaultBatchInterval = 16 * time.Millisecond
+
+	// DefaultMaxBatchSize is the batch size of a MessageBatcher created without
+	// size, which fits a single SCTP packet on typical paths.
+	DefaultMaxBatchSize = 1200
+
+	batchLengthSize = 2
+)
+
+// MessageSender sends messages, like a DataChannel or a DatagramChannel.
+type MessageSender interface {
+	Send(data []byte) error
+}
+
+// MessageBatcher coalesces the small messages sent within a tick into a single
+// message, which saves the per-message overhead of SCTP for frequent updates
+// like game state. Every message is prefixed with its length as a 16-bit
+// unsigned integer in network byte order, the remote peer splits the batches
+// with UnpackMessageBatch.
+type MessageBatcher struct {
+	sender       MessageSender
+	maxBatchSize int
+
+	mu      sync.Mutex
+	pending []byte
+	err     error
+
+	closeOnce sync.Once
+	closed    chan struct{}
+	done      chan struct{}
+}
+
+// NewMessageBatcher creates a MessageBatcher that sends a batch every interval
+// and whenever the next message would exceed maxBatchSize. Zero selects
+// DefaultBatchInterval and DefaultMaxBatchSize.
+func NewMessageBatcher(sender MessageSender, interval time.Duration, maxBatchSize int) *MessageBatcher {
+	if interval <= 0 {
+		interval = DefaultBatchInterval
+	}
+	if maxBatchSize <= 0 {
+		maxBatchSize = DefaultMaxBatchSize
+	}
+
+	batcher := &MessageBatcher{
+		sender:       sender,
+		maxBatchSize: maxBatchSize,
+		closed:       make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+	go batcher.loop(interval)
+
+	return batcher
+}
+
+func (b *MessageBatcher) loop(interval time.Duration) {
+	defer close(b.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			b.mu.Lock()
+			if err := b.flush(); err != nil && b.err == nil {
+				b.err = err
+			}
+			b.mu.Unlock()
+		case <-b.closed:
+			return
+		}
+	}
+}
+
+// Send queues a message for the next batch. Messages that don't fit a batch
+// with other messages are sent in a batch of their own. Errors of sending a
+// batch on a tick are returned by the next call.
+func (b *MessageBatcher) Send(message []byte) error {
+	if len(message) > math.MaxUint16 {
+		return ErrBatchedMessageTooLarge
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.err; err != nil {
+		b.err = nil
+
+		return err
+	}
+
+	if len(b.pending)+batchLengthSize+len(message) > b.maxBatchSize {
+		if err := b.flush(); err != nil {
+			return err
+		}
+	}
+
+	b.pending = binary.BigEndian.AppendUint16(b.pending, uint16(len(message)))
+	b.pending = append(b.pending, message...)
+
+	return nil
+}
+
+// Flush sends the pending messages immediately.
+func (b *MessageBatcher) Flush() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.flush()
+}
+
+func (b *MessageBatcher) flush() error {
+	if len(b.pending) == 0 {
+		return nil
+	}
+
+	batch := b.pending
+	b.pending = nil
+
+	return b.sender.Send(batch)
+}
+
+// Close sends the pending messages and stops the ticks, the sender isn't
+// closed. It returns the error of sending a batch on the last tick.
+func (b *MessageBatcher) Close() error {
+	b.closeOnce.Do(func() {
+		close(b.closed)
+	})
+	<-b.done
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.flush(); err != nil {
+		return err
+	}
+
+	err := b.err
+	b.err = nil
+
+	return err
+}
+
+// UnpackMessageBatch splits a batch sent by a MessageBatcher into its messages.
+// The messages share the memory of the batch.
+func UnpackMessageBatch(batch []byte) ([][]byte, error) {
+	var messages [][]byte
+	for len(batch) > 0 {
+		if len(batch) < batchLengthSize {
+			return nil, ErrInvalidMessageBatch
+		}
+
+		length := int(binary.BigEndian.Uint16(batch))
+		batch = batch[batchLengthSize:]
+		if length > len(batch) {
+			return nil, ErrInvalidMessageBatch
+		}
+
+		messages = append(messages, batch[:length:length])
+		batch = batch[length:]
+	}
+
+	return messages, nil
+}
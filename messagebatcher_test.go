@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package webrtc
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingMessageSender struct {
+	mu      sync.Mutex
+	batches [][]byte
+	err     error
+}
+
+func (s *recordingMessageSender) Send(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.batches = append(s.batches, append([]byte{}, data...))
+
+	return s.err
+}
+
+func (s *recordingMessageSender) sent() [][]byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.batches
+}
+
+func TestMessageBatcher(t *testing.T) {
+	lim := test.TimeOut(time.Second * 10)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	sender := &recordingMessageSender{}
+
+	// Sent on the next tick.
+	batcher := NewMessageBatcher(sender, time.Millisecond, 10)
+	require.NoError(t, batcher.Send([]byte("ab")))
+	require.Eventually(t, func() bool { return len(sender.sent()) == 1 }, time.Second, time.Millisecond)
+	assert.Equal(t, []byte{0, 2, 'a', 'b'}, sender.sent()[0])
+	require.NoError(t, batcher.Close())
+
+	batcher = NewMessageBatcher(sender, time.Hour, 10)
+	require.NoError(t, batcher.Send([]byte("ab")))
+	require.NoError(t, batcher.Send([]byte("cd")))
+	require.NoError(t, batcher.Flush())
+	require.Len(t, sender.sent(), 2)
+	assert.Equal(t, []byte{0, 2, 'a', 'b', 0, 2, 'c', 'd'}, sender.sent()[1])
+
+	// The second message would exceed the batch size and flushes the first.
+	require.NoError(t, batcher.Send([]byte("abcde")))
+	require.NoError(t, batcher.Send([]byte("this is larger than a batch")))
+	require.Len(t, sender.sent(), 3)
+	require.NoError(t, batcher.Close())
+	require.Len(t, sender.sent(), 4)
+
+	messages, err := UnpackMessageBatch(sender.sent()[2])
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("abcde")}, messages)
+
+	messages, err = UnpackMessageBatch(sender.sent()[3])
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("this is larger than a batch")}, messages)
+
+	assert.ErrorIs(t, batcher.Send(make([]byte, math.MaxUint16+1)), ErrBatchedMessageTooLarge)
+}
+
+func TestMessageBatcher_TickError(t *testing.T) {
+	lim := test.TimeOut(time.Second * 10)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	errSend := errors.New("send failed") //nolint:err113
+	sender := &recordingMessageSender{err: errSend}
+	batcher := NewMessageBatcher(sender, time.Millisecond, 0)
+
+	require.NoError(t, batcher.Send([]byte("state")))
+	require.Eventually(t, func() bool { return len(sender.sent()) == 1 }, time.Second, time.Millisecond)
+	assert.ErrorIs(t, batcher.Send([]byte("state")), errSend)
+	assert.NoError(t, batcher.Send([]byte("state")))
+
+	assert.ErrorIs(t, batcher.Close(), errSend)
+}
+
+func TestUnpackMessageBatch(t *testing.T) {
+	messages, err := UnpackMessageBatch([]byte{0, 1, 'a', 0, 0, 0, 2, 'b', 'c'})
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("a"), {}, []byte("bc")}, messages)
+
+	messages, err = UnpackMessageBatch(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, messages)
+
+	for _, invalid := range [][]byte{{0}, {0, 3, 'a', 'b'}} {
+		_, err = UnpackMessageBatch(invalid)
+		assert.ErrorIs(t, err, ErrInvalidMessageBatch)
+	}
+}
@@ -31,6 +31,12 @@ const (
 	// MimeTypePCMA PCMA MIME type
 	// Note: Matching should be case insensitive.
 	MimeTypePCMA = "audio/PCMA"
+	// MimeTypeTelephoneEvent telephone-event MIME type, see RFC 4733
+	// Note: Matching should be case insensitive.
+	MimeTypeTelephoneEvent = "audio/telephone-event"
+	// MimeTypeCN comfort noise MIME type, see RFC 3389
+	// Note: Matching should be case insensitive.
+	MimeTypeCN = "audio/CN"
 	// MimeTypeRTX RTX MIME type
 	// Note: Matching should be case insensitive.
 	MimeTypeRTX = "video/rtx"
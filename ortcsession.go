@@ -0,0 +1,278 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+
+	"github.com/pion/webrtc/v4/internal/util"
+)
+
+// maxSessionParametersSize bounds the decompressed size of encoded
+// SessionParameters.
+const maxSessionParametersSize = 1 << 20
+
+// SessionParameters are the parameters a Session exchanges with the remote peer.
+type SessionParameters struct {
+	ICECandidates    []ICECandidate   `json:"iceCandidates"`
+	ICEParameters    ICEParameters    `json:"iceParameters"`
+	DTLSParameters   DTLSParameters   `json:"dtlsParameters"`
+	SCTPCapabilities SCTPCapabilities `json:"sctpCapabilities"`
+}
+
+// Encode encodes the SessionParameters into a compact string that can be
+// copied to the remote peer, see DecodeSessionParameters.
+func (p SessionParameters) Encode() (string, error) {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err = w.Write(b); err != nil {
+		return "", err
+	}
+	if err = w.Close(); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// DecodeSessionParameters decodes SessionParameters encoded with
+// SessionParameters.Encode.
+func DecodeSessionParameters(s string) (SessionParameters, error) {
+	compressed, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return SessionParameters{}, err
+	}
+
+	r := flate.NewReader(bytes.NewReader(compressed))
+	defer r.Close() //nolint:errcheck
+
+	b, err := io.ReadAll(io.LimitReader(r, maxSessionParametersSize+1))
+	if err != nil {
+		return SessionParameters{}, err
+	}
+	if len(b) > maxSessionParametersSize {
+		return SessionParameters{}, errSessionParametersTooLarge
+	}
+
+	var params SessionParameters
+	if err = json.Unmarshal(b, &params); err != nil {
+		return SessionParameters{}, err
+	}
+
+	return params, nil
+}
+
+// SessionBuilder builds a Session, which wires the ORTC transports together.
+type SessionBuilder struct {
+	api           *API
+	gatherOptions ICEGatherOptions
+	certificates  []Certificate
+	iceRole       ICERole
+}
+
+// NewSessionBuilder creates a SessionBuilder using the configuration of the API.
+func (api *API) NewSessionBuilder() *SessionBuilder {
+	return &SessionBuilder{api: api}
+}
+
+// WithICEServers sets the STUN and TURN servers used to gather candidates.
+func (b *SessionBuilder) WithICEServers(servers ...ICEServer) *SessionBuilder {
+	b.gatherOptions.ICEServers = servers
+
+	return b
+}
+
+// WithICEGatherPolicy sets the candidates that are gathered.
+func (b *SessionBuilder) WithICEGatherPolicy(policy ICETransportPolicy) *SessionBuilder {
+	b.gatherOptions.ICEGatherPolicy = policy
+
+	return b
+}
+
+// WithCertificates sets the certificates of the DTLSTransport. A certificate
+// is generated by default.
+func (b *SessionBuilder) WithCertificates(certificates ...Certificate) *SessionBuilder {
+	b.certificates = certificates
+
+	return b
+}
+
+// WithICERole sets the ICE role of the Session. By default the peer with the
+// greater ICE username fragment is controlling, so both peers pick their roles
+// without signaling them.
+func (b *SessionBuilder) WithICERole(role ICERole) *SessionBuilder {
+	b.iceRole = role
+
+	return b
+}
+
+// Build creates the transports of the Session and gathers its candidates, it
+// blocks until gathering is complete.
+func (b *SessionBuilder) Build() (*Session, error) {
+	gatherer, err := b.api.NewICEGatherer(b.gatherOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	ice := b.api.NewICETransport(gatherer)
+
+	dtls, err := b.api.NewDTLSTransport(ice, b.certificates)
+	if err != nil {
+		return nil, util.FlattenErrs([]error{err, gatherer.Close()})
+	}
+
+	session := &Session{
+		api:      b.api,
+		iceRole:  b.iceRole,
+		gatherer: gatherer,
+		ice:      ice,
+		dtls:     dtls,
+		sctp:     b.api.NewSCTPTransport(dtls),
+	}
+
+	gatherFinished := make(chan struct{})
+	gatherer.OnLocalCandidate(func(candidate *ICECandidate) {
+		if candidate == nil {
+			close(gatherFinished)
+		}
+	})
+	if err = gatherer.Gather(); err != nil {
+		return nil, util.FlattenErrs([]error{err, session.Stop()})
+	}
+	<-gatherFinished
+
+	if session.local, err = session.localParameters(); err != nil {
+		return nil, util.FlattenErrs([]error{err, session.Stop()})
+	}
+
+	return session, nil
+}
+
+// Session is an ICEGatherer, ICETransport, DTLSTransport and SCTPTransport
+// wired together, created with a SessionBuilder. The peers exchange their
+// LocalParameters and call Start with the parameters of the remote peer.
+type Session struct {
+	api     *API
+	iceRole ICERole
+	local   SessionParameters
+
+	gatherer *ICEGatherer
+	ice      *ICETransport
+	dtls     *DTLSTransport
+	sctp     *SCTPTransport
+}
+
+func (s *Session) localParameters() (SessionParameters, error) {
+	candidates, err := s.gatherer.GetLocalCandidates()
+	if err != nil {
+		return SessionParameters{}, err
+	}
+
+	iceParams, err := s.gatherer.GetLocalParameters()
+	if err != nil {
+		return SessionParameters{}, err
+	}
+
+	dtlsParams, err := s.dtls.GetLocalParameters()
+	if err != nil {
+		return SessionParameters{}, err
+	}
+
+	return SessionParameters{
+		ICECandidates:    candidates,
+		ICEParameters:    iceParams,
+		DTLSParameters:   dtlsParams,
+		SCTPCapabilities: s.sctp.GetCapabilities(),
+	}, nil
+}
+
+// LocalParameters returns the parameters to send to the remote peer.
+func (s *Session) LocalParameters() SessionParameters {
+	return s.local
+}
+
+// Start connects the transports to the remote peer, it blocks until the
+// SCTPTransport is started. Both peers have to call Start.
+func (s *Session) Start(remote SessionParameters) error {
+	role := s.iceRole
+	if role == ICERoleUnknown {
+		switch local := s.local.ICEParameters.UsernameFragment; {
+		case local > remote.ICEParameters.UsernameFragment:
+			role = ICERoleControlling
+		case local < remote.ICEParameters.UsernameFragment:
+			role = ICERoleControlled
+		default:
+			return errSessionICERoleConflict
+		}
+	}
+
+	if err := s.ice.SetRemoteCandidates(remote.ICECandidates); err != nil {
+		return err
+	}
+
+	if err := s.ice.Start(nil, remote.ICEParameters, &role); err != nil {
+		return err
+	}
+
+	if err := s.dtls.Start(remote.DTLSParameters); err != nil {
+		return err
+	}
+
+	return s.sctp.Start(remote.SCTPCapabilities)
+}
+
+// NewDataChannel creates a DataChannel on the SCTPTransport, the Session has to
+// be started.
+func (s *Session) NewDataChannel(params *DataChannelParameters) (*DataChannel, error) {
+	return s.api.NewDataChannel(s.sctp, params)
+}
+
+// OnDataChannel sets an event handler which is invoked when the remote peer
+// creates a DataChannel.
+func (s *Session) OnDataChannel(f func(*DataChannel)) {
+	s.sctp.OnDataChannel(f)
+}
+
+// ICEGatherer returns the ICEGatherer of the Session.
+func (s *Session) ICEGatherer() *ICEGatherer {
+	return s.gatherer
+}
+
+// ICETransport returns the ICETransport of the Session.
+func (s *Session) ICETransport() *ICETransport {
+	return s.ice
+}
+
+// DTLSTransport returns the DTLSTransport of the Session.
+func (s *Session) DTLSTransport() *DTLSTransport {
+	return s.dtls
+}
+
+// SCTPTransport returns the SCTPTransport of the Session.
+func (s *Session) SCTPTransport() *SCTPTransport {
+	return s.sctp
+}
+
+// Stop stops the transports of the Session.
+func (s *Session) Stop() error {
+	return util.FlattenErrs([]error{
+		s.sctp.Stop(),
+		s.dtls.Stop(),
+		s.ice.Stop(),
+	})
+}
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionParameters_Encode(t *testing.T) {
+	params := SessionParameters{
+		ICECandidates: []ICECandidate{{
+			Foundation: "1",
+			Priority:   2130706431,
+			Address:    "192.0.2.1",
+			Protocol:   ICEProtocolUDP,
+			Port:       50000,
+			Typ:        ICECandidateTypeHost,
+		}},
+		ICEParameters: ICEParameters{UsernameFragment: "ufrag", Password: "password"},
+		DTLSParameters: DTLSParameters{
+			Role:         DTLSRoleAuto,
+			Fingerprints: []DTLSFingerprint{{Algorithm: "sha-256", Value: "AB:CD"}},
+		},
+		SCTPCapabilities: SCTPCapabilities{MaxMessageSize: 65536},
+	}
+
+	encoded, err := params.Encode()
+	require.NoError(t, err)
+	assert.NotContains(t, encoded, "=")
+
+	decoded, err := DecodeSessionParameters(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, params, decoded)
+
+	_, err = DecodeSessionParameters("not parameters")
+	assert.Error(t, err)
+}
+
+func TestSessionBuilder(t *testing.T) {
+	lim := test.TimeOut(time.Second * 20)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	sessionA, err := NewAPI().NewSessionBuilder().Build()
+	require.NoError(t, err)
+	sessionB, err := NewAPI().NewSessionBuilder().Build()
+	require.NoError(t, err)
+
+	encodedA, err := sessionA.LocalParameters().Encode()
+	require.NoError(t, err)
+	encodedB, err := sessionB.LocalParameters().Encode()
+	require.NoError(t, err)
+
+	received := make(chan string)
+	sessionB.OnDataChannel(func(d *DataChannel) {
+		d.OnMessage(func(msg DataChannelMessage) {
+			received <- string(msg.Data)
+		})
+	})
+
+	errs := make(chan error)
+	go func() {
+		remote, decodeErr := DecodeSessionParameters(encodedA)
+		if decodeErr != nil {
+			errs <- decodeErr
+
+			return
+		}
+		errs <- sessionB.Start(remote)
+	}()
+
+	remote, err := DecodeSessionParameters(encodedB)
+	require.NoError(t, err)
+	assert.NoError(t, sessionA.Start(remote))
+	assert.NoError(t, <-errs)
+
+	roleA, roleB := sessionA.ICETransport().Role(), sessionB.ICETransport().Role()
+	assert.NotEqual(t, roleA, roleB)
+
+	opened := make(chan struct{})
+	dc, err := sessionA.NewDataChannel(&DataChannelParameters{Label: "session"})
+	require.NoError(t, err)
+	dc.OnOpen(func() {
+		close(opened)
+	})
+	<-opened
+	require.NoError(t, dc.SendText("hello"))
+	assert.Equal(t, "hello", <-received)
+
+	assert.NoError(t, sessionA.Stop())
+	assert.NoError(t, sessionB.Stop())
+}
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/pion/interceptor"
+)
+
+// readBufferPool holds the buffers ReadRTP and ReadRTCP read packets into with
+// SettingEngine.EnableRTPPacketPooling.
+// nolint:gochecknoglobals
+var readBufferPool = sync.Pool{}
+
+// pooledReadBuffer holds the pooled buffer of the last packet a TrackRemote,
+// RTPReceiver or RTPSender read with EnableRTPPacketPooling. It goes back to
+// readBufferPool once the next packet is read.
+type pooledReadBuffer struct {
+	last atomic.Pointer[[]byte]
+}
+
+// readPacket reads a packet into a buffer of the receive MTU. With
+// EnableRTPPacketPooling the buffer is taken from readBufferPool and returned to
+// it with the next read into the pooledReadBuffer, so the packet isn't copied
+// and no buffer is allocated once the pool is warm.
+func (e *SettingEngine) readPacket(
+	buffer *pooledReadBuffer,
+	read func([]byte) (int, interceptor.Attributes, error),
+) ([]byte, interceptor.Attributes, error) {
+	receiveMTU := int(e.getReceiveMTU()) //nolint:gosec // G115
+	if !e.rtpPacketPooling {
+		b := make([]byte, receiveMTU)
+		i, attributes, err := read(b)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return b[:i], attributes, nil
+	}
+
+	b, ok := readBufferPool.Get().(*[]byte)
+	if !ok || len(*b) < receiveMTU {
+		buffer := make([]byte, receiveMTU)
+		b = &buffer
+	}
+
+	i, attributes, err := read((*b)[:receiveMTU])
+	if err != nil {
+		readBufferPool.Put(b)
+
+		return nil, nil, err
+	}
+	if last := buffer.last.Swap(b); last != nil {
+		readBufferPool.Put(last)
+	}
+
+	return (*b)[:i], attributes, nil
+}
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pion/interceptor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSettingEngine_readPacket(t *testing.T) {
+	packet := func(value byte) func([]byte) (int, interceptor.Attributes, error) {
+		return func(b []byte) (int, interceptor.Attributes, error) {
+			assert.Len(t, b, receiveMTU)
+
+			return copy(b, []byte{value, value, value}), interceptor.Attributes{}, nil
+		}
+	}
+
+	for _, pooling := range []bool{false, true} {
+		s := SettingEngine{}
+		s.EnableRTPPacketPooling(pooling)
+		buffer := &pooledReadBuffer{}
+
+		first, attributes, err := s.readPacket(buffer, packet(1))
+		assert.NoError(t, err)
+		assert.NotNil(t, attributes)
+		second, _, err := s.readPacket(buffer, packet(2))
+		assert.NoError(t, err)
+
+		assert.Equal(t, []byte{1, 1, 1}, first, "the packet was overwritten by the next one")
+		assert.Equal(t, []byte{2, 2, 2}, second)
+		if pooling {
+			assert.Same(t, &(*buffer.last.Load())[0], &second[0], "the packet was copied out of the pooled buffer")
+		} else {
+			assert.Nil(t, buffer.last.Load())
+		}
+
+		errRead := errors.New("read failed") //nolint:err113
+		_, _, err = s.readPacket(buffer, func([]byte) (int, interceptor.Attributes, error) {
+			return 0, nil, errRead
+		})
+		assert.ErrorIs(t, err, errRead)
+		if pooling {
+			assert.Same(t, &(*buffer.last.Load())[0], &second[0], "a failed read released the last packet")
+		}
+	}
+}
@@ -6,9 +6,6 @@
 package webrtc
 
 import (
-	"crypto/ecdsa"
-	"crypto/elliptic"
-	"crypto/rand"
 	"errors"
 	"fmt"
 	"slices"
@@ -52,6 +49,7 @@ type PeerConnection struct {
 	signalingState           SignalingState
 	iceConnectionState       atomic.Value // ICEConnectionState
 	connectionState          atomic.Value // PeerConnectionState
+	connectionStateCause     atomic.Value // ConnectionStateCause
 
 	idpLoginURL *string
 
@@ -62,8 +60,13 @@ type PeerConnection struct {
 	isNegotiationNeeded                     *atomic.Bool
 	updateNegotiationNeededFlagOnEmptyChain *atomic.Bool
 
+	phaseTimers phaseTimers
+
 	lastOffer  string
 	lastAnswer string
+	// synthesizedMids are the mids of the remote media sections without one, by
+	// their index, see SettingEngine.SetSynthesizeMissingMids.
+	synthesizedMids map[int]string
 	// Whether the remote endpoint can accept trickled ICE candidates.
 	canTrickleICECandidates ICETrickleCapability
 
@@ -76,12 +79,16 @@ type PeerConnection struct {
 	rtpTransceivers        []*RTPTransceiver
 	nonMediaBandwidthProbe atomic.Value // RTPReceiver
 
-	onSignalingStateChangeHandler     func(SignalingState)
-	onICEConnectionStateChangeHandler atomic.Value // func(ICEConnectionState)
-	onConnectionStateChangeHandler    atomic.Value // func(PeerConnectionState)
-	onTrackHandler                    func(*TrackRemote, *RTPReceiver)
-	onDataChannelHandler              func(*DataChannel)
-	onNegotiationNeededHandler        atomic.Value // func()
+	onSignalingStateChangeHandler           func(SignalingState)
+	onICEConnectionStateChangeHandler       atomic.Value // func(ICEConnectionState)
+	onConnectionStateChangeHandler          atomic.Value // func(PeerConnectionState)
+	onConnectionStateChangeWithCauseHandler atomic.Value // func(PeerConnectionState, ConnectionStateCause)
+	// reconnector is the Reconnector installed on the PeerConnection, if any.
+	reconnector                 atomic.Pointer[Reconnector]
+	onTrackHandler              func(*TrackRemote, *RTPReceiver)
+	onDataChannelHandler        func(*DataChannel)
+	dataChannelProtocolHandlers map[string]func(*DataChannel)
+	onNegotiationNeededHandler  atomic.Value // func()
 
 	iceGatherer   *ICEGatherer
 	iceTransport  *ICETransport
@@ -94,6 +101,9 @@ type PeerConnection struct {
 
 	interceptorRTCPWriter interceptor.RTCPWriter
 	statsGetter           stats.Getter
+	eventLogInterceptor   *rtcEventLogInterceptor
+
+	relayUsageMonitorStop chan struct{}
 }
 
 // NewPeerConnection creates a PeerConnection with the default codecs and interceptors.
@@ -149,14 +159,23 @@ func (api *API) NewPeerConnection(configuration Configuration) (*PeerConnection,
 	if err != nil {
 		return nil, err
 	}
+	if api.settingEngine.rtcEventLog {
+		pc.eventLogInterceptor = newRTCEventLogInterceptor()
+		i = interceptor.NewChain([]interceptor.Interceptor{pc.eventLogInterceptor, i})
+	}
+	if instrumentation := api.settingEngine.instrumentation; instrumentation != nil {
+		transport, application := instrumentation.interceptors()
+		i = interceptor.NewChain([]interceptor.Interceptor{transport, i, application})
+	}
 
 	if getter, ok := lookupStats(pc.id); ok {
 		pc.statsGetter = getter
 	}
 
 	pc.api = &API{
-		settingEngine: api.settingEngine,
-		interceptor:   i,
+		settingEngine:    api.settingEngine,
+		interceptor:      i,
+		certificateCache: api.certificateCache,
 	}
 
 	if api.settingEngine.disableMediaEngineCopy {
@@ -190,16 +209,10 @@ func (api *API) NewPeerConnection(configuration Configuration) (*PeerConnection,
 	pc.sctpTransport = pc.api.NewSCTPTransport(pc.dtlsTransport)
 
 	// Wire up the on datachannel handler
-	pc.sctpTransport.OnDataChannel(func(d *DataChannel) {
-		pc.mu.RLock()
-		handler := pc.onDataChannelHandler
-		pc.mu.RUnlock()
-		if handler != nil {
-			handler(d)
-		}
-	})
+	pc.sctpTransport.OnDataChannel(pc.onDataChannel)
 
 	if pc.configuration.ICECandidatePoolSize > 0 {
+		pc.startPhaseTimer(ConnectionPhaseGathering)
 		if err := pc.iceGatherer.Gather(); err != nil {
 			return nil, err
 		}
@@ -221,20 +234,20 @@ func (pc *PeerConnection) initConfiguration(configuration Configuration) error {
 	}
 
 	// https://www.w3.org/TR/webrtc/#constructor (step #3)
-	if len(configuration.Certificates) > 0 {
+	certificates := configuration.Certificates
+	if len(certificates) == 0 {
+		certificates = pc.api.settingEngine.dtls.sharedCertificates
+	}
+	if len(certificates) > 0 {
 		now := time.Now()
-		for _, x509Cert := range configuration.Certificates {
+		for _, x509Cert := range certificates {
 			if !x509Cert.Expires().IsZero() && now.After(x509Cert.Expires()) {
 				return &rtcerr.InvalidAccessError{Err: ErrCertificateExpired}
 			}
 			pc.configuration.Certificates = append(pc.configuration.Certificates, x509Cert)
 		}
 	} else {
-		sk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
-		if err != nil {
-			return &rtcerr.UnknownError{Err: err}
-		}
-		certificate, err := GenerateCertificate(sk)
+		certificate, err := pc.api.generateCertificate()
 		if err != nil {
 			return err
 		}
@@ -418,7 +431,7 @@ func (pc *PeerConnection) checkNegotiationNeeded() bool { //nolint:gocognit,cycl
 				// As calling replaceTrack does not require renegotiation, we skip check for this transceiver
 				continue
 			}
-			if !okMsid || descMsid != track.StreamID()+" "+track.ID() {
+			if !okMsid || descMsid != track.StreamID()+" "+track.ID() && !transceiver.isPoolPlaceholderMsid(descMsid) {
 				return true
 			}
 		}
@@ -462,6 +475,21 @@ func (pc *PeerConnection) OnICECandidate(f func(*ICECandidate)) {
 	pc.iceGatherer.OnLocalCandidate(f)
 }
 
+// OnICECandidateBatch sets an event handler which is invoked with all ICE
+// candidates found within window of the first pending one. This reduces the
+// number of signaling messages on constrained signaling channels.
+// When gathering is finished the remaining candidates are delivered with
+// endOfCandidates set, this is the last invocation of the handler for that
+// gathering. An ICE restart gathers again, so batches of the new candidates
+// follow, ending with their own endOfCandidates.
+// A window of zero or less delivers every candidate as soon as it is found.
+// OnICECandidateBatch replaces any handler set with OnICECandidate.
+func (pc *PeerConnection) OnICECandidateBatch(
+	window time.Duration, f func(candidates []ICECandidate, endOfCandidates bool),
+) {
+	pc.iceGatherer.OnLocalCandidate(newICECandidateBatcher(window, f).onCandidate)
+}
+
 // OnICEGatheringStateChange sets an event handler which is invoked when the
 // ICE candidate gathering state has changed.
 func (pc *PeerConnection) OnICEGatheringStateChange(f func(ICEGatheringState)) {
@@ -521,12 +549,24 @@ func (pc *PeerConnection) OnConnectionStateChange(f func(PeerConnectionState)) {
 	pc.onConnectionStateChangeHandler.Store(f)
 }
 
-func (pc *PeerConnection) onConnectionStateChange(cs PeerConnectionState) {
+func (pc *PeerConnection) onConnectionStateChange(cs PeerConnectionState, cause ConnectionStateCause) {
 	pc.connectionState.Store(cs)
-	pc.log.Infof("peer connection state changed: %s", cs)
+	pc.connectionStateCause.Store(cause)
+	if cause.Transport != ConnectionPhaseUnknown {
+		pc.log.Infof("peer connection state changed: %s (%s: %v)", cs, cause.Transport, cause.Err)
+	} else {
+		pc.log.Infof("peer connection state changed: %s", cs)
+	}
 	if handler, ok := pc.onConnectionStateChangeHandler.Load().(func(PeerConnectionState)); ok && handler != nil {
 		go handler(cs)
 	}
+	type handlerWithCause = func(PeerConnectionState, ConnectionStateCause)
+	if handler, ok := pc.onConnectionStateChangeWithCauseHandler.Load().(handlerWithCause); ok && handler != nil {
+		go handler(cs, cause)
+	}
+	if reconnector := pc.reconnector.Load(); reconnector != nil {
+		go reconnector.handleConnectionState(cs)
+	}
 }
 
 // SetConfiguration updates the configuration of this PeerConnection object.
@@ -576,9 +616,9 @@ func (pc *PeerConnection) SetConfiguration(configuration Configuration) error {
 	}
 
 	// https://www.w3.org/TR/webrtc/#set-the-configuration (step #3.6)
-	if configuration.ICECandidatePoolSize != 0 {
-		if pc.configuration.ICECandidatePoolSize != configuration.ICECandidatePoolSize &&
-			pc.LocalDescription() != nil {
+	if configuration.ICECandidatePoolSize != 0 &&
+		pc.configuration.ICECandidatePoolSize != configuration.ICECandidatePoolSize {
+		if pc.LocalDescription() != nil {
 			return &rtcerr.InvalidModificationError{Err: ErrModifyingICECandidatePoolSize}
 		}
 
@@ -632,9 +672,20 @@ func (pc *PeerConnection) SetConfiguration(configuration Configuration) error {
 // configuration of this PeerConnection object. The returned object is a
 // copy and direct mutation on it will not take affect until SetConfiguration
 // has been called with Configuration passed as its only argument.
+//
+// It is the effective configuration, with the defaults applied to the fields
+// that were left empty, the certificates that were generated when none were
+// given and the ICE servers as they are used for gathering. Passing it to
+// SetConfiguration doesn't change the configuration. The settings of the
+// SettingEngine of the API the PeerConnection was created with aren't part of
+// it, like the ICE credentials, timeouts and network types.
 // https://www.w3.org/TR/webrtc/#dom-rtcpeerconnection-getconfiguration
 func (pc *PeerConnection) GetConfiguration() Configuration {
-	return pc.configuration
+	configuration := pc.configuration
+	configuration.ICEServers = pc.configuration.getICEServers()
+	configuration.Certificates = append([]Certificate{}, pc.configuration.Certificates...)
+
+	return configuration
 }
 
 func (pc *PeerConnection) ID() string {
@@ -678,6 +729,7 @@ func (pc *PeerConnection) CreateOffer(options *OfferOptions) (SessionDescription
 		if err := pc.iceTransport.restart(); err != nil {
 			return SessionDescription{}, err
 		}
+		pc.startPhaseTimer(ConnectionPhaseICE)
 	}
 
 	var (
@@ -736,8 +788,11 @@ func (pc *PeerConnection) CreateOffer(options *OfferOptions) (SessionDescription
 
 					continue
 				}
-				pc.greaterMid++
-				err = t.SetMid(strconv.Itoa(pc.greaterMid))
+				mid, errMid := pc.generateMid()
+				if errMid != nil {
+					return SessionDescription{}, errMid
+				}
+				err = t.SetMid(mid)
 				if err != nil {
 					return SessionDescription{}, err
 				}
@@ -795,6 +850,66 @@ func (pc *PeerConnection) CreateOffer(options *OfferOptions) (SessionDescription
 	return offer, nil
 }
 
+// generateMid returns the mid of a transceiver that is offered, by default the
+// number after the greatest numeric mid so far. The mids of the generator that
+// are already used, like by the data section, are skipped.
+func (pc *PeerConnection) generateMid() (string, error) {
+	generator := pc.api.settingEngine.generators.mid
+	if generator == nil {
+		pc.greaterMid++
+
+		return strconv.Itoa(pc.greaterMid), nil
+	}
+
+	used := pc.usedMids()
+	for range len(used) + 1 {
+		if mid := generator(); !used[mid] {
+			return mid, nil
+		}
+	}
+
+	return "", errMidGeneratorNotUnique
+}
+
+// usedMids returns the mids of the transceivers and of the media sections of the
+// current descriptions.
+func (pc *PeerConnection) usedMids() map[string]bool {
+	used := map[string]bool{}
+	for _, t := range pc.rtpTransceivers {
+		if mid := t.Mid(); mid != "" {
+			used[mid] = true
+		}
+	}
+	for _, description := range []*SessionDescription{pc.currentLocalDescription, pc.currentRemoteDescription} {
+		if description == nil || description.parsed == nil {
+			continue
+		}
+		for _, media := range description.parsed.MediaDescriptions {
+			if mid := getMidValue(media); mid != "" {
+				used[mid] = true
+			}
+		}
+	}
+
+	return used
+}
+
+// newSessionDescription creates a JSEP session description, with the session ID
+// of the generator until the first description is set.
+func (pc *PeerConnection) newSessionDescription(useIdentity bool) (*sdp.SessionDescription, error) {
+	desc, err := sdp.NewJSEPSessionDescription(useIdentity)
+	if err != nil {
+		return nil, err
+	}
+	generator := pc.api.settingEngine.generators.sessionID
+	if generator != nil && atomic.LoadUint64(&pc.sdpOrigin.SessionVersion) == 0 {
+		// The highest bit is zero, see RFC 8829 section 5.2.1.
+		desc.Origin.SessionID = generator() &^ (1 << 63)
+	}
+
+	return desc, nil
+}
+
 func (pc *PeerConnection) createICEGatherer() (*ICEGatherer, error) {
 	g, err := pc.api.NewICEGatherer(ICEGatherOptions{
 		ICEServers:           pc.configuration.getICEServers(),
@@ -822,8 +937,10 @@ func (pc *PeerConnection) updateConnectionState(
 	case pc.isClosed.Load():
 		connectionState = PeerConnectionStateClosed
 
-	// Any of the RTCIceTransports or RTCDtlsTransports are in a "failed" state.
-	case iceConnectionState == ICEConnectionStateFailed || dtlsTransportState == DTLSTransportStateFailed:
+	// Any of the RTCIceTransports or RTCDtlsTransports are in a "failed" state, or a
+	// phase exceeded its deadline.
+	case iceConnectionState == ICEConnectionStateFailed || dtlsTransportState == DTLSTransportStateFailed ||
+		pc.phaseTimeout() != nil:
 		connectionState = PeerConnectionStateFailed
 
 	// Any of the RTCIceTransports or RTCDtlsTransports are in the "disconnected"
@@ -855,11 +972,20 @@ func (pc *PeerConnection) updateConnectionState(
 		return
 	}
 
-	pc.onConnectionStateChange(connectionState)
+	pc.onConnectionStateChange(
+		connectionState, pc.newConnectionStateCause(connectionState, iceConnectionState, dtlsTransportState),
+	)
 }
 
 func (pc *PeerConnection) createICETransport() *ICETransport {
 	transport := pc.api.NewICETransport(pc.iceGatherer)
+	if pc.eventLogInterceptor != nil {
+		transport.internalOnSelectedCandidatePairChange.Store(func(pair *ICECandidatePair) {
+			if log := pc.eventLogInterceptor.load(); log != nil {
+				log.logSelectedCandidatePair(pair)
+			}
+		})
+	}
 	transport.internalOnConnectionStateChangeHandler.Store(func(state ICETransportState) {
 		var cs ICEConnectionState
 		switch state {
@@ -1138,6 +1264,8 @@ func (pc *PeerConnection) SetLocalDescription(desc SessionDescription) error {
 	pc.iceGatherer.flushCandidates()
 
 	if pc.iceGatherer.State() == ICEGathererStateNew {
+		pc.startPhaseTimer(ConnectionPhaseGathering)
+
 		return pc.iceGatherer.Gather()
 	}
 
@@ -1170,6 +1298,12 @@ func (pc *PeerConnection) SetRemoteDescription(desc SessionDescription) error {
 		return err
 	}
 
+	if pc.api.settingEngine.synthesizeMissingMids {
+		if err := pc.synthesizeRemoteMids(&desc); err != nil {
+			return err
+		}
+	}
+
 	if err := pc.setDescription(&desc, stateChangeOpSetRemote); err != nil {
 		return err
 	}
@@ -1282,6 +1416,8 @@ func (pc *PeerConnection) SetRemoteDescription(desc SessionDescription) error {
 		}
 	}
 
+	setRTPTransceiverPacketizationTimes(desc.parsed, pc.GetTransceivers())
+
 	iceDetails, err := extractICEDetails(desc.parsed, pc.log)
 	if err != nil {
 		return err
@@ -1293,6 +1429,7 @@ func (pc *PeerConnection) SetRemoteDescription(desc SessionDescription) error {
 			if err = pc.iceTransport.restart(); err != nil {
 				return err
 			}
+			pc.startPhaseTimer(ConnectionPhaseICE)
 		}
 
 		if err = pc.iceTransport.setRemoteCredentials(iceDetails.Ufrag, iceDetails.Password); err != nil {
@@ -1324,8 +1461,9 @@ func (pc *PeerConnection) SetRemoteDescription(desc SessionDescription) error {
 	}
 
 	remoteIsLite := isIceLiteSet(desc.parsed)
+	pc.dtlsTransport.setCryptex(pc.api.settingEngine.enableCryptex && isCryptexSet(desc.parsed))
 
-	fingerprint, fingerprintHash, err := extractFingerprint(desc.parsed)
+	fingerprints, err := extractFingerprints(desc.parsed)
 	if err != nil {
 		return err
 	}
@@ -1356,8 +1494,7 @@ func (pc *PeerConnection) SetRemoteDescription(desc SessionDescription) error {
 			dtlsRoleFromSDP(desc.parsed),
 			iceDetails.Ufrag,
 			iceDetails.Password,
-			fingerprint,
-			fingerprintHash,
+			fingerprints,
 		)
 		if weOffer {
 			pc.startRTP(false, &desc, currentTransceivers)
@@ -1649,6 +1786,7 @@ func (pc *PeerConnection) startRTPSenders(currentTransceivers []*RTPTransceiver)
 // Start SCTP subsystem.
 func (pc *PeerConnection) startSCTP(maxMessageSize uint32, remoteSctpInit []byte) {
 	// Start sctp
+	pc.startPhaseTimer(ConnectionPhaseSCTP)
 	if err := pc.sctpTransport.Start(SCTPCapabilities{
 		MaxMessageSize: maxMessageSize,
 		sctpInit:       string(remoteSctpInit),
@@ -1660,6 +1798,9 @@ func (pc *PeerConnection) startSCTP(maxMessageSize uint32, remoteSctpInit []byte
 
 		return
 	}
+
+	// Recover from a SCTP phase timeout.
+	pc.updateConnectionState(pc.ICEConnectionState(), pc.dtlsTransport.State())
 }
 
 func (pc *PeerConnection) handleUndeclaredSSRC(
@@ -1795,6 +1936,25 @@ func (pc *PeerConnection) handleIncomingSSRC(rtpStream *srtp.ReadStreamSRTP, ssr
 		}
 	}
 
+	// We read the RTP packet to determine the payload type
+	b := make([]byte, pc.api.settingEngine.getReceiveMTU())
+
+	i, err := rtpStream.Peek(b)
+	if err != nil {
+		return err
+	}
+
+	if i < 4 {
+		return errRTPTooShort
+	}
+
+	// RTP keep-alives are dropped, RFC 6263 Section 4.6.
+	if pc.isRTPKeepAlive(b[:i]) {
+		pc.log.Tracef("Dropping RTP keep-alives of SSRC %d", ssrc)
+
+		return nil
+	}
+
 	// if the SSRC is not declared in the SDP and there is only one media section,
 	// we attempt to resolve it using this single section
 	// This applies even if the client supports RTP extensions:
@@ -1810,18 +1970,6 @@ func (pc *PeerConnection) handleIncomingSSRC(rtpStream *srtp.ReadStreamSRTP, ssr
 		}
 	}
 
-	// We read the RTP packet to determine the payload type
-	b := make([]byte, pc.api.settingEngine.getReceiveMTU())
-
-	i, err := rtpStream.Peek(b)
-	if err != nil {
-		return err
-	}
-
-	if i < 4 {
-		return errRTPTooShort
-	}
-
 	payloadType := PayloadType(b[1] & 0x7f)
 	params, err := pc.api.mediaEngine.getRTPParametersByPayloadType(payloadType)
 	if err != nil {
@@ -2076,12 +2224,12 @@ func (pc *PeerConnection) AddICECandidate(candidate ICECandidateInit) error {
 		return &rtcerr.InvalidStateError{Err: ErrNoRemoteDescription}
 	}
 
-	candidateValue := strings.TrimPrefix(candidate.Candidate, "candidate:")
-
-	if candidateValue == "" {
+	if candidate.IsEndOfCandidates() {
 		return pc.iceTransport.AddRemoteCandidate(nil)
 	}
 
+	candidateValue := strings.TrimPrefix(candidate.Candidate, "candidate:")
+
 	cand, err := ice.UnmarshalCandidate(candidateValue)
 	if err != nil {
 		if errors.Is(err, ice.ErrUnknownCandidateTyp) || errors.Is(err, ice.ErrDetermineNetworkType) {
@@ -2358,6 +2506,10 @@ func (pc *PeerConnection) AddTransceiverFromTrack(
 // and optional DataChannelInit used to configure properties of the
 // underlying channel such as data reliability.
 //
+// DataChannels created before the SCTP association is established are queued
+// and opened in the order they were created once it is, the queue can be bounded
+// with SettingEngine.SetDataChannelQueueLimits.
+//
 //nolint:cyclop
 func (pc *PeerConnection) CreateDataChannel(label string, options *DataChannelInit) (*DataChannel, error) {
 	// https://w3c.github.io/webrtc-pc/#peer-to-peer-data-api (Step #2)
@@ -2407,6 +2559,13 @@ func (pc *PeerConnection) CreateDataChannel(label string, options *DataChannelIn
 		if options.Negotiated != nil {
 			params.Negotiated = *options.Negotiated
 		}
+
+		params.Compression = options.Compression
+		params.Fragmentation = options.Fragmentation
+
+		if options.Priority != nil {
+			params.Priority = *options.Priority
+		}
 	}
 
 	dataChannel, err := pc.api.newDataChannel(params, nil, pc.log)
@@ -2419,13 +2578,9 @@ func (pc *PeerConnection) CreateDataChannel(label string, options *DataChannelIn
 		return nil, &rtcerr.TypeError{Err: ErrRetransmitsOrPacketLifeTime}
 	}
 
-	pc.sctpTransport.lock.Lock()
-	pc.sctpTransport.dataChannels = append(pc.sctpTransport.dataChannels, dataChannel)
-	if dataChannel.ID() != nil {
-		pc.sctpTransport.dataChannelIDsUsed[*dataChannel.ID()] = struct{}{}
+	if err = pc.sctpTransport.addDataChannel(dataChannel); err != nil {
+		return nil, err
 	}
-	pc.sctpTransport.dataChannelsRequested++
-	pc.sctpTransport.lock.Unlock()
 
 	// If SCTP already connected open all the channels
 	if pc.sctpTransport.State() == SCTPTransportStateConnected {
@@ -2460,17 +2615,17 @@ func (pc *PeerConnection) writeRTCP(pkts []rtcp.Packet, _ interceptor.Attributes
 
 // Close ends the PeerConnection.
 func (pc *PeerConnection) Close() error {
-	return pc.close(false /* shouldGracefullyClose */)
+	return pc.close(false /* shouldGracefullyClose */, nil)
 }
 
 // GracefulClose ends the PeerConnection. It also waits
 // for any goroutines it started to complete. This is only safe to call outside of
 // PeerConnection callbacks or if in a callback, in its own goroutine.
 func (pc *PeerConnection) GracefulClose() error {
-	return pc.close(true /* shouldGracefullyClose */)
+	return pc.close(true /* shouldGracefullyClose */, nil)
 }
 
-func (pc *PeerConnection) close(shouldGracefullyClose bool) error { //nolint:cyclop
+func (pc *PeerConnection) close(shouldGracefullyClose bool, options *CloseOptions) error { //nolint:cyclop
 	// https://www.w3.org/TR/webrtc/#dom-rtcpeerconnection-close (step #1)
 	// https://www.w3.org/TR/webrtc/#dom-rtcpeerconnection-close (step #2)
 
@@ -2513,6 +2668,8 @@ func (pc *PeerConnection) close(shouldGracefullyClose bool) error { //nolint:cyc
 		defer close(pc.isGracefulCloseDone)
 	}
 
+	pc.stopPhaseTimers()
+
 	// Try closing everything and collect the errors
 	// Shutdown strategy:
 	// 1. All Conn close by closing their underlying Conn.
@@ -2547,6 +2704,10 @@ func (pc *PeerConnection) close(shouldGracefullyClose bool) error { //nolint:cyc
 		return util.FlattenErrs(doGracefulCloseOps())
 	}
 
+	if options != nil {
+		closeErrs = append(closeErrs, pc.closeInOrder(options)...)
+	}
+
 	// https://www.w3.org/TR/webrtc/#dom-rtcpeerconnection-close (step #3)
 	pc.signalingState.Set(SignalingStateClosed)
 
@@ -2572,14 +2733,9 @@ func (pc *PeerConnection) close(shouldGracefullyClose bool) error { //nolint:cyc
 		closeErrs = append(closeErrs, pc.sctpTransport.Stop())
 	}
 
-	// https://www.w3.org/TR/webrtc/#dom-rtcpeerconnection-close (step #7)
-	closeErrs = append(closeErrs, pc.dtlsTransport.Stop())
-
-	// https://www.w3.org/TR/webrtc/#dom-rtcpeerconnection-close (step #8, #9, #10)
-	if pc.iceTransport != nil && !shouldGracefullyClose {
-		// we will stop gracefully in doGracefulCloseOps
-		closeErrs = append(closeErrs, pc.iceTransport.Stop())
-	}
+	// https://www.w3.org/TR/webrtc/#dom-rtcpeerconnection-close (step #7, #8, #9, #10)
+	// When gracefully closing, the ICE transport is stopped in doGracefulCloseOps.
+	closeErrs = append(closeErrs, pc.stopTransports(options, pc.iceTransport != nil && !shouldGracefullyClose)...)
 
 	// https://www.w3.org/TR/webrtc/#dom-rtcpeerconnection-close (step #11)
 	pc.updateConnectionState(pc.ICEConnectionState(), pc.dtlsTransport.State())
@@ -2714,7 +2870,7 @@ func (pc *PeerConnection) GetStats() StatsReport {
 		pc.iceGatherer.collectStats(statsCollector)
 	}
 	if pc.iceTransport != nil {
-		pc.iceTransport.collectStats(statsCollector)
+		pc.iceTransport.collectStats(statsCollector, pc.dtlsTransport)
 	}
 
 	pc.sctpTransport.lock.Lock()
@@ -2768,9 +2924,11 @@ func (pc *PeerConnection) GetStats() StatsReport {
 func (pc *PeerConnection) startTransports(
 	iceRole ICERole,
 	dtlsRole DTLSRole,
-	remoteUfrag, remotePwd, fingerprint, fingerprintHash string,
+	remoteUfrag, remotePwd string,
+	fingerprints []DTLSFingerprint,
 ) {
 	// Start the ice transport
+	pc.startPhaseTimer(ConnectionPhaseICE)
 	err := pc.iceTransport.Start(
 		pc.iceGatherer,
 		ICEParameters{
@@ -2800,9 +2958,10 @@ func (pc *PeerConnection) startTransports(
 	}
 
 	// Start the dtls transport
+	pc.startPhaseTimer(ConnectionPhaseDTLS)
 	err = pc.dtlsTransport.Start(DTLSParameters{
 		Role:         dtlsRole,
-		Fingerprints: []DTLSFingerprint{{Algorithm: fingerprintHash, Value: fingerprint}},
+		Fingerprints: fingerprints,
 	})
 	pc.updateConnectionState(pc.ICEConnectionState(), pc.dtlsTransport.State())
 	if err != nil {
@@ -2820,6 +2979,7 @@ func (pc *PeerConnection) startRTP(
 ) {
 	if !isRenegotiation {
 		pc.undeclaredMediaProcessor()
+		pc.startRTPKeepAlives()
 	}
 
 	pc.startRTPReceivers(remoteDesc, currentTransceivers)
@@ -2837,11 +2997,14 @@ func (pc *PeerConnection) generateUnmatchedSDP(
 	transceivers []*RTPTransceiver,
 	useIdentity bool,
 ) (*sdp.SessionDescription, error) {
-	desc, err := sdp.NewJSEPSessionDescription(useIdentity)
+	desc, err := pc.newSessionDescription(useIdentity)
 	if err != nil {
 		return nil, err
 	}
 	desc.Attributes = append(desc.Attributes, sdp.Attribute{Key: sdp.AttrKeyMsidSemantic, Value: "WMS *"})
+	if pc.api.settingEngine.enableCryptex {
+		desc = desc.WithPropertyAttribute(sdp.AttrKeyCryptex)
+	}
 
 	iceParams, err := pc.iceGatherer.GetLocalParameters()
 	if err != nil {
@@ -2900,14 +3063,16 @@ func (pc *PeerConnection) generateUnmatchedSDP(
 
 		if pc.configuration.AlwaysNegotiateDataChannels || pc.sctpTransport.dataChannelsRequested != 0 {
 			mediaSections = append(mediaSections, mediaSection{
-				id:       strconv.Itoa(len(mediaSections)),
+				id:       dataSectionMid(mediaSections),
 				data:     true,
 				sctpInit: localSctpInit,
 			})
 		}
 	}
 
-	dtlsFingerprints, err := pc.configuration.Certificates[0].GetFingerprints()
+	dtlsFingerprints, err := pc.configuration.Certificates[0].getFingerprints(
+		pc.api.settingEngine.getDTLSFingerprintAlgorithms(),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -2941,7 +3106,7 @@ func (pc *PeerConnection) generateMatchedSDP(
 	connectionRole sdp.ConnectionRole,
 	ignoreRidPauseForRecv bool,
 ) (*sdp.SessionDescription, error) {
-	desc, err := sdp.NewJSEPSessionDescription(useIdentity)
+	desc, err := pc.newSessionDescription(useIdentity)
 	if err != nil {
 		return nil, err
 	}
@@ -2963,6 +3128,9 @@ func (pc *PeerConnection) generateMatchedSDP(
 		remoteDescription = pc.pendingRemoteDescription
 	}
 	isExtmapAllowMixed := isExtMapAllowMixedSet(remoteDescription.parsed)
+	if pc.api.settingEngine.enableCryptex && isCryptexSet(remoteDescription.parsed) {
+		desc = desc.WithPropertyAttribute(sdp.AttrKeyCryptex)
+	}
 	localTransceivers := append([]*RTPTransceiver{}, transceivers...)
 
 	detectedPlanB := descriptionIsPlanB(remoteDescription, pc.log)
@@ -3076,7 +3244,7 @@ func (pc *PeerConnection) generateMatchedSDP(
 				mediaSections = append(mediaSections, mediaSection{id: "data", data: true})
 			} else {
 				mediaSections = append(mediaSections, mediaSection{
-					id:       strconv.Itoa(len(mediaSections)),
+					id:       dataSectionMid(mediaSections),
 					data:     true,
 					sctpInit: localSctpInit,
 				})
@@ -3092,7 +3260,9 @@ func (pc *PeerConnection) generateMatchedSDP(
 		pc.log.Info("Plan-B Offer detected; responding with Plan-B Answer")
 	}
 
-	dtlsFingerprints, err := pc.configuration.Certificates[0].GetFingerprints()
+	dtlsFingerprints, err := pc.configuration.Certificates[0].getFingerprints(
+		pc.api.settingEngine.getDTLSFingerprintAlgorithms(),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -3120,6 +3290,14 @@ func (pc *PeerConnection) setGatherCompleteHandler(handler func()) {
 	pc.iceGatherer.onGatheringCompleteHandler.Store(handler)
 }
 
+// ICETransport returns the ICETransport of this PeerConnection, which all media
+// and DataChannels are sent over. Its candidates, selected candidate pair and
+// role can be queried without reading a stats report, see the W3C
+// RTCIceTransport.
+func (pc *PeerConnection) ICETransport() *ICETransport {
+	return pc.iceTransport
+}
+
 // SCTP returns the SCTPTransport for this PeerConnection
 //
 // The SCTP transport over which SCTP data is sent and received. If SCTP has not been negotiated, the value is nil.
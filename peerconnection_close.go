@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/pion/rtcp"
+)
+
+const (
+	defaultCloseRTCPByeTimeout      = time.Second
+	defaultCloseDataChannelsTimeout = time.Second
+	defaultCloseSCTPTimeout         = time.Second
+	defaultCloseDTLSTimeout         = time.Second
+	defaultCloseICETimeout          = time.Second
+)
+
+// CloseOptions are the steps of closing a PeerConnection with
+// CloseWithOptions, so the remote peer sees the PeerConnection closed instead
+// of losing the transports. They run in the order of the fields, then the DTLS
+// close_notify alert is sent and the ICE transport is stopped like with Close,
+// waiting at most DTLSTimeout and ICETimeout which are 1 second by default.
+type CloseOptions struct {
+	// SendRTCPBye sends an RTCP BYE for the streams that are sent, so the remote
+	// peer ends them right away instead of when they time out. Sending it takes
+	// at most RTCPByeTimeout which is 1 second by default.
+	SendRTCPBye    bool
+	RTCPByeTimeout time.Duration
+
+	// RTCPByeReason is the reason sent with the RTCP BYE, empty for none.
+	RTCPByeReason string
+
+	// CloseDataChannels closes the DataChannels and waits for the remote peer to
+	// close them as well, at most DataChannelsTimeout which is 1 second by
+	// default. The SCTP association is shut down afterward instead of aborted,
+	// waiting at most SCTPTimeout which is 1 second by default.
+	CloseDataChannels   bool
+	DataChannelsTimeout time.Duration
+	SCTPTimeout         time.Duration
+
+	DTLSTimeout time.Duration
+	ICETimeout  time.Duration
+}
+
+// CloseWithOptions ends the PeerConnection like Close, after the steps of the
+// options. The steps are skipped if the PeerConnection is already closed, and
+// one that times out is given up without an error.
+func (pc *PeerConnection) CloseWithOptions(options CloseOptions) error {
+	return pc.close(false /* shouldGracefullyClose */, &options)
+}
+
+// closeInOrder runs the steps of the CloseOptions.
+func (pc *PeerConnection) closeInOrder(options *CloseOptions) []error {
+	var closeErrs []error
+
+	if options.SendRTCPBye && pc.dtlsTransport.State() == DTLSTransportStateConnected {
+		if sources := pc.sentSSRCs(); len(sources) != 0 {
+			timeout := closeTimeout(options.RTCPByeTimeout, defaultCloseRTCPByeTimeout)
+			closeErrs = append(closeErrs, pc.closeStep("RTCP BYE", timeout, func() error {
+				return pc.WriteRTCP([]rtcp.Packet{&rtcp.Goodbye{Sources: sources, Reason: options.RTCPByeReason}})
+			}))
+		}
+	}
+
+	if options.CloseDataChannels {
+		timeout := closeTimeout(options.DataChannelsTimeout, defaultCloseDataChannelsTimeout)
+		closeErrs = append(closeErrs, pc.closeDataChannels(timeout)...)
+
+		timeout = closeTimeout(options.SCTPTimeout, defaultCloseSCTPTimeout)
+		if err := pc.sctpTransport.shutdown(timeout); errors.Is(err, context.DeadlineExceeded) {
+			pc.log.Warnf("SCTP association not shut down within %s", timeout)
+		} else if err != nil {
+			closeErrs = append(closeErrs, err)
+		}
+	}
+
+	return closeErrs
+}
+
+// stopTransports sends the DTLS close_notify alert and stops the ICE transport,
+// each within its timeout when closing with CloseOptions.
+func (pc *PeerConnection) stopTransports(options *CloseOptions, stopICE bool) []error {
+	if options == nil {
+		closeErrs := []error{pc.dtlsTransport.Stop()}
+		if stopICE {
+			closeErrs = append(closeErrs, pc.iceTransport.Stop())
+		}
+
+		return closeErrs
+	}
+
+	// The ICE transport is stopped after a DTLS close_notify that timed out, which
+	// ends it by closing its connection.
+	closeErrs := []error{pc.closeStep("DTLS close_notify", closeTimeout(options.DTLSTimeout, defaultCloseDTLSTimeout),
+		pc.dtlsTransport.Stop)}
+	if stopICE {
+		closeErrs = append(closeErrs, pc.closeStep("ICE transport stop",
+			closeTimeout(options.ICETimeout, defaultCloseICETimeout), pc.iceTransport.Stop))
+	}
+
+	return closeErrs
+}
+
+// closeStep runs a step of closing, giving it up without an error after the
+// timeout. A step that is given up keeps running until it is unblocked by the
+// next steps.
+func (pc *PeerConnection) closeStep(name string, timeout time.Duration, step func() error) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- step()
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		pc.log.Warnf("%s not done within %s", name, timeout)
+
+		return nil
+	}
+}
+
+func closeTimeout(timeout, defaultTimeout time.Duration) time.Duration {
+	if timeout == 0 {
+		return defaultTimeout
+	}
+
+	return timeout
+}
+
+// sentSSRCs returns the SSRCs of the streams that were sent.
+func (pc *PeerConnection) sentSSRCs() []uint32 {
+	var sources []uint32
+	for _, sender := range pc.GetSenders() {
+		if !sender.hasSent() || sender.hasStopped() {
+			continue
+		}
+		for _, encoding := range sender.GetParameters().Encodings {
+			sources = append(sources, uint32(encoding.SSRC))
+			if encoding.RTX.SSRC != 0 {
+				sources = append(sources, uint32(encoding.RTX.SSRC))
+			}
+		}
+	}
+
+	return sources
+}
+
+// closeDataChannels closes the DataChannels and waits for their streams to be
+// reset by the remote peer.
+func (pc *PeerConnection) closeDataChannels(timeout time.Duration) []error {
+	pc.sctpTransport.lock.RLock()
+	dataChannels := append([]*DataChannel(nil), pc.sctpTransport.dataChannels...)
+	pc.sctpTransport.lock.RUnlock()
+
+	var closeErrs []error
+	readLoops := make([]chan struct{}, 0, len(dataChannels))
+	for _, d := range dataChannels {
+		closeErrs = append(closeErrs, d.Close())
+
+		d.mu.RLock()
+		if d.readLoopActive != nil {
+			readLoops = append(readLoops, d.readLoopActive)
+		}
+		d.mu.RUnlock()
+	}
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	for _, readLoop := range readLoops {
+		select {
+		case <-readLoop:
+		case <-deadline.C:
+			pc.log.Warnf("DataChannels not closed by the remote peer within %s", timeout)
+
+			return closeErrs
+		}
+	}
+
+	return closeErrs
+}
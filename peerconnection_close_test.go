@@ -6,13 +6,17 @@
 package webrtc
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/pion/rtcp"
 	"github.com/pion/transport/v4/test"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPeerConnection_Close(t *testing.T) {
@@ -286,3 +290,100 @@ func TestPeerConnection_GracefulCloseConcurrent(t *testing.T) {
 		})
 	}
 }
+
+func TestPeerConnection_CloseWithOptions(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	pcOffer, pcAnswer, err := newPair()
+	require.NoError(t, err)
+
+	track, err := NewTrackLocalStaticSample(RTPCodecCapability{MimeType: MimeTypeVP8}, "video", "pion")
+	require.NoError(t, err)
+	sender, err := pcOffer.AddTrack(track)
+	require.NoError(t, err)
+
+	dataChannel, err := pcOffer.CreateDataChannel("data", nil)
+	require.NoError(t, err)
+	opened, openedDone := context.WithCancel(context.Background())
+	dataChannel.OnOpen(openedDone)
+
+	goodbye := make(chan *rtcp.Goodbye, 1)
+	sending, sendingDone := context.WithCancel(context.Background())
+	pcAnswer.OnTrack(func(_ *TrackRemote, receiver *RTPReceiver) {
+		sendingDone()
+		for {
+			packets, _, readErr := receiver.ReadRTCP()
+			if readErr != nil {
+				return
+			}
+			for _, packet := range packets {
+				if bye, ok := packet.(*rtcp.Goodbye); ok {
+					goodbye <- bye
+				}
+			}
+		}
+	})
+	remoteClosed, remoteClosedDone := context.WithCancel(context.Background())
+	pcAnswer.OnDataChannel(func(d *DataChannel) {
+		if d.Label() == "data" {
+			d.OnClose(remoteClosedDone)
+		}
+	})
+
+	go sendVideoUntilDone(t, sending.Done(), []*TrackLocalStaticSample{track})
+	require.NoError(t, signalPair(pcOffer, pcAnswer))
+	<-opened.Done()
+	<-sending.Done()
+
+	start := time.Now()
+	require.NoError(t, pcOffer.CloseWithOptions(CloseOptions{
+		SendRTCPBye:       true,
+		RTCPByeReason:     "done",
+		CloseDataChannels: true,
+	}))
+	// The remote peer closed the DataChannel and acknowledged the SCTP shutdown
+	// before the timeouts.
+	assert.Less(t, time.Since(start), defaultCloseDataChannelsTimeout)
+	// The association that was shut down isn't aborted.
+	assert.NotNil(t, pcOffer.sctpTransport.associationShutDown)
+
+	select {
+	case bye := <-goodbye:
+		assert.Equal(t, "done", bye.Reason)
+		assert.Contains(t, bye.Sources, uint32(sender.GetParameters().Encodings[0].SSRC))
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "no RTCP BYE received")
+	}
+	<-remoteClosed.Done()
+	assert.Equal(t, DataChannelStateClosed, dataChannel.ReadyState())
+
+	require.NoError(t, pcAnswer.Close())
+}
+
+func TestPeerConnection_closeStep(t *testing.T) {
+	pc, err := NewPeerConnection(Configuration{})
+	require.NoError(t, err)
+
+	errStep := errors.New("step failed") //nolint:err113
+	assert.ErrorIs(t, pc.closeStep("failing", time.Second, func() error { return errStep }), errStep)
+
+	// A step that blocks is given up after its timeout.
+	unblock := make(chan struct{})
+	start := time.Now()
+	assert.NoError(t, pc.closeStep("blocking", 50*time.Millisecond, func() error {
+		<-unblock
+
+		return errStep
+	}))
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	close(unblock)
+
+	assert.Equal(t, defaultCloseICETimeout, closeTimeout(0, defaultCloseICETimeout))
+	assert.Equal(t, time.Millisecond, closeTimeout(time.Millisecond, defaultCloseICETimeout))
+
+	require.NoError(t, pc.Close())
+}
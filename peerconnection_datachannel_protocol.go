@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+// OnDataChannelProtocol sets an event handler which is invoked when the remote
+// peer opens a DataChannel whose Protocol is protocol. These channels are not
+// passed to the OnDataChannel handler, which still receives the channels of
+// protocols without handler. The handler is invoked for every channel, so it
+// is where per-channel protocol state is created. A nil handler removes the
+// handler of the protocol.
+func (pc *PeerConnection) OnDataChannelProtocol(protocol string, f func(*DataChannel)) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	if f == nil {
+		delete(pc.dataChannelProtocolHandlers, protocol)
+
+		return
+	}
+
+	if pc.dataChannelProtocolHandlers == nil {
+		pc.dataChannelProtocolHandlers = map[string]func(*DataChannel){}
+	}
+	pc.dataChannelProtocolHandlers[protocol] = f
+}
+
+// onDataChannel dispatches a DataChannel opened by the remote peer to the
+// handler of its protocol, or to the OnDataChannel handler.
+func (pc *PeerConnection) onDataChannel(d *DataChannel) {
+	pc.mu.RLock()
+	handler, ok := pc.dataChannelProtocolHandlers[d.Protocol()]
+	if !ok {
+		handler = pc.onDataChannelHandler
+	}
+	pc.mu.RUnlock()
+
+	if handler != nil {
+		handler(d)
+	}
+}
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerConnection_OnDataChannelProtocol(t *testing.T) {
+	lim := test.TimeOut(time.Second * 20)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	pcOffer, pcAnswer, err := newPair()
+	require.NoError(t, err)
+
+	mqtt := make(chan string, 1)
+	fileTransfer := make(chan string, 1)
+	other := make(chan string, 2)
+
+	pcAnswer.OnDataChannelProtocol("mqtt", func(d *DataChannel) {
+		mqtt <- d.Label()
+	})
+	pcAnswer.OnDataChannelProtocol("filetransfer-v1", func(d *DataChannel) {
+		fileTransfer <- d.Label()
+	})
+	pcAnswer.OnDataChannelProtocol("removed", func(*DataChannel) {
+		assert.Fail(t, "removed protocol handler invoked")
+	})
+	pcAnswer.OnDataChannelProtocol("removed", nil)
+	pcAnswer.OnDataChannel(func(d *DataChannel) {
+		other <- d.Label()
+	})
+
+	for label, protocol := range map[string]string{
+		"broker":  "mqtt",
+		"file":    "filetransfer-v1",
+		"removed": "removed",
+		"plain":   "",
+	} {
+		_, err = pcOffer.CreateDataChannel(label, &DataChannelInit{Protocol: &protocol})
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, signalPair(pcOffer, pcAnswer))
+
+	assert.Equal(t, "broker", <-mqtt)
+	assert.Equal(t, "file", <-fileTransfer)
+	assert.ElementsMatch(t, []string{"removed", "plain"}, []string{<-other, <-other})
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
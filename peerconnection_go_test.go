@@ -8,6 +8,7 @@ package webrtc
 import (
 	"bufio"
 	"context"
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
@@ -307,6 +308,33 @@ func TestPeerConnection_GetConfiguration_Go(t *testing.T) {
 	assert.NoError(t, pc.Close())
 }
 
+func TestPeerConnection_GetConfiguration_Effective(t *testing.T) {
+	pc, err := NewPeerConnection(Configuration{
+		ICEServers: []ICEServer{{URLs: []string{"stun:stun.l.google.com:19302?transport=udp"}}},
+	})
+	assert.NoError(t, err)
+
+	cfg := pc.GetConfiguration()
+	assert.Equal(t, []ICEServer{{URLs: []string{"stun:stun.l.google.com:19302"}}}, cfg.ICEServers)
+	assert.Equal(t, ICETransportPolicyAll, cfg.ICETransportPolicy)
+	assert.Equal(t, BundlePolicyBalanced, cfg.BundlePolicy)
+	assert.Equal(t, RTCPMuxPolicyRequire, cfg.RTCPMuxPolicy)
+	assert.Equal(t, SDPSemanticsUnifiedPlan, cfg.SDPSemantics)
+	assert.Len(t, cfg.Certificates, 1)
+
+	// The returned configuration is a copy.
+	cfg.ICEServers[0].URLs[0] = "stun:example.com"
+	cfg.Certificates[0] = Certificate{}
+	assert.Equal(t, "stun:stun.l.google.com:19302", pc.GetConfiguration().ICEServers[0].URLs[0])
+	assert.NotEqual(t, Certificate{}, pc.GetConfiguration().Certificates[0])
+
+	cfg = pc.GetConfiguration()
+	assert.NoError(t, pc.SetConfiguration(cfg))
+	assert.Equal(t, cfg, pc.GetConfiguration())
+
+	assert.NoError(t, pc.Close())
+}
+
 func TestPeerConnection_EventHandlers_Go(t *testing.T) {
 	lim := test.TimeOut(time.Second * 5)
 	defer lim.Stop()
@@ -1655,12 +1683,12 @@ func TestPeerConnectionNilCallback(t *testing.T) {
 	pc.OnSignalingStateChange(nil)
 	pc.onSignalingStateChange(SignalingStateStable)
 
-	pc.onConnectionStateChange(PeerConnectionStateNew)
+	pc.onConnectionStateChange(PeerConnectionStateNew, ConnectionStateCause{})
 	pc.OnConnectionStateChange(func(PeerConnectionState) {
 		assert.Fail(t, "OnConnectionStateChange called")
 	})
 	pc.OnConnectionStateChange(nil)
-	pc.onConnectionStateChange(PeerConnectionStateNew)
+	pc.onConnectionStateChange(PeerConnectionStateNew, ConnectionStateCause{})
 
 	pc.onICEConnectionStateChange(ICEConnectionStateNew)
 	pc.OnICEConnectionStateChange(func(ICEConnectionState) {
@@ -2712,3 +2740,40 @@ func TestSctpSnap(t *testing.T) {
 
 	closePairNow(t, offer, answer)
 }
+
+func TestPeerConnection_FingerprintAlgorithms(t *testing.T) {
+	lim := test.TimeOut(time.Second * 20)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	s := SettingEngine{}
+	require.NoError(t, s.SetDTLSFingerprintAlgorithms(crypto.SHA384, crypto.SHA512))
+	pcOffer, err := NewAPI(WithSettingEngine(s)).NewPeerConnection(Configuration{})
+	require.NoError(t, err)
+	pcAnswer, err := NewPeerConnection(Configuration{})
+	require.NoError(t, err)
+
+	connected := untilConnectionState(PeerConnectionStateConnected, pcOffer, pcAnswer)
+
+	var offerFingerprints []string
+	require.NoError(t, signalPairWithModification(pcOffer, pcAnswer, func(sdp string) string {
+		offerFingerprints = regexp.MustCompile(`a=fingerprint:(\S+) `).FindAllString(sdp, -1)
+
+		// The remote peer ignores fingerprints with unsupported hash algorithms.
+		return strings.Replace(sdp, "a=fingerprint:", "a=fingerprint:sha-3 00:00\r\na=fingerprint:", 1)
+	}))
+	connected.Wait()
+
+	assert.Equal(t, []string{"a=fingerprint:sha-384 ", "a=fingerprint:sha-512 "}, offerFingerprints)
+
+	// The answerer has the default fingerprints, SHA-256 first.
+	answerFingerprints := regexp.MustCompile(`a=fingerprint:(\S+) `).
+		FindAllString(pcAnswer.CurrentLocalDescription().SDP, -1)
+	assert.Equal(t, []string{
+		"a=fingerprint:sha-256 ", "a=fingerprint:sha-384 ", "a=fingerprint:sha-512 ",
+	}, answerFingerprints)
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
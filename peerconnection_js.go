@@ -772,6 +772,10 @@ func dataChannelInitToValue(options *DataChannelInit) js.Value {
 	}
 
 	maxPacketLifeTime := uint16PointerToValue(options.MaxPacketLifeTime)
+	priority := js.Undefined()
+	if options.Priority != nil {
+		priority = js.ValueOf(options.Priority.String())
+	}
 	return js.ValueOf(map[string]any{
 		"ordered":           boolPointerToValue(options.Ordered),
 		"maxPacketLifeTime": maxPacketLifeTime,
@@ -782,6 +786,7 @@ func dataChannelInitToValue(options *DataChannelInit) js.Value {
 		"protocol":          stringPointerToValue(options.Protocol),
 		"negotiated":        boolPointerToValue(options.Negotiated),
 		"id":                uint16PointerToValue(options.ID),
+		"priority":          priority,
 	})
 }
 
@@ -2751,3 +2751,92 @@ func TestPeerConnection_Simulcast_Probe_PacketLoss(t *testing.T) { //nolint:cycl
 	assert.NoError(t, wan.Stop())
 	closePairNow(t, pcOffer, pcAnswer)
 }
+
+func TestPeerConnection_Media_Cryptex(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	newCryptexPair := func(offerCryptex, answerCryptex bool) (*PeerConnection, *PeerConnection) {
+		offerSettingEngine := SettingEngine{}
+		offerSettingEngine.EnableCryptex(offerCryptex)
+		answerSettingEngine := SettingEngine{}
+		answerSettingEngine.EnableCryptex(answerCryptex)
+
+		pcOffer, err := NewAPI(WithSettingEngine(offerSettingEngine)).NewPeerConnection(Configuration{})
+		require.NoError(t, err)
+		pcAnswer, err := NewAPI(WithSettingEngine(answerSettingEngine)).NewPeerConnection(Configuration{})
+		require.NoError(t, err)
+
+		return pcOffer, pcAnswer
+	}
+
+	t.Run("Negotiated", func(t *testing.T) {
+		pcOffer, pcAnswer := newCryptexPair(true, true)
+
+		track, err := NewTrackLocalStaticRTP(RTPCodecCapability{MimeType: MimeTypeOpus}, "audio", "pion")
+		require.NoError(t, err)
+		_, err = pcOffer.AddTrack(track)
+		require.NoError(t, err)
+
+		received := make(chan *rtp.Packet, 1)
+		pcAnswer.OnTrack(func(remote *TrackRemote, _ *RTPReceiver) {
+			packet, _, readErr := remote.ReadRTP()
+			if readErr == nil {
+				received <- packet
+			}
+		})
+
+		require.NoError(t, signalPair(pcOffer, pcAnswer))
+		assert.Contains(t, pcOffer.LocalDescription().SDP, "a=cryptex\r\n")
+		assert.Contains(t, pcAnswer.LocalDescription().SDP, "a=cryptex\r\n")
+
+		// The header extension is decrypted by the answerer.
+		packet := &rtp.Packet{Header: rtp.Header{Version: 2}, Payload: []byte{0x00}}
+		require.NoError(t, packet.Header.SetExtension(1, []byte("sensitive")))
+		func() {
+			ticker := time.NewTicker(20 * time.Millisecond)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case packet := <-received:
+					assert.Equal(t, []byte("sensitive"), packet.Header.GetExtension(1))
+
+					return
+				case <-ticker.C:
+					require.NoError(t, track.WriteRTP(packet))
+				}
+			}
+		}()
+
+		for _, pc := range []*PeerConnection{pcOffer, pcAnswer} {
+			pc.dtlsTransport.lock.RLock()
+			assert.True(t, pc.dtlsTransport.cryptex)
+			pc.dtlsTransport.lock.RUnlock()
+		}
+
+		closePairNow(t, pcOffer, pcAnswer)
+	})
+
+	t.Run("Answerer Disabled", func(t *testing.T) {
+		pcOffer, pcAnswer := newCryptexPair(true, false)
+
+		_, err := pcOffer.AddTransceiverFromKind(RTPCodecTypeAudio)
+		require.NoError(t, err)
+
+		require.NoError(t, signalPair(pcOffer, pcAnswer))
+		assert.Contains(t, pcOffer.LocalDescription().SDP, "a=cryptex\r\n")
+		assert.NotContains(t, pcAnswer.LocalDescription().SDP, "a=cryptex")
+
+		for _, pc := range []*PeerConnection{pcOffer, pcAnswer} {
+			pc.dtlsTransport.lock.RLock()
+			assert.False(t, pc.dtlsTransport.cryptex)
+			pc.dtlsTransport.lock.RUnlock()
+		}
+
+		closePairNow(t, pcOffer, pcAnswer)
+	})
+}
@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ConnectionPhaseTimeouts are the deadlines of the phases of establishing a
+// PeerConnection, see SettingEngine.SetConnectionPhaseTimeouts. A zero
+// deadline disables it.
+type ConnectionPhaseTimeouts struct {
+	// Gathering starts when ICE candidates are gathered and ends once
+	// gathering is complete or ICE is connected, a PeerConnection doesn't
+	// fail while gathering continues after a candidate pair succeeded.
+	Gathering time.Duration
+
+	// ICE starts when the ICE transport is started or restarted and ends once
+	// it is connected.
+	ICE time.Duration
+
+	// DTLS starts with the DTLS handshake and ends once it is connected.
+	DTLS time.Duration
+
+	// SCTP starts with the SCTP association and ends once it is established.
+	SCTP time.Duration
+}
+
+func (t ConnectionPhaseTimeouts) get(phase ConnectionPhase) time.Duration {
+	switch phase {
+	case ConnectionPhaseGathering:
+		return t.Gathering
+	case ConnectionPhaseICE:
+		return t.ICE
+	case ConnectionPhaseDTLS:
+		return t.DTLS
+	case ConnectionPhaseSCTP:
+		return t.SCTP
+	default:
+		return 0
+	}
+}
+
+// PhaseTimeoutError is the cause of a PeerConnection moving to Failed because a
+// phase didn't complete within its deadline.
+type PhaseTimeoutError struct {
+	Phase   ConnectionPhase
+	Timeout time.Duration
+}
+
+func (e *PhaseTimeoutError) Error() string {
+	return fmt.Sprintf("%s: %s phase exceeded %s", ErrConnectionPhaseTimeout, e.Phase, e.Timeout)
+}
+
+// Unwrap returns ErrConnectionPhaseTimeout.
+func (e *PhaseTimeoutError) Unwrap() error {
+	return ErrConnectionPhaseTimeout
+}
+
+type phaseTimers struct {
+	mu     sync.Mutex
+	timers map[ConnectionPhase]*time.Timer
+	err    *PhaseTimeoutError
+	closed bool
+}
+
+// startPhaseTimer starts the deadline of a phase, restarting it if the phase
+// was already running.
+func (pc *PeerConnection) startPhaseTimer(phase ConnectionPhase) {
+	timeout := pc.api.settingEngine.timeout.ConnectionPhases.get(phase)
+	if timeout <= 0 {
+		return
+	}
+
+	pc.phaseTimers.mu.Lock()
+	defer pc.phaseTimers.mu.Unlock()
+
+	if pc.phaseTimers.closed {
+		return
+	}
+
+	if pc.phaseTimers.timers == nil {
+		pc.phaseTimers.timers = map[ConnectionPhase]*time.Timer{}
+	}
+	if timer, ok := pc.phaseTimers.timers[phase]; ok {
+		timer.Stop()
+	}
+
+	pc.phaseTimers.timers[phase] = time.AfterFunc(timeout, func() {
+		if pc.phaseComplete(phase) {
+			return
+		}
+
+		pc.phaseTimers.mu.Lock()
+		if pc.phaseTimers.closed {
+			pc.phaseTimers.mu.Unlock()
+
+			return
+		}
+		pc.phaseTimers.err = &PhaseTimeoutError{Phase: phase, Timeout: timeout}
+		pc.phaseTimers.mu.Unlock()
+
+		pc.log.Warnf("%s phase did not complete within %s", phase, timeout)
+		pc.updateConnectionState(pc.ICEConnectionState(), pc.dtlsTransport.State())
+	})
+}
+
+func (pc *PeerConnection) stopPhaseTimers() {
+	pc.phaseTimers.mu.Lock()
+	defer pc.phaseTimers.mu.Unlock()
+
+	pc.phaseTimers.closed = true
+	for _, timer := range pc.phaseTimers.timers {
+		timer.Stop()
+	}
+}
+
+func (pc *PeerConnection) phaseComplete(phase ConnectionPhase) bool {
+	switch phase {
+	case ConnectionPhaseGathering:
+		return pc.iceGatherer.State() == ICEGathererStateComplete || pc.phaseComplete(ConnectionPhaseICE)
+	case ConnectionPhaseICE:
+		state := pc.ICEConnectionState()
+
+		return state == ICEConnectionStateConnected || state == ICEConnectionStateCompleted
+	case ConnectionPhaseDTLS:
+		return pc.dtlsTransport.State() == DTLSTransportStateConnected
+	case ConnectionPhaseSCTP:
+		return pc.sctpTransport.State() == SCTPTransportStateConnected
+	default:
+		return true
+	}
+}
+
+// phaseTimeout returns the PhaseTimeoutError of a phase that exceeded its
+// deadline. It is cleared once the phase completes later, like after an ICE
+// restart.
+func (pc *PeerConnection) phaseTimeout() *PhaseTimeoutError {
+	pc.phaseTimers.mu.Lock()
+	err := pc.phaseTimers.err
+	pc.phaseTimers.mu.Unlock()
+
+	if err == nil || !pc.phaseComplete(err.Phase) {
+		return err
+	}
+
+	pc.phaseTimers.mu.Lock()
+	if pc.phaseTimers.err == err {
+		pc.phaseTimers.err = nil
+	}
+	pc.phaseTimers.mu.Unlock()
+
+	return nil
+}
+
+// ConnectionFailureCause returns the error of the ConnectionStateCause while the
+// PeerConnection is Failed, like a *PhaseTimeoutError when a phase exceeded its
+// deadline, see SettingEngine.SetConnectionPhaseTimeouts, and nil otherwise.
+func (pc *PeerConnection) ConnectionFailureCause() error {
+	if pc.ConnectionState() != PeerConnectionStateFailed {
+		return nil
+	}
+
+	cause, _ := pc.connectionStateCause.Load().(ConnectionStateCause)
+
+	return cause.Err
+}
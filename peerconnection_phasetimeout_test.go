@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPhaseTimeoutError(t *testing.T) {
+	err := &PhaseTimeoutError{Phase: ConnectionPhaseDTLS, Timeout: time.Second}
+	assert.ErrorIs(t, err, ErrConnectionPhaseTimeout)
+	assert.Equal(t, "connection phase timed out: dtls phase exceeded 1s", err.Error())
+	assert.Equal(t, ErrUnknownType.Error(), ConnectionPhaseUnknown.String())
+}
+
+func TestPeerConnection_ConnectionPhaseTimeout(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	// Without remote candidates the connectivity checks never succeed.
+	settingEngine := SettingEngine{}
+	settingEngine.SetRemoteIPFilter(func(net.IP) bool { return false })
+	settingEngine.SetConnectionPhaseTimeouts(ConnectionPhaseTimeouts{ICE: 200 * time.Millisecond})
+
+	pcOffer, pcAnswer, err := NewAPI(WithSettingEngine(settingEngine)).newPair(Configuration{})
+	require.NoError(t, err)
+
+	failed := make(chan struct{})
+	pcOffer.OnConnectionStateChange(func(state PeerConnectionState) {
+		if state == PeerConnectionStateFailed {
+			close(failed)
+		}
+	})
+
+	_, err = pcOffer.CreateDataChannel("data", nil)
+	require.NoError(t, err)
+	require.NoError(t, signalPair(pcOffer, pcAnswer))
+
+	<-failed
+	assert.Equal(t, ICEConnectionStateChecking, pcOffer.ICEConnectionState())
+
+	var timeoutErr *PhaseTimeoutError
+	require.ErrorAs(t, pcOffer.ConnectionFailureCause(), &timeoutErr)
+	assert.Equal(t, ConnectionPhaseICE, timeoutErr.Phase)
+	assert.Equal(t, 200*time.Millisecond, timeoutErr.Timeout)
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
+
+func TestPeerConnection_ConnectionPhaseTimeoutNotExceeded(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	settingEngine := SettingEngine{}
+	settingEngine.SetConnectionPhaseTimeouts(ConnectionPhaseTimeouts{
+		Gathering: 5 * time.Second,
+		ICE:       5 * time.Second,
+		DTLS:      5 * time.Second,
+		SCTP:      5 * time.Second,
+	})
+
+	pcOffer, pcAnswer, err := NewAPI(WithSettingEngine(settingEngine)).newPair(Configuration{})
+	require.NoError(t, err)
+
+	dataChannel, err := pcOffer.CreateDataChannel("data", nil)
+	require.NoError(t, err)
+
+	opened := make(chan struct{})
+	dataChannel.OnOpen(func() {
+		close(opened)
+	})
+
+	require.NoError(t, signalPair(pcOffer, pcAnswer))
+	<-opened
+
+	assert.Equal(t, PeerConnectionStateConnected, pcOffer.ConnectionState())
+	assert.NoError(t, pcOffer.ConnectionFailureCause())
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
+
+func TestPeerConnection_GatheringTimeoutAfterConnected(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	settingEngine := SettingEngine{}
+	settingEngine.SetSTUNGatherTimeout(3 * time.Second)
+	settingEngine.SetConnectionPhaseTimeouts(ConnectionPhaseTimeouts{Gathering: 500 * time.Millisecond})
+	api := NewAPI(WithSettingEngine(settingEngine))
+
+	// The STUN server never responds, so the offerer is still gathering when
+	// the connectivity checks with the candidates of the answer succeed.
+	pcOffer, err := api.NewPeerConnection(Configuration{
+		ICEServers: []ICEServer{{URLs: []string{"stun:127.0.0.1:9"}}},
+	})
+	require.NoError(t, err)
+	pcAnswer, err := api.NewPeerConnection(Configuration{})
+	require.NoError(t, err)
+
+	connected := make(chan struct{})
+	pcOffer.OnConnectionStateChange(func(state PeerConnectionState) {
+		if state == PeerConnectionStateConnected {
+			close(connected)
+		}
+	})
+
+	_, err = pcOffer.CreateDataChannel("data", nil)
+	require.NoError(t, err)
+
+	offer, err := pcOffer.CreateOffer(nil)
+	require.NoError(t, err)
+	require.NoError(t, pcOffer.SetLocalDescription(offer))
+	require.NoError(t, pcAnswer.SetRemoteDescription(offer))
+
+	answer, err := pcAnswer.CreateAnswer(nil)
+	require.NoError(t, err)
+	answerGatheringComplete := GatheringCompletePromise(pcAnswer)
+	require.NoError(t, pcAnswer.SetLocalDescription(answer))
+	<-answerGatheringComplete
+	require.NoError(t, pcOffer.SetRemoteDescription(*pcAnswer.LocalDescription()))
+
+	<-connected
+	require.Equal(t, ICEGatheringStateGathering, pcOffer.ICEGatheringState())
+
+	time.Sleep(time.Second)
+	assert.Equal(t, PeerConnectionStateConnected, pcOffer.ConnectionState())
+	assert.NoError(t, pcOffer.ConnectionFailureCause())
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
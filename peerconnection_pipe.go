@@ -0,0 +1,238 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"sync"
+
+	"github.com/pion/rtcp"
+)
+
+// PipeOptions configures a PeerConnectionPipe.
+type PipeOptions struct {
+	// Signal sends an offer of one of the PeerConnections to its remote peer,
+	// after the PeerConnectionPipe added or removed tracks or DataChannels. The
+	// answer has to be applied with SetRemoteDescription. Signal is required.
+	Signal func(pc *PeerConnection, offer SessionDescription) error
+
+	// MaxQueuedBytes is how many bytes of messages are queued for a piped
+	// DataChannel until the DataChannel created for it is open, one MiB when
+	// zero. Both DataChannels are closed when a message doesn't fit anymore.
+	MaxQueuedBytes int
+}
+
+const defaultPipeMaxQueuedBytes = 1 << 20
+
+// PeerConnectionPipe connects two PeerConnections back to back, like a gateway
+// with one PeerConnection toward a browser and one toward another service. The
+// tracks and DataChannels the remote peer of one PeerConnection creates are
+// created on the other one, and removed again when the remote peer removes them.
+//
+// The PeerConnectionPipe sets the OnTrack, OnDataChannel and
+// OnNegotiationNeeded handlers of both PeerConnections. It creates the offers
+// after mirroring, answering the offers of the remote peers is left to the
+// application. Simulcast encodings are piped as separate tracks.
+type PeerConnectionPipe struct {
+	options PipeOptions
+
+	mu     sync.Mutex
+	closed bool
+	pcs    [2]*PeerConnection
+}
+
+// NewPeerConnectionPipe starts piping the tracks and DataChannels between the
+// PeerConnections, until the PeerConnectionPipe is closed.
+func NewPeerConnectionPipe(a, b *PeerConnection, options PipeOptions) (*PeerConnectionPipe, error) {
+	if options.Signal == nil {
+		return nil, errPipeNoSignal
+	}
+	if options.MaxQueuedBytes <= 0 {
+		options.MaxQueuedBytes = defaultPipeMaxQueuedBytes
+	}
+
+	pipe := &PeerConnectionPipe{options: options, pcs: [2]*PeerConnection{a, b}}
+	for i, pc := range pipe.pcs {
+		dst := pipe.pcs[1-i]
+		pc.OnTrack(func(track *TrackRemote, _ *RTPReceiver) {
+			pipe.pipeTrack(pc, dst, track)
+		})
+		pc.OnDataChannel(func(d *DataChannel) {
+			pipe.pipeDataChannel(dst, d)
+		})
+		pc.OnNegotiationNeeded(func() {
+			pipe.negotiate(pc)
+		})
+	}
+
+	return pipe, nil
+}
+
+// Close stops piping new tracks and DataChannels. The piped ones stay piped
+// until they end, the PeerConnections aren't closed.
+func (p *PeerConnectionPipe) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil
+	}
+	p.closed = true
+	for _, pc := range p.pcs {
+		pc.OnTrack(func(*TrackRemote, *RTPReceiver) {})
+		pc.OnDataChannel(func(*DataChannel) {})
+		pc.OnNegotiationNeeded(func() {})
+	}
+
+	return nil
+}
+
+func (p *PeerConnectionPipe) isClosed() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.closed
+}
+
+func (p *PeerConnectionPipe) negotiate(pc *PeerConnection) {
+	if p.isClosed() || pc.SignalingState() != SignalingStateStable {
+		return
+	}
+
+	offer, err := pc.CreateOffer(nil)
+	if err == nil {
+		err = pc.SetLocalDescription(offer)
+	}
+	if err == nil {
+		err = p.options.Signal(pc, offer)
+	}
+	if err != nil {
+		pc.log.Warnf("PeerConnectionPipe failed to negotiate: %v", err)
+	}
+}
+
+// pipeTrack forwards the RTP packets of the track to a track of dst, and the
+// picture loss indications of the remote peer of dst back to src.
+func (p *PeerConnectionPipe) pipeTrack(src, dst *PeerConnection, track *TrackRemote) {
+	forwarder, err := NewTrackLocalForwarder(track.Codec().RTPCodecCapability, track.ID(), track.StreamID())
+	if err != nil {
+		return
+	}
+
+	sender, err := dst.AddTrack(forwarder)
+	if err != nil {
+		dst.log.Warnf("PeerConnectionPipe failed to add track %s: %v", track.ID(), err)
+
+		return
+	}
+
+	go func() {
+		for {
+			pkts, _, err := sender.ReadRTCP()
+			if err != nil {
+				return
+			}
+			for _, pkt := range pkts {
+				switch pkt.(type) {
+				case *rtcp.PictureLossIndication, *rtcp.FullIntraRequest:
+					_ = src.WriteRTCP([]rtcp.Packet{&rtcp.PictureLossIndication{MediaSSRC: uint32(track.SSRC())}})
+				}
+			}
+		}
+	}()
+
+	go func() {
+		for {
+			packet, _, err := track.ReadRTP()
+			if err != nil {
+				break
+			}
+			_ = forwarder.WriteRTP(packet)
+		}
+
+		if dst.ConnectionState() != PeerConnectionStateClosed {
+			_ = dst.RemoveTrack(sender)
+		}
+	}()
+}
+
+// pipeDataChannel creates a DataChannel like d on dst and forwards the messages
+// between them. The messages of d are queued until the new DataChannel is open,
+// up to PipeOptions.MaxQueuedBytes.
+func (p *PeerConnectionPipe) pipeDataChannel(dst *PeerConnection, d *DataChannel) {
+	ordered := d.Ordered()
+	protocol := d.Protocol()
+	mirror, err := dst.CreateDataChannel(d.Label(), &DataChannelInit{
+		Ordered:           &ordered,
+		MaxPacketLifeTime: d.MaxPacketLifeTime(),
+		MaxRetransmits:    d.MaxRetransmits(),
+		Protocol:          &protocol,
+	})
+	if err != nil {
+		dst.log.Warnf("PeerConnectionPipe failed to create DataChannel %s: %v", d.Label(), err)
+		_ = d.Close()
+
+		return
+	}
+
+	var (
+		mu          sync.Mutex
+		open        bool
+		queued      []DataChannelMessage
+		queuedBytes int
+	)
+	send := func(to *DataChannel, msg DataChannelMessage) {
+		if msg.IsString {
+			_ = to.SendText(string(msg.Data))
+		} else {
+			_ = to.Send(msg.Data)
+		}
+	}
+
+	d.OnMessage(func(msg DataChannelMessage) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if open {
+			send(mirror, msg)
+
+			return
+		}
+
+		if queuedBytes+len(msg.Data) > p.options.MaxQueuedBytes {
+			dst.log.Warnf("PeerConnectionPipe queued too many messages of DataChannel %s, closing it", d.Label())
+			queued, queuedBytes = nil, 0
+			_ = d.Close()
+			_ = mirror.Close()
+
+			return
+		}
+
+		// The read buffer may be reused for the next message while this one is queued.
+		msg.Data = append([]byte{}, msg.Data...)
+		queued = append(queued, msg)
+		queuedBytes += len(msg.Data)
+	})
+	mirror.OnOpen(func() {
+		mu.Lock()
+		defer mu.Unlock()
+
+		open = true
+		for _, msg := range queued {
+			send(mirror, msg)
+		}
+		queued, queuedBytes = nil, 0
+	})
+	mirror.OnMessage(func(msg DataChannelMessage) {
+		send(d, msg)
+	})
+
+	d.OnClose(func() {
+		_ = mirror.Close()
+	})
+	mirror.OnClose(func() {
+		_ = d.Close()
+	})
+}
@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerConnectionPipe(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	// browser <-> gatewayIn | gatewayOut <-> service
+	browser, gatewayIn, err := newPair()
+	require.NoError(t, err)
+	gatewayOut, service, err := newPair()
+	require.NoError(t, err)
+
+	connected := untilConnectionState(PeerConnectionStateConnected, browser, gatewayIn, gatewayOut, service)
+	require.NoError(t, signalPair(browser, gatewayIn))
+	require.NoError(t, signalPair(gatewayOut, service))
+	connected.Wait()
+
+	remotes := map[*PeerConnection]*PeerConnection{gatewayIn: browser, gatewayOut: service}
+	pipe, err := NewPeerConnectionPipe(gatewayIn, gatewayOut, PipeOptions{
+		Signal: func(pc *PeerConnection, offer SessionDescription) error {
+			remote := remotes[pc]
+			if err := remote.SetRemoteDescription(offer); err != nil {
+				return err
+			}
+			answer, err := remote.CreateAnswer(nil)
+			if err != nil {
+				return err
+			}
+			if err = remote.SetLocalDescription(answer); err != nil {
+				return err
+			}
+
+			return pc.SetRemoteDescription(answer)
+		},
+	})
+	require.NoError(t, err)
+
+	// The track of the browser is piped to the service, until it is removed.
+	track, err := NewTrackLocalStaticSample(RTPCodecCapability{MimeType: MimeTypeVP8}, "video", "browser")
+	require.NoError(t, err)
+	sender, err := browser.AddTrack(track)
+	require.NoError(t, err)
+
+	trackReceived, trackReceivedDone := context.WithCancel(context.Background())
+	trackEnded, trackEndedDone := context.WithCancel(context.Background())
+	service.OnTrack(func(remote *TrackRemote, _ *RTPReceiver) {
+		assert.Equal(t, "video", remote.ID())
+		assert.Equal(t, "browser", remote.StreamID())
+		for {
+			if _, _, readErr := remote.ReadRTP(); readErr != nil {
+				assert.True(t, errors.Is(readErr, io.EOF), readErr)
+				trackEndedDone()
+
+				return
+			}
+			trackReceivedDone()
+		}
+	})
+
+	// The DataChannel of the browser is piped to the service, which echoes.
+	service.OnDataChannel(func(d *DataChannel) {
+		// The initial DataChannels of the pairs may be received late.
+		if d.Label() != "chat" {
+			return
+		}
+		assert.Equal(t, "proto", d.Protocol())
+		d.OnMessage(func(msg DataChannelMessage) {
+			assert.NoError(t, d.SendText("echo "+string(msg.Data)))
+		})
+	})
+
+	protocol := "proto"
+	chat, err := browser.CreateDataChannel("chat", &DataChannelInit{Protocol: &protocol})
+	require.NoError(t, err)
+	echoed := make(chan string, 1)
+	chat.OnOpen(func() {
+		assert.NoError(t, chat.SendText("hello"))
+	})
+	chat.OnMessage(func(msg DataChannelMessage) {
+		echoed <- string(msg.Data)
+	})
+
+	require.NoError(t, signalPair(browser, gatewayIn))
+	sendVideoUntilDone(t, trackReceived.Done(), []*TrackLocalStaticSample{track})
+	assert.Equal(t, "echo hello", <-echoed)
+
+	require.NoError(t, browser.RemoveTrack(sender))
+	require.NoError(t, signalPair(browser, gatewayIn))
+	<-trackEnded.Done()
+
+	assert.NoError(t, pipe.Close())
+	closePairNow(t, browser, gatewayIn)
+	closePairNow(t, gatewayOut, service)
+}
+
+func TestNewPeerConnectionPipe_NoSignal(t *testing.T) {
+	_, err := NewPeerConnectionPipe(nil, nil, PipeOptions{})
+	assert.ErrorIs(t, err, errPipeNoSignal)
+}
+
+func TestPeerConnectionPipe_QueueLimit(t *testing.T) {
+	src, err := NewPeerConnection(Configuration{})
+	require.NoError(t, err)
+	dst, err := NewPeerConnection(Configuration{})
+	require.NoError(t, err)
+
+	d, err := src.CreateDataChannel("queued", nil)
+	require.NoError(t, err)
+
+	// dst isn't connected, so the messages of d are queued.
+	pipe := &PeerConnectionPipe{options: PipeOptions{MaxQueuedBytes: 4}}
+	pipe.pipeDataChannel(dst, d)
+
+	d.onMessage(DataChannelMessage{Data: []byte("abc")})
+	assert.Equal(t, DataChannelStateConnecting, d.ReadyState())
+
+	d.onMessage(DataChannelMessage{Data: []byte("de")})
+	assert.Equal(t, DataChannelStateClosing, d.ReadyState())
+
+	assert.NoError(t, src.Close())
+	assert.NoError(t, dst.Close())
+}
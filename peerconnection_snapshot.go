@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"time"
+)
+
+// PeerConnectionSnapshot is the state of a PeerConnection at one point in time,
+// see PeerConnection.Snapshot. It is a copy that doesn't change afterwards.
+type PeerConnectionSnapshot struct {
+	Timestamp time.Time
+
+	SignalingState     SignalingState
+	ICEGatheringState  ICEGatheringState
+	ICEConnectionState ICEConnectionState
+	ConnectionState    PeerConnectionState
+	DTLSState          DTLSTransportState
+	SCTPState          SCTPTransportState
+
+	// SelectedCandidatePair is the selected ICE candidate pair, nil if no pair
+	// is selected yet.
+	SelectedCandidatePair *ICECandidatePair
+
+	// BytesSent and BytesReceived are the bytes sent and received on the selected
+	// candidate pair.
+	BytesSent     uint64
+	BytesReceived uint64
+
+	// CurrentRoundTripTime is the latest round trip time of the selected candidate
+	// pair in seconds.
+	CurrentRoundTripTime float64
+
+	Transceivers []RTPTransceiverSnapshot
+}
+
+// RTPTransceiverSnapshot is the state of an RTPTransceiver in a
+// PeerConnectionSnapshot.
+type RTPTransceiverSnapshot struct {
+	Mid              string
+	Kind             RTPCodecType
+	Direction        RTPTransceiverDirection
+	CurrentDirection RTPTransceiverDirection
+
+	// Codecs are the codecs of the transceiver, the negotiated ones once the
+	// remote description is set.
+	Codecs []RTPCodecParameters
+
+	// SenderTrackID is the ID of the track that is sent, empty if none is.
+	SenderTrackID string
+
+	// SenderSSRCs are the SSRCs of the encodings that are sent.
+	SenderSSRCs []SSRC
+
+	// ReceiverTrackIDs are the IDs of the tracks that are received.
+	ReceiverTrackIDs []string
+}
+
+// Snapshot returns the transceivers, states and key statistics of the
+// PeerConnection at once. They are read while holding the lock of the
+// PeerConnection, so unlike several calls of the getters the transceivers can't
+// change in between. This is meant for debugging dumps and admin APIs, use
+// GetStats for detailed statistics.
+func (pc *PeerConnection) Snapshot() PeerConnectionSnapshot {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	snapshot := PeerConnectionSnapshot{
+		Timestamp:          time.Now(),
+		SignalingState:     pc.SignalingState(),
+		ICEGatheringState:  pc.ICEGatheringState(),
+		ICEConnectionState: pc.ICEConnectionState(),
+		ConnectionState:    pc.ConnectionState(),
+		DTLSState:          pc.dtlsTransport.State(),
+		SCTPState:          pc.sctpTransport.State(),
+	}
+
+	if pair, err := pc.iceTransport.GetSelectedCandidatePair(); err == nil {
+		snapshot.SelectedCandidatePair = pair
+	}
+	if stats, ok := pc.iceGatherer.getSelectedCandidatePairStats(); ok {
+		snapshot.BytesSent = stats.BytesSent
+		snapshot.BytesReceived = stats.BytesReceived
+		snapshot.CurrentRoundTripTime = stats.CurrentRoundTripTime
+	}
+
+	snapshot.Transceivers = make([]RTPTransceiverSnapshot, 0, len(pc.rtpTransceivers))
+	for _, transceiver := range pc.rtpTransceivers {
+		snapshot.Transceivers = append(snapshot.Transceivers, transceiver.snapshot())
+	}
+
+	return snapshot
+}
+
+func (t *RTPTransceiver) snapshot() RTPTransceiverSnapshot {
+	snapshot := RTPTransceiverSnapshot{
+		Mid:              t.Mid(),
+		Kind:             t.Kind(),
+		Direction:        t.Direction(),
+		CurrentDirection: t.getCurrentDirection(),
+		Codecs:           t.getCodecs(),
+	}
+
+	if sender := t.Sender(); sender != nil {
+		if track := sender.Track(); track != nil {
+			snapshot.SenderTrackID = track.ID()
+		}
+		for _, encoding := range sender.GetParameters().Encodings {
+			snapshot.SenderSSRCs = append(snapshot.SenderSSRCs, encoding.SSRC)
+		}
+	}
+
+	if receiver := t.Receiver(); receiver != nil {
+		for _, track := range receiver.Tracks() {
+			snapshot.ReceiverTrackIDs = append(snapshot.ReceiverTrackIDs, track.ID())
+		}
+	}
+
+	return snapshot
+}
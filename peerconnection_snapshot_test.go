@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerConnection_Snapshot(t *testing.T) {
+	lim := test.TimeOut(time.Second * 20)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	pcOffer, pcAnswer, err := newPair()
+	require.NoError(t, err)
+
+	snapshot := pcOffer.Snapshot()
+	assert.Equal(t, SignalingStateStable, snapshot.SignalingState)
+	assert.Equal(t, PeerConnectionStateNew, snapshot.ConnectionState)
+	assert.Nil(t, snapshot.SelectedCandidatePair)
+	assert.Empty(t, snapshot.Transceivers)
+
+	track, err := NewTrackLocalStaticSample(RTPCodecCapability{MimeType: MimeTypeVP8}, "video", "pion")
+	require.NoError(t, err)
+	_, err = pcOffer.AddTrack(track)
+	require.NoError(t, err)
+
+	onTrack, onTrackFired := context.WithCancel(context.Background())
+	pcAnswer.OnTrack(func(*TrackRemote, *RTPReceiver) {
+		onTrackFired()
+	})
+
+	connected := untilConnectionState(PeerConnectionStateConnected, pcOffer, pcAnswer)
+	require.NoError(t, signalPair(pcOffer, pcAnswer))
+	connected.Wait()
+	sendVideoUntilDone(t, onTrack.Done(), []*TrackLocalStaticSample{track})
+
+	snapshot = pcOffer.Snapshot()
+	assert.Equal(t, PeerConnectionStateConnected, snapshot.ConnectionState)
+	assert.Equal(t, ICEConnectionStateConnected, snapshot.ICEConnectionState)
+	assert.Equal(t, DTLSTransportStateConnected, snapshot.DTLSState)
+	assert.NotNil(t, snapshot.SelectedCandidatePair)
+	assert.NotZero(t, snapshot.BytesSent)
+	require.Len(t, snapshot.Transceivers, 1)
+
+	sent := snapshot.Transceivers[0]
+	assert.Equal(t, "0", sent.Mid)
+	assert.Equal(t, RTPCodecTypeVideo, sent.Kind)
+	assert.Equal(t, "video", sent.SenderTrackID)
+	require.Len(t, sent.SenderSSRCs, 1)
+	assert.NotZero(t, sent.SenderSSRCs[0])
+	require.NotEmpty(t, sent.Codecs)
+	assert.Equal(t, MimeTypeVP8, sent.Codecs[0].MimeType)
+
+	snapshot = pcAnswer.Snapshot()
+	require.Len(t, snapshot.Transceivers, 1)
+	assert.Equal(t, []string{"video"}, snapshot.Transceivers[0].ReceiverTrackIDs)
+	assert.Empty(t, snapshot.Transceivers[0].SenderTrackID)
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
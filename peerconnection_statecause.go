@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+// ConnectionStateCause describes why a PeerConnection changed its state.
+type ConnectionStateCause struct {
+	// Transport is the phase whose transport caused a Failed or Disconnected
+	// state, ConnectionPhaseUnknown otherwise.
+	Transport ConnectionPhase
+
+	// Err is the underlying error, like the DTLS handshake error or a
+	// PhaseTimeoutError. It is nil if the transport failed without an error,
+	// like ICE when no candidate pair works.
+	Err error
+
+	// SelectedCandidatePair is the most recently selected ICE candidate pair,
+	// which is kept once ICE failed. It is nil if no pair was selected yet.
+	SelectedCandidatePair *ICECandidatePair
+}
+
+// OnConnectionStateChangeWithCause sets an event handler which is called when
+// the PeerConnectionState has changed, with the cause of the change. It is
+// called in addition to the handler set by OnConnectionStateChange.
+func (pc *PeerConnection) OnConnectionStateChangeWithCause(f func(PeerConnectionState, ConnectionStateCause)) {
+	pc.onConnectionStateChangeWithCauseHandler.Store(f)
+}
+
+func (pc *PeerConnection) newConnectionStateCause(
+	connectionState PeerConnectionState,
+	iceConnectionState ICEConnectionState,
+	dtlsTransportState DTLSTransportState,
+) ConnectionStateCause {
+	cause := ConnectionStateCause{SelectedCandidatePair: pc.iceTransport.getLastSelectedCandidatePair()}
+
+	switch connectionState {
+	case PeerConnectionStateFailed:
+		switch {
+		case iceConnectionState == ICEConnectionStateFailed:
+			cause.Transport = ConnectionPhaseICE
+		case dtlsTransportState == DTLSTransportStateFailed:
+			cause.Transport = ConnectionPhaseDTLS
+			cause.Err = pc.dtlsTransport.startError()
+		default:
+			if err := pc.phaseTimeout(); err != nil {
+				cause.Transport = err.Phase
+				cause.Err = err
+			}
+		}
+	case PeerConnectionStateDisconnected:
+		cause.Transport = ConnectionPhaseICE
+	default:
+	}
+
+	return cause
+}
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerConnection_ConnectionStateCause(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	pcOffer, pcAnswer, err := newPair()
+	require.NoError(t, err)
+
+	causes := make(chan ConnectionStateCause, 1)
+	pcOffer.OnConnectionStateChangeWithCause(func(state PeerConnectionState, cause ConnectionStateCause) {
+		if state == PeerConnectionStateConnected {
+			causes <- cause
+		}
+	})
+
+	require.NoError(t, signalPair(pcOffer, pcAnswer))
+
+	cause := <-causes
+	assert.Equal(t, ConnectionPhaseUnknown, cause.Transport)
+	assert.NoError(t, cause.Err)
+	require.NotNil(t, cause.SelectedCandidatePair)
+	assert.NoError(t, pcOffer.ConnectionFailureCause())
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
+
+func TestPeerConnection_ConnectionStateCauseDTLS(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	// Keep the PeerConnections open when the DTLS handshake fails.
+	settingEngine := SettingEngine{}
+	settingEngine.DisableCloseByDTLS(true)
+
+	pcOffer, pcAnswer, err := NewAPI(WithSettingEngine(settingEngine)).newPair(Configuration{})
+	require.NoError(t, err)
+
+	causes := make(chan ConnectionStateCause, 1)
+	pcAnswer.OnConnectionStateChangeWithCause(func(state PeerConnectionState, cause ConnectionStateCause) {
+		if state == PeerConnectionStateFailed {
+			causes <- cause
+		}
+	})
+
+	// The answerer doesn't accept the certificate of the offerer.
+	wrongFingerprint := strings.TrimSuffix(strings.Repeat("00:", 32), ":")
+	fingerprint := regexp.MustCompile(`fingerprint:(\S+) [0-9A-F:]+`)
+	require.NoError(t, signalPairWithModification(pcOffer, pcAnswer, func(sdp string) string {
+		return fingerprint.ReplaceAllString(sdp, "fingerprint:$1 "+wrongFingerprint)
+	}))
+
+	cause := <-causes
+	assert.Equal(t, ConnectionPhaseDTLS, cause.Transport)
+	assert.ErrorIs(t, cause.Err, errNoMatchingCertificateFingerprint)
+	assert.NotNil(t, cause.SelectedCandidatePair)
+	assert.ErrorIs(t, pcAnswer.ConnectionFailureCause(), errNoMatchingCertificateFingerprint)
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
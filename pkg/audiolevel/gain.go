@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package audiolevel
+
+import (
+	"sync"
+
+	"github.com/pion/rtp"
+)
+
+// silenceLevel is the level of digital silence, the quietest one.
+const silenceLevel = 127
+
+// Gain returns the level raised by gain dB, a negative gain lowers it. The
+// level is clipped at 0 and 127 dBov, digital silence stays silent.
+func Gain(level rtp.AudioLevelExtension, gain int) rtp.AudioLevelExtension {
+	if level.Level >= silenceLevel {
+		return level
+	}
+
+	level.Level = uint8(min(max(int(level.Level)-gain, 0), silenceLevel)) //nolint:gosec // G115
+
+	return level
+}
+
+// Clip returns the level limited to loudest, levels louder than it are reported
+// as loudest. An SFU can use it to keep a participant from dominating the
+// active speaker detection of the receivers.
+func Clip(level rtp.AudioLevelExtension, loudest uint8) rtp.AudioLevelExtension {
+	level.Level = max(level.Level, min(loudest, silenceLevel))
+
+	return level
+}
+
+// Gains applies a gain to the audio levels of each stream, streams without one
+// are sent as they are. Its Rewrite method is a RewriteFunc.
+//
+// Gains is safe for concurrent use, the gains can be changed while packets are
+// sent.
+type Gains struct {
+	mu    sync.RWMutex
+	gains map[uint32]int
+}
+
+// Set sets the gain in dB of the stream with the SSRC, like the SSRC of an
+// RTPSender forwarding a participant.
+func (g *Gains) Set(ssrc uint32, gain int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.gains == nil {
+		g.gains = map[uint32]int{}
+	}
+	g.gains[ssrc] = gain
+}
+
+// Delete removes the gain of the stream with the SSRC.
+func (g *Gains) Delete(ssrc uint32) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.gains, ssrc)
+}
+
+// Rewrite applies the gain of the stream with the SSRC to the level.
+func (g *Gains) Rewrite(ssrc uint32, level rtp.AudioLevelExtension) rtp.AudioLevelExtension {
+	g.mu.RLock()
+	gain, ok := g.gains[ssrc]
+	g.mu.RUnlock()
+	if !ok {
+		return level
+	}
+
+	return Gain(level, gain)
+}
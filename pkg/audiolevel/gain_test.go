@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package audiolevel
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGain(t *testing.T) {
+	for _, test := range []struct {
+		level, expected uint8
+		gain            int
+	}{
+		{level: 30, gain: 6, expected: 24},
+		{level: 30, gain: -6, expected: 36},
+		{level: 3, gain: 6, expected: 0},
+		{level: 125, gain: -6, expected: 127},
+		{level: 127, gain: 6, expected: 127},
+	} {
+		assert.Equal(t,
+			rtp.AudioLevelExtension{Level: test.expected, Voice: true},
+			Gain(rtp.AudioLevelExtension{Level: test.level, Voice: true}, test.gain),
+			"level %d, gain %d", test.level, test.gain,
+		)
+	}
+}
+
+func TestClip(t *testing.T) {
+	assert.Equal(t, rtp.AudioLevelExtension{Level: 20}, Clip(rtp.AudioLevelExtension{Level: 5}, 20))
+	assert.Equal(t, rtp.AudioLevelExtension{Level: 40, Voice: true},
+		Clip(rtp.AudioLevelExtension{Level: 40, Voice: true}, 20))
+	assert.Equal(t, rtp.AudioLevelExtension{Level: 127}, Clip(rtp.AudioLevelExtension{Level: 5}, 200))
+}
+
+func TestGains(t *testing.T) {
+	var gains Gains
+	level := rtp.AudioLevelExtension{Level: 30, Voice: true}
+	assert.Equal(t, level, gains.Rewrite(1, level))
+
+	gains.Set(1, -10)
+	assert.Equal(t, rtp.AudioLevelExtension{Level: 40, Voice: true}, gains.Rewrite(1, level))
+	assert.Equal(t, level, gains.Rewrite(2, level))
+
+	gains.Delete(1)
+	assert.Equal(t, level, gains.Rewrite(1, level))
+}
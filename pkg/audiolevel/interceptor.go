@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+// Package audiolevel provides an interceptor that rewrites the audio level
+// header extension of RFC 6464 in the packets sent, so an SFU can change the
+// volume its receivers see for each participant without decoding the audio.
+package audiolevel
+
+import (
+	"errors"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/rtp"
+	"github.com/pion/sdp/v3"
+)
+
+var errRewriteFuncRequired = errors.New("audiolevel: a RewriteFunc is required")
+
+// RewriteFunc returns the audio level to send instead of level in a packet of
+// the stream with the SSRC. Levels are in -dBov, 0 is the loudest and 127
+// digital silence.
+type RewriteFunc func(ssrc uint32, level rtp.AudioLevelExtension) rtp.AudioLevelExtension
+
+// Option configures the Interceptor.
+type Option func(*Interceptor) error
+
+// WithRewriteFunc sets the function that rewrites the audio levels, see Gains
+// and Clip.
+func WithRewriteFunc(rewrite RewriteFunc) Option {
+	return func(i *Interceptor) error {
+		i.rewrite = rewrite
+
+		return nil
+	}
+}
+
+// InterceptorFactory is an interceptor.Factory for an Interceptor.
+type InterceptorFactory struct {
+	opts []Option
+}
+
+// NewInterceptor returns a new InterceptorFactory. WithRewriteFunc is required.
+func NewInterceptor(opts ...Option) (*InterceptorFactory, error) {
+	if _, err := newInterceptor(opts...); err != nil {
+		return nil, err
+	}
+
+	return &InterceptorFactory{opts: opts}, nil
+}
+
+// NewInterceptor returns a new Interceptor.
+func (f *InterceptorFactory) NewInterceptor(string) (interceptor.Interceptor, error) {
+	return newInterceptor(f.opts...)
+}
+
+// Interceptor rewrites the audio level header extension of the local streams
+// that negotiated it. Packets without the extension are sent as they are.
+type Interceptor struct {
+	interceptor.NoOp
+
+	rewrite RewriteFunc
+}
+
+func newInterceptor(opts ...Option) (*Interceptor, error) {
+	i := &Interceptor{}
+	for _, opt := range opts {
+		if err := opt(i); err != nil {
+			return nil, err
+		}
+	}
+
+	if i.rewrite == nil {
+		return nil, errRewriteFuncRequired
+	}
+
+	return i, nil
+}
+
+// BindLocalStream rewrites the audio levels of the packets of the stream.
+func (i *Interceptor) BindLocalStream(
+	info *interceptor.StreamInfo, writer interceptor.RTPWriter,
+) interceptor.RTPWriter {
+	var extensionID uint8
+	for _, extension := range info.RTPHeaderExtensions {
+		if extension.URI == sdp.AudioLevelURI {
+			extensionID = uint8(extension.ID) //nolint:gosec // G115, IDs are at most 255
+		}
+	}
+	if extensionID == 0 {
+		return writer
+	}
+
+	ssrc := info.SSRC
+
+	return interceptor.RTPWriterFunc(func(header *rtp.Header, payload []byte, a interceptor.Attributes) (int, error) {
+		var level rtp.AudioLevelExtension
+		if err := level.Unmarshal(header.GetExtension(extensionID)); err != nil {
+			return writer.Write(header, payload, a)
+		}
+
+		rewritten := i.rewrite(ssrc, level)
+		if rewritten == level {
+			return writer.Write(header, payload, a)
+		}
+
+		raw, err := rewritten.Marshal()
+		if err != nil {
+			return 0, err
+		}
+
+		// The header is shared with the other streams the packet is written to,
+		// like the other PeerConnections of a TrackLocalStaticRTP.
+		rewrittenHeader := header.Clone()
+		if err := rewrittenHeader.SetExtension(extensionID, raw); err != nil {
+			return 0, err
+		}
+
+		return writer.Write(&rewrittenHeader, payload, a)
+	})
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package audiolevel
+
+import (
+	"testing"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/rtp"
+	"github.com/pion/sdp/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInterceptor(t *testing.T) {
+	_, err := NewInterceptor()
+	assert.ErrorIs(t, err, errRewriteFuncRequired)
+
+	factory, err := NewInterceptor(WithRewriteFunc(func(_ uint32, level rtp.AudioLevelExtension) rtp.AudioLevelExtension {
+		return level
+	}))
+	require.NoError(t, err)
+
+	i, err := factory.NewInterceptor("")
+	require.NoError(t, err)
+	assert.IsType(t, &Interceptor{}, i)
+}
+
+func TestInterceptor_BindLocalStream(t *testing.T) {
+	const extensionID = 3
+
+	var gains Gains
+	gains.Set(1234, -10)
+	i, err := newInterceptor(WithRewriteFunc(gains.Rewrite))
+	require.NoError(t, err)
+
+	var written []rtp.Header
+	writer := interceptor.RTPWriterFunc(func(header *rtp.Header, _ []byte, _ interceptor.Attributes) (int, error) {
+		written = append(written, *header)
+
+		return 0, nil
+	})
+
+	newHeader := func(level uint8) *rtp.Header {
+		header := &rtp.Header{SSRC: 1234}
+		require.NoError(t, header.SetExtension(extensionID, []byte{0x80 | level}))
+
+		return header
+	}
+
+	t.Run("Rewritten", func(t *testing.T) {
+		written = nil
+		bound := i.BindLocalStream(&interceptor.StreamInfo{
+			SSRC:                1234,
+			RTPHeaderExtensions: []interceptor.RTPHeaderExtension{{URI: sdp.AudioLevelURI, ID: extensionID}},
+		}, writer)
+
+		header := newHeader(30)
+		_, err := bound.Write(header, nil, nil)
+		require.NoError(t, err)
+		_, err = bound.Write(&rtp.Header{SSRC: 1234}, nil, nil)
+		require.NoError(t, err)
+
+		require.Len(t, written, 2)
+		assert.Equal(t, []byte{0x80 | 40}, written[0].GetExtension(extensionID))
+		assert.Nil(t, written[1].GetExtension(extensionID))
+
+		// The header of the caller isn't modified, it may be written to other streams.
+		assert.Equal(t, []byte{0x80 | 30}, header.GetExtension(extensionID))
+	})
+
+	t.Run("Other Stream", func(t *testing.T) {
+		written = nil
+		bound := i.BindLocalStream(&interceptor.StreamInfo{
+			SSRC:                5678,
+			RTPHeaderExtensions: []interceptor.RTPHeaderExtension{{URI: sdp.AudioLevelURI, ID: extensionID}},
+		}, writer)
+
+		_, err := bound.Write(newHeader(30), nil, nil)
+		require.NoError(t, err)
+		require.Len(t, written, 1)
+		assert.Equal(t, []byte{0x80 | 30}, written[0].GetExtension(extensionID))
+	})
+
+	t.Run("Not Negotiated", func(t *testing.T) {
+		written = nil
+		bound := i.BindLocalStream(&interceptor.StreamInfo{SSRC: 1234}, writer)
+
+		_, err := bound.Write(newHeader(30), nil, nil)
+		require.NoError(t, err)
+		require.Len(t, written, 1)
+		assert.Equal(t, []byte{0x80 | 30}, written[0].GetExtension(extensionID))
+	})
+}
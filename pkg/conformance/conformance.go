@@ -0,0 +1,266 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+// Package conformance runs a matrix of negotiation, reconnection and media
+// flow scenarios against a configuration of this module, in-process between
+// two PeerConnections created with the same API. Products built on this module
+// can run it with their own MediaEngine, SettingEngine and interceptors to
+// validate an upgrade before shipping it.
+//
+// The media scenarios run once for every codec, so the matrix grows with the
+// codecs registered. Scenario failures are reported in the Report, Run only
+// returns an error when the matrix can't be built.
+package conformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pion/webrtc/v4"
+)
+
+const defaultScenarioTimeout = 10 * time.Second
+
+var (
+	errUnknownScenario = errors.New("conformance: unknown scenario")
+	errNoAPI           = errors.New("conformance: API is required")
+)
+
+// Scenario names, in the order they run.
+const (
+	// ScenarioOfferAnswer connects with a DataChannel, exchanging the session
+	// descriptions once gathering is complete.
+	ScenarioOfferAnswer = "offer-answer"
+
+	// ScenarioTrickleICE connects with the candidates trickled as they are
+	// gathered.
+	ScenarioTrickleICE = "trickle-ice"
+
+	// ScenarioRenegotiation adds a transceiver with an offer of the answerer
+	// once connected. It only runs when there is a codec.
+	ScenarioRenegotiation = "renegotiation"
+
+	// ScenarioDataChannel sends messages of growing sizes on an ordered
+	// DataChannel and expects them echoed in order.
+	ScenarioDataChannel = "datachannel"
+
+	// ScenarioICERestart restarts ICE once connected and expects DataChannel
+	// messages to keep flowing.
+	ScenarioICERestart = "ice-restart"
+
+	// ScenarioMedia sends RTP with a codec and expects the remote track to
+	// receive it with the same codec. It runs once for every codec.
+	ScenarioMedia = "media"
+
+	// ScenarioMediaICERestart restarts ICE while RTP is sent with a codec and
+	// expects packets sent after the restart to be received. It runs once for
+	// every codec.
+	ScenarioMediaICERestart = "media-ice-restart"
+)
+
+// Scenarios returns the names of all scenarios, in the order they run.
+func Scenarios() []string {
+	return []string{
+		ScenarioOfferAnswer,
+		ScenarioTrickleICE,
+		ScenarioRenegotiation,
+		ScenarioDataChannel,
+		ScenarioICERestart,
+		ScenarioMedia,
+		ScenarioMediaICERestart,
+	}
+}
+
+// Config configures a conformance run.
+type Config struct {
+	// API creates the PeerConnections of both peers, with the codecs, settings
+	// and interceptors under test. API is required.
+	API *webrtc.API
+
+	// Configuration is used for the PeerConnections of both peers.
+	Configuration webrtc.Configuration
+
+	// Codecs are the codecs the media scenarios run with, nil selects every
+	// audio and video codec registered with the MediaEngine of the API.
+	// Retransmission, FEC, redundancy, comfort noise and DTMF codecs are left
+	// out, as they don't carry media of their own.
+	Codecs []webrtc.RTPCodecCapability
+
+	// Scenarios are the names of the scenarios to run, nil runs all of them.
+	Scenarios []string
+
+	// ScenarioTimeout is how long a scenario may run before it fails, ten
+	// seconds when zero.
+	ScenarioTimeout time.Duration
+}
+
+// Run runs the scenarios of the Config one after the other. It returns once
+// all of them are done or the context is done, the scenarios that didn't run
+// are left out of the Report.
+func Run(ctx context.Context, config Config) (*Report, error) {
+	if config.API == nil {
+		return nil, errNoAPI
+	}
+	if config.ScenarioTimeout <= 0 {
+		config.ScenarioTimeout = defaultScenarioTimeout
+	}
+	if config.Scenarios == nil {
+		config.Scenarios = Scenarios()
+	}
+	for _, name := range config.Scenarios {
+		if !slices.Contains(Scenarios(), name) {
+			return nil, fmt.Errorf("%w: %s", errUnknownScenario, name)
+		}
+	}
+	if config.Codecs == nil {
+		codecs, err := registeredCodecs(config)
+		if err != nil {
+			return nil, err
+		}
+		config.Codecs = codecs
+	}
+
+	report := &Report{}
+	start := time.Now()
+	for _, s := range matrix(config) {
+		if ctx.Err() != nil {
+			break
+		}
+		report.Results = append(report.Results, s.result(ctx, config))
+	}
+	report.Duration = time.Since(start)
+
+	return report, nil
+}
+
+type scenario struct {
+	name  string
+	codec *webrtc.RTPCodecCapability
+	run   func(ctx context.Context, p *pair) error
+}
+
+func matrix(config Config) []scenario {
+	var scenarios []scenario
+	for _, name := range Scenarios() {
+		if !slices.Contains(config.Scenarios, name) {
+			continue
+		}
+
+		switch name {
+		case ScenarioOfferAnswer:
+			scenarios = append(scenarios, scenario{name: name, run: runOfferAnswer})
+		case ScenarioTrickleICE:
+			scenarios = append(scenarios, scenario{name: name, run: runTrickleICE})
+		case ScenarioRenegotiation:
+			if len(config.Codecs) != 0 {
+				kind := codecKind(config.Codecs[0])
+				scenarios = append(scenarios, scenario{name: name, run: func(ctx context.Context, p *pair) error {
+					return runRenegotiation(ctx, p, kind)
+				}})
+			}
+		case ScenarioDataChannel:
+			scenarios = append(scenarios, scenario{name: name, run: runDataChannel})
+		case ScenarioICERestart:
+			scenarios = append(scenarios, scenario{name: name, run: runICERestart})
+		case ScenarioMedia, ScenarioMediaICERestart:
+			restart := name == ScenarioMediaICERestart
+			for _, codec := range config.Codecs {
+				scenarios = append(scenarios, scenario{name: name, codec: &codec, run: func(ctx context.Context, p *pair) error {
+					return runMedia(ctx, p, codec, restart)
+				}})
+			}
+		}
+	}
+
+	return scenarios
+}
+
+func (s scenario) result(ctx context.Context, config Config) Result {
+	ctx, cancel := context.WithTimeout(ctx, config.ScenarioTimeout)
+	defer cancel()
+
+	result := Result{Scenario: s.name}
+	if s.codec != nil {
+		result.Codec = codecName(*s.codec)
+	}
+
+	start := time.Now()
+	err := func() error {
+		p, err := newPair(config)
+		if err != nil {
+			return err
+		}
+
+		return errors.Join(s.run(ctx, p), p.close())
+	}()
+	result.Duration = time.Since(start)
+	if err != nil {
+		result.Error = err.Error()
+	} else {
+		result.Passed = true
+	}
+
+	return result
+}
+
+// registeredCodecs returns the media codecs of the MediaEngine of the API. They
+// are the codecs of the receivers of transceivers that aren't negotiated yet.
+func registeredCodecs(config Config) ([]webrtc.RTPCodecCapability, error) {
+	pc, err := config.API.NewPeerConnection(config.Configuration)
+	if err != nil {
+		return nil, err
+	}
+
+	var codecs []webrtc.RTPCodecCapability
+	for _, kind := range []webrtc.RTPCodecType{webrtc.RTPCodecTypeVideo, webrtc.RTPCodecTypeAudio} {
+		transceiver, err := pc.AddTransceiverFromKind(kind, webrtc.RTPTransceiverInit{
+			Direction: webrtc.RTPTransceiverDirectionRecvonly,
+		})
+		if err != nil {
+			return nil, errors.Join(err, pc.Close())
+		}
+
+		for _, codec := range transceiver.Receiver().GetParameters().Codecs {
+			registered := slices.ContainsFunc(codecs, func(c webrtc.RTPCodecCapability) bool {
+				return codecName(c) == codecName(codec.RTPCodecCapability)
+			})
+			if !registered && carriesMedia(codec.RTPCodecCapability) {
+				codecs = append(codecs, codec.RTPCodecCapability)
+			}
+		}
+	}
+
+	return codecs, pc.Close()
+}
+
+func carriesMedia(codec webrtc.RTPCodecCapability) bool {
+	_, subtype, _ := strings.Cut(strings.ToLower(codec.MimeType), "/")
+	switch subtype {
+	case "rtx", "red", "ulpfec", "flexfec", "flexfec-03", "cn", "telephone-event":
+		return false
+	default:
+		return true
+	}
+}
+
+func codecKind(codec webrtc.RTPCodecCapability) webrtc.RTPCodecType {
+	if strings.HasPrefix(strings.ToLower(codec.MimeType), "audio/") {
+		return webrtc.RTPCodecTypeAudio
+	}
+
+	return webrtc.RTPCodecTypeVideo
+}
+
+func codecName(codec webrtc.RTPCodecCapability) string {
+	if codec.SDPFmtpLine == "" {
+		return codec.MimeType
+	}
+
+	return codec.MimeType + " " + codec.SDPFmtpLine
+}
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package conformance
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/pion/webrtc/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAPI(t *testing.T) *webrtc.API {
+	t.Helper()
+
+	mediaEngine := &webrtc.MediaEngine{}
+	require.NoError(t, mediaEngine.RegisterCodec(webrtc.RTPCodecParameters{
+		RTPCodecCapability: webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeVP8, ClockRate: 90000},
+		PayloadType:        96,
+	}, webrtc.RTPCodecTypeVideo))
+	require.NoError(t, mediaEngine.RegisterCodec(webrtc.RTPCodecParameters{
+		RTPCodecCapability: webrtc.RTPCodecCapability{MimeType: "video/rtx", ClockRate: 90000, SDPFmtpLine: "apt=96"},
+		PayloadType:        97,
+	}, webrtc.RTPCodecTypeVideo))
+	require.NoError(t, mediaEngine.RegisterCodec(webrtc.RTPCodecParameters{
+		RTPCodecCapability: webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeOpus, ClockRate: 48000, Channels: 2},
+		PayloadType:        111,
+	}, webrtc.RTPCodecTypeAudio))
+
+	return webrtc.NewAPI(webrtc.WithMediaEngine(mediaEngine))
+}
+
+func TestRun(t *testing.T) {
+	lim := test.TimeOut(time.Minute)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	result, err := Run(context.Background(), Config{API: newAPI(t)})
+	require.NoError(t, err)
+	assert.True(t, result.Passed(), result.String())
+	assert.Empty(t, result.Failed())
+
+	var names []string
+	for _, r := range result.Results {
+		names = append(names, r.Scenario+" "+r.Codec)
+	}
+	assert.Equal(t, []string{
+		"offer-answer ",
+		"trickle-ice ",
+		"renegotiation ",
+		"datachannel ",
+		"ice-restart ",
+		"media video/VP8",
+		"media audio/opus",
+		"media-ice-restart video/VP8",
+		"media-ice-restart audio/opus",
+	}, names, "the retransmission codec isn't left out of the matrix")
+
+	marshaled, err := json.Marshal(result)
+	require.NoError(t, err)
+	var unmarshaled Report
+	require.NoError(t, json.Unmarshal(marshaled, &unmarshaled))
+	assert.Equal(t, *result, unmarshaled)
+}
+
+func TestRun_Failure(t *testing.T) {
+	lim := test.TimeOut(time.Minute)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	// Without servers no candidates can be gathered with a relay only policy.
+	result, err := Run(context.Background(), Config{
+		API:             newAPI(t),
+		Configuration:   webrtc.Configuration{ICETransportPolicy: webrtc.ICETransportPolicyRelay},
+		Scenarios:       []string{ScenarioOfferAnswer},
+		ScenarioTimeout: time.Second,
+	})
+	require.NoError(t, err)
+	assert.False(t, result.Passed())
+	require.Len(t, result.Failed(), 1)
+	assert.Equal(t, ScenarioOfferAnswer, result.Failed()[0].Scenario)
+	assert.NotEmpty(t, result.Failed()[0].Error)
+	assert.Contains(t, result.String(), "FAIL offer-answer")
+	assert.Contains(t, result.String(), "0 of 1 scenarios passed")
+}
+
+func TestRun_Config(t *testing.T) {
+	_, err := Run(context.Background(), Config{})
+	assert.ErrorIs(t, err, errNoAPI)
+
+	_, err = Run(context.Background(), Config{API: newAPI(t), Scenarios: []string{"unknown"}})
+	assert.ErrorIs(t, err, errUnknownScenario)
+
+	result, err := Run(context.Background(), Config{
+		API:       newAPI(t),
+		Codecs:    []webrtc.RTPCodecCapability{},
+		Scenarios: []string{ScenarioRenegotiation, ScenarioMedia},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.Results, "media scenarios ran without codecs")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err = Run(ctx, Config{API: newAPI(t)})
+	require.NoError(t, err)
+	assert.Empty(t, result.Results, "scenarios ran after the context was done")
+}
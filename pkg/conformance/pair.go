@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package conformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pion/sdp/v3"
+	"github.com/pion/webrtc/v4"
+)
+
+const pollInterval = 10 * time.Millisecond
+
+var (
+	errNoICECredentials = errors.New("conformance: session description has no ICE credentials")
+	errICENotRestarted  = errors.New("conformance: ICE restart kept the ICE credentials")
+)
+
+// pair is the two PeerConnections of a scenario, both created with the API of
+// the Config.
+type pair struct {
+	offerer  *webrtc.PeerConnection
+	answerer *webrtc.PeerConnection
+}
+
+func newPair(config Config) (*pair, error) {
+	offerer, err := config.API.NewPeerConnection(config.Configuration)
+	if err != nil {
+		return nil, err
+	}
+
+	answerer, err := config.API.NewPeerConnection(config.Configuration)
+	if err != nil {
+		return nil, errors.Join(err, offerer.Close())
+	}
+
+	return &pair{offerer: offerer, answerer: answerer}, nil
+}
+
+func (p *pair) close() error {
+	return errors.Join(p.offerer.Close(), p.answerer.Close())
+}
+
+// connected waits until both PeerConnections are connected.
+func (p *pair) connected(ctx context.Context) error {
+	return waitFor(ctx, "PeerConnections to connect", func() bool {
+		return p.offerer.ConnectionState() == webrtc.PeerConnectionStateConnected &&
+			p.answerer.ConnectionState() == webrtc.PeerConnectionStateConnected
+	})
+}
+
+// negotiate makes an offer with from and answers it with to. The session
+// descriptions are exchanged once gathering is complete, so they carry all
+// candidates.
+func negotiate(ctx context.Context, from, to *webrtc.PeerConnection, options *webrtc.OfferOptions) error {
+	offer, err := from.CreateOffer(options)
+	if err != nil {
+		return fmt.Errorf("create offer: %w", err)
+	}
+	if err = setLocalDescription(ctx, from, offer); err != nil {
+		return err
+	}
+	if err = to.SetRemoteDescription(*from.LocalDescription()); err != nil {
+		return fmt.Errorf("set remote offer: %w", err)
+	}
+
+	answer, err := to.CreateAnswer(nil)
+	if err != nil {
+		return fmt.Errorf("create answer: %w", err)
+	}
+	if err = setLocalDescription(ctx, to, answer); err != nil {
+		return err
+	}
+	if err = from.SetRemoteDescription(*to.LocalDescription()); err != nil {
+		return fmt.Errorf("set remote answer: %w", err)
+	}
+
+	return nil
+}
+
+func setLocalDescription(ctx context.Context, pc *webrtc.PeerConnection, description webrtc.SessionDescription) error {
+	gathered := webrtc.GatheringCompletePromise(pc)
+	if err := pc.SetLocalDescription(description); err != nil {
+		return fmt.Errorf("set local %s: %w", description.Type, err)
+	}
+
+	select {
+	case <-gathered:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("gather candidates: %w", ctx.Err())
+	}
+}
+
+// restartICE restarts ICE with an offer of the offerer and waits until both
+// PeerConnections selected a candidate pair with the new credentials and are
+// connected. The PeerConnections stay connected during the restart, the
+// previous candidate pair keeps working until then.
+func (p *pair) restartICE(ctx context.Context) error {
+	ufrag, err := remoteUfrag(p.answerer)
+	if err != nil {
+		return err
+	}
+
+	if err = negotiate(ctx, p.offerer, p.answerer, &webrtc.OfferOptions{ICERestart: true}); err != nil {
+		return err
+	}
+
+	offererUfrag, err := remoteUfrag(p.answerer)
+	if err != nil {
+		return err
+	}
+	if offererUfrag == ufrag {
+		return fmt.Errorf("%w: %s", errICENotRestarted, ufrag)
+	}
+	answererUfrag, err := remoteUfrag(p.offerer)
+	if err != nil {
+		return err
+	}
+
+	if err = waitFor(ctx, "ICE restart", func() bool {
+		return restarted(p.offerer, answererUfrag) && restarted(p.answerer, offererUfrag)
+	}); err != nil {
+		return err
+	}
+
+	return p.connected(ctx)
+}
+
+// restarted reports whether the ICE agent of the PeerConnection uses the
+// remote credentials of the restart and selected a candidate pair since. The
+// agent drops the selected candidate pair and the remote credentials when it
+// restarts.
+func restarted(pc *webrtc.PeerConnection, remoteUfrag string) bool {
+	iceTransport := pc.SCTP().Transport().ICETransport()
+	remote, err := iceTransport.GetRemoteParameters()
+	if err != nil || remote.UsernameFragment != remoteUfrag {
+		return false
+	}
+
+	pair, err := iceTransport.GetSelectedCandidatePair()
+
+	return err == nil && pair != nil
+}
+
+func remoteUfrag(pc *webrtc.PeerConnection) (string, error) {
+	description := pc.RemoteDescription()
+	if description == nil {
+		return "", errNoICECredentials
+	}
+
+	parsed := &sdp.SessionDescription{}
+	if err := parsed.UnmarshalString(description.SDP); err != nil {
+		return "", err
+	}
+
+	if ufrag, ok := parsed.Attribute("ice-ufrag"); ok {
+		return ufrag, nil
+	}
+	for _, media := range parsed.MediaDescriptions {
+		if ufrag, ok := media.Attribute("ice-ufrag"); ok {
+			return ufrag, nil
+		}
+	}
+
+	return "", errNoICECredentials
+}
+
+// waitFor polls the condition until it is true or the context is done.
+func waitFor(ctx context.Context, what string, condition func() bool) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for !condition() {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return fmt.Errorf("wait for %s: %w", what, ctx.Err())
+		}
+	}
+
+	return nil
+}
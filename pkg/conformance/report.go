@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package conformance
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Report holds the results of a conformance run. It can be marshaled to JSON
+// to be kept as a build artifact.
+type Report struct {
+	Results  []Result      `json:"results"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Result is the outcome of one scenario.
+type Result struct {
+	Scenario string `json:"scenario"`
+
+	// Codec is the MIME type and format parameters of the codec of media
+	// scenarios, empty for the other scenarios.
+	Codec string `json:"codec,omitempty"`
+
+	Passed   bool          `json:"passed"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Passed reports whether every scenario that ran passed.
+func (r *Report) Passed() bool {
+	return len(r.Failed()) == 0
+}
+
+// Failed returns the results of the scenarios that failed.
+func (r *Report) Failed() []Result {
+	var failed []Result
+	for _, result := range r.Results {
+		if !result.Passed {
+			failed = append(failed, result)
+		}
+	}
+
+	return failed
+}
+
+// String formats the Report as one line per scenario, followed by a summary.
+func (r *Report) String() string {
+	var b strings.Builder
+	for _, result := range r.Results {
+		b.WriteString(result.String())
+		b.WriteByte('\n')
+	}
+	fmt.Fprintf(&b, "%d of %d scenarios passed in %s", len(r.Results)-len(r.Failed()), len(r.Results),
+		r.Duration.Round(time.Millisecond))
+
+	return b.String()
+}
+
+// String formats the Result as PASS or FAIL, the scenario, the codec and the
+// error of a failure.
+func (r Result) String() string {
+	status := "PASS"
+	if !r.Passed {
+		status = "FAIL"
+	}
+
+	name := r.Scenario
+	if r.Codec != "" {
+		name += " " + r.Codec
+	}
+
+	line := fmt.Sprintf("%s %s (%s)", status, name, r.Duration.Round(time.Millisecond))
+	if r.Error != "" {
+		line += ": " + r.Error
+	}
+
+	return line
+}
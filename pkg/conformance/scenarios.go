@@ -0,0 +1,487 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/pion/webrtc/v4"
+)
+
+const (
+	dataChannelLabel = "conformance"
+	orderedMessages  = 100
+
+	mediaPacketInterval = 20 * time.Millisecond
+	mediaPayloadSize    = 100
+)
+
+// dataChannelMessageSizes are the sizes of the messages of ScenarioDataChannel,
+// up to the default maximum message size of SCTP.
+var dataChannelMessageSizes = []int{1, 1200, 16 * 1024, 64 * 1024} //nolint:gochecknoglobals
+
+var (
+	errNoCandidates        = errors.New("conformance: no candidates were trickled")
+	errTransceiverMismatch = errors.New("conformance: renegotiated transceiver is missing on the remote peer")
+	errMessageMismatch     = errors.New("conformance: echoed message doesn't match the message sent")
+	errCodecMismatch       = errors.New("conformance: remote track has a different codec")
+	errPayloadMismatch     = errors.New("conformance: received payload doesn't match the payload sent")
+)
+
+func runOfferAnswer(ctx context.Context, p *pair) error {
+	channel, err := newEchoChannel(p)
+	if err != nil {
+		return err
+	}
+	if err = negotiate(ctx, p.offerer, p.answerer, nil); err != nil {
+		return err
+	}
+	if err = p.connected(ctx); err != nil {
+		return err
+	}
+
+	return channel.opened(ctx)
+}
+
+func runTrickleICE(ctx context.Context, p *pair) error {
+	channel, err := newEchoChannel(p)
+	if err != nil {
+		return err
+	}
+
+	toAnswerer := &trickler{to: p.answerer}
+	toOfferer := &trickler{to: p.offerer}
+	p.offerer.OnICECandidate(toAnswerer.add)
+	p.answerer.OnICECandidate(toOfferer.add)
+
+	offer, err := p.offerer.CreateOffer(nil)
+	if err != nil {
+		return fmt.Errorf("create offer: %w", err)
+	}
+	if err = p.offerer.SetLocalDescription(offer); err != nil {
+		return fmt.Errorf("set local offer: %w", err)
+	}
+	if err = p.answerer.SetRemoteDescription(offer); err != nil {
+		return fmt.Errorf("set remote offer: %w", err)
+	}
+	toAnswerer.start()
+
+	answer, err := p.answerer.CreateAnswer(nil)
+	if err != nil {
+		return fmt.Errorf("create answer: %w", err)
+	}
+	if err = p.answerer.SetLocalDescription(answer); err != nil {
+		return fmt.Errorf("set local answer: %w", err)
+	}
+	if err = p.offerer.SetRemoteDescription(answer); err != nil {
+		return fmt.Errorf("set remote answer: %w", err)
+	}
+	toOfferer.start()
+
+	if err = p.connected(ctx); err != nil {
+		return err
+	}
+	if err = channel.opened(ctx); err != nil {
+		return err
+	}
+
+	return errors.Join(toAnswerer.result(), toOfferer.result())
+}
+
+func runRenegotiation(ctx context.Context, p *pair, kind webrtc.RTPCodecType) error {
+	channel, err := newEchoChannel(p)
+	if err != nil {
+		return err
+	}
+	if err = negotiate(ctx, p.offerer, p.answerer, nil); err != nil {
+		return err
+	}
+	if err = p.connected(ctx); err != nil {
+		return err
+	}
+
+	// The answerer of the first negotiation makes the offer of the second.
+	transceiver, err := p.answerer.AddTransceiverFromKind(kind, webrtc.RTPTransceiverInit{
+		Direction: webrtc.RTPTransceiverDirectionRecvonly,
+	})
+	if err != nil {
+		return fmt.Errorf("add transceiver: %w", err)
+	}
+	if err = negotiate(ctx, p.answerer, p.offerer, nil); err != nil {
+		return err
+	}
+
+	found := false
+	for _, remote := range p.offerer.GetTransceivers() {
+		found = found || (remote.Mid() != "" && remote.Mid() == transceiver.Mid() && remote.Kind() == kind)
+	}
+	if !found {
+		return fmt.Errorf("%w: mid %q", errTransceiverMismatch, transceiver.Mid())
+	}
+
+	if err = p.connected(ctx); err != nil {
+		return err
+	}
+
+	return channel.echo(ctx, []byte("renegotiated"))
+}
+
+func runDataChannel(ctx context.Context, p *pair) error {
+	channel, err := newEchoChannel(p)
+	if err != nil {
+		return err
+	}
+	if err = negotiate(ctx, p.offerer, p.answerer, nil); err != nil {
+		return err
+	}
+	if err = p.connected(ctx); err != nil {
+		return err
+	}
+
+	for _, size := range dataChannelMessageSizes {
+		if err = channel.echo(ctx, bytes.Repeat([]byte{byte(size)}, size)); err != nil {
+			return fmt.Errorf("message of %d bytes: %w", size, err)
+		}
+	}
+
+	messages := make([][]byte, orderedMessages)
+	for i := range messages {
+		messages[i] = []byte(fmt.Sprintf("ordered %d", i))
+	}
+
+	return channel.echo(ctx, messages...)
+}
+
+func runICERestart(ctx context.Context, p *pair) error {
+	channel, err := newEchoChannel(p)
+	if err != nil {
+		return err
+	}
+	if err = negotiate(ctx, p.offerer, p.answerer, nil); err != nil {
+		return err
+	}
+	if err = p.connected(ctx); err != nil {
+		return err
+	}
+	if err = channel.echo(ctx, []byte("before restart")); err != nil {
+		return err
+	}
+
+	if err = p.restartICE(ctx); err != nil {
+		return err
+	}
+
+	return channel.echo(ctx, []byte("after restart"))
+}
+
+func runMedia(ctx context.Context, p *pair, codec webrtc.RTPCodecCapability, restart bool) error {
+	track, err := webrtc.NewTrackLocalStaticRTP(codec, "conformance", "conformance")
+	if err != nil {
+		return err
+	}
+	if _, err = p.offerer.AddTrack(track); err != nil {
+		return fmt.Errorf("add track: %w", err)
+	}
+
+	receiver := &mediaReceiver{codec: codec}
+	p.answerer.OnTrack(func(track *webrtc.TrackRemote, _ *webrtc.RTPReceiver) {
+		receiver.read(track)
+	})
+
+	if err = negotiate(ctx, p.offerer, p.answerer, nil); err != nil {
+		return err
+	}
+	if err = p.connected(ctx); err != nil {
+		return err
+	}
+
+	sender := newMediaSender(track)
+	defer sender.stop()
+
+	// The first packets may be sent before the answerer is ready to receive
+	// them, so any packet after the first one sent is accepted.
+	if err = receiver.receivedAfter(ctx, 0); err != nil {
+		return err
+	}
+	if !restart {
+		return nil
+	}
+
+	sent := sender.sent()
+	if err = p.restartICE(ctx); err != nil {
+		return err
+	}
+
+	return receiver.receivedAfter(ctx, sent)
+}
+
+// echoChannel is a DataChannel of the offerer whose messages are echoed by the
+// answerer.
+type echoChannel struct {
+	local    *webrtc.DataChannel
+	remote   chan *webrtc.DataChannel
+	messages chan []byte
+}
+
+func newEchoChannel(p *pair) (*echoChannel, error) {
+	local, err := p.offerer.CreateDataChannel(dataChannelLabel, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create DataChannel: %w", err)
+	}
+
+	channel := &echoChannel{
+		local:    local,
+		remote:   make(chan *webrtc.DataChannel, 1),
+		messages: make(chan []byte, orderedMessages),
+	}
+	local.OnMessage(func(msg webrtc.DataChannelMessage) {
+		channel.messages <- msg.Data
+	})
+
+	p.answerer.OnDataChannel(func(remote *webrtc.DataChannel) {
+		if remote.Label() != dataChannelLabel {
+			return
+		}
+		remote.OnMessage(func(msg webrtc.DataChannelMessage) {
+			_ = remote.Send(msg.Data)
+		})
+		remote.OnOpen(func() {
+			channel.remote <- remote
+		})
+	})
+
+	return channel, nil
+}
+
+// opened waits until the DataChannel is open on both peers.
+func (c *echoChannel) opened(ctx context.Context) error {
+	select {
+	case remote := <-c.remote:
+		c.remote <- remote
+	case <-ctx.Done():
+		return fmt.Errorf("wait for remote DataChannel: %w", ctx.Err())
+	}
+
+	return waitFor(ctx, "DataChannel to open", func() bool {
+		return c.local.ReadyState() == webrtc.DataChannelStateOpen
+	})
+}
+
+// echo sends the messages and waits until they are echoed, in order.
+func (c *echoChannel) echo(ctx context.Context, messages ...[]byte) error {
+	if err := c.opened(ctx); err != nil {
+		return err
+	}
+
+	for _, message := range messages {
+		if err := c.local.Send(message); err != nil {
+			return fmt.Errorf("send message: %w", err)
+		}
+	}
+
+	for _, message := range messages {
+		select {
+		case echoed := <-c.messages:
+			if !bytes.Equal(echoed, message) {
+				return errMessageMismatch
+			}
+		case <-ctx.Done():
+			return fmt.Errorf("wait for echoed message: %w", ctx.Err())
+		}
+	}
+
+	return nil
+}
+
+// trickler adds the candidates of one peer to the other, once the other has a
+// remote description to add them to.
+type trickler struct {
+	to *webrtc.PeerConnection
+
+	mu      sync.Mutex
+	started bool
+	pending []webrtc.ICECandidateInit
+	added   int
+	err     error
+}
+
+func (t *trickler) add(candidate *webrtc.ICECandidate) {
+	if candidate == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.started {
+		t.pending = append(t.pending, candidate.ToJSON())
+
+		return
+	}
+	t.addCandidate(candidate.ToJSON())
+}
+
+func (t *trickler) start() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.started = true
+	for _, candidate := range t.pending {
+		t.addCandidate(candidate)
+	}
+	t.pending = nil
+}
+
+func (t *trickler) addCandidate(candidate webrtc.ICECandidateInit) {
+	if err := t.to.AddICECandidate(candidate); err != nil {
+		t.err = errors.Join(t.err, fmt.Errorf("add candidate: %w", err))
+
+		return
+	}
+	t.added++
+}
+
+func (t *trickler) result() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.err == nil && t.added == 0 {
+		return errNoCandidates
+	}
+
+	return t.err
+}
+
+// mediaSender writes RTP packets with increasing sequence numbers until it is
+// stopped. The payload is derived from the sequence number.
+type mediaSender struct {
+	track *webrtc.TrackLocalStaticRTP
+	done  chan struct{}
+	wg    sync.WaitGroup
+
+	mu       sync.Mutex
+	sequence uint16
+}
+
+func newMediaSender(track *webrtc.TrackLocalStaticRTP) *mediaSender {
+	sender := &mediaSender{track: track, done: make(chan struct{})}
+	sender.wg.Add(1)
+	go sender.run()
+
+	return sender
+}
+
+func (s *mediaSender) run() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(mediaPacketInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-s.done:
+			return
+		}
+
+		s.mu.Lock()
+		s.sequence++
+		sequence := s.sequence
+		s.mu.Unlock()
+
+		// Write errors are expected while ICE restarts, the scenario fails if
+		// no packets are received afterwards.
+		_ = s.track.WriteRTP(&rtp.Packet{
+			Header: rtp.Header{
+				Version:        2,
+				Marker:         true,
+				SequenceNumber: sequence,
+				Timestamp:      uint32(sequence) * 960,
+			},
+			Payload: mediaPayload(sequence),
+		})
+	}
+}
+
+// sent returns the sequence number of the last packet sent.
+func (s *mediaSender) sent() uint16 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sequence
+}
+
+func (s *mediaSender) stop() {
+	close(s.done)
+	s.wg.Wait()
+}
+
+// mediaReceiver reads the remote track and keeps the sequence number of the
+// last packet received.
+type mediaReceiver struct {
+	codec webrtc.RTPCodecCapability
+
+	mu       sync.Mutex
+	sequence uint16
+	err      error
+}
+
+func (r *mediaReceiver) read(track *webrtc.TrackRemote) {
+	if !strings.EqualFold(track.Codec().MimeType, r.codec.MimeType) {
+		r.fail(fmt.Errorf("%w: %s", errCodecMismatch, track.Codec().MimeType))
+
+		return
+	}
+
+	for {
+		packet, _, err := track.ReadRTP()
+		if err != nil {
+			return
+		}
+		if !bytes.Equal(packet.Payload, mediaPayload(packet.SequenceNumber)) {
+			r.fail(errPayloadMismatch)
+
+			return
+		}
+
+		r.mu.Lock()
+		r.sequence = packet.SequenceNumber
+		r.mu.Unlock()
+	}
+}
+
+func (r *mediaReceiver) fail(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.err = err
+}
+
+// receivedAfter waits for a packet sent after the one with the sequence number.
+func (r *mediaReceiver) receivedAfter(ctx context.Context, sequence uint16) error {
+	var err error
+	waitErr := waitFor(ctx, "RTP packets", func() bool {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		err = r.err
+
+		return r.err != nil || r.sequence > sequence
+	})
+
+	return errors.Join(err, waitErr)
+}
+
+func mediaPayload(sequence uint16) []byte {
+	return bytes.Repeat([]byte{byte(sequence)}, mediaPayloadSize)
+}
@@ -0,0 +1,293 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+// Package dcnet provides net.Conn, net.Listener and dialer implementations
+// backed by DataChannels, so protocols and libraries written for TCP
+// connections, like gRPC, can use a PeerConnection as their transport.
+//
+// Connections use detached DataChannels, the PeerConnections have to be created
+// with an API whose SettingEngine has DetachDataChannels enabled.
+package dcnet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pion/datachannel"
+	"github.com/pion/transport/v4/deadline"
+	"github.com/pion/webrtc/v4"
+)
+
+const (
+	// maxWriteSize is the largest message a Conn sends, the size every
+	// DataChannel implementation accepts.
+	maxWriteSize = 16384
+
+	// readBufferSize is the largest message a Conn can receive, the default
+	// maximum message size of the SCTP transport.
+	readBufferSize = 65536
+
+	// Writes block while more than bufferedAmountHigh bytes are queued, until
+	// the queue drains below bufferedAmountLow.
+	bufferedAmountHigh = 1024 * 1024
+	bufferedAmountLow  = 512 * 1024
+)
+
+var (
+	errDataChannelClosed     = errors.New("dcnet: DataChannel closed before it opened")
+	errDataChannelUnreliable = errors.New("dcnet: DataChannel is not reliable and ordered")
+)
+
+// Addr is the address of a DataChannel. Both ends of a Conn have the same
+// address, as DataChannels are symmetric.
+type Addr struct {
+	Label    string
+	Protocol string
+}
+
+// Network returns "webrtc".
+func (a Addr) Network() string {
+	return "webrtc"
+}
+
+func (a Addr) String() string {
+	return fmt.Sprintf("%s/%s", a.Protocol, a.Label)
+}
+
+// Conn is a net.Conn that carries a byte stream over a reliable, ordered
+// DataChannel. Writes are split into messages of at most 16KiB and block
+// while the DataChannel's buffered amount is high. Messages of any size up to
+// 64KiB are received, so the remote end doesn't have to be a Conn.
+type Conn struct {
+	dataChannel *webrtc.DataChannel
+	rwc         datachannel.ReadWriteCloserDeadliner
+	addr        Addr
+
+	readMu  sync.Mutex
+	readBuf []byte
+	pending []byte
+
+	writeMu       sync.Mutex
+	writable      chan struct{}
+	writeDeadline *deadline.Deadline
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+// NewConn detaches an open DataChannel and returns a Conn using it. It is
+// usually called from the DataChannel's OnOpen handler.
+func NewConn(dataChannel *webrtc.DataChannel) (*Conn, error) {
+	if !isReliable(dataChannel) {
+		return nil, errDataChannelUnreliable
+	}
+
+	rwc, err := dataChannel.DetachWithDeadline()
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &Conn{
+		dataChannel:   dataChannel,
+		rwc:           rwc,
+		addr:          Addr{Label: dataChannel.Label(), Protocol: dataChannel.Protocol()},
+		readBuf:       make([]byte, readBufferSize),
+		writable:      make(chan struct{}, 1),
+		writeDeadline: deadline.New(),
+		closed:        make(chan struct{}),
+	}
+
+	dataChannel.SetBufferedAmountLowThreshold(bufferedAmountLow)
+	dataChannel.OnBufferedAmountLow(func() {
+		select {
+		case conn.writable <- struct{}{}:
+		default:
+		}
+	})
+
+	return conn, nil
+}
+
+// Dial creates a DataChannel on the PeerConnection and returns a Conn once it
+// is open. The DataChannel has to be reliable and ordered, init may set its
+// protocol. It opens once the PeerConnection's SCTP transport is connected, so
+// the PeerConnection has to negotiate an application media section.
+func Dial(ctx context.Context, pc *webrtc.PeerConnection, label string, init *webrtc.DataChannelInit) (*Conn, error) {
+	dataChannel, err := pc.CreateDataChannel(label, init)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isReliable(dataChannel) {
+		_ = dataChannel.Close()
+
+		return nil, errDataChannelUnreliable
+	}
+
+	// The DataChannel is detached in OnOpen, detaching later logs a warning.
+	result := make(chan dialResult, 1)
+	dataChannel.OnOpen(func() {
+		conn, err := NewConn(dataChannel)
+		select {
+		case result <- dialResult{conn, err}:
+		default:
+			if conn != nil {
+				_ = conn.Close()
+			}
+		}
+	})
+	dataChannel.OnClose(func() {
+		select {
+		case result <- dialResult{err: errDataChannelClosed}:
+		default:
+		}
+	})
+
+	select {
+	case res := <-result:
+		if res.err != nil {
+			_ = dataChannel.Close()
+		}
+
+		return res.conn, res.err
+	case <-ctx.Done():
+		_ = dataChannel.Close()
+
+		return nil, ctx.Err()
+	}
+}
+
+func isReliable(dataChannel *webrtc.DataChannel) bool {
+	return dataChannel.Ordered() && dataChannel.MaxRetransmits() == nil && dataChannel.MaxPacketLifeTime() == nil
+}
+
+type dialResult struct {
+	conn *Conn
+	err  error
+}
+
+// DataChannel returns the DataChannel of the Conn.
+func (c *Conn) DataChannel() *webrtc.DataChannel {
+	return c.dataChannel
+}
+
+// Read reads data received on the DataChannel. io.EOF is returned once the
+// remote end closed the DataChannel.
+func (c *Conn) Read(b []byte) (int, error) {
+	c.readMu.Lock()
+	defer c.readMu.Unlock()
+
+	for len(c.pending) == 0 {
+		n, err := c.rwc.Read(c.readBuf)
+		if err != nil {
+			return 0, c.mapError(err)
+		}
+		c.pending = c.readBuf[:n]
+	}
+
+	n := copy(b, c.pending)
+	c.pending = c.pending[n:]
+
+	return n, nil
+}
+
+// Write sends b on the DataChannel.
+func (c *Conn) Write(b []byte) (int, error) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	var written int
+	for len(b) > 0 {
+		if err := c.waitWritable(); err != nil {
+			return written, err
+		}
+
+		message := b[:min(len(b), maxWriteSize)]
+		n, err := c.rwc.Write(message)
+		written += n
+		if err != nil {
+			return written, c.mapError(err)
+		}
+		b = b[len(message):]
+	}
+
+	return written, nil
+}
+
+func (c *Conn) waitWritable() error {
+	for c.dataChannel.BufferedAmount() > bufferedAmountHigh {
+		select {
+		case <-c.writable:
+		case <-c.writeDeadline.Done():
+			return os.ErrDeadlineExceeded
+		case <-c.closed:
+			return net.ErrClosed
+		}
+	}
+
+	return nil
+}
+
+func (c *Conn) mapError(err error) error {
+	select {
+	case <-c.closed:
+		return net.ErrClosed
+	default:
+	}
+
+	if errors.Is(err, io.EOF) {
+		return io.EOF
+	}
+
+	return err
+}
+
+// Close closes the DataChannel.
+func (c *Conn) Close() error {
+	err := net.ErrClosed
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		err = c.dataChannel.Close()
+	})
+
+	return err
+}
+
+// LocalAddr returns the address of the DataChannel.
+func (c *Conn) LocalAddr() net.Addr {
+	return c.addr
+}
+
+// RemoteAddr returns the address of the DataChannel.
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.addr
+}
+
+// SetDeadline sets the read and write deadlines.
+func (c *Conn) SetDeadline(t time.Time) error {
+	if err := c.SetReadDeadline(t); err != nil {
+		return err
+	}
+
+	return c.SetWriteDeadline(t)
+}
+
+// SetReadDeadline sets the deadline of Read calls.
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	return c.rwc.SetReadDeadline(t)
+}
+
+// SetWriteDeadline sets the deadline of Write calls.
+func (c *Conn) SetWriteDeadline(t time.Time) error {
+	c.writeDeadline.Set(t)
+
+	return c.rwc.SetWriteDeadline(t)
+}
@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package dcnet
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/pion/webrtc/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newConnectedPair returns two connected PeerConnections with detached
+// DataChannels and an established SCTP association.
+func newConnectedPair(t *testing.T) (*webrtc.PeerConnection, *webrtc.PeerConnection) {
+	t.Helper()
+
+	settingEngine := webrtc.SettingEngine{}
+	settingEngine.DetachDataChannels()
+	api := webrtc.NewAPI(webrtc.WithSettingEngine(settingEngine))
+
+	offerer, err := api.NewPeerConnection(webrtc.Configuration{})
+	require.NoError(t, err)
+	answerer, err := api.NewPeerConnection(webrtc.Configuration{})
+	require.NoError(t, err)
+
+	// Negotiates the SCTP transport, the channel itself is unused.
+	_, err = offerer.CreateDataChannel("negotiate", nil)
+	require.NoError(t, err)
+
+	connected := make(chan struct{})
+	offerer.OnConnectionStateChange(func(state webrtc.PeerConnectionState) {
+		if state == webrtc.PeerConnectionStateConnected {
+			close(connected)
+		}
+	})
+
+	offer, err := offerer.CreateOffer(nil)
+	require.NoError(t, err)
+	offerGathered := webrtc.GatheringCompletePromise(offerer)
+	require.NoError(t, offerer.SetLocalDescription(offer))
+	<-offerGathered
+	require.NoError(t, answerer.SetRemoteDescription(*offerer.LocalDescription()))
+
+	answer, err := answerer.CreateAnswer(nil)
+	require.NoError(t, err)
+	answerGathered := webrtc.GatheringCompletePromise(answerer)
+	require.NoError(t, answerer.SetLocalDescription(answer))
+	<-answerGathered
+	require.NoError(t, offerer.SetRemoteDescription(*answerer.LocalDescription()))
+	<-connected
+
+	return offerer, answerer
+}
+
+func TestDialListen(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	offerer, answerer := newConnectedPair(t)
+
+	listener := Listen("echo", answerer)
+	assert.Equal(t, Addr{Protocol: "echo"}, listener.Addr())
+
+	echoDone := make(chan struct{})
+	go func() {
+		defer close(echoDone)
+
+		conn, err := listener.Accept()
+		if !assert.NoError(t, err) {
+			return
+		}
+		_, err = io.Copy(conn, conn)
+		assert.NoError(t, err)
+		assert.NoError(t, conn.Close())
+	}()
+
+	protocol := "echo"
+	conn, err := Dial(context.Background(), offerer, "client", &webrtc.DataChannelInit{Protocol: &protocol})
+	require.NoError(t, err)
+	assert.Equal(t, Addr{Label: "client", Protocol: "echo"}, conn.RemoteAddr())
+
+	// Larger than a read buffer and not a multiple of the message size.
+	sent := make([]byte, readBufferSize*3+100)
+	_, err = rand.Read(sent)
+	require.NoError(t, err)
+
+	writeDone := make(chan struct{})
+	go func() {
+		defer close(writeDone)
+		_, writeErr := conn.Write(sent)
+		assert.NoError(t, writeErr)
+	}()
+
+	received := make([]byte, len(sent))
+	_, err = io.ReadFull(conn, received)
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(sent, received))
+	<-writeDone
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(10*time.Millisecond)))
+	_, err = conn.Read(received)
+	var netErr net.Error
+	require.ErrorAs(t, err, &netErr)
+	assert.True(t, netErr.Timeout())
+	require.NoError(t, conn.SetReadDeadline(time.Time{}))
+
+	require.NoError(t, conn.Close())
+	assert.ErrorIs(t, conn.Close(), net.ErrClosed)
+	<-echoDone
+
+	require.NoError(t, listener.Close())
+	_, err = listener.Accept()
+	assert.ErrorIs(t, err, net.ErrClosed)
+
+	assert.NoError(t, offerer.Close())
+	assert.NoError(t, answerer.Close())
+}
+
+func TestDialer(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	offerer, answerer := newConnectedPair(t)
+	listener := Listen("grpc", answerer)
+	dialer := &Dialer{PeerConnection: offerer, Protocol: "grpc"}
+
+	// Every connection is a DataChannel of its own.
+	for _, address := range []string{"first", "second"} {
+		conn, err := dialer.DialContext(context.Background(), address)
+		require.NoError(t, err)
+
+		accepted, err := listener.Accept()
+		require.NoError(t, err)
+		assert.Equal(t, Addr{Label: address, Protocol: "grpc"}, accepted.LocalAddr())
+
+		_, err = conn.Write([]byte(address))
+		require.NoError(t, err)
+
+		received := make([]byte, len(address))
+		_, err = io.ReadFull(accepted, received)
+		require.NoError(t, err)
+		assert.Equal(t, address, string(received))
+
+		assert.NoError(t, conn.Close())
+		assert.NoError(t, accepted.Close())
+	}
+
+	assert.NoError(t, listener.Close())
+	assert.NoError(t, offerer.Close())
+	assert.NoError(t, answerer.Close())
+}
+
+func TestDial_ContextCanceled(t *testing.T) {
+	lim := test.TimeOut(time.Second * 10)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	settingEngine := webrtc.SettingEngine{}
+	settingEngine.DetachDataChannels()
+	pc, err := webrtc.NewAPI(webrtc.WithSettingEngine(settingEngine)).NewPeerConnection(webrtc.Configuration{})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = Dial(ctx, pc, "unconnected", nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	assert.NoError(t, pc.Close())
+}
@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package dcnet
+
+import (
+	"context"
+	"net"
+
+	"github.com/pion/webrtc/v4"
+)
+
+// Dialer opens connections on a PeerConnection, every connection is a new
+// DataChannel. Its DialContext method has the signature of grpc-go's
+// WithContextDialer option, so a gRPC client can reach a server that serves a
+// Listener of the same protocol on the remote peer:
+//
+//	dialer := &dcnet.Dialer{PeerConnection: pc, Protocol: "grpc"}
+//	client, err := grpc.NewClient("passthrough:///peer",
+//		grpc.WithContextDialer(dialer.DialContext),
+//		grpc.WithTransportCredentials(insecure.NewCredentials()))
+//
+// The DataChannels are already encrypted by DTLS.
+type Dialer struct {
+	PeerConnection *webrtc.PeerConnection
+
+	// Protocol is the protocol of the DataChannels, matched by the remote
+	// peer's Listener.
+	Protocol string
+}
+
+// DialContext opens a connection whose DataChannel is labeled with address.
+func (d *Dialer) DialContext(ctx context.Context, address string) (net.Conn, error) {
+	protocol := d.Protocol
+
+	return Dial(ctx, d.PeerConnection, address, &webrtc.DataChannelInit{Protocol: &protocol})
+}
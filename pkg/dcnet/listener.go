@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package dcnet
+
+import (
+	"net"
+	"slices"
+	"sync"
+
+	"github.com/pion/webrtc/v4"
+)
+
+// Listener is a net.Listener that accepts the DataChannels of a protocol the
+// remote peers of its PeerConnections open. Channels that aren't reliable and
+// ordered are closed. A single Listener can serve any
+// number of PeerConnections.
+type Listener struct {
+	protocol string
+
+	mu              sync.Mutex
+	peerConnections []*webrtc.PeerConnection
+
+	conns     chan *Conn
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+// Listen returns a Listener for the DataChannels with the given protocol. It
+// replaces the PeerConnections' OnDataChannelProtocol handler of the protocol,
+// channels of other protocols are still passed to their OnDataChannel handler.
+func Listen(protocol string, peerConnections ...*webrtc.PeerConnection) *Listener {
+	listener := &Listener{
+		protocol: protocol,
+		conns:    make(chan *Conn),
+		closed:   make(chan struct{}),
+	}
+
+	for _, pc := range peerConnections {
+		listener.AddPeerConnection(pc)
+	}
+
+	return listener
+}
+
+// AddPeerConnection accepts the DataChannels opened on a PeerConnection.
+func (l *Listener) AddPeerConnection(pc *webrtc.PeerConnection) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	select {
+	case <-l.closed:
+		return
+	default:
+	}
+
+	if !slices.Contains(l.peerConnections, pc) {
+		l.peerConnections = append(l.peerConnections, pc)
+	}
+	pc.OnDataChannelProtocol(l.protocol, l.handleDataChannel)
+}
+
+// RemovePeerConnection stops accepting DataChannels on a PeerConnection.
+// Connections that were already accepted stay open.
+func (l *Listener) RemovePeerConnection(pc *webrtc.PeerConnection) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if i := slices.Index(l.peerConnections, pc); i != -1 {
+		l.peerConnections = slices.Delete(l.peerConnections, i, i+1)
+		pc.OnDataChannelProtocol(l.protocol, nil)
+	}
+}
+
+func (l *Listener) handleDataChannel(dataChannel *webrtc.DataChannel) {
+	dataChannel.OnOpen(func() {
+		conn, err := NewConn(dataChannel)
+		if err != nil {
+			_ = dataChannel.Close()
+
+			return
+		}
+
+		select {
+		case l.conns <- conn:
+		case <-l.closed:
+			_ = conn.Close()
+		}
+	})
+}
+
+// Accept waits for and returns the next connection.
+func (l *Listener) Accept() (net.Conn, error) {
+	conn, err := l.AcceptConn()
+	if err != nil {
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// AcceptConn is like Accept, but returns a *Conn.
+func (l *Listener) AcceptConn() (*Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+// Close stops accepting DataChannels on all PeerConnections. Connections that
+// were already accepted stay open.
+func (l *Listener) Close() error {
+	err := net.ErrClosed
+	l.closeOnce.Do(func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		close(l.closed)
+		for _, pc := range l.peerConnections {
+			pc.OnDataChannelProtocol(l.protocol, nil)
+		}
+		l.peerConnections = nil
+		err = nil
+	})
+
+	return err
+}
+
+// Addr returns an Addr with the Listener's protocol.
+func (l *Listener) Addr() net.Addr {
+	return Addr{Protocol: l.protocol}
+}
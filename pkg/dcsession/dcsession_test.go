@@ -0,0 +1,202 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package dcsession
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/pion/webrtc/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newConnectedPair returns two connected PeerConnections with an established
+// SCTP association.
+func newConnectedPair(t *testing.T) (*webrtc.PeerConnection, *webrtc.PeerConnection) {
+	t.Helper()
+
+	offerer, err := webrtc.NewPeerConnection(webrtc.Configuration{})
+	require.NoError(t, err)
+	answerer, err := webrtc.NewPeerConnection(webrtc.Configuration{})
+	require.NoError(t, err)
+
+	// Negotiates the SCTP transport, the channel itself is unused.
+	_, err = offerer.CreateDataChannel("negotiate", nil)
+	require.NoError(t, err)
+
+	connected := make(chan struct{})
+	offerer.OnConnectionStateChange(func(state webrtc.PeerConnectionState) {
+		if state == webrtc.PeerConnectionStateConnected {
+			close(connected)
+		}
+	})
+
+	offer, err := offerer.CreateOffer(nil)
+	require.NoError(t, err)
+	offerGathered := webrtc.GatheringCompletePromise(offerer)
+	require.NoError(t, offerer.SetLocalDescription(offer))
+	<-offerGathered
+	require.NoError(t, answerer.SetRemoteDescription(*offerer.LocalDescription()))
+
+	answer, err := answerer.CreateAnswer(nil)
+	require.NoError(t, err)
+	answerGathered := webrtc.GatheringCompletePromise(answerer)
+	require.NoError(t, answerer.SetLocalDescription(answer))
+	<-answerGathered
+	require.NoError(t, offerer.SetRemoteDescription(*answerer.LocalDescription()))
+	<-connected
+
+	return offerer, answerer
+}
+
+func TestSession_Resume(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	// Nothing is acknowledged before the first PeerConnections fail, so every
+	// message is sent again and the duplicates have to be dropped.
+	config := Config{AckDelay: time.Hour}
+
+	accepted := make(chan *Session, 1)
+	acceptedReceived := make(chan string, 10)
+	listener := Listen(config, func(session *Session) {
+		session.OnMessage(func(msg webrtc.DataChannelMessage) {
+			assert.True(t, msg.IsString)
+			acceptedReceived <- string(msg.Data)
+		})
+		accepted <- session
+	})
+
+	offerer, answerer := newConnectedPair(t)
+	listener.AddPeerConnection(answerer)
+
+	session, err := New(config)
+	require.NoError(t, err)
+	dialerReceived := make(chan []byte, 10)
+	session.OnMessage(func(msg webrtc.DataChannelMessage) {
+		assert.False(t, msg.IsString)
+		dialerReceived <- msg.Data
+	})
+	detached := make(chan struct{}, 1)
+	session.OnDetach(func() {
+		detached <- struct{}{}
+	})
+	closed := make(chan error, 1)
+	session.OnClose(func(err error) {
+		closed <- err
+	})
+
+	require.NoError(t, session.Dial(offerer, "session"))
+	for i := range 3 {
+		require.NoError(t, session.SendText(fmt.Sprintf("message %d", i)))
+	}
+	for i := range 3 {
+		assert.Equal(t, fmt.Sprintf("message %d", i), <-acceptedReceived)
+	}
+	remote := <-accepted
+	require.NoError(t, remote.Send([]byte{1}))
+	assert.Equal(t, []byte{1}, <-dialerReceived)
+
+	// The PeerConnections fail, messages sent meanwhile are queued.
+	require.NoError(t, offerer.Close())
+	require.NoError(t, answerer.Close())
+	<-detached
+	require.NoError(t, session.SendText("message 3"))
+	require.NoError(t, remote.Send([]byte{2}))
+
+	offerer, answerer = newConnectedPair(t)
+	listener.AddPeerConnection(answerer)
+	require.NoError(t, session.Dial(offerer, "session"))
+
+	assert.Equal(t, "message 3", <-acceptedReceived)
+	assert.Equal(t, []byte{2}, <-dialerReceived)
+	assert.Empty(t, accepted, "the Session was accepted again")
+
+	require.NoError(t, session.Close())
+	assert.NoError(t, <-closed)
+	assert.ErrorIs(t, session.SendText("closed"), ErrClosed)
+	assert.NoError(t, listener.Close())
+
+	assert.NoError(t, offerer.Close())
+	assert.NoError(t, answerer.Close())
+}
+
+func TestSession_Lost(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	config := Config{AckDelay: time.Millisecond}
+	received := make(chan string, 1)
+	listener := Listen(config, func(session *Session) {
+		session.OnMessage(func(msg webrtc.DataChannelMessage) {
+			received <- string(msg.Data)
+		})
+	})
+
+	offerer, answerer := newConnectedPair(t)
+	listener.AddPeerConnection(answerer)
+
+	session, err := New(config)
+	require.NoError(t, err)
+	closed := make(chan error, 1)
+	session.OnClose(func(err error) {
+		closed <- err
+	})
+	require.NoError(t, session.Dial(offerer, "session"))
+	require.NoError(t, session.SendText("acknowledged"))
+	assert.Equal(t, "acknowledged", <-received)
+
+	// A new Listener doesn't know the message was received.
+	assert.Eventually(t, func() bool {
+		session.mu.Lock()
+		defer session.mu.Unlock()
+
+		return len(session.unacknowledged) == 0
+	}, time.Second, time.Millisecond)
+	assert.NoError(t, listener.Close())
+	assert.NoError(t, answerer.Close())
+	assert.NoError(t, offerer.Close())
+
+	listener = Listen(config, nil)
+	offerer, answerer = newConnectedPair(t)
+	listener.AddPeerConnection(answerer)
+	require.NoError(t, session.Dial(offerer, "session"))
+	assert.ErrorIs(t, <-closed, ErrSessionLost)
+
+	assert.NoError(t, listener.Close())
+	assert.NoError(t, offerer.Close())
+	assert.NoError(t, answerer.Close())
+}
+
+func TestSession_HelloID(t *testing.T) {
+	session, err := New(Config{})
+	require.NoError(t, err)
+
+	hello := make([]byte, helloLength)
+	hello[0] = frameHello
+	copy(hello[1+seqLength:], session.id[:])
+
+	session.mu.Lock()
+	defer session.mu.Unlock()
+
+	// The hello of another Session doesn't resume the dialing one.
+	other := append([]byte{}, hello...)
+	other[len(other)-1] ^= 0xff
+	assert.ErrorIs(t, session.handleHello(other), errSessionIDMismatch)
+	assert.False(t, session.resumed)
+
+	assert.NoError(t, session.handleHello(hello))
+	assert.True(t, session.resumed)
+}
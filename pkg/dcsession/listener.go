@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package dcsession
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/pion/webrtc/v4"
+)
+
+// Listener accepts the Sessions the remote peers of its PeerConnections dial.
+// The DataChannels of a Session it accepted before are attached to it again,
+// also when they are opened on another PeerConnection of the Listener. The
+// Session is found by its ID alone, so the PeerConnections of a Listener have
+// to belong to peers that may take over each other's Sessions, like the
+// connections of a single user.
+type Listener struct {
+	config    Config
+	onSession func(*Session)
+
+	mu              sync.Mutex
+	peerConnections []*webrtc.PeerConnection
+	sessions        map[[sessionIDLength]byte]*Session
+	closed          bool
+}
+
+// Listen returns a Listener that invokes onSession for every new Session,
+// before the Session receives its first message, so onSession sets its
+// handlers. It replaces the PeerConnections' OnDataChannelProtocol handler of
+// Protocol.
+func Listen(config Config, onSession func(*Session), peerConnections ...*webrtc.PeerConnection) *Listener {
+	listener := &Listener{
+		config:    config,
+		onSession: onSession,
+		sessions:  map[[sessionIDLength]byte]*Session{},
+	}
+
+	for _, pc := range peerConnections {
+		listener.AddPeerConnection(pc)
+	}
+
+	return listener
+}
+
+// AddPeerConnection accepts the Sessions dialed on a PeerConnection, like on
+// the PeerConnection replacing a failed one.
+func (l *Listener) AddPeerConnection(pc *webrtc.PeerConnection) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return
+	}
+
+	if !slices.Contains(l.peerConnections, pc) {
+		l.peerConnections = append(l.peerConnections, pc)
+	}
+	pc.OnDataChannelProtocol(Protocol, l.handleDataChannel)
+}
+
+// RemovePeerConnection stops accepting Sessions on a PeerConnection.
+func (l *Listener) RemovePeerConnection(pc *webrtc.PeerConnection) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if i := slices.Index(l.peerConnections, pc); i != -1 {
+		l.peerConnections = slices.Delete(l.peerConnections, i, i+1)
+		pc.OnDataChannelProtocol(Protocol, nil)
+	}
+}
+
+// Close stops accepting Sessions, the accepted Sessions stay open but can't
+// be resumed anymore.
+func (l *Listener) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return ErrClosed
+	}
+	l.closed = true
+	for _, pc := range l.peerConnections {
+		pc.OnDataChannelProtocol(Protocol, nil)
+	}
+	l.peerConnections = nil
+	l.sessions = nil
+
+	return nil
+}
+
+// handleDataChannel waits for the hello of the dialing Session on the
+// DataChannel.
+func (l *Listener) handleDataChannel(dataChannel *webrtc.DataChannel) {
+	var once sync.Once
+	dataChannel.OnMessage(func(msg webrtc.DataChannelMessage) {
+		once.Do(func() {
+			l.handleHello(dataChannel, msg.Data)
+		})
+	})
+}
+
+func (l *Listener) handleHello(dataChannel *webrtc.DataChannel, frame []byte) {
+	if len(frame) != helloLength || frame[0] != frameHello {
+		_ = dataChannel.Close()
+
+		return
+	}
+
+	var id [sessionIDLength]byte
+	copy(id[:], frame[1+seqLength:])
+
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		_ = dataChannel.Close()
+
+		return
+	}
+	session, known := l.sessions[id]
+	if !known {
+		session = newSession(l.config)
+		session.id = id
+		session.release = func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+
+			if l.sessions[id] == session {
+				delete(l.sessions, id)
+			}
+		}
+		l.sessions[id] = session
+	}
+	l.mu.Unlock()
+
+	if !known && l.onSession != nil {
+		l.onSession(session)
+	}
+	if err := session.attach(dataChannel); err != nil {
+		return
+	}
+	session.handleFrame(dataChannel, frame)
+}
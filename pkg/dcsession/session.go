@@ -0,0 +1,519 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+// Package dcsession provides sessions, logical channels of messages that
+// survive the DataChannels carrying them. A Session numbers the messages it
+// sends and keeps them until the remote Session acknowledges them. When the
+// DataChannel closes, like after a failed ICE restart, the application
+// attaches a new one, possibly on a new PeerConnection, and both Sessions
+// resend the messages the other one didn't receive. The application sees every
+// message once and in order.
+//
+// The dialing peer creates a Session with New and a DataChannel with Dial for
+// every connection, the other peer accepts Sessions with a Listener, which
+// attaches the DataChannels of known Sessions to them again. A DataChannel
+// that survives an ICE restart keeps carrying its Session.
+//
+// A Session is identified by a random ID the dialing peer sends in the first
+// frame of every DataChannel. A Listener attaches a DataChannel to the Session
+// of the ID on any of its PeerConnections, so anyone who learns the ID of a
+// Session can take it over. The ID is only carried by the DataChannels, which
+// are encrypted, keep it confidential if the application logs or stores it.
+package dcsession
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/pion/webrtc/v4"
+)
+
+// Protocol is the protocol of the DataChannels carrying Sessions.
+const Protocol = "pion-session"
+
+const (
+	frameHello byte = iota + 1
+	frameData
+	frameAck
+	frameClose
+
+	sessionIDLength = 16
+	seqLength       = 8
+
+	// A hello carries the number of the last message received and the ID of
+	// the Session, a data frame the number of the message and its type.
+	helloLength      = 1 + seqLength + sessionIDLength
+	dataHeaderLength = 1 + seqLength + 1
+
+	defaultMaxUnacknowledgedBytes = 4 << 20
+	defaultAckDelay               = 50 * time.Millisecond
+)
+
+var (
+	// ErrClosed indicates that the Session is closed.
+	ErrClosed = errors.New("dcsession: session closed")
+
+	// ErrBufferFull indicates that Send would exceed
+	// Config.MaxUnacknowledgedBytes.
+	ErrBufferFull = errors.New("dcsession: too many unacknowledged bytes")
+
+	// ErrSessionLost indicates that the remote Session lost messages it
+	// acknowledged before, like because the remote peer restarted, so the
+	// Session can't be resumed.
+	ErrSessionLost = errors.New("dcsession: remote session lost acknowledged messages")
+
+	errInvalidFrame          = errors.New("dcsession: invalid frame")
+	errSessionIDMismatch     = errors.New("dcsession: hello of another session")
+	errAcceptedSessionDial   = errors.New("dcsession: accepted sessions can't dial")
+	errDataChannelUnreliable = errors.New("dcsession: DataChannel is not reliable and ordered")
+)
+
+// Config configures a Session.
+type Config struct {
+	// MaxUnacknowledgedBytes bounds the bytes of the sent messages the Session
+	// keeps until the remote Session acknowledges them, Send returns
+	// ErrBufferFull beyond it. Leave it 0 for the default of 4MiB.
+	MaxUnacknowledgedBytes int
+
+	// AckDelay is the time the Session waits to acknowledge the received
+	// messages in one frame. Leave it 0 for the default of 50ms.
+	AckDelay time.Duration
+}
+
+type message struct {
+	seq      uint64
+	isString bool
+	data     []byte
+}
+
+// Session is a logical channel of messages carried by a DataChannel at a time.
+// Messages sent while no DataChannel is attached are sent once the next one is
+// resumed.
+type Session struct {
+	id     [sessionIDLength]byte
+	config Config
+	dialer bool
+
+	mu          sync.Mutex
+	dataChannel *webrtc.DataChannel
+	resumed     bool
+
+	// sent is the number of the last message sent, unacknowledged are the sent
+	// messages the remote Session didn't acknowledge yet.
+	sent                uint64
+	unacknowledged      []message
+	unacknowledgedBytes int
+
+	// received is the number of the last message received, acknowledged the
+	// number of the last message acknowledged.
+	received     uint64
+	acknowledged uint64
+	ackTimer     *time.Timer
+
+	closed      bool
+	onMessage   func(webrtc.DataChannelMessage)
+	onDetach    func()
+	onClose     func(error)
+	onCloseOnce sync.Once
+
+	// release removes an accepted Session from its Listener once it is closed.
+	release func()
+}
+
+// New returns a Session with a new random ID, for the peer that dials.
+func New(config Config) (*Session, error) {
+	session := newSession(config)
+	session.dialer = true
+	if _, err := rand.Read(session.id[:]); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func newSession(config Config) *Session {
+	if config.MaxUnacknowledgedBytes <= 0 {
+		config.MaxUnacknowledgedBytes = defaultMaxUnacknowledgedBytes
+	}
+	if config.AckDelay <= 0 {
+		config.AckDelay = defaultAckDelay
+	}
+
+	return &Session{config: config}
+}
+
+// Dial creates a DataChannel on the PeerConnection for the Session and
+// attaches it, replacing the DataChannel attached before. The remote peer
+// accepts it with a Listener. Dial doesn't wait for the DataChannel to open,
+// messages are sent once the Session is resumed.
+func (s *Session) Dial(pc *webrtc.PeerConnection, label string) error {
+	if !s.dialer {
+		return errAcceptedSessionDial
+	}
+
+	protocol := Protocol
+	dataChannel, err := pc.CreateDataChannel(label, &webrtc.DataChannelInit{Protocol: &protocol})
+	if err != nil {
+		return err
+	}
+
+	if err = s.attach(dataChannel); err != nil {
+		return err
+	}
+	dataChannel.OnOpen(func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if s.dataChannel == dataChannel {
+			s.writeHello()
+		}
+	})
+
+	return nil
+}
+
+// OnMessage sets the handler of the messages received from the remote Session.
+func (s *Session) OnMessage(f func(msg webrtc.DataChannelMessage)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.onMessage = f
+}
+
+// OnDetach sets the handler invoked when the attached DataChannel closes, the
+// dialing peer attaches a new one with Dial then.
+func (s *Session) OnDetach(f func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.onDetach = f
+}
+
+// OnClose sets the handler invoked once the Session is closed, by either peer
+// or with ErrSessionLost if it can't be resumed.
+func (s *Session) OnClose(f func(err error)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.onClose = f
+}
+
+// Send sends the binary message to the remote Session.
+func (s *Session) Send(data []byte) error {
+	return s.send(data, false)
+}
+
+// SendText sends the text message to the remote Session.
+func (s *Session) SendText(text string) error {
+	return s.send([]byte(text), true)
+}
+
+func (s *Session) send(data []byte, isString bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrClosed
+	}
+	if s.unacknowledgedBytes+len(data) > s.config.MaxUnacknowledgedBytes {
+		return ErrBufferFull
+	}
+
+	s.sent++
+	msg := message{seq: s.sent, isString: isString, data: append([]byte{}, data...)}
+	s.unacknowledged = append(s.unacknowledged, msg)
+	s.unacknowledgedBytes += len(msg.data)
+
+	// Messages that can't be written now are resent once the Session resumes.
+	if s.resumed {
+		s.writeData(msg)
+	}
+
+	return nil
+}
+
+// Close closes the Session and its DataChannel, the remote Session is closed
+// as well.
+func (s *Session) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+
+		return ErrClosed
+	}
+	dataChannel := s.dataChannel
+	if s.resumed {
+		s.write([]byte{frameClose})
+	}
+	s.closeLocked()
+	s.mu.Unlock()
+
+	s.invokeOnClose(nil)
+	if dataChannel != nil {
+		return dataChannel.Close()
+	}
+
+	return nil
+}
+
+// closeLocked marks the Session closed. The caller holds the lock and invokes
+// invokeOnClose afterwards.
+func (s *Session) closeLocked() {
+	s.closed = true
+	s.dataChannel = nil
+	s.resumed = false
+	s.unacknowledged = nil
+	s.unacknowledgedBytes = 0
+	if s.ackTimer != nil {
+		s.ackTimer.Stop()
+		s.ackTimer = nil
+	}
+}
+
+func (s *Session) invokeOnClose(err error) {
+	s.mu.Lock()
+	handler, release := s.onClose, s.release
+	s.mu.Unlock()
+
+	s.onCloseOnce.Do(func() {
+		if release != nil {
+			release()
+		}
+		if handler != nil {
+			handler(err)
+		}
+	})
+}
+
+// attach makes the DataChannel the one carrying the Session, the DataChannel
+// attached before is closed.
+func (s *Session) attach(dataChannel *webrtc.DataChannel) error {
+	if !dataChannel.Ordered() || dataChannel.MaxRetransmits() != nil || dataChannel.MaxPacketLifeTime() != nil {
+		_ = dataChannel.Close()
+
+		return errDataChannelUnreliable
+	}
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		_ = dataChannel.Close()
+
+		return ErrClosed
+	}
+	previous := s.dataChannel
+	s.dataChannel = dataChannel
+	s.resumed = false
+	s.mu.Unlock()
+
+	if previous != nil {
+		_ = previous.Close()
+	}
+
+	dataChannel.OnMessage(func(msg webrtc.DataChannelMessage) {
+		s.handleFrame(dataChannel, msg.Data)
+	})
+	dataChannel.OnClose(func() {
+		s.detach(dataChannel)
+	})
+
+	return nil
+}
+
+func (s *Session) detach(dataChannel *webrtc.DataChannel) {
+	s.mu.Lock()
+	if s.dataChannel != dataChannel {
+		s.mu.Unlock()
+
+		return
+	}
+	s.dataChannel = nil
+	s.resumed = false
+	handler := s.onDetach
+	s.mu.Unlock()
+
+	if handler != nil {
+		handler()
+	}
+}
+
+func (s *Session) handleFrame(dataChannel *webrtc.DataChannel, frame []byte) {
+	s.mu.Lock()
+	if s.dataChannel != dataChannel || len(frame) == 0 {
+		s.mu.Unlock()
+
+		return
+	}
+
+	var err error
+	var deliver *message
+	switch frame[0] {
+	case frameHello:
+		err = s.handleHello(frame)
+	case frameData:
+		deliver, err = s.handleData(frame)
+	case frameAck:
+		err = s.handleAck(frame)
+	case frameClose:
+		s.closeLocked()
+		s.mu.Unlock()
+		s.invokeOnClose(nil)
+		_ = dataChannel.Close()
+
+		return
+	default:
+		err = errInvalidFrame
+	}
+	if err != nil {
+		s.closeLocked()
+		s.mu.Unlock()
+		s.invokeOnClose(err)
+		_ = dataChannel.Close()
+
+		return
+	}
+	handler := s.onMessage
+	s.mu.Unlock()
+
+	// The read loop of the DataChannel invokes the handler for one message at a
+	// time, in order.
+	if deliver != nil && handler != nil {
+		handler(webrtc.DataChannelMessage{IsString: deliver.isString, Data: deliver.data})
+	}
+}
+
+// handleHello resumes the Session, the messages the remote Session didn't
+// receive are sent again. The caller holds the lock.
+func (s *Session) handleHello(frame []byte) error {
+	if len(frame) != helloLength {
+		return errInvalidFrame
+	}
+	// The accepting peer echoes the ID, so the dialing peer doesn't resume a
+	// Session on a DataChannel of another one.
+	if !bytes.Equal(frame[1+seqLength:], s.id[:]) {
+		return errSessionIDMismatch
+	}
+	remoteReceived := binary.BigEndian.Uint64(frame[1:])
+
+	acknowledged := s.sent - uint64(len(s.unacknowledged))
+	if remoteReceived < acknowledged {
+		return ErrSessionLost
+	}
+	if err := s.acknowledge(remoteReceived); err != nil {
+		return err
+	}
+
+	// The accepting peer answers the hello of the dialing peer.
+	if !s.dialer {
+		s.writeHello()
+	}
+	s.resumed = true
+	s.acknowledged = s.received
+	for _, msg := range s.unacknowledged {
+		s.writeData(msg)
+	}
+
+	return nil
+}
+
+// handleData returns the message if it wasn't received before. The caller
+// holds the lock.
+func (s *Session) handleData(frame []byte) (*message, error) {
+	if !s.resumed || len(frame) < dataHeaderLength {
+		return nil, errInvalidFrame
+	}
+
+	seq := binary.BigEndian.Uint64(frame[1:])
+	switch {
+	case seq <= s.received:
+		return nil, nil //nolint:nilnil // The message was received before.
+	case seq != s.received+1:
+		return nil, ErrSessionLost
+	}
+	s.received = seq
+
+	if s.ackTimer == nil {
+		s.ackTimer = time.AfterFunc(s.config.AckDelay, s.writeAck)
+	}
+
+	return &message{
+		seq:      seq,
+		isString: frame[1+seqLength] != 0,
+		data:     frame[dataHeaderLength:],
+	}, nil
+}
+
+// handleAck forgets the acknowledged messages. The caller holds the lock.
+func (s *Session) handleAck(frame []byte) error {
+	if len(frame) != 1+seqLength {
+		return errInvalidFrame
+	}
+
+	return s.acknowledge(binary.BigEndian.Uint64(frame[1:]))
+}
+
+// acknowledge forgets the messages up to seq. The caller holds the lock.
+func (s *Session) acknowledge(seq uint64) error {
+	if seq > s.sent {
+		return errInvalidFrame
+	}
+
+	for len(s.unacknowledged) != 0 && s.unacknowledged[0].seq <= seq {
+		s.unacknowledgedBytes -= len(s.unacknowledged[0].data)
+		s.unacknowledged[0] = message{}
+		s.unacknowledged = s.unacknowledged[1:]
+	}
+
+	return nil
+}
+
+func (s *Session) writeAck() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ackTimer = nil
+	if !s.resumed || s.acknowledged == s.received {
+		return
+	}
+
+	frame := make([]byte, 1+seqLength)
+	frame[0] = frameAck
+	binary.BigEndian.PutUint64(frame[1:], s.received)
+	s.write(frame)
+	s.acknowledged = s.received
+}
+
+// writeHello writes the hello frame. The caller holds the lock.
+func (s *Session) writeHello() {
+	frame := make([]byte, helloLength)
+	frame[0] = frameHello
+	binary.BigEndian.PutUint64(frame[1:], s.received)
+	copy(frame[1+seqLength:], s.id[:])
+	s.write(frame)
+}
+
+// writeData writes the message. The caller holds the lock.
+func (s *Session) writeData(msg message) {
+	frame := make([]byte, dataHeaderLength, dataHeaderLength+len(msg.data))
+	frame[0] = frameData
+	binary.BigEndian.PutUint64(frame[1:], msg.seq)
+	if msg.isString {
+		frame[1+seqLength] = 1
+	}
+	s.write(append(frame, msg.data...))
+}
+
+// write sends the frame on the attached DataChannel. Frames that fail are
+// sent again once the Session resumes on the next DataChannel. The caller
+// holds the lock.
+func (s *Session) write(frame []byte) {
+	if s.dataChannel != nil {
+		_ = s.dataChannel.Send(frame)
+	}
+}
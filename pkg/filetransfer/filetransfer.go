@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+// Package filetransfer implements a file transfer protocol over DataChannels
+// with integrity checking and resumption of interrupted transfers.
+//
+// Every transfer uses a reliable, ordered DataChannel with the
+// "filetransfer-v1" protocol. The sender announces the file with a Manifest
+// carrying its size and SHA-256 hash, the receiver answers with the number of
+// bytes it already holds from an earlier attempt, and the sender transfers the
+// rest in chunks. The receiver verifies the hash of the complete file and
+// reports the result to the sender. A transfer interrupted by a lost
+// connection is resumed by sending the same Manifest again, also on a new
+// PeerConnection.
+//
+// The PeerConnections have to be created with an API whose SettingEngine has
+// DetachDataChannels enabled.
+package filetransfer
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/pion/webrtc/v4"
+	"github.com/pion/webrtc/v4/pkg/dcnet"
+)
+
+// Protocol is the DataChannel protocol of file transfers.
+const Protocol = "filetransfer-v1"
+
+const (
+	// ChunkSize is the unit in which files are transferred and progress is
+	// reported.
+	ChunkSize = 64 * 1024
+
+	frameHeaderSize = 4
+	maxFrameSize    = 64 * 1024
+)
+
+var (
+	// ErrHashMismatch indicates a received file whose SHA-256 hash doesn't match
+	// the Manifest.
+	ErrHashMismatch = errors.New("filetransfer: hash mismatch")
+
+	// ErrRejected indicates a transfer the receiver rejected or failed.
+	ErrRejected = errors.New("filetransfer: transfer rejected by receiver")
+
+	errFrameTooLarge   = errors.New("filetransfer: frame too large")
+	errInvalidManifest = errors.New("filetransfer: invalid manifest")
+)
+
+// Manifest describes a transferred file.
+type Manifest struct {
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+
+	// SHA256 is the hex encoded SHA-256 hash of the file.
+	SHA256 string `json:"sha256"`
+}
+
+// NewManifest creates the Manifest of a file by reading it completely.
+func NewManifest(name string, file io.Reader) (Manifest, error) {
+	hasher := sha256.New()
+	size, err := io.Copy(hasher, file)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	return Manifest{Name: name, Size: size, SHA256: hex.EncodeToString(hasher.Sum(nil))}, nil
+}
+
+func (m Manifest) validate() error {
+	if m.Size < 0 {
+		return fmt.Errorf("%w: negative size", errInvalidManifest)
+	}
+
+	if sum, err := hex.DecodeString(m.SHA256); err != nil || len(sum) != sha256.Size {
+		return fmt.Errorf("%w: invalid SHA-256 hash", errInvalidManifest)
+	}
+
+	return nil
+}
+
+// acceptMessage answers a Manifest.
+type acceptMessage struct {
+	Offset int64  `json:"offset"`
+	Error  string `json:"error,omitempty"`
+}
+
+// resultMessage reports the verification of the received file.
+type resultMessage struct {
+	Error string `json:"error,omitempty"`
+}
+
+// Listen returns a listener accepting the transfers remote peers start on the
+// PeerConnections, to be served by a Receiver.
+func Listen(peerConnections ...*webrtc.PeerConnection) *dcnet.Listener {
+	return dcnet.Listen(Protocol, peerConnections...)
+}
+
+// writeFrame writes a length prefixed JSON message.
+func writeFrame(w io.Writer, message any) error {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	frame := make([]byte, frameHeaderSize, frameHeaderSize+len(payload))
+	binary.BigEndian.PutUint32(frame, uint32(len(payload))) //nolint:gosec // G115
+	_, err = w.Write(append(frame, payload...))
+
+	return err
+}
+
+func readFrame(r io.Reader, message any) error {
+	header := make([]byte, frameHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return err
+	}
+
+	size := binary.BigEndian.Uint32(header)
+	if size > maxFrameSize {
+		return errFrameTooLarge
+	}
+
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return err
+	}
+
+	return json.Unmarshal(payload, message)
+}
+
+// copyChunks copies n bytes in chunks, invoking progress with the total copied
+// so far after every chunk.
+func copyChunks(dst io.Writer, src io.Reader, n, offset int64, hasher hash.Hash, progress func(int64)) error {
+	buffer := make([]byte, ChunkSize)
+	for copied := int64(0); copied < n; {
+		chunk := buffer[:min(int64(len(buffer)), n-copied)]
+		if _, err := io.ReadFull(src, chunk); err != nil {
+			return err
+		}
+
+		if hasher != nil {
+			hasher.Write(chunk)
+		}
+
+		if _, err := dst.Write(chunk); err != nil {
+			return err
+		}
+
+		copied += int64(len(chunk))
+		if progress != nil {
+			progress(offset + copied)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,205 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package filetransfer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/pion/webrtc/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newConnectedPair(t *testing.T) (*webrtc.PeerConnection, *webrtc.PeerConnection) {
+	t.Helper()
+
+	settingEngine := webrtc.SettingEngine{}
+	settingEngine.DetachDataChannels()
+	api := webrtc.NewAPI(webrtc.WithSettingEngine(settingEngine))
+
+	offerer, err := api.NewPeerConnection(webrtc.Configuration{})
+	require.NoError(t, err)
+	answerer, err := api.NewPeerConnection(webrtc.Configuration{})
+	require.NoError(t, err)
+
+	_, err = offerer.CreateDataChannel("negotiate", nil)
+	require.NoError(t, err)
+
+	connected := make(chan struct{})
+	offerer.OnConnectionStateChange(func(state webrtc.PeerConnectionState) {
+		if state == webrtc.PeerConnectionStateConnected {
+			close(connected)
+		}
+	})
+
+	offer, err := offerer.CreateOffer(nil)
+	require.NoError(t, err)
+	offerGathered := webrtc.GatheringCompletePromise(offerer)
+	require.NoError(t, offerer.SetLocalDescription(offer))
+	<-offerGathered
+	require.NoError(t, answerer.SetRemoteDescription(*offerer.LocalDescription()))
+
+	answer, err := answerer.CreateAnswer(nil)
+	require.NoError(t, err)
+	answerGathered := webrtc.GatheringCompletePromise(answerer)
+	require.NoError(t, answerer.SetLocalDescription(answer))
+	<-answerGathered
+	require.NoError(t, offerer.SetRemoteDescription(*answerer.LocalDescription()))
+	<-connected
+
+	return offerer, answerer
+}
+
+// memoryFile is a File in memory.
+type memoryFile struct {
+	mu   sync.Mutex
+	data []byte
+}
+
+func (f *memoryFile) ReadAt(b []byte, off int64) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return bytes.NewReader(f.data).ReadAt(b, off)
+}
+
+func (f *memoryFile) WriteAt(b []byte, off int64) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if end := int(off) + len(b); end > len(f.data) {
+		f.data = append(f.data, make([]byte, end-len(f.data))...)
+	}
+
+	return copy(f.data[off:], b), nil
+}
+
+func (f *memoryFile) bytes() []byte {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]byte{}, f.data...)
+}
+
+type completion struct {
+	manifest Manifest
+	err      error
+}
+
+func TestTransfer(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	sender, receiver := newConnectedPair(t)
+
+	content := make([]byte, 3*ChunkSize+1000)
+	_, err := rand.Read(content)
+	require.NoError(t, err)
+
+	manifest, err := NewManifest("file.bin", bytes.NewReader(content))
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), manifest.Size)
+
+	// The destination of the next transfer and the bytes it already holds.
+	var destination *memoryFile
+	var offset int64
+	completions := make(chan completion, 1)
+	var received []int64
+	transfers := &Receiver{
+		Accept: func(m Manifest) (File, int64, error) {
+			if m.Name != manifest.Name {
+				return nil, 0, errors.New("unexpected file") //nolint:err113
+			}
+
+			return destination, offset, nil
+		},
+		OnProgress: func(_ Manifest, n int64) {
+			received = append(received, n)
+		},
+		OnComplete: func(m Manifest, err error) {
+			completions <- completion{m, err}
+		},
+	}
+
+	listener := Listen(receiver)
+	serveDone := make(chan error, 1)
+	go func() {
+		serveDone <- transfers.Serve(listener)
+	}()
+
+	send := func(m Manifest) ([]int64, error) {
+		var sent []int64
+		err := Send(context.Background(), sender, m, bytes.NewReader(content), func(n int64) {
+			sent = append(sent, n)
+		})
+
+		return sent, err
+	}
+
+	t.Run("Complete", func(t *testing.T) {
+		destination, offset, received = &memoryFile{}, 0, nil
+
+		sent, err := send(manifest)
+		require.NoError(t, err)
+		assert.Equal(t, completion{manifest, nil}, <-completions)
+		assert.Equal(t, content, destination.bytes())
+		assert.Equal(t, []int64{0, ChunkSize, 2 * ChunkSize, 3 * ChunkSize, manifest.Size}, sent)
+		assert.Equal(t, sent[1:], received)
+	})
+
+	t.Run("Resume", func(t *testing.T) {
+		destination, offset, received = &memoryFile{data: append([]byte{}, content[:ChunkSize+10]...)}, ChunkSize+10, nil
+
+		sent, err := send(manifest)
+		require.NoError(t, err)
+		assert.Equal(t, completion{manifest, nil}, <-completions)
+		assert.Equal(t, content, destination.bytes())
+		assert.Equal(t, int64(ChunkSize+10), sent[0])
+	})
+
+	t.Run("HashMismatch", func(t *testing.T) {
+		corrupted := append([]byte{}, content[:ChunkSize]...)
+		corrupted[0]++
+		destination, offset = &memoryFile{data: corrupted}, ChunkSize
+
+		_, err := send(manifest)
+		assert.ErrorIs(t, err, ErrRejected)
+		assert.Equal(t, completion{manifest, ErrHashMismatch}, <-completions)
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		other := manifest
+		other.Name = "other.bin"
+
+		_, err := send(other)
+		assert.ErrorIs(t, err, ErrRejected)
+	})
+
+	t.Run("InvalidManifest", func(t *testing.T) {
+		invalid := manifest
+		invalid.SHA256 = "00"
+
+		_, err := send(invalid)
+		assert.ErrorIs(t, err, errInvalidManifest)
+	})
+
+	require.NoError(t, listener.Close())
+	assert.ErrorIs(t, <-serveDone, net.ErrClosed)
+
+	assert.NoError(t, sender.Close())
+	assert.NoError(t, receiver.Close())
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package filetransfer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net"
+)
+
+// File is the destination of a received file, *os.File implements it.
+type File interface {
+	io.ReaderAt
+	io.WriterAt
+}
+
+// Receiver receives the files remote peers send.
+type Receiver struct {
+	// Accept is invoked for every transfer. It returns the destination of the
+	// file and how many bytes of it an earlier attempt of the same Manifest
+	// received, which the sender skips. A non-nil error rejects the transfer.
+	Accept func(manifest Manifest) (file File, offset int64, err error)
+
+	// OnProgress, if not nil, is invoked after every chunk written to the File
+	// with the number of bytes it holds, including the offset returned by
+	// Accept. It is not invoked for the offset itself.
+	OnProgress func(manifest Manifest, received int64)
+
+	// OnComplete, if not nil, is invoked when an accepted transfer ends. err is
+	// nil if the file was received and verified, ErrHashMismatch if the file
+	// has to be discarded, and other errors leave the file to be resumed.
+	OnComplete func(manifest Manifest, err error)
+}
+
+// Serve receives the transfers accepted by the listener, usually created with
+// Listen, until the listener is closed.
+func (r *Receiver) Serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+
+		go func() {
+			_ = r.Receive(conn)
+		}()
+	}
+}
+
+// Receive receives a single transfer on the connection and closes it.
+func (r *Receiver) Receive(conn net.Conn) error {
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	var manifest Manifest
+	if err := readFrame(conn, &manifest); err != nil {
+		return err
+	}
+
+	if err := manifest.validate(); err != nil {
+		_ = writeFrame(conn, acceptMessage{Error: err.Error()})
+
+		return err
+	}
+
+	file, offset, err := r.Accept(manifest)
+	if err != nil {
+		_ = writeFrame(conn, acceptMessage{Error: err.Error()})
+
+		return err
+	}
+
+	err = r.receive(conn, manifest, file, offset)
+	if r.OnComplete != nil {
+		r.OnComplete(manifest, err)
+	}
+
+	return err
+}
+
+func (r *Receiver) receive(conn net.Conn, manifest Manifest, file File, offset int64) error {
+	if offset < 0 || offset > manifest.Size {
+		offset = 0
+	}
+
+	// The hash covers the part received by earlier attempts.
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, io.NewSectionReader(file, 0, offset)); err != nil {
+		_ = writeFrame(conn, acceptMessage{Error: err.Error()})
+
+		return err
+	}
+
+	if err := writeFrame(conn, acceptMessage{Offset: offset}); err != nil {
+		return err
+	}
+
+	var progress func(int64)
+	if r.OnProgress != nil {
+		progress = func(received int64) {
+			r.OnProgress(manifest, received)
+		}
+	}
+
+	remaining := manifest.Size - offset
+	if err := copyChunks(io.NewOffsetWriter(file, offset), conn, remaining, offset, hasher, progress); err != nil {
+		return err
+	}
+
+	if hex.EncodeToString(hasher.Sum(nil)) != manifest.SHA256 {
+		_ = writeFrame(conn, resultMessage{Error: ErrHashMismatch.Error()})
+
+		return ErrHashMismatch
+	}
+
+	return writeFrame(conn, resultMessage{})
+}
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package filetransfer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/pion/webrtc/v4"
+	"github.com/pion/webrtc/v4/pkg/dcnet"
+)
+
+var errInvalidOffset = errors.New("filetransfer: receiver requested invalid offset")
+
+// Send transfers a file to the Receiver of the remote peer over a new
+// DataChannel labeled with the file name. progress, if not nil, is invoked once
+// the receiver answered the Manifest with the number of bytes it already holds,
+// and then after every chunk written to the DataChannel with that offset plus
+// the bytes sent so far. Sent chunks may still be buffered, so the receiver can
+// lag behind. Send returns nil once the receiver verified the file.
+//
+// After an error the transfer is resumed by calling Send with the same Manifest
+// and file again, the receiver decides which part it still needs.
+func Send(
+	ctx context.Context, pc *webrtc.PeerConnection, manifest Manifest, file io.ReaderAt, progress func(sent int64),
+) error {
+	if err := manifest.validate(); err != nil {
+		return err
+	}
+
+	protocol := Protocol
+	conn, err := dcnet.Dial(ctx, pc, manifest.Name, &webrtc.DataChannelInit{Protocol: &protocol})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.Close()
+	})
+	defer stop()
+
+	if err = send(conn, manifest, file, progress); err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return err
+}
+
+func send(conn io.ReadWriter, manifest Manifest, file io.ReaderAt, progress func(int64)) error {
+	if err := writeFrame(conn, manifest); err != nil {
+		return err
+	}
+
+	var accept acceptMessage
+	if err := readFrame(conn, &accept); err != nil {
+		return err
+	}
+	if accept.Error != "" {
+		return fmt.Errorf("%w: %s", ErrRejected, accept.Error)
+	}
+	if accept.Offset < 0 || accept.Offset > manifest.Size {
+		return errInvalidOffset
+	}
+
+	if progress != nil {
+		progress(accept.Offset)
+	}
+
+	remaining := manifest.Size - accept.Offset
+	section := io.NewSectionReader(file, accept.Offset, remaining)
+	if err := copyChunks(conn, section, remaining, accept.Offset, nil, progress); err != nil {
+		return err
+	}
+
+	var result resultMessage
+	if err := readFrame(conn, &result); err != nil {
+		return err
+	}
+	if result.Error != "" {
+		return fmt.Errorf("%w: %s", ErrRejected, result.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+// Package telephony provides framing and packetization helpers for the G.711
+// and G.722 telephony codecs.
+//
+// All of them produce 8000 bytes per second and use a RTP clock rate of 8000 Hz,
+// G.722 included: it samples at 16 kHz, but RFC 3551 Section 4.5.2 keeps its RTP
+// clock rate at 8000 Hz for historical reasons. RTP timestamps computed from
+// the 16 kHz sample count advance twice as fast as remote endpoints expect.
+package telephony
+
+import "time"
+
+// Codec is a telephony codec with a fixed bitrate of 64 kbit/s.
+type Codec int
+
+const (
+	// CodecUnknown is the enum's zero-value.
+	CodecUnknown Codec = iota
+
+	// CodecPCMU is G.711 µ-law.
+	CodecPCMU
+
+	// CodecPCMA is G.711 A-law.
+	CodecPCMA
+
+	// CodecG722 is G.722 at 64 kbit/s.
+	CodecG722
+)
+
+const (
+	// clockRate is the RTP clock rate of all telephony codecs.
+	clockRate = 8000
+
+	// bytesPerSecond is the size of one second of encoded audio, one byte per RTP clock tick.
+	bytesPerSecond = 8000
+)
+
+func (c Codec) String() string {
+	switch c {
+	case CodecPCMU:
+		return "PCMU"
+	case CodecPCMA:
+		return "PCMA"
+	case CodecG722:
+		return "G722"
+	default:
+		return "unknown"
+	}
+}
+
+// MimeType returns the MIME type of the codec.
+func (c Codec) MimeType() string {
+	if c == CodecUnknown || c > CodecG722 {
+		return ""
+	}
+
+	return "audio/" + c.String()
+}
+
+// PayloadType returns the static RTP payload type of the codec, see RFC 3551 Section 6.
+func (c Codec) PayloadType() uint8 {
+	switch c {
+	case CodecPCMA:
+		return 8
+	case CodecG722:
+		return 9
+	default:
+		return 0
+	}
+}
+
+// SampleRate returns the rate at which the codec samples audio. This is not the
+// RTP clock rate for G.722, see ClockRate.
+func (c Codec) SampleRate() uint32 {
+	if c == CodecG722 {
+		return 16000
+	}
+
+	return 8000
+}
+
+// ClockRate returns the RTP clock rate of the codec, which always is 8000 Hz.
+func (c Codec) ClockRate() uint32 {
+	return clockRate
+}
+
+// FrameSize returns the size in bytes of a frame of the given duration.
+func (c Codec) FrameSize(duration time.Duration) int {
+	return int(duration * bytesPerSecond / time.Second)
+}
+
+// Duration returns the duration of a frame of the given size in bytes.
+func (c Codec) Duration(size int) time.Duration {
+	return time.Duration(size) * time.Second / bytesPerSecond
+}
+
+// IsSilent returns true if no sample of a G.711 frame exceeds the threshold in
+// linear 16-bit PCM. It is used to stop sending during silence (DTX). G.722
+// frames can't be checked without decoding them, so false is returned for them.
+func (c Codec) IsSilent(frame []byte, threshold int16) bool {
+	var decode func(byte) int16
+	switch c {
+	case CodecPCMU:
+		decode = ulawToLinear
+	case CodecPCMA:
+		decode = alawToLinear
+	default:
+		return false
+	}
+
+	for _, b := range frame {
+		sample := decode(b)
+		if sample > threshold || -sample > threshold {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ulawToLinear decodes a G.711 µ-law sample.
+func ulawToLinear(u byte) int16 {
+	u = ^u
+	sample := (int16(u&0x0F) << 3) + 0x84
+	sample <<= (u & 0x70) >> 4
+	if u&0x80 != 0 {
+		return 0x84 - sample
+	}
+
+	return sample - 0x84
+}
+
+// alawToLinear decodes a G.711 A-law sample.
+func alawToLinear(a byte) int16 {
+	a ^= 0x55
+	sample := int16(a&0x0F) << 4
+	switch segment := (a & 0x70) >> 4; segment {
+	case 0:
+		sample += 8
+	case 1:
+		sample += 0x108
+	default:
+		sample += 0x108
+		sample <<= segment - 1
+	}
+	if a&0x80 != 0 {
+		return sample
+	}
+
+	return -sample
+}
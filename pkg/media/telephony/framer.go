@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package telephony
+
+import (
+	"errors"
+	"time"
+
+	"github.com/pion/webrtc/v4/pkg/media"
+)
+
+// DefaultFrameDuration is the usual framing of telephony audio.
+const DefaultFrameDuration = 20 * time.Millisecond
+
+var (
+	errUnknownCodec         = errors.New("unknown telephony codec")
+	errInvalidFrameDuration = errors.New("frame duration must be a positive multiple of 125µs")
+)
+
+// Framer splits an encoded telephony stream into samples of a fixed duration,
+// ready for TrackLocalStaticSample.WriteSample. The track's codec has to use the
+// RTP clock rate returned by Codec.ClockRate, also for G.722.
+type Framer struct {
+	codec     Codec
+	frameSize int
+	buffer    []byte
+}
+
+// NewFramer creates a Framer for frames of the given duration, zero selects
+// DefaultFrameDuration.
+func NewFramer(codec Codec, frameDuration time.Duration) (*Framer, error) {
+	if codec == CodecUnknown || codec > CodecG722 {
+		return nil, errUnknownCodec
+	}
+
+	if frameDuration == 0 {
+		frameDuration = DefaultFrameDuration
+	}
+
+	frameSize := codec.FrameSize(frameDuration)
+	if frameSize <= 0 || codec.Duration(frameSize) != frameDuration {
+		return nil, errInvalidFrameDuration
+	}
+
+	return &Framer{codec: codec, frameSize: frameSize}, nil
+}
+
+// Write adds encoded audio and returns the samples that are complete. The rest
+// is buffered until the next call.
+func (f *Framer) Write(data []byte) []media.Sample {
+	f.buffer = append(f.buffer, data...)
+
+	var samples []media.Sample
+	for len(f.buffer) >= f.frameSize {
+		samples = append(samples, f.sample(f.frameSize))
+	}
+
+	if len(f.buffer) == 0 {
+		f.buffer = nil
+	}
+
+	return samples
+}
+
+// Flush returns the buffered audio as a shorter sample, it returns false if
+// nothing is buffered.
+func (f *Framer) Flush() (media.Sample, bool) {
+	if len(f.buffer) == 0 {
+		return media.Sample{}, false
+	}
+
+	sample := f.sample(len(f.buffer))
+	f.buffer = nil
+
+	return sample, true
+}
+
+// Buffered returns the number of bytes waiting for a complete frame.
+func (f *Framer) Buffered() int {
+	return len(f.buffer)
+}
+
+func (f *Framer) sample(size int) media.Sample {
+	data := make([]byte, size)
+	copy(data, f.buffer)
+	f.buffer = f.buffer[size:]
+
+	return media.Sample{Data: data, Duration: f.codec.Duration(size)}
+}
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package telephony
+
+import (
+	"time"
+
+	"github.com/pion/randutil"
+	"github.com/pion/rtp"
+)
+
+// Packetizer turns telephony frames into RTP packets for TrackLocalStaticRTP,
+// with support for discontinuous transmission: while Skip is called instead of
+// sending frames, the timestamp keeps advancing and the first packet after the
+// silence has the marker bit set, see RFC 3551 Section 4.1. Comfort noise can be
+// sent during the silence with RTPSender.SetComfortNoise.
+type Packetizer struct {
+	codec       Codec
+	payloadType uint8
+	ssrc        uint32
+	sequencer   rtp.Sequencer
+	timestamp   uint32
+	marker      bool
+
+	// skipped is the part of skipped durations that is shorter than a clock tick.
+	skipped time.Duration
+}
+
+// NewPacketizer creates a Packetizer with a random initial sequence number and
+// timestamp. payloadType is usually the static payload type of the codec, see
+// Codec.PayloadType.
+func NewPacketizer(codec Codec, payloadType uint8, ssrc uint32) *Packetizer {
+	return &Packetizer{
+		codec:       codec,
+		payloadType: payloadType,
+		ssrc:        ssrc,
+		sequencer:   rtp.NewRandomSequencer(),
+		timestamp:   randutil.NewMathRandomGenerator().Uint32(),
+		marker:      true,
+	}
+}
+
+// Packetize returns the RTP packet of a frame and advances the timestamp by
+// the frame's duration.
+func (p *Packetizer) Packetize(frame []byte) *rtp.Packet {
+	packet := &rtp.Packet{
+		Header: rtp.Header{
+			Version:        2,
+			Marker:         p.marker,
+			PayloadType:    p.payloadType,
+			SequenceNumber: p.sequencer.NextSequenceNumber(),
+			Timestamp:      p.timestamp,
+			SSRC:           p.ssrc,
+		},
+		Payload: frame,
+	}
+
+	p.marker = false
+	p.timestamp += uint32(len(frame)) //nolint:gosec // G115
+
+	return packet
+}
+
+// Skip advances the timestamp by duration without sending, for frames that are
+// not sent during silence.
+func (p *Packetizer) Skip(duration time.Duration) {
+	p.skipped += duration
+	ticks := p.codec.FrameSize(p.skipped)
+	p.skipped -= p.codec.Duration(ticks)
+
+	p.timestamp += uint32(ticks) //nolint:gosec // G115
+	p.marker = true
+}
+
+// Timestamp returns the RTP timestamp of the next packet.
+func (p *Packetizer) Timestamp() uint32 {
+	return p.timestamp
+}
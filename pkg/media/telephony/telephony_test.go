@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package telephony
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodec(t *testing.T) {
+	for _, test := range []struct {
+		codec       Codec
+		mimeType    string
+		payloadType uint8
+		sampleRate  uint32
+	}{
+		{CodecPCMU, "audio/PCMU", 0, 8000},
+		{CodecPCMA, "audio/PCMA", 8, 8000},
+		{CodecG722, "audio/G722", 9, 16000},
+	} {
+		assert.Equal(t, test.mimeType, test.codec.MimeType())
+		assert.Equal(t, test.payloadType, test.codec.PayloadType())
+		assert.Equal(t, test.sampleRate, test.codec.SampleRate())
+		assert.Equal(t, uint32(8000), test.codec.ClockRate())
+		assert.Equal(t, 160, test.codec.FrameSize(20*time.Millisecond))
+		assert.Equal(t, 20*time.Millisecond, test.codec.Duration(160))
+	}
+
+	assert.Equal(t, "unknown", CodecUnknown.String())
+	assert.Equal(t, "", CodecUnknown.MimeType())
+}
+
+func TestCodec_IsSilent(t *testing.T) {
+	assert.Equal(t, int16(0), ulawToLinear(0xFF))
+	assert.Equal(t, int16(-32124), ulawToLinear(0x00))
+	assert.Equal(t, int16(32124), ulawToLinear(0x80))
+	assert.Equal(t, int16(8), alawToLinear(0xD5))
+	assert.Equal(t, int16(-32256), alawToLinear(0x2A))
+	assert.Equal(t, int16(32256), alawToLinear(0xAA))
+
+	assert.True(t, CodecPCMU.IsSilent(bytes.Repeat([]byte{0xFF}, 160), 16))
+	assert.False(t, CodecPCMU.IsSilent(append(bytes.Repeat([]byte{0xFF}, 159), 0x00), 16))
+	assert.True(t, CodecPCMA.IsSilent(bytes.Repeat([]byte{0xD5, 0x55}, 80), 16))
+	assert.False(t, CodecPCMA.IsSilent([]byte{0xAA}, 16))
+	assert.False(t, CodecG722.IsSilent(make([]byte, 160), 16))
+}
+
+func TestFramer(t *testing.T) {
+	_, err := NewFramer(CodecUnknown, 0)
+	assert.ErrorIs(t, err, errUnknownCodec)
+	_, err = NewFramer(CodecPCMU, 100*time.Microsecond)
+	assert.ErrorIs(t, err, errInvalidFrameDuration)
+
+	framer, err := NewFramer(CodecG722, 0)
+	require.NoError(t, err)
+
+	assert.Empty(t, framer.Write(make([]byte, 100)))
+	assert.Equal(t, 100, framer.Buffered())
+
+	samples := framer.Write(bytes.Repeat([]byte{0x01}, 400))
+	require.Len(t, samples, 3)
+	for _, sample := range samples {
+		assert.Len(t, sample.Data, 160)
+		assert.Equal(t, 20*time.Millisecond, sample.Duration)
+	}
+	assert.Equal(t, byte(0x00), samples[0].Data[0])
+	assert.Equal(t, byte(0x01), samples[2].Data[0])
+
+	sample, ok := framer.Flush()
+	assert.True(t, ok)
+	assert.Len(t, sample.Data, 20)
+	assert.Equal(t, 2500*time.Microsecond, sample.Duration)
+
+	_, ok = framer.Flush()
+	assert.False(t, ok)
+}
+
+func TestPacketizer(t *testing.T) {
+	packetizer := NewPacketizer(CodecG722, CodecG722.PayloadType(), 1234)
+	timestamp := packetizer.Timestamp()
+	frame := make([]byte, 160)
+
+	first := packetizer.Packetize(frame)
+	assert.True(t, first.Marker)
+	assert.Equal(t, uint8(9), first.PayloadType)
+	assert.Equal(t, uint32(1234), first.SSRC)
+	assert.Equal(t, timestamp, first.Timestamp)
+
+	second := packetizer.Packetize(frame)
+	assert.False(t, second.Marker)
+	assert.Equal(t, timestamp+160, second.Timestamp, "G.722 advances by 160 ticks per 20ms")
+	assert.Equal(t, first.SequenceNumber+1, second.SequenceNumber)
+
+	// DTX: three frames of silence aren't sent.
+	for range 3 {
+		packetizer.Skip(20 * time.Millisecond)
+	}
+	packetizer.Skip(100 * time.Microsecond)
+	packetizer.Skip(100 * time.Microsecond)
+
+	talkspurt := packetizer.Packetize(frame)
+	assert.True(t, talkspurt.Marker)
+	assert.Equal(t, timestamp+5*160+1, talkspurt.Timestamp)
+	assert.Equal(t, second.SequenceNumber+1, talkspurt.SequenceNumber, "skipped frames don't use sequence numbers")
+}
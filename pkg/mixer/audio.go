@@ -0,0 +1,365 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+// Package mixer provides MCU style helpers that combine the media of multiple
+// inbound tracks into a single outbound stream. Codecs are pluggable, the
+// package only deals with raw audio samples and decoded video frames.
+package mixer
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/rtp"
+	"github.com/pion/webrtc/v4/pkg/media"
+)
+
+const (
+	defaultAudioSampleRate    = 48000
+	defaultAudioChannels      = 1
+	defaultAudioFrameDuration = 20 * time.Millisecond
+	defaultAudioMaxQueue      = 5
+
+	// maxEncodedFrameSize is the buffer size handed to an AudioEncoder, it can hold
+	// the largest Opus packet.
+	maxEncodedFrameSize = 1500
+)
+
+var (
+	errAudioSourceExists    = errors.New("mixer: audio source with this id already exists")
+	errAudioSourceRemoved   = errors.New("mixer: audio source was removed")
+	errAudioMixerClosed     = errors.New("mixer: audio mixer is closed")
+	errInvalidAudioSettings = errors.New("mixer: sample rate, channels and frame duration must be positive")
+)
+
+// AudioDecoder decodes the payload of a single RTP packet (e.g. an Opus frame)
+// into interleaved 16-bit PCM. It returns the number of samples per channel
+// written to pcm. A nil payload requests packet loss concealment.
+type AudioDecoder interface {
+	Decode(payload []byte, pcm []int16) (samples int, err error)
+}
+
+// AudioEncoder encodes a frame of interleaved 16-bit PCM into data and returns
+// the number of bytes written.
+type AudioEncoder interface {
+	Encode(pcm []int16, data []byte) (n int, err error)
+}
+
+// SampleWriter is where mixed frames are published, TrackLocalStaticSample
+// implements it.
+type SampleWriter interface {
+	WriteSample(sample media.Sample) error
+}
+
+// RTPReader is a source of RTP packets, TrackRemote implements it.
+type RTPReader interface {
+	ReadRTP() (*rtp.Packet, interceptor.Attributes, error)
+}
+
+// AudioMixerOption configures an AudioMixer.
+type AudioMixerOption func(*AudioMixer)
+
+// WithAudioSampleRate sets the sample rate of the decoded and mixed PCM. Defaults to 48000.
+func WithAudioSampleRate(sampleRate int) AudioMixerOption {
+	return func(m *AudioMixer) {
+		m.sampleRate = sampleRate
+	}
+}
+
+// WithAudioChannels sets the number of interleaved channels of the decoded and
+// mixed PCM. Defaults to 1.
+func WithAudioChannels(channels int) AudioMixerOption {
+	return func(m *AudioMixer) {
+		m.channels = channels
+	}
+}
+
+// WithAudioFrameDuration sets the duration of every mixed frame. Defaults to 20ms.
+func WithAudioFrameDuration(duration time.Duration) AudioMixerOption {
+	return func(m *AudioMixer) {
+		m.frameDuration = duration
+	}
+}
+
+// WithAudioMaxQueue sets how many decoded frames are buffered per source. When a
+// source delivers faster than the mixer consumes, the oldest frames are dropped.
+// Defaults to 5.
+func WithAudioMaxQueue(frames int) AudioMixerOption {
+	return func(m *AudioMixer) {
+		m.maxQueue = frames
+	}
+}
+
+// AudioMixer decodes N inbound audio streams, mixes them with a per-source gain,
+// re-encodes the result and publishes it to a single SampleWriter.
+//
+// Every Mix call consumes at most one decoded frame of every source. Sources
+// that have nothing queued contribute silence, so one slow source never stalls
+// the mix. Start drives Mix at the frame duration.
+type AudioMixer struct {
+	mu sync.Mutex
+
+	encoder AudioEncoder
+	output  SampleWriter
+
+	sampleRate    int
+	channels      int
+	frameDuration time.Duration
+	maxQueue      int
+
+	sources []*AudioSource
+
+	mixBuffer     []int32
+	pcm           []int16
+	encodedBuffer []byte
+
+	closed bool
+	loop   runLoop
+}
+
+// NewAudioMixer creates an AudioMixer that publishes frames encoded with encoder to output.
+func NewAudioMixer(encoder AudioEncoder, output SampleWriter, options ...AudioMixerOption) (*AudioMixer, error) {
+	mixer := &AudioMixer{
+		encoder:       encoder,
+		output:        output,
+		sampleRate:    defaultAudioSampleRate,
+		channels:      defaultAudioChannels,
+		frameDuration: defaultAudioFrameDuration,
+		maxQueue:      defaultAudioMaxQueue,
+	}
+
+	for _, option := range options {
+		option(mixer)
+	}
+
+	if mixer.sampleRate <= 0 || mixer.channels <= 0 || mixer.frameDuration <= 0 {
+		return nil, errInvalidAudioSettings
+	}
+	if mixer.maxQueue <= 0 {
+		mixer.maxQueue = 1
+	}
+
+	frameSize := mixer.frameSize()
+	mixer.mixBuffer = make([]int32, frameSize)
+	mixer.pcm = make([]int16, frameSize)
+	mixer.encodedBuffer = make([]byte, maxEncodedFrameSize)
+
+	return mixer, nil
+}
+
+// frameSize is the number of interleaved samples in one frame.
+func (m *AudioMixer) frameSize() int {
+	return int(int64(m.sampleRate)*int64(m.frameDuration)/int64(time.Second)) * m.channels
+}
+
+// AddSource adds an inbound stream decoded with decoder. The gain starts at 1.
+func (m *AudioMixer) AddSource(id string, decoder AudioDecoder) (*AudioSource, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return nil, errAudioMixerClosed
+	}
+
+	for _, s := range m.sources {
+		if s.id == id {
+			return nil, errAudioSourceExists
+		}
+	}
+
+	source := &AudioSource{
+		id:      id,
+		mixer:   m,
+		decoder: decoder,
+		gain:    1,
+		decoded: make([]int16, m.frameSize()*2),
+	}
+	m.sources = append(m.sources, source)
+
+	return source, nil
+}
+
+// RemoveSource removes the source with the given id. Writes to a removed source fail.
+func (m *AudioMixer) RemoveSource(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, s := range m.sources {
+		if s.id == id {
+			s.removed = true
+			m.sources = append(m.sources[:i], m.sources[i+1:]...)
+
+			return
+		}
+	}
+}
+
+// Mix combines the next queued frame of every source and writes the encoded
+// result to the SampleWriter.
+func (m *AudioMixer) Mix() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return errAudioMixerClosed
+	}
+
+	clear(m.mixBuffer)
+	for _, s := range m.sources {
+		if len(s.queue) == 0 {
+			continue
+		}
+
+		frame := s.queue[0]
+		s.queue = s.queue[1:]
+		for i, sample := range frame {
+			m.mixBuffer[i] += int32(math.Round(float64(sample) * s.gain))
+		}
+	}
+
+	for i, sample := range m.mixBuffer {
+		m.pcm[i] = clampInt16(sample)
+	}
+
+	n, err := m.encoder.Encode(m.pcm, m.encodedBuffer)
+	if err != nil {
+		return err
+	}
+
+	return m.output.WriteSample(media.Sample{
+		Data:     append([]byte(nil), m.encodedBuffer[:n]...),
+		Duration: m.frameDuration,
+	})
+}
+
+// Start calls Mix every frame duration until Close is called. Errors returned by
+// Mix are passed to onError, which may be nil.
+func (m *AudioMixer) Start(onError func(error)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return errAudioMixerClosed
+	}
+
+	return m.loop.start(m.frameDuration, func() {
+		if err := m.Mix(); err != nil && !errors.Is(err, errAudioMixerClosed) && onError != nil {
+			onError(err)
+		}
+	})
+}
+
+// Close stops the mixer. Close is idempotent.
+func (m *AudioMixer) Close() error {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+
+		return nil
+	}
+	m.closed = true
+	m.sources = nil
+	m.mu.Unlock()
+
+	m.loop.stop()
+
+	return nil
+}
+
+// AudioSource is a single inbound stream of an AudioMixer.
+type AudioSource struct {
+	id      string
+	mixer   *AudioMixer
+	decoder AudioDecoder
+
+	// everything below is protected by mixer.mu
+	gain    float64
+	queue   [][]int16
+	decoded []int16
+	removed bool
+}
+
+// ID returns the id the source was added with.
+func (s *AudioSource) ID() string {
+	return s.id
+}
+
+// SetGain sets the linear gain applied to the source when mixing. 1 keeps the
+// source unchanged and 0 mutes it.
+func (s *AudioSource) SetGain(gain float64) {
+	s.mixer.mu.Lock()
+	defer s.mixer.mu.Unlock()
+
+	s.gain = gain
+}
+
+// Gain returns the linear gain applied to the source.
+func (s *AudioSource) Gain() float64 {
+	s.mixer.mu.Lock()
+	defer s.mixer.mu.Unlock()
+
+	return s.gain
+}
+
+// WritePayload decodes an encoded frame and queues it for mixing. Decoded frames
+// shorter than the mixer's frame are padded with silence, longer frames are truncated.
+func (s *AudioSource) WritePayload(payload []byte) error {
+	s.mixer.mu.Lock()
+	defer s.mixer.mu.Unlock()
+
+	switch {
+	case s.mixer.closed:
+		return errAudioMixerClosed
+	case s.removed:
+		return errAudioSourceRemoved
+	}
+
+	samples, err := s.decoder.Decode(payload, s.decoded)
+	if err != nil {
+		return err
+	}
+
+	frame := make([]int16, s.mixer.frameSize())
+	copy(frame, s.decoded[:min(samples*s.mixer.channels, len(s.decoded))])
+
+	if len(s.queue) >= s.mixer.maxQueue {
+		s.queue = s.queue[1:]
+	}
+	s.queue = append(s.queue, frame)
+
+	return nil
+}
+
+// WriteRTP queues the payload of a RTP packet, see WritePayload.
+func (s *AudioSource) WriteRTP(packet *rtp.Packet) error {
+	return s.WritePayload(packet.Payload)
+}
+
+// ReadFrom reads packets from reader and queues them until reader or the
+// source returns an error, which is returned.
+func (s *AudioSource) ReadFrom(reader RTPReader) error {
+	for {
+		packet, _, err := reader.ReadRTP()
+		if err != nil {
+			return err
+		}
+
+		if err = s.WriteRTP(packet); err != nil {
+			return err
+		}
+	}
+}
+
+func clampInt16(sample int32) int16 {
+	switch {
+	case sample > math.MaxInt16:
+		return math.MaxInt16
+	case sample < math.MinInt16:
+		return math.MinInt16
+	default:
+		return int16(sample)
+	}
+}
@@ -0,0 +1,232 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package mixer
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/rtp"
+	"github.com/pion/transport/v4/test"
+	"github.com/pion/webrtc/v4/pkg/media"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pcmCodec is a raw little-endian PCM AudioDecoder and AudioEncoder.
+type pcmCodec struct{}
+
+func (pcmCodec) Decode(payload []byte, pcm []int16) (int, error) {
+	samples := min(len(payload)/2, len(pcm))
+	for i := range samples {
+		pcm[i] = int16(binary.LittleEndian.Uint16(payload[i*2:])) //nolint:gosec // G115
+	}
+
+	return samples, nil
+}
+
+func (pcmCodec) Encode(pcm []int16, data []byte) (int, error) {
+	for i, sample := range pcm {
+		binary.LittleEndian.PutUint16(data[i*2:], uint16(sample)) //nolint:gosec // G115
+	}
+
+	return len(pcm) * 2, nil
+}
+
+func pcmPayload(samples ...int16) []byte {
+	payload := make([]byte, len(samples)*2)
+	_, _ = pcmCodec{}.Encode(samples, payload)
+
+	return payload
+}
+
+func decodeSample(t *testing.T, sample media.Sample) []int16 {
+	t.Helper()
+
+	pcm := make([]int16, len(sample.Data)/2)
+	_, err := pcmCodec{}.Decode(sample.Data, pcm)
+	require.NoError(t, err)
+
+	return pcm
+}
+
+type sampleRecorder struct {
+	mu      sync.Mutex
+	samples []media.Sample
+}
+
+func (r *sampleRecorder) WriteSample(sample media.Sample) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.samples = append(r.samples, sample)
+
+	return nil
+}
+
+func (r *sampleRecorder) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.samples)
+}
+
+// 4 samples per frame keeps the test vectors readable.
+func newTestAudioMixer(t *testing.T, output SampleWriter, options ...AudioMixerOption) *AudioMixer {
+	t.Helper()
+
+	mixer, err := NewAudioMixer(pcmCodec{}, output, append([]AudioMixerOption{
+		WithAudioSampleRate(200),
+		WithAudioFrameDuration(20 * time.Millisecond),
+	}, options...)...)
+	require.NoError(t, err)
+
+	return mixer
+}
+
+func TestAudioMixer_Mix(t *testing.T) {
+	output := &sampleRecorder{}
+	mixer := newTestAudioMixer(t, output)
+	defer func() { assert.NoError(t, mixer.Close()) }()
+
+	a, err := mixer.AddSource("a", pcmCodec{})
+	require.NoError(t, err)
+	b, err := mixer.AddSource("b", pcmCodec{})
+	require.NoError(t, err)
+
+	_, err = mixer.AddSource("a", pcmCodec{})
+	assert.ErrorIs(t, err, errAudioSourceExists)
+
+	b.SetGain(0.5)
+	assert.Equal(t, 0.5, b.Gain())
+	assert.Equal(t, "b", b.ID())
+
+	require.NoError(t, a.WritePayload(pcmPayload(100, -100, 30000, -30000)))
+	require.NoError(t, b.WriteRTP(&rtp.Packet{Payload: pcmPayload(100, 100, 10000, -10000)}))
+	require.NoError(t, mixer.Mix())
+
+	// Source b only delivers a short frame, the rest is silence.
+	require.NoError(t, a.WritePayload(pcmPayload(1, 2, 3, 4)))
+	require.NoError(t, b.WritePayload(pcmPayload(10)))
+	require.NoError(t, mixer.Mix())
+
+	// Nothing queued mixes silence.
+	require.NoError(t, mixer.Mix())
+
+	require.Len(t, output.samples, 3)
+	assert.Equal(t, []int16{150, -50, math.MaxInt16, math.MinInt16}, decodeSample(t, output.samples[0]))
+	assert.Equal(t, []int16{6, 2, 3, 4}, decodeSample(t, output.samples[1]))
+	assert.Equal(t, []int16{0, 0, 0, 0}, decodeSample(t, output.samples[2]))
+	assert.Equal(t, 20*time.Millisecond, output.samples[0].Duration)
+
+	mixer.RemoveSource("a")
+	assert.ErrorIs(t, a.WritePayload(pcmPayload(1)), errAudioSourceRemoved)
+}
+
+func TestAudioMixer_MaxQueue(t *testing.T) {
+	output := &sampleRecorder{}
+	mixer := newTestAudioMixer(t, output, WithAudioMaxQueue(2))
+	defer func() { assert.NoError(t, mixer.Close()) }()
+
+	source, err := mixer.AddSource("a", pcmCodec{})
+	require.NoError(t, err)
+
+	for i := range int16(3) {
+		require.NoError(t, source.WritePayload(pcmPayload(i, i, i, i)))
+	}
+
+	require.NoError(t, mixer.Mix())
+	require.NoError(t, mixer.Mix())
+
+	require.Len(t, output.samples, 2)
+	assert.Equal(t, []int16{1, 1, 1, 1}, decodeSample(t, output.samples[0]))
+	assert.Equal(t, []int16{2, 2, 2, 2}, decodeSample(t, output.samples[1]))
+}
+
+func TestAudioMixer_InvalidSettings(t *testing.T) {
+	_, err := NewAudioMixer(pcmCodec{}, &sampleRecorder{}, WithAudioChannels(0))
+	assert.ErrorIs(t, err, errInvalidAudioSettings)
+}
+
+type errEncoder struct{}
+
+var errEncodeBoom = errors.New("encode boom")
+
+func (errEncoder) Encode([]int16, []byte) (int, error) {
+	return 0, errEncodeBoom
+}
+
+func TestAudioMixer_EncodeError(t *testing.T) {
+	mixer, err := NewAudioMixer(errEncoder{}, &sampleRecorder{})
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, mixer.Mix(), errEncodeBoom)
+	assert.NoError(t, mixer.Close())
+}
+
+func TestAudioMixer_StartClose(t *testing.T) {
+	lim := test.TimeOut(time.Second * 10)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	output := &sampleRecorder{}
+	mixer := newTestAudioMixer(t, output, WithAudioFrameDuration(time.Millisecond))
+
+	require.NoError(t, mixer.Start(nil))
+	assert.ErrorIs(t, mixer.Start(nil), errMixerStarted)
+
+	assert.Eventually(t, func() bool {
+		return output.count() >= 3
+	}, time.Second, time.Millisecond)
+
+	assert.NoError(t, mixer.Close())
+	assert.NoError(t, mixer.Close())
+
+	assert.ErrorIs(t, mixer.Mix(), errAudioMixerClosed)
+	assert.ErrorIs(t, mixer.Start(nil), errAudioMixerClosed)
+	_, err := mixer.AddSource("a", pcmCodec{})
+	assert.ErrorIs(t, err, errAudioMixerClosed)
+}
+
+type packetReader struct {
+	packets []*rtp.Packet
+}
+
+func (r *packetReader) ReadRTP() (*rtp.Packet, interceptor.Attributes, error) {
+	if len(r.packets) == 0 {
+		return nil, nil, io.EOF
+	}
+
+	packet := r.packets[0]
+	r.packets = r.packets[1:]
+
+	return packet, nil, nil
+}
+
+func TestAudioSource_ReadFrom(t *testing.T) {
+	output := &sampleRecorder{}
+	mixer := newTestAudioMixer(t, output)
+	defer func() { assert.NoError(t, mixer.Close()) }()
+
+	source, err := mixer.AddSource("a", pcmCodec{})
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, source.ReadFrom(&packetReader{packets: []*rtp.Packet{
+		{Payload: pcmPayload(1, 1, 1, 1)},
+		{Payload: pcmPayload(2, 2, 2, 2)},
+	}}), io.EOF)
+
+	require.NoError(t, mixer.Mix())
+	require.NoError(t, mixer.Mix())
+	assert.Equal(t, []int16{1, 1, 1, 1}, decodeSample(t, output.samples[0]))
+	assert.Equal(t, []int16{2, 2, 2, 2}, decodeSample(t, output.samples[1]))
+}
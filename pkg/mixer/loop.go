@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package mixer
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var errMixerStarted = errors.New("mixer: already started")
+
+// runLoop calls a function at a fixed interval on its own goroutine.
+type runLoop struct {
+	mu      sync.Mutex
+	started bool
+	stopped bool
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+func (l *runLoop) start(interval time.Duration, tick func()) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.started {
+		return errMixerStarted
+	}
+	l.started = true
+	l.done = make(chan struct{})
+
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-l.done:
+				return
+			case <-ticker.C:
+				tick()
+			}
+		}
+	}()
+
+	return nil
+}
+
+// stop ends the loop and waits for the current tick to return. It must not be
+// called from the tick function.
+func (l *runLoop) stop() {
+	l.mu.Lock()
+	if l.started && !l.stopped {
+		l.stopped = true
+		close(l.done)
+	}
+	l.mu.Unlock()
+
+	l.wg.Wait()
+}
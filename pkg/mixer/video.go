@@ -0,0 +1,327 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package mixer
+
+import (
+	"errors"
+	"image"
+	"image/draw"
+	"sync"
+	"time"
+
+	"github.com/pion/webrtc/v4/pkg/media"
+)
+
+const (
+	defaultVideoFrameRate  = 30
+	defaultVideoStaleAfter = time.Second
+)
+
+var (
+	errVideoSourceExists     = errors.New("mixer: video source with this id already exists")
+	errVideoSourceRemoved    = errors.New("mixer: video source was removed")
+	errVideoCompositorClosed = errors.New("mixer: video compositor is closed")
+	errInvalidFrameRate      = errors.New("mixer: frame rate must be positive")
+)
+
+// VideoEncoder encodes a composed frame. keyframe requests an intra frame, it is
+// set for the first frame and after RequestKeyframe was called.
+type VideoEncoder interface {
+	Encode(frame image.Image, keyframe bool) ([]byte, error)
+}
+
+// VideoSourceFrame is the latest decoded frame of a source, as handed to a VideoLayout.
+type VideoSourceFrame struct {
+	SourceID string
+	Frame    image.Image
+
+	// Age is how long ago the frame was written to the source.
+	Age time.Duration
+}
+
+// VideoLayout composes the latest frame of every source into a single frame.
+// frames are in the order the sources were added, sources without a frame or
+// with a stale frame are omitted. Returning nil skips the output frame.
+type VideoLayout func(frames []VideoSourceFrame) image.Image
+
+// VideoCompositorOption configures a VideoCompositor.
+type VideoCompositorOption func(*VideoCompositor)
+
+// WithVideoFrameRate sets how many frames per second are composed. Defaults to 30.
+func WithVideoFrameRate(fps float64) VideoCompositorOption {
+	return func(c *VideoCompositor) {
+		c.frameRate = fps
+	}
+}
+
+// WithVideoStaleAfter sets after how long without a new frame a source is left
+// out of the layout. Zero keeps the last frame forever. Defaults to one second.
+func WithVideoStaleAfter(duration time.Duration) VideoCompositorOption {
+	return func(c *VideoCompositor) {
+		c.staleAfter = duration
+	}
+}
+
+// VideoCompositor combines the decoded frames of N sources with a VideoLayout,
+// encodes the result and publishes it to a single SampleWriter.
+//
+// Sources deliver frames at their own pace, the compositor always uses the latest
+// frame of every source and produces output at a constant frame rate, so the
+// timing of the output doesn't depend on any single source.
+type VideoCompositor struct {
+	mu sync.Mutex
+
+	encoder VideoEncoder
+	layout  VideoLayout
+	output  SampleWriter
+
+	frameRate  float64
+	staleAfter time.Duration
+
+	sources []*VideoSource
+	frames  []VideoSourceFrame
+
+	keyframe     bool
+	lastCompose  time.Time
+	closed       bool
+	loop         runLoop
+	nowFunc      func() time.Time
+	frameElapsed time.Duration
+}
+
+// NewVideoCompositor creates a VideoCompositor that composes frames with layout,
+// encodes them with encoder and publishes them to output.
+func NewVideoCompositor(
+	encoder VideoEncoder,
+	layout VideoLayout,
+	output SampleWriter,
+	options ...VideoCompositorOption,
+) (*VideoCompositor, error) {
+	compositor := &VideoCompositor{
+		encoder:    encoder,
+		layout:     layout,
+		output:     output,
+		frameRate:  defaultVideoFrameRate,
+		staleAfter: defaultVideoStaleAfter,
+		keyframe:   true,
+		nowFunc:    time.Now,
+	}
+
+	for _, option := range options {
+		option(compositor)
+	}
+
+	if compositor.frameRate <= 0 {
+		return nil, errInvalidFrameRate
+	}
+	compositor.frameElapsed = time.Duration(float64(time.Second) / compositor.frameRate)
+
+	return compositor, nil
+}
+
+// AddSource adds a source of decoded frames.
+func (c *VideoCompositor) AddSource(id string) (*VideoSource, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil, errVideoCompositorClosed
+	}
+
+	for _, s := range c.sources {
+		if s.id == id {
+			return nil, errVideoSourceExists
+		}
+	}
+
+	source := &VideoSource{id: id, compositor: c}
+	c.sources = append(c.sources, source)
+
+	return source, nil
+}
+
+// RemoveSource removes the source with the given id. Writes to a removed source fail.
+func (c *VideoCompositor) RemoveSource(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, s := range c.sources {
+		if s.id == id {
+			s.removed = true
+			c.sources = append(c.sources[:i], c.sources[i+1:]...)
+
+			return
+		}
+	}
+}
+
+// RequestKeyframe makes the next composed frame a keyframe, e.g. when a PLI is received.
+func (c *VideoCompositor) RequestKeyframe() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.keyframe = true
+}
+
+// Compose runs the layout on the latest frame of every source and writes the
+// encoded result to the SampleWriter. The sample duration is the time since the
+// previous Compose call, or one frame interval for the first frame.
+func (c *VideoCompositor) Compose() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return errVideoCompositorClosed
+	}
+
+	now := c.nowFunc()
+	c.frames = c.frames[:0]
+	for _, s := range c.sources {
+		if s.frame == nil {
+			continue
+		}
+
+		age := now.Sub(s.updated)
+		if c.staleAfter > 0 && age > c.staleAfter {
+			continue
+		}
+
+		c.frames = append(c.frames, VideoSourceFrame{SourceID: s.id, Frame: s.frame, Age: age})
+	}
+
+	frame := c.layout(c.frames)
+	if frame == nil {
+		return nil
+	}
+
+	data, err := c.encoder.Encode(frame, c.keyframe)
+	if err != nil {
+		return err
+	}
+	c.keyframe = false
+
+	duration := c.frameElapsed
+	if !c.lastCompose.IsZero() {
+		duration = now.Sub(c.lastCompose)
+	}
+	c.lastCompose = now
+
+	return c.output.WriteSample(media.Sample{
+		Data:      data,
+		Timestamp: now,
+		Duration:  duration,
+	})
+}
+
+// Start calls Compose at the configured frame rate until Close is called. Errors
+// returned by Compose are passed to onError, which may be nil.
+func (c *VideoCompositor) Start(onError func(error)) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return errVideoCompositorClosed
+	}
+
+	return c.loop.start(c.frameElapsed, func() {
+		if err := c.Compose(); err != nil && !errors.Is(err, errVideoCompositorClosed) && onError != nil {
+			onError(err)
+		}
+	})
+}
+
+// Close stops the compositor. Close is idempotent.
+func (c *VideoCompositor) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+
+		return nil
+	}
+	c.closed = true
+	c.sources = nil
+	c.mu.Unlock()
+
+	c.loop.stop()
+
+	return nil
+}
+
+// VideoSource is a single inbound stream of a VideoCompositor.
+type VideoSource struct {
+	id         string
+	compositor *VideoCompositor
+
+	// everything below is protected by compositor.mu
+	frame   image.Image
+	updated time.Time
+	removed bool
+}
+
+// ID returns the id the source was added with.
+func (s *VideoSource) ID() string {
+	return s.id
+}
+
+// WriteFrame replaces the latest frame of the source. The frame must not be
+// modified afterwards, the layout may read it at any time.
+func (s *VideoSource) WriteFrame(frame image.Image) error {
+	s.compositor.mu.Lock()
+	defer s.compositor.mu.Unlock()
+
+	switch {
+	case s.compositor.closed:
+		return errVideoCompositorClosed
+	case s.removed:
+		return errVideoSourceRemoved
+	}
+
+	s.frame = frame
+	s.updated = s.compositor.nowFunc()
+
+	return nil
+}
+
+// NewGridLayout returns a VideoLayout that arranges the frames in a grid with
+// the smallest square number of cells on a width x height canvas. Frames are
+// scaled to the cell with nearest neighbor sampling. The returned image is
+// reused by every call, so the encoder must not keep a reference to it.
+func NewGridLayout(width, height int) VideoLayout {
+	canvas := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	return func(frames []VideoSourceFrame) image.Image {
+		draw.Draw(canvas, canvas.Bounds(), image.Black, image.Point{}, draw.Src)
+
+		columns := 1
+		for columns*columns < len(frames) {
+			columns++
+		}
+
+		cellWidth, cellHeight := width/columns, height/columns
+		for i, frame := range frames {
+			cell := image.Rect(0, 0, cellWidth, cellHeight).Add(image.Pt(
+				(i%columns)*cellWidth,
+				(i/columns)*cellHeight,
+			))
+			scaleNearest(canvas, cell, frame.Frame)
+		}
+
+		return canvas
+	}
+}
+
+func scaleNearest(dst *image.RGBA, rect image.Rectangle, src image.Image) {
+	bounds := src.Bounds()
+	if bounds.Empty() || rect.Empty() {
+		return
+	}
+
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		srcY := bounds.Min.Y + (y-rect.Min.Y)*bounds.Dy()/rect.Dy()
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			srcX := bounds.Min.X + (x-rect.Min.X)*bounds.Dx()/rect.Dx()
+			dst.Set(x, y, src.At(srcX, srcY))
+		}
+	}
+}
@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package mixer
+
+import (
+	"image"
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// keyframeEncoder encodes a frame as its width followed by the keyframe flag.
+type keyframeEncoder struct{}
+
+func (keyframeEncoder) Encode(frame image.Image, keyframe bool) ([]byte, error) {
+	data := []byte{byte(frame.Bounds().Dx()), 0}
+	if keyframe {
+		data[1] = 1
+	}
+
+	return data, nil
+}
+
+func uniformFrame(c color.Color, width, height int) image.Image {
+	frame := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			frame.Set(x, y, c)
+		}
+	}
+
+	return frame
+}
+
+func TestVideoCompositor_Compose(t *testing.T) {
+	now := time.Unix(100, 0)
+	var seen [][]string
+
+	output := &sampleRecorder{}
+	compositor, err := NewVideoCompositor(keyframeEncoder{}, func(frames []VideoSourceFrame) image.Image {
+		ids := []string{}
+		for _, f := range frames {
+			ids = append(ids, f.SourceID)
+		}
+		seen = append(seen, ids)
+
+		if len(frames) == 0 {
+			return nil
+		}
+
+		return frames[0].Frame
+	}, output, WithVideoFrameRate(10), WithVideoStaleAfter(time.Second))
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, compositor.Close()) }()
+	compositor.nowFunc = func() time.Time { return now }
+
+	a, err := compositor.AddSource("a")
+	require.NoError(t, err)
+	b, err := compositor.AddSource("b")
+	require.NoError(t, err)
+	assert.Equal(t, "b", b.ID())
+
+	_, err = compositor.AddSource("a")
+	assert.ErrorIs(t, err, errVideoSourceExists)
+
+	// No frames yet, the layout skips the output frame.
+	require.NoError(t, compositor.Compose())
+	assert.Empty(t, output.samples)
+
+	require.NoError(t, a.WriteFrame(uniformFrame(color.White, 4, 4)))
+	require.NoError(t, b.WriteFrame(uniformFrame(color.White, 2, 2)))
+	require.NoError(t, compositor.Compose())
+
+	now = now.Add(500 * time.Millisecond)
+	require.NoError(t, b.WriteFrame(uniformFrame(color.White, 2, 2)))
+	require.NoError(t, compositor.Compose())
+
+	// a becomes stale.
+	now = now.Add(700 * time.Millisecond)
+	compositor.RequestKeyframe()
+	require.NoError(t, compositor.Compose())
+
+	assert.Equal(t, [][]string{{}, {"a", "b"}, {"a", "b"}, {"b"}}, seen)
+	require.Len(t, output.samples, 3)
+	assert.Equal(t, []byte{4, 1}, output.samples[0].Data)
+	assert.Equal(t, 100*time.Millisecond, output.samples[0].Duration)
+	assert.Equal(t, []byte{4, 0}, output.samples[1].Data)
+	assert.Equal(t, 500*time.Millisecond, output.samples[1].Duration)
+	assert.Equal(t, []byte{2, 1}, output.samples[2].Data)
+	assert.Equal(t, now, output.samples[2].Timestamp)
+
+	compositor.RemoveSource("a")
+	assert.ErrorIs(t, a.WriteFrame(uniformFrame(color.White, 1, 1)), errVideoSourceRemoved)
+}
+
+func TestVideoCompositor_InvalidFrameRate(t *testing.T) {
+	_, err := NewVideoCompositor(keyframeEncoder{}, NewGridLayout(2, 2), &sampleRecorder{}, WithVideoFrameRate(0))
+	assert.ErrorIs(t, err, errInvalidFrameRate)
+}
+
+func TestVideoCompositor_StartClose(t *testing.T) {
+	lim := test.TimeOut(time.Second * 10)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	output := &sampleRecorder{}
+	compositor, err := NewVideoCompositor(keyframeEncoder{}, NewGridLayout(2, 2), output, WithVideoFrameRate(1000))
+	require.NoError(t, err)
+
+	require.NoError(t, compositor.Start(nil))
+	assert.ErrorIs(t, compositor.Start(nil), errMixerStarted)
+
+	assert.Eventually(t, func() bool {
+		return output.count() >= 3
+	}, time.Second, time.Millisecond)
+
+	assert.NoError(t, compositor.Close())
+	assert.NoError(t, compositor.Close())
+
+	assert.ErrorIs(t, compositor.Compose(), errVideoCompositorClosed)
+	_, err = compositor.AddSource("a")
+	assert.ErrorIs(t, err, errVideoCompositorClosed)
+}
+
+func TestGridLayout(t *testing.T) {
+	red := color.RGBA{R: 0xff, A: 0xff}
+	green := color.RGBA{G: 0xff, A: 0xff}
+	blue := color.RGBA{B: 0xff, A: 0xff}
+	black := color.RGBA{A: 0xff}
+
+	layout := NewGridLayout(4, 4)
+
+	single := layout([]VideoSourceFrame{{Frame: uniformFrame(red, 1, 1)}})
+	assert.Equal(t, red, single.At(0, 0))
+	assert.Equal(t, red, single.At(3, 3))
+
+	// Three sources need a 2x2 grid, the last cell stays black.
+	grid := layout([]VideoSourceFrame{
+		{Frame: uniformFrame(red, 8, 8)},
+		{Frame: uniformFrame(green, 8, 8)},
+		{Frame: uniformFrame(blue, 8, 8)},
+	})
+	assert.Equal(t, red, grid.At(1, 1))
+	assert.Equal(t, green, grid.At(2, 1))
+	assert.Equal(t, blue, grid.At(1, 2))
+	assert.Equal(t, black, grid.At(3, 3))
+}
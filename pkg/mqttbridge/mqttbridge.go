@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+// Package mqttbridge carries MQTT connections over DataChannels, so MQTT
+// clients and brokers can reach each other through NAT using a PeerConnection.
+//
+// A connection is a reliable, ordered DataChannel with the "mqtt" protocol,
+// carrying the MQTT byte stream like MQTT over WebSockets does, see MQTT 5.0
+// Section 6. Browser clients can therefore use their WebSocket MQTT stack by
+// sending every control packet as a binary message.
+//
+// Clients use the net.Conn returned by Dial as their network connection, for
+// example from the custom open connection function of paho.mqtt.golang or as
+// the Conn of a paho.golang client. Brokers serve the net.Listener returned by
+// Listen, for example with mochi-mqtt's listeners.NewNet.
+//
+// The PeerConnections have to be created with an API whose SettingEngine has
+// DetachDataChannels enabled.
+package mqttbridge
+
+import (
+	"context"
+	"net"
+
+	"github.com/pion/webrtc/v4"
+	"github.com/pion/webrtc/v4/pkg/dcnet"
+)
+
+// Protocol is the DataChannel protocol of MQTT connections, the WebSocket
+// subprotocol registered for MQTT.
+const Protocol = "mqtt"
+
+// DefaultLabel is the label of the DataChannels created by Dial.
+const DefaultLabel = "mqtt"
+
+// Dial opens a MQTT connection to the broker that listens on the remote peer's
+// PeerConnection. It returns once the DataChannel is open or ctx is done.
+func Dial(ctx context.Context, pc *webrtc.PeerConnection) (net.Conn, error) {
+	protocol := Protocol
+
+	return dcnet.Dial(ctx, pc, DefaultLabel, &webrtc.DataChannelInit{Protocol: &protocol})
+}
+
+// Listen returns a listener accepting the MQTT connections remote peers open
+// on the PeerConnections. PeerConnections of clients that join later are added
+// with AddPeerConnection.
+func Listen(peerConnections ...*webrtc.PeerConnection) *dcnet.Listener {
+	return dcnet.Listen(Protocol, peerConnections...)
+}
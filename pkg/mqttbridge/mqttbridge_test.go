@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package mqttbridge
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/pion/webrtc/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newConnectedPair(t *testing.T) (*webrtc.PeerConnection, *webrtc.PeerConnection) {
+	t.Helper()
+
+	settingEngine := webrtc.SettingEngine{}
+	settingEngine.DetachDataChannels()
+	api := webrtc.NewAPI(webrtc.WithSettingEngine(settingEngine))
+
+	client, err := api.NewPeerConnection(webrtc.Configuration{})
+	require.NoError(t, err)
+	broker, err := api.NewPeerConnection(webrtc.Configuration{})
+	require.NoError(t, err)
+
+	_, err = client.CreateDataChannel("negotiate", nil)
+	require.NoError(t, err)
+
+	connected := make(chan struct{})
+	client.OnConnectionStateChange(func(state webrtc.PeerConnectionState) {
+		if state == webrtc.PeerConnectionStateConnected {
+			close(connected)
+		}
+	})
+
+	offer, err := client.CreateOffer(nil)
+	require.NoError(t, err)
+	offerGathered := webrtc.GatheringCompletePromise(client)
+	require.NoError(t, client.SetLocalDescription(offer))
+	<-offerGathered
+	require.NoError(t, broker.SetRemoteDescription(*client.LocalDescription()))
+
+	answer, err := broker.CreateAnswer(nil)
+	require.NoError(t, err)
+	answerGathered := webrtc.GatheringCompletePromise(broker)
+	require.NoError(t, broker.SetLocalDescription(answer))
+	<-answerGathered
+	require.NoError(t, client.SetRemoteDescription(*broker.LocalDescription()))
+	<-connected
+
+	return client, broker
+}
+
+func TestDialListen(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	client, broker := newConnectedPair(t)
+	listener := Listen(broker)
+
+	// CONNECT with protocol name MQTT, level 4, clean session, keep alive 60s
+	// and client identifier "pion", answered by CONNACK.
+	connect := []byte{
+		0x10, 0x10, 0x00, 0x04, 'M', 'Q', 'T', 'T', 0x04, 0x02, 0x00, 0x3c, 0x00, 0x04, 'p', 'i', 'o', 'n',
+	}
+	connack := []byte{0x20, 0x02, 0x00, 0x00}
+
+	brokerDone := make(chan struct{})
+	go func() {
+		defer close(brokerDone)
+
+		conn, err := listener.Accept()
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer func() {
+			assert.NoError(t, conn.Close())
+		}()
+
+		received := make([]byte, len(connect))
+		if _, err = io.ReadFull(conn, received); assert.NoError(t, err) {
+			assert.Equal(t, connect, received)
+			_, err = conn.Write(connack)
+			assert.NoError(t, err)
+		}
+	}()
+
+	conn, err := Dial(context.Background(), client)
+	require.NoError(t, err)
+
+	// Writes in pieces, MQTT packets aren't aligned to messages.
+	_, err = conn.Write(connect[:5])
+	require.NoError(t, err)
+	_, err = conn.Write(connect[5:])
+	require.NoError(t, err)
+
+	received := make([]byte, len(connack))
+	_, err = io.ReadFull(conn, received)
+	require.NoError(t, err)
+	assert.Equal(t, connack, received)
+
+	<-brokerDone
+	assert.NoError(t, conn.Close())
+	assert.NoError(t, listener.Close())
+	assert.NoError(t, client.Close())
+	assert.NoError(t, broker.Close())
+}
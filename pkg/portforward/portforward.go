@@ -0,0 +1,407 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+// Package portforward forwards TCP connections over a PeerConnection, like the
+// local and remote port forwarding of SSH. Every forwarded connection is a
+// DataChannel of its own, so connections are multiplexed on the SCTP
+// association without head-of-line blocking and are flow controlled
+// individually.
+//
+// Forwarding needs a Forwarder on both peers. The PeerConnections have to be
+// created with an API whose SettingEngine has DetachDataChannels enabled.
+// Connections are closed completely once either direction ends, as
+// DataChannels can't be half-closed.
+package portforward
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/pion/webrtc/v4"
+	"github.com/pion/webrtc/v4/pkg/dcnet"
+)
+
+const (
+	// ProtocolConnect is the DataChannel protocol of a connection the remote
+	// peer connects to the address in the label.
+	ProtocolConnect = "tcp-forward"
+
+	// ProtocolListen is the DataChannel protocol of a request to listen on the
+	// address in the label, which stays active while the DataChannel is open.
+	ProtocolListen = "tcp-forward-listen"
+
+	// ProtocolReverse is the DataChannel protocol of a connection accepted by
+	// the listener of a ProtocolListen request, labeled with its address.
+	ProtocolReverse = "tcp-forward-reverse"
+)
+
+const (
+	listenStatusOK    = 0
+	listenStatusError = 1
+
+	maxListenStatusSize = 1024
+)
+
+var (
+	errRemoteListen        = errors.New("portforward: remote peer failed to listen")
+	errAlreadyForwarded    = errors.New("portforward: remote address is already forwarded")
+	errInvalidListenStatus = errors.New("portforward: invalid listen status")
+	errListenNotAllowed    = errors.New("listening on the address is not allowed")
+	errForwarderClosed     = errors.New("portforward: forwarder closed")
+)
+
+// Config configures a Forwarder.
+type Config struct {
+	// AllowConnect decides if the remote peer may connect to the target
+	// address, nil denies all targets.
+	AllowConnect func(target string) bool
+
+	// AllowListen decides if the remote peer may listen on the address, nil
+	// denies all addresses.
+	AllowListen func(address string) bool
+
+	// Dial connects to targets, nil selects a net.Dialer.
+	Dial func(ctx context.Context, network, address string) (net.Conn, error)
+
+	// Listen listens for remote forwards, nil selects net.Listen.
+	Listen func(network, address string) (net.Listener, error)
+}
+
+// Forwarder forwards TCP connections to and from the remote peer of a
+// PeerConnection.
+type Forwarder struct {
+	pc     *webrtc.PeerConnection
+	config Config
+
+	connectListener *dcnet.Listener
+	listenListener  *dcnet.Listener
+	reverseListener *dcnet.Listener
+
+	mu             sync.Mutex
+	reverseTargets map[string]string
+
+	ctx    context.Context //nolint:containedctx
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New creates a Forwarder for the PeerConnection. It serves the forwarding
+// requests of the remote peer that Config allows.
+func New(pc *webrtc.PeerConnection, config Config) *Forwarder {
+	if config.Dial == nil {
+		dialer := &net.Dialer{}
+		config.Dial = dialer.DialContext
+	}
+	if config.Listen == nil {
+		config.Listen = net.Listen
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	forwarder := &Forwarder{
+		pc:              pc,
+		config:          config,
+		connectListener: dcnet.Listen(ProtocolConnect, pc),
+		listenListener:  dcnet.Listen(ProtocolListen, pc),
+		reverseListener: dcnet.Listen(ProtocolReverse, pc),
+		reverseTargets:  map[string]string{},
+		ctx:             ctx,
+		cancel:          cancel,
+	}
+
+	forwarder.serve(forwarder.connectListener, forwarder.handleConnect)
+	forwarder.serve(forwarder.listenListener, forwarder.handleListen)
+	forwarder.serve(forwarder.reverseListener, forwarder.handleReverse)
+
+	return forwarder
+}
+
+// Close stops forwarding and closes all forwarded connections.
+func (f *Forwarder) Close() error {
+	f.cancel()
+
+	err := errors.Join(f.connectListener.Close(), f.listenListener.Close(), f.reverseListener.Close())
+	f.wg.Wait()
+
+	return err
+}
+
+// ForwardLocal forwards the connections accepted by the listener to the target
+// address, which the remote peer connects to. It returns once the listener or
+// the Forwarder is closed, the listener is closed in both cases.
+func (f *Forwarder) ForwardLocal(listener net.Listener, target string) error {
+	stop := context.AfterFunc(f.ctx, func() {
+		_ = listener.Close()
+	})
+	defer stop()
+	defer func() {
+		_ = listener.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if f.ctx.Err() != nil {
+				return errForwarderClosed
+			}
+
+			return err
+		}
+
+		f.goForward(conn, target, ProtocolConnect)
+	}
+}
+
+// goForward forwards a TCP connection over a new DataChannel with the protocol.
+func (f *Forwarder) goForward(conn net.Conn, label, protocol string) {
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+
+		forwarded, err := dcnet.Dial(f.ctx, f.pc, label, &webrtc.DataChannelInit{Protocol: &protocol})
+		if err != nil {
+			_ = conn.Close()
+
+			return
+		}
+
+		f.pipe(conn, forwarded)
+	}()
+}
+
+// RemoteForward is an active remote forward, the remote peer listens and its
+// connections are forwarded to the local target.
+type RemoteForward struct {
+	control *dcnet.Conn
+	addr    string
+	done    chan struct{}
+}
+
+// Addr returns the address the remote peer listens on, which differs from the
+// requested address if it had port zero.
+func (r *RemoteForward) Addr() string {
+	return r.addr
+}
+
+// Done returns a channel that is closed when the remote forward ends.
+func (r *RemoteForward) Done() <-chan struct{} {
+	return r.done
+}
+
+// Close stops the remote forward, connections that were forwarded stay open.
+func (r *RemoteForward) Close() error {
+	return r.control.Close()
+}
+
+// ForwardRemote asks the remote peer to listen on the address and forwards the
+// connections it accepts to the local target address. An address can only be
+// forwarded once at a time.
+func (f *Forwarder) ForwardRemote(ctx context.Context, address, target string) (*RemoteForward, error) {
+	f.mu.Lock()
+	if _, ok := f.reverseTargets[address]; ok {
+		f.mu.Unlock()
+
+		return nil, errAlreadyForwarded
+	}
+	f.reverseTargets[address] = target
+	f.mu.Unlock()
+
+	removeTarget := func() {
+		f.mu.Lock()
+		delete(f.reverseTargets, address)
+		f.mu.Unlock()
+	}
+
+	protocol := ProtocolListen
+	control, err := dcnet.Dial(ctx, f.pc, address, &webrtc.DataChannelInit{Protocol: &protocol})
+	if err != nil {
+		removeTarget()
+
+		return nil, err
+	}
+
+	listenAddr, err := readListenStatus(control)
+	if err != nil {
+		removeTarget()
+		_ = control.Close()
+
+		return nil, err
+	}
+
+	remoteForward := &RemoteForward{control: control, addr: listenAddr, done: make(chan struct{})}
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+		defer close(remoteForward.done)
+		defer removeTarget()
+
+		stop := context.AfterFunc(f.ctx, func() {
+			_ = control.Close()
+		})
+		defer stop()
+
+		// The remote peer doesn't send anything else, Read returns once either
+		// peer closes the DataChannel.
+		_, _ = control.Read(make([]byte, 1))
+		_ = control.Close()
+	}()
+
+	return remoteForward, nil
+}
+
+func (f *Forwarder) serve(listener *dcnet.Listener, handle func(*dcnet.Conn)) {
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+
+		for {
+			conn, err := listener.AcceptConn()
+			if err != nil {
+				return
+			}
+
+			f.wg.Add(1)
+			go func() {
+				defer f.wg.Done()
+				handle(conn)
+			}()
+		}
+	}()
+}
+
+func (f *Forwarder) handleConnect(conn *dcnet.Conn) {
+	target := conn.DataChannel().Label()
+	if f.config.AllowConnect == nil || !f.config.AllowConnect(target) {
+		_ = conn.Close()
+
+		return
+	}
+
+	f.dialAndPipe(conn, target)
+}
+
+func (f *Forwarder) handleReverse(conn *dcnet.Conn) {
+	f.mu.Lock()
+	target, ok := f.reverseTargets[conn.DataChannel().Label()]
+	f.mu.Unlock()
+
+	if !ok {
+		_ = conn.Close()
+
+		return
+	}
+
+	f.dialAndPipe(conn, target)
+}
+
+func (f *Forwarder) dialAndPipe(conn *dcnet.Conn, target string) {
+	targetConn, err := f.config.Dial(f.ctx, "tcp", target)
+	if err != nil {
+		_ = conn.Close()
+
+		return
+	}
+
+	f.pipe(targetConn, conn)
+}
+
+func (f *Forwarder) handleListen(control *dcnet.Conn) {
+	address := control.DataChannel().Label()
+	if f.config.AllowListen == nil || !f.config.AllowListen(address) {
+		writeListenStatus(control, "", errListenNotAllowed)
+		_ = control.Close()
+
+		return
+	}
+
+	listener, err := f.config.Listen("tcp", address)
+	if err != nil {
+		writeListenStatus(control, "", err)
+		_ = control.Close()
+
+		return
+	}
+	writeListenStatus(control, listener.Addr().String(), nil)
+
+	// The listener is active until either peer closes the control DataChannel.
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+
+		stop := context.AfterFunc(f.ctx, func() {
+			_ = control.Close()
+		})
+		defer stop()
+
+		_, _ = control.Read(make([]byte, 1))
+		_ = control.Close()
+		_ = listener.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		f.goForward(conn, address, ProtocolReverse)
+	}
+}
+
+// writeListenStatus answers a ProtocolListen request with the listening
+// address or the error.
+func writeListenStatus(control net.Conn, addr string, err error) {
+	status := append([]byte{listenStatusOK}, addr...)
+	if err != nil {
+		status = append([]byte{listenStatusError}, err.Error()...)
+	}
+
+	_, _ = control.Write(status)
+}
+
+func readListenStatus(control net.Conn) (string, error) {
+	status := make([]byte, maxListenStatusSize)
+	n, err := control.Read(status)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case n > 0 && status[0] == listenStatusOK:
+		return string(status[1:n]), nil
+	case n > 0 && status[0] == listenStatusError:
+		return "", fmt.Errorf("%w: %s", errRemoteListen, status[1:n])
+	default:
+		return "", errInvalidListenStatus
+	}
+}
+
+// pipe copies between the connections until either direction ends or the
+// Forwarder is closed, and closes both.
+func (f *Forwarder) pipe(a, b net.Conn) {
+	done := make(chan struct{}, 2)
+	copyConn := func(dst, src net.Conn) {
+		_, _ = io.Copy(dst, src)
+		done <- struct{}{}
+	}
+	go copyConn(a, b)
+	go copyConn(b, a)
+
+	remaining := 2
+	select {
+	case <-done:
+		remaining--
+	case <-f.ctx.Done():
+	}
+	_ = a.Close()
+	_ = b.Close()
+
+	for ; remaining > 0; remaining-- {
+		<-done
+	}
+}
@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package portforward
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/pion/webrtc/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newConnectedPair(t *testing.T) (*webrtc.PeerConnection, *webrtc.PeerConnection) {
+	t.Helper()
+
+	settingEngine := webrtc.SettingEngine{}
+	settingEngine.DetachDataChannels()
+	api := webrtc.NewAPI(webrtc.WithSettingEngine(settingEngine))
+
+	offerer, err := api.NewPeerConnection(webrtc.Configuration{})
+	require.NoError(t, err)
+	answerer, err := api.NewPeerConnection(webrtc.Configuration{})
+	require.NoError(t, err)
+
+	_, err = offerer.CreateDataChannel("negotiate", nil)
+	require.NoError(t, err)
+
+	connected := make(chan struct{})
+	offerer.OnConnectionStateChange(func(state webrtc.PeerConnectionState) {
+		if state == webrtc.PeerConnectionStateConnected {
+			close(connected)
+		}
+	})
+
+	offer, err := offerer.CreateOffer(nil)
+	require.NoError(t, err)
+	offerGathered := webrtc.GatheringCompletePromise(offerer)
+	require.NoError(t, offerer.SetLocalDescription(offer))
+	<-offerGathered
+	require.NoError(t, answerer.SetRemoteDescription(*offerer.LocalDescription()))
+
+	answer, err := answerer.CreateAnswer(nil)
+	require.NoError(t, err)
+	answerGathered := webrtc.GatheringCompletePromise(answerer)
+	require.NoError(t, answerer.SetLocalDescription(answer))
+	<-answerGathered
+	require.NoError(t, offerer.SetRemoteDescription(*answerer.LocalDescription()))
+	<-connected
+
+	return offerer, answerer
+}
+
+// listenEcho starts a TCP server that echoes every connection.
+func listenEcho(t *testing.T) net.Listener {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				_, _ = io.Copy(conn, conn)
+				_ = conn.Close()
+			}()
+		}
+	}()
+
+	return listener
+}
+
+func assertEcho(t *testing.T, address string) {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", address)
+	require.NoError(t, err)
+
+	for _, message := range []string{"ping", "pong"} {
+		_, err = conn.Write([]byte(message))
+		require.NoError(t, err)
+
+		received := make([]byte, len(message))
+		_, err = io.ReadFull(conn, received)
+		require.NoError(t, err)
+		assert.Equal(t, message, string(received))
+	}
+
+	assert.NoError(t, conn.Close())
+}
+
+func TestForwarder(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	echo := listenEcho(t)
+	client, server := newConnectedPair(t)
+
+	clientForwarder := New(client, Config{})
+	serverForwarder := New(server, Config{
+		AllowConnect: func(target string) bool { return target == echo.Addr().String() },
+		AllowListen:  func(address string) bool { return address == "127.0.0.1:0" },
+	})
+
+	// Local forward, the client accepts connections and the server connects.
+	local, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	forwardDone := make(chan error, 1)
+	go func() {
+		forwardDone <- clientForwarder.ForwardLocal(local, echo.Addr().String())
+	}()
+
+	assertEcho(t, local.Addr().String())
+	assertEcho(t, local.Addr().String())
+
+	// The client doesn't allow listening, the server only on 127.0.0.1:0.
+	_, err = serverForwarder.ForwardRemote(context.Background(), "127.0.0.1:0", echo.Addr().String())
+	assert.ErrorIs(t, err, errRemoteListen)
+	_, err = clientForwarder.ForwardRemote(context.Background(), "127.0.0.2:0", echo.Addr().String())
+	assert.ErrorIs(t, err, errRemoteListen)
+
+	// Remote forward, the server accepts connections and the client connects.
+	remote, err := clientForwarder.ForwardRemote(context.Background(), "127.0.0.1:0", echo.Addr().String())
+	require.NoError(t, err)
+	assert.NotEqual(t, "127.0.0.1:0", remote.Addr())
+
+	_, err = clientForwarder.ForwardRemote(context.Background(), "127.0.0.1:0", echo.Addr().String())
+	assert.ErrorIs(t, err, errAlreadyForwarded)
+
+	assertEcho(t, remote.Addr())
+
+	require.NoError(t, remote.Close())
+	<-remote.Done()
+
+	assert.NoError(t, clientForwarder.Close())
+	assert.ErrorIs(t, <-forwardDone, errForwarderClosed)
+	assert.NoError(t, serverForwarder.Close())
+
+	assert.NoError(t, client.Close())
+	assert.NoError(t, server.Close())
+	assert.NoError(t, echo.Close())
+}
@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtptranslator
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/pion/rtp"
+)
+
+// rtxOSNSize is the size of the original sequence number that prefixes the
+// payload of a retransmission, see RFC 4588 Section 4.
+const rtxOSNSize = 2
+
+var errRTXPayloadTooShort = errors.New("rtx payload too short to contain the original sequence number")
+
+// UnwrapRTX turns a RFC 4588 retransmission packet back into the packet it
+// repairs. The original sequence number is read from the payload, and the SSRC
+// and payload type are replaced with the ones of the original stream. The
+// packet is modified in place and its payload keeps referencing the same buffer.
+func UnwrapRTX(packet *rtp.Packet, originalSSRC uint32, originalPayloadType uint8) error {
+	if len(packet.Payload) < rtxOSNSize {
+		return errRTXPayloadTooShort
+	}
+
+	packet.SequenceNumber = binary.BigEndian.Uint16(packet.Payload)
+	packet.Payload = packet.Payload[rtxOSNSize:]
+	packet.SSRC = originalSSRC
+	packet.PayloadType = originalPayloadType
+
+	return nil
+}
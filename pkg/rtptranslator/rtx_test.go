@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtptranslator
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnwrapRTX(t *testing.T) {
+	packet := &rtp.Packet{
+		Header:  rtp.Header{SequenceNumber: 7, SSRC: 2, PayloadType: 97},
+		Payload: []byte{0x01, 0x02, 0xAA, 0xBB},
+	}
+	assert.NoError(t, UnwrapRTX(packet, 1, 96))
+	assert.Equal(t, uint16(0x0102), packet.SequenceNumber)
+	assert.Equal(t, uint32(1), packet.SSRC)
+	assert.Equal(t, uint8(96), packet.PayloadType)
+	assert.Equal(t, []byte{0xAA, 0xBB}, packet.Payload)
+
+	assert.ErrorIs(t, UnwrapRTX(&rtp.Packet{Payload: []byte{0x01}}, 1, 96), errRTXPayloadTooShort)
+}
@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+// Package rtptranslator provides utilities to rewrite RTP sequence numbers and
+// timestamps when forwarding packets from one or more sources onto a single stream.
+package rtptranslator
+
+// SequenceTranslator maps the sequence numbers of incoming packets onto a
+// contiguous outgoing sequence. Sources can be switched (e.g. a simulcast layer
+// change) and packets can be dropped without leaving gaps in the output,
+// which the receiver would otherwise report as loss.
+//
+// SequenceTranslator is not safe for concurrent use.
+type SequenceTranslator struct {
+	started bool
+
+	// offset is added to incoming sequence numbers to produce outgoing ones.
+	offset uint16
+
+	// highestIn is the highest incoming sequence number of the current source.
+	highestIn uint16
+
+	// firstIn is the first incoming sequence number of the current source, older
+	// packets belong to a previous source and can't be translated.
+	firstIn uint16
+
+	// lastOut is the highest outgoing sequence number produced.
+	lastOut uint16
+
+	switching bool
+}
+
+// NewSequenceTranslator creates a new SequenceTranslator.
+func NewSequenceTranslator() *SequenceTranslator {
+	return &SequenceTranslator{}
+}
+
+// Translate returns the outgoing sequence number for seq. ok is false when the
+// packet can't be forwarded, because it belongs to a source that was switched
+// away from or it is older than the window that can be tracked.
+func (s *SequenceTranslator) Translate(seq uint16) (out uint16, ok bool) {
+	switch {
+	case !s.started:
+		s.started = true
+		s.resetSource(seq, seq)
+	case s.switching:
+		s.resetSource(seq, s.lastOut+1)
+	}
+
+	diff := int16(seq - s.highestIn) //nolint:gosec // G115, sequence numbers wrap
+	if diff > 0 {
+		s.highestIn = seq
+	} else if int16(seq-s.firstIn) < 0 { //nolint:gosec // G115, sequence numbers wrap
+		return 0, false
+	}
+
+	out = seq + s.offset
+	if int16(out-s.lastOut) > 0 { //nolint:gosec // G115, sequence numbers wrap
+		s.lastOut = out
+	}
+
+	return out, true
+}
+
+// Drop marks seq as intentionally not forwarded, e.g. a padding only packet.
+// Following packets are shifted so the output has no gap. Only a packet newer
+// than every packet seen so far can be dropped, otherwise false is returned.
+// Packets older than a dropped packet can no longer be translated.
+func (s *SequenceTranslator) Drop(seq uint16) bool {
+	switch {
+	case !s.started:
+		s.started = true
+		s.resetSource(seq, seq)
+	case s.switching:
+		s.resetSource(seq, s.lastOut+1)
+	case int16(seq-s.highestIn) <= 0: //nolint:gosec // G115, sequence numbers wrap
+		return false
+	}
+
+	s.offset--
+	s.highestIn = seq
+	s.firstIn = seq + 1
+
+	return true
+}
+
+// Switch tells the SequenceTranslator the next packet comes from a different
+// source. The outgoing sequence continues right after the last forwarded packet.
+func (s *SequenceTranslator) Switch() {
+	if s.started {
+		s.switching = true
+	}
+}
+
+func (s *SequenceTranslator) resetSource(seq, out uint16) {
+	s.switching = false
+	s.firstIn = seq
+	s.highestIn = seq - 1
+	s.offset = out - seq
+	s.lastOut = out - 1
+}
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtptranslator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func translateAll(t *testing.T, s *SequenceTranslator, in ...uint16) []uint16 {
+	t.Helper()
+
+	out := make([]uint16, 0, len(in))
+	for _, seq := range in {
+		translated, ok := s.Translate(seq)
+		assert.True(t, ok, "seq %d", seq)
+		out = append(out, translated)
+	}
+
+	return out
+}
+
+func TestSequenceTranslator(t *testing.T) {
+	t.Run("Passthrough", func(t *testing.T) {
+		s := NewSequenceTranslator()
+		assert.Equal(t, []uint16{10, 11, 13, 12}, translateAll(t, s, 10, 11, 13, 12))
+	})
+
+	t.Run("Wrap", func(t *testing.T) {
+		s := NewSequenceTranslator()
+		assert.Equal(t, []uint16{65534, 65535, 0, 1}, translateAll(t, s, 65534, 65535, 0, 1))
+	})
+
+	t.Run("Switch", func(t *testing.T) {
+		s := NewSequenceTranslator()
+		assert.Equal(t, []uint16{100, 101}, translateAll(t, s, 100, 101))
+
+		s.Switch()
+		assert.Equal(t, []uint16{102, 103}, translateAll(t, s, 5000, 5001))
+
+		// Late packet from the previous source
+		_, ok := s.Translate(4999)
+		assert.False(t, ok)
+
+		s.Switch()
+		assert.Equal(t, []uint16{104, 105}, translateAll(t, s, 65535, 0))
+	})
+
+	t.Run("Drop", func(t *testing.T) {
+		s := NewSequenceTranslator()
+		assert.Equal(t, []uint16{1, 2}, translateAll(t, s, 1, 2))
+
+		assert.True(t, s.Drop(3))
+		assert.False(t, s.Drop(3))
+		assert.Equal(t, []uint16{3, 5, 4}, translateAll(t, s, 4, 6, 5))
+
+		_, ok := s.Translate(3)
+		assert.False(t, ok)
+	})
+
+	t.Run("DropFirst", func(t *testing.T) {
+		s := NewSequenceTranslator()
+		assert.True(t, s.Drop(50))
+		assert.Equal(t, []uint16{50, 51}, translateAll(t, s, 51, 52))
+	})
+
+	t.Run("DropAfterSwitch", func(t *testing.T) {
+		s := NewSequenceTranslator()
+		assert.Equal(t, []uint16{1}, translateAll(t, s, 1))
+
+		s.Switch()
+		assert.True(t, s.Drop(700))
+		assert.Equal(t, []uint16{2}, translateAll(t, s, 701))
+	})
+}
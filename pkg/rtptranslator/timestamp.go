@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtptranslator
+
+import (
+	"time"
+)
+
+// TimestampTranslator maps the RTP timestamps of incoming packets onto a single
+// outgoing timeline. When the source is switched the first timestamp of the new
+// source is placed after the last forwarded one, advanced by the wall clock time
+// that elapsed in between, so the receiver's jitter and playout calculations
+// stay correct across the switch.
+//
+// TimestampTranslator is not safe for concurrent use.
+type TimestampTranslator struct {
+	clockRate uint32
+
+	started   bool
+	switching bool
+
+	offset      uint32
+	lastOut     uint32
+	lastOutTime time.Time
+}
+
+// NewTimestampTranslator creates a new TimestampTranslator for a stream with
+// the given RTP clock rate.
+func NewTimestampTranslator(clockRate uint32) *TimestampTranslator {
+	return &TimestampTranslator{clockRate: clockRate}
+}
+
+// Translate returns the outgoing timestamp for timestamp, now is the time the
+// packet was received. Timestamps older than the last forwarded one are
+// translated with the same offset so reordered packets keep their spacing.
+func (t *TimestampTranslator) Translate(timestamp uint32, now time.Time) uint32 {
+	switch {
+	case !t.started:
+		t.started = true
+		t.offset = 0
+		t.lastOut = timestamp
+		t.lastOutTime = now
+	case t.switching:
+		t.switching = false
+
+		expected := t.lastOut + t.ticks(now.Sub(t.lastOutTime))
+		if expected == t.lastOut {
+			// Never repeat the previous timestamp for a different frame.
+			expected++
+		}
+		t.offset = expected - timestamp
+	}
+
+	out := timestamp + t.offset
+	if int32(out-t.lastOut) > 0 { //nolint:gosec // G115, timestamps wrap
+		t.lastOut = out
+		t.lastOutTime = now
+	}
+
+	return out
+}
+
+// Switch tells the TimestampTranslator the next packet comes from a different source.
+func (t *TimestampTranslator) Switch() {
+	if t.started {
+		t.switching = true
+	}
+}
+
+func (t *TimestampTranslator) ticks(elapsed time.Duration) uint32 {
+	if elapsed <= 0 {
+		return 0
+	}
+
+	return uint32(elapsed.Seconds() * float64(t.clockRate))
+}
@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package rtptranslator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimestampTranslator(t *testing.T) {
+	now := time.Unix(0, 0)
+
+	t.Run("Passthrough", func(t *testing.T) {
+		translator := NewTimestampTranslator(90000)
+		assert.Equal(t, uint32(1000), translator.Translate(1000, now))
+		assert.Equal(t, uint32(4000), translator.Translate(4000, now.Add(33*time.Millisecond)))
+		assert.Equal(t, uint32(2500), translator.Translate(2500, now.Add(40*time.Millisecond)))
+	})
+
+	t.Run("Switch", func(t *testing.T) {
+		translator := NewTimestampTranslator(90000)
+		assert.Equal(t, uint32(4294967000), translator.Translate(4294967000, now))
+
+		translator.Switch()
+		assert.Equal(t, uint32(8704), translator.Translate(123, now.Add(100*time.Millisecond)))
+		assert.Equal(t, uint32(11704), translator.Translate(3123, now.Add(133*time.Millisecond)))
+	})
+
+	t.Run("SwitchWithoutElapsedTime", func(t *testing.T) {
+		translator := NewTimestampTranslator(48000)
+		assert.Equal(t, uint32(960), translator.Translate(960, now))
+
+		translator.Switch()
+		assert.Equal(t, uint32(961), translator.Translate(50, now))
+	})
+}
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package sipgw
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SDESSuiteAESCM128HMACSHA180 is the crypto suite every SDES endpoint supports,
+// see RFC 4568 Section 6.2.
+const SDESSuiteAESCM128HMACSHA180 = "AES_CM_128_HMAC_SHA1_80"
+
+const (
+	sdesKeyLength  = 16
+	sdesSaltLength = 14
+	sdesKeyMethod  = "inline:"
+)
+
+var (
+	errSDESInvalidCrypto = errors.New("sipgw: invalid crypto attribute")
+	errSDESUnknownSuite  = errors.New("sipgw: unsupported crypto suite")
+	errSDESInvalidKey    = errors.New("sipgw: invalid SDES key parameter")
+)
+
+// SDESCrypto is a RFC 4568 a=crypto attribute. It is used by media gateways that
+// bridge SDES-SRTP endpoints to a PeerConnection's DTLS-SRTP.
+type SDESCrypto struct {
+	// Tag identifies the attribute in offer and answer.
+	Tag int
+
+	// Suite is the crypto suite, only SDESSuiteAESCM128HMACSHA180 is supported.
+	Suite string
+
+	// Key and Salt are the SRTP master key and master salt.
+	Key  []byte
+	Salt []byte
+}
+
+// NewSDESCrypto creates a SDESCrypto with the AES_CM_128_HMAC_SHA1_80 suite and
+// a random master key and salt.
+func NewSDESCrypto(tag int) (*SDESCrypto, error) {
+	keySalt := make([]byte, sdesKeyLength+sdesSaltLength)
+	if _, err := rand.Read(keySalt); err != nil {
+		return nil, err
+	}
+
+	return &SDESCrypto{
+		Tag:   tag,
+		Suite: SDESSuiteAESCM128HMACSHA180,
+		Key:   keySalt[:sdesKeyLength],
+		Salt:  keySalt[sdesKeyLength:],
+	}, nil
+}
+
+// ParseSDESCrypto parses the value of an a=crypto attribute, like
+// "1 AES_CM_128_HMAC_SHA1_80 inline:<key||salt>". Lifetime and MKI of the key
+// parameter as well as session parameters are ignored.
+func ParseSDESCrypto(value string) (*SDESCrypto, error) {
+	fields := strings.Fields(value)
+	if len(fields) < 3 {
+		return nil, fmt.Errorf("%w: %s", errSDESInvalidCrypto, value)
+	}
+
+	tag, err := strconv.Atoi(fields[0])
+	if err != nil || tag < 0 {
+		return nil, fmt.Errorf("%w: %s", errSDESInvalidCrypto, value)
+	}
+
+	if fields[1] != SDESSuiteAESCM128HMACSHA180 {
+		return nil, fmt.Errorf("%w: %s", errSDESUnknownSuite, fields[1])
+	}
+
+	// Only the first key parameter is used, see RFC 4568 Section 9.2.
+	keyParam, _, _ := strings.Cut(fields[2], ";")
+	if !strings.HasPrefix(keyParam, sdesKeyMethod) {
+		return nil, fmt.Errorf("%w: %s", errSDESInvalidKey, keyParam)
+	}
+
+	keyInfo, _, _ := strings.Cut(strings.TrimPrefix(keyParam, sdesKeyMethod), "|")
+	keySalt, err := base64.StdEncoding.DecodeString(keyInfo)
+	if err != nil {
+		// Some endpoints omit the padding.
+		keySalt, err = base64.RawStdEncoding.DecodeString(keyInfo)
+	}
+	if err != nil || len(keySalt) != sdesKeyLength+sdesSaltLength {
+		return nil, fmt.Errorf("%w: %s", errSDESInvalidKey, keyParam)
+	}
+
+	return &SDESCrypto{
+		Tag:   tag,
+		Suite: fields[1],
+		Key:   keySalt[:sdesKeyLength],
+		Salt:  keySalt[sdesKeyLength:],
+	}, nil
+}
+
+// String returns the value of the a=crypto attribute.
+func (c *SDESCrypto) String() string {
+	keySalt := append(append([]byte{}, c.Key...), c.Salt...)
+
+	return fmt.Sprintf("%d %s %s%s", c.Tag, c.Suite, sdesKeyMethod, base64.StdEncoding.EncodeToString(keySalt))
+}
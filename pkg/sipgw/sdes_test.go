@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package sipgw
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSDESCrypto(t *testing.T) {
+	crypto, err := NewSDESCrypto(1)
+	require.NoError(t, err)
+	assert.Len(t, crypto.Key, sdesKeyLength)
+	assert.Len(t, crypto.Salt, sdesSaltLength)
+
+	parsed, err := ParseSDESCrypto(crypto.String())
+	require.NoError(t, err)
+	assert.Equal(t, crypto, parsed)
+
+	// RFC 4568 Section 4, with lifetime, MKI and a session parameter.
+	parsed, err = ParseSDESCrypto(
+		"1 AES_CM_128_HMAC_SHA1_80 inline:PS1uQCVeeCFCanVmcjkpPywjNWhcYD0mXXtxaVBR|2^20|1:4 KDR=1",
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 1, parsed.Tag)
+	assert.Equal(t, []byte("=-n@%^x!Bjufr9)"), parsed.Key[:15])
+
+	for _, value := range []string{
+		"",
+		"x AES_CM_128_HMAC_SHA1_80 inline:PS1uQCVeeCFCanVmcjkpPywjNWhcYD0mXXtxaVBR",
+		"1 AES_CM_128_HMAC_SHA1_32 inline:PS1uQCVeeCFCanVmcjkpPywjNWhcYD0mXXtxaVBR",
+		"1 AES_CM_128_HMAC_SHA1_80 PS1uQCVeeCFCanVmcjkpPywjNWhcYD0mXXtxaVBR",
+		"1 AES_CM_128_HMAC_SHA1_80 inline:PS1uQCVe",
+	} {
+		_, err = ParseSDESCrypto(value)
+		assert.Error(t, err, value)
+	}
+}
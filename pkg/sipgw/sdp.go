@@ -0,0 +1,298 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+// Package sipgw helps bridging a PeerConnection to SIP endpoints, like PSTN
+// gateways, SBCs and PBXs. It adapts the SDP a PeerConnection produces to what
+// typical SIP endpoints accept and translates SIP's offer/answer patterns, like
+// delayed offers and session refresh re-INVITEs, into PeerConnection calls.
+//
+// The SIP endpoint still has to support ICE and DTLS-SRTP (RFC 5763) to establish
+// media with the PeerConnection. Endpoints that only support SDES keying have to
+// be bridged by a media gateway, the SDES helpers of this package produce and
+// parse the crypto attributes for it.
+package sipgw
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/pion/ice/v4"
+	"github.com/pion/rtp"
+	"github.com/pion/sdp/v3"
+	"github.com/pion/webrtc/v4"
+)
+
+const (
+	attrKeyRTCP        = "rtcp"
+	attrKeyRTCPFb      = "rtcp-fb"
+	attrKeyRID         = "rid"
+	attrKeySimulcast   = "simulcast"
+	attrKeyPtime       = "ptime"
+	attrKeyFingerprint = "fingerprint"
+	attrKeyCrypto      = "crypto"
+
+	telephoneEventPayloadType = 101
+	defaultPtime              = 20
+)
+
+// ErrSDESOnly indicates a SIP session description that only offers SDES keying,
+// which a PeerConnection can't negotiate.
+var ErrSDESOnly = errors.New("sipgw: session description only supports SDES keying")
+
+// Options controls how a PeerConnection's session description is adapted for SIP.
+type Options struct {
+	// KeepBundle keeps the BUNDLE group. SIP endpoints that don't implement RFC 8843
+	// may reject the session, so it is removed by default. All media still uses the
+	// same transport, as PeerConnections always multiplex media.
+	KeepBundle bool
+
+	// KeepHeaderExtensions keeps the a=extmap lines, which are removed by default.
+	KeepHeaderExtensions bool
+
+	// KeepRTCPFeedback keeps the a=rtcp-fb lines, which are removed by default.
+	KeepRTCPFeedback bool
+
+	// Ptime is added as a=ptime to audio sections. Zero selects 20ms, the usual
+	// framing of G.711, negative values don't add the attribute.
+	Ptime int
+}
+
+// RegisterDefaultCodecs registers the codecs typically supported by SIP
+// endpoints: G.711 µ-law and A-law with their static payload types, and RFC 4733
+// telephone-events for DTMF.
+func RegisterDefaultCodecs(mediaEngine *webrtc.MediaEngine) error {
+	for _, codec := range []webrtc.RTPCodecParameters{
+		{
+			RTPCodecCapability: webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypePCMU, ClockRate: 8000},
+			PayloadType:        rtp.PayloadTypePCMU,
+		},
+		{
+			RTPCodecCapability: webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypePCMA, ClockRate: 8000},
+			PayloadType:        rtp.PayloadTypePCMA,
+		},
+		{
+			RTPCodecCapability: webrtc.RTPCodecCapability{
+				MimeType: webrtc.MimeTypeTelephoneEvent, ClockRate: 8000, SDPFmtpLine: "0-16",
+			},
+			PayloadType: telephoneEventPayloadType,
+		},
+	} {
+		if err := mediaEngine.RegisterCodec(codec, webrtc.RTPCodecTypeAudio); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ToSIP adapts a session description created by a PeerConnection for a SIP
+// endpoint. Trickle ICE, WebRTC specific stream identification and, depending on
+// the Options, BUNDLE, header extensions and RTCP feedback are removed.
+//
+// Without BUNDLE every media section gets the ICE candidates and the best
+// candidate is set as the default in m= and c= lines for endpoints that don't
+// implement ICE, see RFC 8839 Section 4.2.1.2. An a=rtcp attribute pointing at
+// the RTP port is added, so endpoints that don't implement rtcp-mux still send
+// RTCP where the PeerConnection expects it. The description should be taken
+// after ICE gathering completed, as SIP has no trickle ICE.
+func ToSIP(desc webrtc.SessionDescription, options Options) (string, error) {
+	parsed, err := desc.Unmarshal()
+	if err != nil {
+		return "", err
+	}
+
+	parsed.Attributes = filterAttributes(parsed.Attributes, func(attr sdp.Attribute) bool {
+		switch attr.Key {
+		case sdp.AttrKeyGroup:
+			return options.KeepBundle || !strings.HasPrefix(attr.Value, "BUNDLE")
+		case sdp.AttrKeyMsidSemantic, sdp.AttrKeyExtMapAllowMixed, sdp.AttrKeyICEOptions:
+			return false
+		default:
+			return true
+		}
+	})
+
+	ptime := options.Ptime
+	if ptime == 0 {
+		ptime = defaultPtime
+	}
+
+	candidates := collectCandidates(parsed)
+	defaultCandidate := pickDefaultCandidate(candidates)
+
+	for _, media := range parsed.MediaDescriptions {
+		// Rejected media sections stay untouched.
+		if media.MediaName.Port.Value == 0 {
+			continue
+		}
+
+		if !options.KeepBundle && !hasCandidates(media) {
+			media.Attributes = append(media.Attributes, candidates...)
+		}
+
+		if defaultCandidate != nil {
+			setDefaultCandidate(media, defaultCandidate)
+		}
+
+		adaptMediaForSIP(media, options, ptime)
+	}
+
+	out, err := parsed.Marshal()
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+func adaptMediaForSIP(media *sdp.MediaDescription, options Options, ptime int) {
+	_, hasRTCP := media.Attribute(attrKeyRTCP)
+	_, hasRTCPMux := media.Attribute(sdp.AttrKeyRTCPMux)
+	_, hasPtime := media.Attribute(attrKeyPtime)
+
+	media.Attributes = filterAttributes(media.Attributes, func(attr sdp.Attribute) bool {
+		switch attr.Key {
+		case sdp.AttrKeyExtMap:
+			return options.KeepHeaderExtensions
+		case attrKeyRTCPFb:
+			return options.KeepRTCPFeedback
+		case sdp.AttrKeyMsid, attrKeyRID, attrKeySimulcast, sdp.AttrKeyExtMapAllowMixed, sdp.AttrKeyICEOptions:
+			return false
+		case sdp.AttrKeySSRC:
+			// Keep cname, drop msid, mslabel and label.
+			return strings.Contains(attr.Value, " cname:")
+		default:
+			return true
+		}
+	})
+
+	if hasRTCPMux && !hasRTCP && media.MediaName.Media != "application" {
+		media.WithValueAttribute(attrKeyRTCP, strconv.Itoa(media.MediaName.Port.Value))
+	}
+
+	if ptime > 0 && !hasPtime && media.MediaName.Media == "audio" {
+		media.WithValueAttribute(attrKeyPtime, strconv.Itoa(ptime))
+	}
+}
+
+// collectCandidates returns the candidate and end-of-candidates attributes of
+// all media sections, without duplicates.
+func collectCandidates(parsed *sdp.SessionDescription) []sdp.Attribute {
+	var candidates []sdp.Attribute
+	seen := map[sdp.Attribute]bool{}
+	for _, media := range parsed.MediaDescriptions {
+		for _, attr := range media.Attributes {
+			if (attr.IsICECandidate() || attr.Key == sdp.AttrKeyEndOfCandidates) && !seen[attr] {
+				seen[attr] = true
+				candidates = append(candidates, attr)
+			}
+		}
+	}
+
+	return candidates
+}
+
+func hasCandidates(media *sdp.MediaDescription) bool {
+	for _, attr := range media.Attributes {
+		if attr.IsICECandidate() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pickDefaultCandidate returns the RTP UDP candidate most likely to work for an
+// endpoint without ICE: relay before server reflexive before host.
+func pickDefaultCandidate(candidates []sdp.Attribute) ice.Candidate {
+	rank := map[ice.CandidateType]int{
+		ice.CandidateTypeRelay:           3,
+		ice.CandidateTypeServerReflexive: 2,
+		ice.CandidateTypeHost:            1,
+	}
+
+	var best ice.Candidate
+	for _, attr := range candidates {
+		if !attr.IsICECandidate() {
+			continue
+		}
+
+		candidate, err := ice.UnmarshalCandidate(attr.Value)
+		if err != nil || candidate.Component() != ice.ComponentRTP || !candidate.NetworkType().IsUDP() ||
+			net.ParseIP(candidate.Address()) == nil {
+			continue
+		}
+
+		if best == nil || rank[candidate.Type()] > rank[best.Type()] {
+			best = candidate
+		}
+	}
+
+	return best
+}
+
+func setDefaultCandidate(media *sdp.MediaDescription, candidate ice.Candidate) {
+	addressType := "IP4"
+	if candidate.NetworkType().IsIPv6() {
+		addressType = "IP6"
+	}
+
+	media.MediaName.Port = sdp.RangedPort{Value: candidate.Port()}
+	media.ConnectionInformation = &sdp.ConnectionInformation{
+		NetworkType: "IN",
+		AddressType: addressType,
+		Address:     &sdp.Address{Address: candidate.Address()},
+	}
+}
+
+// FromSIP turns a session description received from a SIP endpoint into one a
+// PeerConnection accepts. Media sections without a=mid get their index as mid.
+// ErrSDESOnly is returned when the endpoint only offers SDES keying.
+func FromSIP(sdpType webrtc.SDPType, body string) (webrtc.SessionDescription, error) {
+	parsed := &sdp.SessionDescription{}
+	if err := parsed.UnmarshalString(body); err != nil {
+		return webrtc.SessionDescription{}, fmt.Errorf("%w: %w", webrtc.ErrSDPUnmarshalling, err)
+	}
+
+	_, hasFingerprint := parsed.Attribute(attrKeyFingerprint)
+	hasCrypto := false
+	for i, media := range parsed.MediaDescriptions {
+		if _, ok := media.Attribute(sdp.AttrKeyMID); !ok {
+			media.WithValueAttribute(sdp.AttrKeyMID, strconv.Itoa(i))
+		}
+
+		if _, ok := media.Attribute(attrKeyFingerprint); ok {
+			hasFingerprint = true
+		}
+		if _, ok := media.Attribute(attrKeyCrypto); ok {
+			hasCrypto = true
+		}
+	}
+
+	if hasCrypto && !hasFingerprint {
+		return webrtc.SessionDescription{}, ErrSDESOnly
+	}
+
+	out, err := parsed.Marshal()
+	if err != nil {
+		return webrtc.SessionDescription{}, err
+	}
+
+	return webrtc.SessionDescription{Type: sdpType, SDP: string(out)}, nil
+}
+
+func filterAttributes(attributes []sdp.Attribute, keep func(sdp.Attribute) bool) []sdp.Attribute {
+	filtered := attributes[:0]
+	for _, attr := range attributes {
+		if keep(attr) {
+			filtered = append(filtered, attr)
+		}
+	}
+
+	return filtered
+}
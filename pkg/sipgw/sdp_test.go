@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package sipgw
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pion/sdp/v3"
+	"github.com/pion/webrtc/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sipOffer = `v=0
+o=- 4711 1 IN IP4 192.0.2.10
+s=-
+c=IN IP4 192.0.2.10
+t=0 0
+m=audio 49170 RTP/SAVPF 0 8 101
+a=ice-ufrag:F7gI
+a=ice-pwd:x9cml/YzichV2+XlhiMu8g
+a=fingerprint:sha-256 ` +
+	`5A:D4:F8:9F:67:D2:A4:AF:3B:1F:B3:EF:9B:36:65:13:74:99:84:97:BA:90:F8:63:D2:8C:0B:81:1B:9C:0A:AE
+a=setup:actpass
+a=candidate:1 1 udp 2130706431 192.0.2.10 49170 typ host
+a=rtpmap:0 PCMU/8000
+a=rtpmap:8 PCMA/8000
+a=rtpmap:101 telephone-event/8000
+a=fmtp:101 0-16
+a=sendrecv
+`
+
+func newSIPPeerConnection(t *testing.T) *webrtc.PeerConnection {
+	t.Helper()
+
+	mediaEngine := &webrtc.MediaEngine{}
+	require.NoError(t, RegisterDefaultCodecs(mediaEngine))
+
+	pc, err := webrtc.NewAPI(webrtc.WithMediaEngine(mediaEngine)).NewPeerConnection(webrtc.Configuration{})
+	require.NoError(t, err)
+
+	return pc
+}
+
+func TestToSIP(t *testing.T) {
+	pc := newSIPPeerConnection(t)
+	defer func() { assert.NoError(t, pc.Close()) }()
+
+	for range 2 {
+		_, err := pc.AddTransceiverFromKind(webrtc.RTPCodecTypeAudio)
+		require.NoError(t, err)
+	}
+
+	offer, err := pc.CreateOffer(nil)
+	require.NoError(t, err)
+
+	gatherComplete := webrtc.GatheringCompletePromise(pc)
+	require.NoError(t, pc.SetLocalDescription(offer))
+	<-gatherComplete
+
+	body, err := ToSIP(*pc.LocalDescription(), Options{})
+	require.NoError(t, err)
+
+	parsed := &sdp.SessionDescription{}
+	require.NoError(t, parsed.UnmarshalString(body))
+
+	for _, attr := range parsed.Attributes {
+		assert.NotEqual(t, sdp.AttrKeyMsidSemantic, attr.Key)
+		assert.False(t, strings.HasPrefix(attr.Value, "BUNDLE"))
+	}
+
+	require.Len(t, parsed.MediaDescriptions, 2)
+	for _, media := range parsed.MediaDescriptions {
+		assert.Equal(t, []string{"0", "8", "101"}, media.MediaName.Formats)
+		assert.NotEqual(t, 9, media.MediaName.Port.Value, "the default candidate must be set")
+		assert.NotEqual(t, "0.0.0.0", media.ConnectionInformation.Address.Address)
+		assert.True(t, hasCandidates(media))
+
+		rtcp, ok := media.Attribute(attrKeyRTCP)
+		assert.True(t, ok)
+		assert.Equal(t, rtcp, media.MediaName.Port.String())
+
+		ptime, ok := media.Attribute(attrKeyPtime)
+		assert.True(t, ok)
+		assert.Equal(t, "20", ptime)
+
+		for _, attr := range media.Attributes {
+			assert.NotContains(t, []string{sdp.AttrKeyExtMap, attrKeyRTCPFb, sdp.AttrKeyMsid}, attr.Key)
+		}
+	}
+
+	body, err = ToSIP(*pc.LocalDescription(), Options{KeepBundle: true, Ptime: -1})
+	require.NoError(t, err)
+	assert.Contains(t, body, "a=group:BUNDLE")
+	assert.NotContains(t, body, "a=ptime")
+
+	_, err = ToSIP(webrtc.SessionDescription{Type: webrtc.SDPTypeOffer, SDP: "invalid"}, Options{})
+	assert.Error(t, err)
+}
+
+func TestPickDefaultCandidate(t *testing.T) {
+	candidates := []sdp.Attribute{
+		sdp.NewAttribute(sdp.AttrKeyCandidate, "1 1 udp 2130706431 10.0.0.1 5000 typ host"),
+		sdp.NewAttribute(sdp.AttrKeyCandidate, "2 1 udp 1694498815 198.51.100.1 6000 typ srflx raddr 10.0.0.1 rport 5000"),
+		sdp.NewAttribute(sdp.AttrKeyCandidate, "3 1 tcp 16777215 203.0.113.1 7000 typ relay raddr 0.0.0.0 rport 0"),
+		sdp.NewAttribute(sdp.AttrKeyCandidate, "4 1 udp 2130706431 abc.local 8000 typ host"),
+		sdp.NewAttribute(sdp.AttrKeyEndOfCandidates, ""),
+	}
+
+	candidate := pickDefaultCandidate(candidates)
+	require.NotNil(t, candidate)
+	assert.Equal(t, "198.51.100.1", candidate.Address())
+	assert.Equal(t, 6000, candidate.Port())
+
+	assert.Nil(t, pickDefaultCandidate(candidates[3:]))
+}
+
+func TestFromSIP(t *testing.T) {
+	desc, err := FromSIP(webrtc.SDPTypeOffer, sipOffer)
+	require.NoError(t, err)
+	assert.Equal(t, webrtc.SDPTypeOffer, desc.Type)
+
+	parsed, err := desc.Unmarshal()
+	require.NoError(t, err)
+	mid, ok := parsed.MediaDescriptions[0].Attribute(sdp.AttrKeyMID)
+	assert.True(t, ok)
+	assert.Equal(t, "0", mid)
+
+	pc := newSIPPeerConnection(t)
+	defer func() { assert.NoError(t, pc.Close()) }()
+	require.NoError(t, pc.SetRemoteDescription(desc))
+
+	sdesOnly := strings.Replace(sipOffer, "a=fingerprint:", "a=crypto:1 AES_CM_128_HMAC_SHA1_80 inline:", 1)
+	_, err = FromSIP(webrtc.SDPTypeOffer, sdesOnly)
+	assert.ErrorIs(t, err, ErrSDESOnly)
+
+	_, err = FromSIP(webrtc.SDPTypeOffer, "invalid")
+	assert.ErrorIs(t, err, webrtc.ErrSDPUnmarshalling)
+}
@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package sipgw
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/pion/sdp/v3"
+	"github.com/pion/webrtc/v4"
+)
+
+// Session maps the offer/answer exchanges of a SIP dialog to a PeerConnection.
+// The SDP bodies it returns are complete, ICE candidates are gathered before a
+// description is returned.
+type Session struct {
+	pc      *webrtc.PeerConnection
+	options Options
+
+	mu sync.Mutex
+
+	// The origin of the last offer of the remote endpoint, and the answer to it.
+	hasRemoteOrigin      bool
+	remoteSessionID      uint64
+	remoteSessionVersion uint64
+	lastAnswer           string
+}
+
+// NewSession creates a Session for a PeerConnection. The PeerConnection's
+// transceivers and tracks are still managed by the caller.
+func NewSession(pc *webrtc.PeerConnection, options Options) *Session {
+	return &Session{pc: pc, options: options}
+}
+
+// CreateOffer creates an offer for an INVITE or a re-INVITE. The answer of the
+// remote endpoint is applied with HandleAnswer.
+func (s *Session) CreateOffer() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	offer, err := s.pc.CreateOffer(nil)
+	if err != nil {
+		return "", err
+	}
+
+	return s.setLocalDescription(offer)
+}
+
+// HandleAnswer applies the answer of the remote endpoint to an offer created
+// with CreateOffer. It is carried by the 2xx response of an INVITE, or by the ACK
+// for delayed offers.
+func (s *Session) HandleAnswer(body string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	answer, err := FromSIP(webrtc.SDPTypeAnswer, body)
+	if err != nil {
+		return err
+	}
+
+	return s.pc.SetRemoteDescription(answer)
+}
+
+// HandleOffer applies an offer of the remote endpoint and returns the answer.
+// An offer whose o= line has the same session id and version as the previous
+// offer is a session refresh, see RFC 3264 Section 8, and is answered with the
+// previous answer without renegotiating.
+func (s *Session) HandleOffer(body string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	parsed := &sdp.SessionDescription{}
+	if err := parsed.UnmarshalString(body); err != nil {
+		return "", fmt.Errorf("%w: %w", webrtc.ErrSDPUnmarshalling, err)
+	}
+
+	if s.hasRemoteOrigin && s.lastAnswer != "" &&
+		parsed.Origin.SessionID == s.remoteSessionID && parsed.Origin.SessionVersion == s.remoteSessionVersion {
+		return s.lastAnswer, nil
+	}
+
+	offer, err := FromSIP(webrtc.SDPTypeOffer, body)
+	if err != nil {
+		return "", err
+	}
+
+	if err = s.pc.SetRemoteDescription(offer); err != nil {
+		return "", err
+	}
+
+	answer, err := s.pc.CreateAnswer(nil)
+	if err != nil {
+		return "", err
+	}
+
+	sipAnswer, err := s.setLocalDescription(answer)
+	if err != nil {
+		return "", err
+	}
+
+	s.hasRemoteOrigin = true
+	s.remoteSessionID = parsed.Origin.SessionID
+	s.remoteSessionVersion = parsed.Origin.SessionVersion
+	s.lastAnswer = sipAnswer
+
+	return sipAnswer, nil
+}
+
+// HandleInvite handles the body of a received INVITE or re-INVITE and returns
+// the body of the 2xx response. For an INVITE without body, a delayed offer, an
+// offer is returned and the answer carried by the ACK is applied with HandleAnswer.
+func (s *Session) HandleInvite(body string) (string, error) {
+	if strings.TrimSpace(body) == "" {
+		return s.CreateOffer()
+	}
+
+	return s.HandleOffer(body)
+}
+
+// setLocalDescription applies the local description and waits for ICE
+// gathering, as SIP has no trickle ICE. s.mu must be held.
+func (s *Session) setLocalDescription(desc webrtc.SessionDescription) (string, error) {
+	gatherComplete := webrtc.GatheringCompletePromise(s.pc)
+	if err := s.pc.SetLocalDescription(desc); err != nil {
+		return "", err
+	}
+	<-gatherComplete
+
+	return ToSIP(*s.pc.LocalDescription(), s.options)
+}
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package sipgw
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/pion/webrtc/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSessionPair(t *testing.T) (*webrtc.PeerConnection, *webrtc.PeerConnection) {
+	t.Helper()
+
+	caller, callee := newSIPPeerConnection(t), newSIPPeerConnection(t)
+	for _, pc := range []*webrtc.PeerConnection{caller, callee} {
+		_, err := pc.AddTransceiverFromKind(webrtc.RTPCodecTypeAudio)
+		require.NoError(t, err)
+	}
+
+	return caller, callee
+}
+
+func TestSession(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	caller, callee := newSessionPair(t)
+	callerSession, calleeSession := NewSession(caller, Options{}), NewSession(callee, Options{})
+
+	callerConnected := make(chan struct{})
+	caller.OnICEConnectionStateChange(func(state webrtc.ICEConnectionState) {
+		if state == webrtc.ICEConnectionStateConnected {
+			close(callerConnected)
+		}
+	})
+
+	// INVITE with offer, 200 OK with answer.
+	offer, err := callerSession.CreateOffer()
+	require.NoError(t, err)
+
+	answer, err := calleeSession.HandleInvite(offer)
+	require.NoError(t, err)
+	require.NoError(t, callerSession.HandleAnswer(answer))
+	<-callerConnected
+
+	// A session refresh re-INVITE with the unchanged offer gets the same answer.
+	refreshAnswer, err := calleeSession.HandleInvite(offer)
+	require.NoError(t, err)
+	assert.Equal(t, answer, refreshAnswer)
+
+	// A re-INVITE with a new version of the offer renegotiates.
+	reOffer, err := callerSession.CreateOffer()
+	require.NoError(t, err)
+	assert.NotEqual(t, offer, reOffer)
+
+	reAnswer, err := calleeSession.HandleInvite(reOffer)
+	require.NoError(t, err)
+	require.NoError(t, callerSession.HandleAnswer(reAnswer))
+	assert.Equal(t, webrtc.SignalingStateStable, callee.SignalingState())
+
+	assert.NoError(t, caller.Close())
+	assert.NoError(t, callee.Close())
+}
+
+func TestSession_DelayedOffer(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	caller, callee := newSessionPair(t)
+	callerSession, calleeSession := NewSession(caller, Options{}), NewSession(callee, Options{})
+
+	calleeConnected := make(chan struct{})
+	callee.OnICEConnectionStateChange(func(state webrtc.ICEConnectionState) {
+		if state == webrtc.ICEConnectionStateConnected {
+			close(calleeConnected)
+		}
+	})
+
+	// INVITE without body, 200 OK with offer, ACK with answer.
+	offer, err := calleeSession.HandleInvite("")
+	require.NoError(t, err)
+
+	answer, err := callerSession.HandleOffer(offer)
+	require.NoError(t, err)
+	require.NoError(t, calleeSession.HandleAnswer(answer))
+	<-calleeConnected
+
+	_, err = calleeSession.HandleOffer("invalid")
+	assert.ErrorIs(t, err, webrtc.ErrSDPUnmarshalling)
+
+	assert.NoError(t, caller.Close())
+	assert.NoError(t, callee.Close())
+}
@@ -0,0 +1,334 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+// Package tunnel forwards IP packets between a network device and an unordered,
+// unreliable DataChannel, the building block of VPN-like tools that use
+// PeerConnections for NAT traversal.
+//
+// The device is an io.ReadWriter that reads and writes a single IP packet per
+// call, like the file of a TUN device opened without packet information, or an
+// adapter to a userspace network stack like gVisor's netstack. Both peers
+// announce their MTU when the DataChannel opens, packets larger than the
+// smaller of both MTUs are dropped. The device's MTU should be set to the
+// negotiated MTU, see OnMTU.
+package tunnel
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pion/webrtc/v4"
+)
+
+const (
+	// Protocol is the DataChannel protocol of tunnels.
+	Protocol = "ip-tunnel"
+
+	// DefaultMTU is the MTU announced when Config.MTU is zero, the minimum MTU
+	// of IPv6. It leaves room for the ICE, DTLS and SCTP overhead on 1500 byte
+	// links.
+	DefaultMTU = 1280
+
+	// MaxMTU is the largest MTU a tunnel can use.
+	MaxMTU = 16383
+)
+
+const (
+	messageTypeHello  = 1
+	messageTypePacket = 2
+
+	helloLength    = 4
+	helloFlagAcked = 0x01
+
+	// helloInterval is how often the hello is repeated until the remote peer
+	// acknowledged it, as the DataChannel doesn't retransmit.
+	helloInterval = 200 * time.Millisecond
+
+	// Packets are dropped instead of queued while more than maxBufferedAmount
+	// bytes wait to be sent, queuing only adds latency.
+	maxBufferedAmount = 256 * 1024
+
+	readBufferSize = 65536
+)
+
+var (
+	errReliableDataChannel = errors.New("tunnel: DataChannel has to be unordered and unreliable")
+	errInvalidMTU          = errors.New("tunnel: invalid MTU")
+)
+
+// Config configures a Tunnel.
+type Config struct {
+	// MTU is the largest IP packet the local device sends and accepts, zero
+	// selects DefaultMTU.
+	MTU int
+}
+
+// Stats are the packet counters of a Tunnel.
+type Stats struct {
+	// PacketsSent and PacketsReceived count the packets read from and written
+	// to the device.
+	PacketsSent     uint64
+	PacketsReceived uint64
+
+	// PacketsDropped counts the packets read from the device that couldn't be
+	// sent, because the MTU wasn't negotiated yet, they exceeded the MTU or too
+	// many packets were queued, and received packets the device rejected.
+	PacketsDropped uint64
+}
+
+// Tunnel forwards IP packets between a device and a DataChannel.
+type Tunnel struct {
+	dataChannel *webrtc.DataChannel
+	device      io.ReadWriter
+	localMTU    int
+
+	mu          sync.Mutex
+	remoteMTU   int
+	remoteAcked bool
+	started     bool
+	onMTU       func(mtu int)
+
+	packetsSent     atomic.Uint64
+	packetsReceived atomic.Uint64
+	packetsDropped  atomic.Uint64
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+// DataChannelInit returns the parameters of a DataChannel for a Tunnel.
+func DataChannelInit() *webrtc.DataChannelInit {
+	ordered := false
+	maxRetransmits := uint16(0)
+	protocol := Protocol
+
+	return &webrtc.DataChannelInit{Ordered: &ordered, MaxRetransmits: &maxRetransmits, Protocol: &protocol}
+}
+
+// New creates a Tunnel forwarding packets between the device and the
+// DataChannel, which has to be unordered and unreliable, like the ones created
+// with DataChannelInit. Forwarding starts once the DataChannel is open and the
+// MTU is negotiated. The Tunnel takes over the DataChannel's OnOpen, OnMessage
+// and OnClose handlers.
+func New(dataChannel *webrtc.DataChannel, device io.ReadWriter, config Config) (*Tunnel, error) {
+	if dataChannel.Ordered() || (dataChannel.MaxRetransmits() == nil && dataChannel.MaxPacketLifeTime() == nil) {
+		return nil, errReliableDataChannel
+	}
+
+	mtu := config.MTU
+	if mtu == 0 {
+		mtu = DefaultMTU
+	}
+	if mtu < 0 || mtu > MaxMTU {
+		return nil, errInvalidMTU
+	}
+
+	tunnel := &Tunnel{
+		dataChannel: dataChannel,
+		device:      device,
+		localMTU:    mtu,
+		closed:      make(chan struct{}),
+	}
+
+	dataChannel.OnMessage(tunnel.handleMessage)
+	dataChannel.OnClose(func() {
+		_ = tunnel.Close()
+	})
+	dataChannel.OnOpen(tunnel.start)
+
+	return tunnel, nil
+}
+
+// OnMTU sets an event handler which is invoked when the MTU is negotiated.
+func (t *Tunnel) OnMTU(f func(mtu int)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.onMTU = f
+}
+
+// MTU returns the negotiated MTU, or zero before the remote peer announced its
+// MTU.
+func (t *Tunnel) MTU() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.mtu()
+}
+
+func (t *Tunnel) mtu() int {
+	if t.remoteMTU == 0 {
+		return 0
+	}
+
+	mtu := min(t.localMTU, t.remoteMTU)
+	if sctp := t.dataChannel.Transport(); sctp != nil {
+		// One byte of every message is the message type.
+		if maxMessageSize := int(sctp.GetCapabilities().MaxMessageSize); maxMessageSize > 1 {
+			mtu = min(mtu, maxMessageSize-1)
+		}
+	}
+
+	return mtu
+}
+
+// Stats returns the packet counters.
+func (t *Tunnel) Stats() Stats {
+	return Stats{
+		PacketsSent:     t.packetsSent.Load(),
+		PacketsReceived: t.packetsReceived.Load(),
+		PacketsDropped:  t.packetsDropped.Load(),
+	}
+}
+
+// Close stops forwarding and closes the DataChannel. The device isn't closed,
+// a device read that is in progress returns once the caller closes it.
+func (t *Tunnel) Close() error {
+	var err error
+	t.closeOnce.Do(func() {
+		close(t.closed)
+		err = t.dataChannel.Close()
+	})
+
+	return err
+}
+
+func (t *Tunnel) isClosed() bool {
+	select {
+	case <-t.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+func (t *Tunnel) start() {
+	t.mu.Lock()
+	if t.started {
+		t.mu.Unlock()
+
+		return
+	}
+	t.started = true
+	t.mu.Unlock()
+
+	go t.helloLoop()
+	go t.readLoop()
+}
+
+// helloLoop announces the local MTU until the remote peer acknowledged it and
+// announced its own.
+func (t *Tunnel) helloLoop() {
+	ticker := time.NewTicker(helloInterval)
+	defer ticker.Stop()
+
+	for {
+		t.mu.Lock()
+		done := t.remoteAcked && t.remoteMTU != 0
+		acked := t.remoteMTU != 0
+		t.mu.Unlock()
+
+		if done {
+			return
+		}
+		t.sendHello(acked)
+
+		select {
+		case <-ticker.C:
+		case <-t.closed:
+			return
+		}
+	}
+}
+
+func (t *Tunnel) sendHello(acked bool) {
+	hello := make([]byte, helloLength)
+	hello[0] = messageTypeHello
+	if acked {
+		hello[1] = helloFlagAcked
+	}
+	binary.BigEndian.PutUint16(hello[2:], uint16(t.localMTU)) //nolint:gosec // G115, MaxMTU fits
+
+	_ = t.dataChannel.Send(hello)
+}
+
+// readLoop sends the packets read from the device.
+func (t *Tunnel) readLoop() {
+	buffer := make([]byte, readBufferSize+1)
+	buffer[0] = messageTypePacket
+
+	for {
+		n, err := t.device.Read(buffer[1:])
+		if err != nil || t.isClosed() {
+			_ = t.Close()
+
+			return
+		}
+
+		if mtu := t.MTU(); mtu == 0 || n > mtu || t.dataChannel.BufferedAmount() > maxBufferedAmount {
+			t.packetsDropped.Add(1)
+
+			continue
+		}
+
+		if err = t.dataChannel.Send(buffer[:n+1]); err != nil {
+			t.packetsDropped.Add(1)
+
+			continue
+		}
+		t.packetsSent.Add(1)
+	}
+}
+
+func (t *Tunnel) handleMessage(msg webrtc.DataChannelMessage) {
+	if len(msg.Data) == 0 || t.isClosed() {
+		return
+	}
+
+	switch msg.Data[0] {
+	case messageTypeHello:
+		t.handleHello(msg.Data)
+	case messageTypePacket:
+		if _, err := t.device.Write(msg.Data[1:]); err != nil {
+			t.packetsDropped.Add(1)
+
+			return
+		}
+		t.packetsReceived.Add(1)
+	}
+}
+
+func (t *Tunnel) handleHello(hello []byte) {
+	if len(hello) < helloLength {
+		return
+	}
+
+	remoteMTU := int(binary.BigEndian.Uint16(hello[2:]))
+	if remoteMTU == 0 {
+		return
+	}
+
+	t.mu.Lock()
+	previousMTU := t.mtu()
+	t.remoteMTU = remoteMTU
+	if hello[1]&helloFlagAcked != 0 {
+		t.remoteAcked = true
+	}
+	mtu, onMTU := t.mtu(), t.onMTU
+	t.mu.Unlock()
+
+	// The remote peer waits for our acknowledgement.
+	if hello[1]&helloFlagAcked == 0 {
+		t.sendHello(true)
+	}
+
+	if mtu != previousMTU && onMTU != nil {
+		onMTU(mtu)
+	}
+}
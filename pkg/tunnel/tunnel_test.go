@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package tunnel
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/pion/webrtc/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testDevice reads the packets written to outbound and passes the packets the
+// tunnel writes to inbound.
+type testDevice struct {
+	outbound chan []byte
+	inbound  chan []byte
+}
+
+func newTestDevice() *testDevice {
+	return &testDevice{outbound: make(chan []byte), inbound: make(chan []byte, 16)}
+}
+
+func (d *testDevice) Read(b []byte) (int, error) {
+	packet, ok := <-d.outbound
+	if !ok {
+		return 0, io.EOF
+	}
+
+	return copy(b, packet), nil
+}
+
+func (d *testDevice) Write(b []byte) (int, error) {
+	d.inbound <- append([]byte{}, b...)
+
+	return len(b), nil
+}
+
+func signal(t *testing.T, offerer, answerer *webrtc.PeerConnection) {
+	t.Helper()
+
+	offer, err := offerer.CreateOffer(nil)
+	require.NoError(t, err)
+	offerGathered := webrtc.GatheringCompletePromise(offerer)
+	require.NoError(t, offerer.SetLocalDescription(offer))
+	<-offerGathered
+	require.NoError(t, answerer.SetRemoteDescription(*offerer.LocalDescription()))
+
+	answer, err := answerer.CreateAnswer(nil)
+	require.NoError(t, err)
+	answerGathered := webrtc.GatheringCompletePromise(answerer)
+	require.NoError(t, answerer.SetLocalDescription(answer))
+	<-answerGathered
+	require.NoError(t, offerer.SetRemoteDescription(*answerer.LocalDescription()))
+}
+
+func TestTunnel(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	offerer, err := webrtc.NewPeerConnection(webrtc.Configuration{})
+	require.NoError(t, err)
+	answerer, err := webrtc.NewPeerConnection(webrtc.Configuration{})
+	require.NoError(t, err)
+
+	offerDevice, answerDevice := newTestDevice(), newTestDevice()
+	offerMTU, answerMTU := make(chan int, 1), make(chan int, 1)
+
+	dataChannel, err := offerer.CreateDataChannel("tunnel", DataChannelInit())
+	require.NoError(t, err)
+	offerTunnel, err := New(dataChannel, offerDevice, Config{MTU: 1400})
+	require.NoError(t, err)
+	offerTunnel.OnMTU(func(mtu int) { offerMTU <- mtu })
+
+	answerTunnels := make(chan *Tunnel, 1)
+	answerer.OnDataChannelProtocol(Protocol, func(dataChannel *webrtc.DataChannel) {
+		tunnel, tunnelErr := New(dataChannel, answerDevice, Config{MTU: 1300})
+		if assert.NoError(t, tunnelErr) {
+			tunnel.OnMTU(func(mtu int) { answerMTU <- mtu })
+			answerTunnels <- tunnel
+		}
+	})
+
+	signal(t, offerer, answerer)
+	answerTunnel := <-answerTunnels
+
+	assert.Equal(t, 1300, <-offerMTU)
+	assert.Equal(t, 1300, <-answerMTU)
+	assert.Equal(t, 1300, offerTunnel.MTU())
+
+	ipv4 := []byte{0x45, 0x00, 0x00, 0x14}
+	offerDevice.outbound <- ipv4
+	assert.Equal(t, ipv4, <-answerDevice.inbound)
+
+	ipv6 := []byte{0x60, 0x00, 0x00, 0x00}
+	answerDevice.outbound <- ipv6
+	assert.Equal(t, ipv6, <-offerDevice.inbound)
+
+	// Exceeds the MTU announced by the answerer.
+	offerDevice.outbound <- make([]byte, 1350)
+	offerDevice.outbound <- ipv4
+	assert.Equal(t, ipv4, <-answerDevice.inbound)
+
+	// The counters are updated after the packets are passed on.
+	assert.Eventually(t, func() bool {
+		return offerTunnel.Stats() == Stats{PacketsSent: 2, PacketsReceived: 1, PacketsDropped: 1} &&
+			answerTunnel.Stats() == Stats{PacketsSent: 1, PacketsReceived: 2}
+	}, time.Second, 10*time.Millisecond)
+
+	close(offerDevice.outbound)
+	close(answerDevice.outbound)
+	assert.NoError(t, offerer.Close())
+	assert.NoError(t, answerer.Close())
+}
+
+func TestNew_ReliableDataChannel(t *testing.T) {
+	pc, err := webrtc.NewPeerConnection(webrtc.Configuration{})
+	require.NoError(t, err)
+
+	dataChannel, err := pc.CreateDataChannel("reliable", nil)
+	require.NoError(t, err)
+
+	_, err = New(dataChannel, newTestDevice(), Config{})
+	assert.ErrorIs(t, err, errReliableDataChannel)
+
+	unreliable, err := pc.CreateDataChannel("unreliable", DataChannelInit())
+	require.NoError(t, err)
+
+	_, err = New(unreliable, newTestDevice(), Config{MTU: MaxMTU + 1})
+	assert.ErrorIs(t, err, errInvalidMTU)
+
+	assert.NoError(t, pc.Close())
+}
@@ -0,0 +1,248 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"errors"
+	"time"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/interceptor/pkg/nack"
+	"github.com/pion/interceptor/pkg/report"
+)
+
+// Preset is a coherent set of MediaEngine, InterceptorRegistry and SettingEngine
+// configuration for a common use-case. Presets are a starting point, every value
+// they set can still be overridden by calling the usual setters afterwards.
+//
+// Presets don't tune a jitter buffer, as there is none in the receive path of
+// this package. Applications reorder and time the playout of the packets they
+// read themselves, for example with pkg/media/samplebuilder.
+type Preset int
+
+const (
+	// PresetUnknown is the enum's zero-value.
+	PresetUnknown Preset = iota
+
+	// PresetAudioOnlyLowBandwidth registers Opus with DTX and in-band FEC only,
+	// skips video specific interceptors and lowers the RTCP report frequency.
+	PresetAudioOnlyLowBandwidth
+
+	// PresetDataChannelBulk registers no media and tunes SCTP for throughput
+	// with larger receive buffers and congestion windows.
+	PresetDataChannelBulk
+
+	// PresetLowLatencyVideo registers the default codecs and tunes NACK, RTCP
+	// reports and ICE timers for faster loss recovery and failure detection.
+	PresetLowLatencyVideo
+
+	// PresetCPUConstrained registers a single codec per kind (Opus and VP8)
+	// and only the interceptors that are needed to keep a session healthy.
+	PresetCPUConstrained
+)
+
+// This is done this way because of a linter.
+const (
+	presetAudioOnlyLowBandwidthStr = "audio-only-low-bandwidth"
+	presetDataChannelBulkStr       = "datachannel-bulk"
+	presetLowLatencyVideoStr       = "low-latency-video"
+	presetCPUConstrainedStr        = "cpu-constrained"
+)
+
+const (
+	presetLowBandwidthOpusFmtp   = "minptime=10;useinbandfec=1;usedtx=1;maxaveragebitrate=24000"
+	presetLowBandwidthReportRate = 5 * time.Second
+
+	presetBulkSCTPReceiveBuffer = 8 * 1024 * 1024
+	presetBulkSCTPMinCwnd       = 128 * 1024
+	presetBulkSCTPFastRtxWnd    = 256 * 1024
+	presetBulkSCTPCwndCAStep    = 16 * 1024
+
+	presetLowLatencyNackInterval   = 20 * time.Millisecond
+	presetLowLatencyNackSize       = 1024
+	presetLowLatencyReportRate     = 500 * time.Millisecond
+	presetLowLatencyDisconnected   = 2 * time.Second
+	presetLowLatencyFailed         = 8 * time.Second
+	presetLowLatencyKeepalive      = 1 * time.Second
+	presetLowLatencySCTPRTOMax     = 1 * time.Second
+	presetCPUConstrainedNackSize   = 256
+	presetCPUConstrainedReportRate = 5 * time.Second
+)
+
+var (
+	errPresetUnknown                 = errors.New("unknown preset")
+	errPresetInterceptorsMediaEngine = errors.New("preset interceptors can't be registered without a MediaEngine")
+)
+
+func (p Preset) String() string {
+	switch p {
+	case PresetAudioOnlyLowBandwidth:
+		return presetAudioOnlyLowBandwidthStr
+	case PresetDataChannelBulk:
+		return presetDataChannelBulkStr
+	case PresetLowLatencyVideo:
+		return presetLowLatencyVideoStr
+	case PresetCPUConstrained:
+		return presetCPUConstrainedStr
+	default:
+		return ErrUnknownType.Error()
+	}
+}
+
+// Apply configures the provided engines for the Preset. The engines that are nil
+// are skipped, except that the InterceptorRegistry of some Presets needs the
+// MediaEngine, see below. The MediaEngine and InterceptorRegistry are expected
+// to be empty, Apply registers everything the Preset needs and does not remove
+// previously registered codecs or interceptors.
+//
+// PresetLowLatencyVideo and PresetCPUConstrained register NACK interceptors,
+// which need the MediaEngine to register their RTCP feedback. Apply returns an
+// error without changing anything if their InterceptorRegistry is given with a
+// nil MediaEngine.
+func (p Preset) Apply(
+	mediaEngine *MediaEngine, interceptorRegistry *interceptor.Registry, settingEngine *SettingEngine,
+) error {
+	switch p {
+	case PresetAudioOnlyLowBandwidth:
+		return applyPresetAudioOnlyLowBandwidth(mediaEngine, interceptorRegistry)
+	case PresetDataChannelBulk:
+		applyPresetDataChannelBulk(settingEngine)
+
+		return nil
+	case PresetLowLatencyVideo:
+		return applyPresetLowLatencyVideo(mediaEngine, interceptorRegistry, settingEngine)
+	case PresetCPUConstrained:
+		return applyPresetCPUConstrained(mediaEngine, interceptorRegistry, settingEngine)
+	default:
+		return errPresetUnknown
+	}
+}
+
+func applyPresetAudioOnlyLowBandwidth(mediaEngine *MediaEngine, interceptorRegistry *interceptor.Registry) error {
+	if mediaEngine != nil {
+		if err := mediaEngine.RegisterCodec(RTPCodecParameters{
+			RTPCodecCapability: RTPCodecCapability{MimeTypeOpus, 48000, 2, presetLowBandwidthOpusFmtp, nil},
+			PayloadType:        111,
+		}, RTPCodecTypeAudio); err != nil {
+			return err
+		}
+	}
+
+	if interceptorRegistry == nil {
+		return nil
+	}
+
+	if err := ConfigureRTCPReportsWithOptions(interceptorRegistry,
+		[]report.ReceiverOption{report.ReceiverInterval(presetLowBandwidthReportRate)},
+		report.SenderInterval(presetLowBandwidthReportRate),
+	); err != nil {
+		return err
+	}
+
+	return ConfigureStatsInterceptor(interceptorRegistry)
+}
+
+func applyPresetDataChannelBulk(settingEngine *SettingEngine) {
+	if settingEngine == nil {
+		return
+	}
+
+	settingEngine.SetSCTPMaxReceiveBufferSize(presetBulkSCTPReceiveBuffer)
+	settingEngine.SetSCTPMinCwnd(presetBulkSCTPMinCwnd)
+	settingEngine.SetSCTPFastRtxWnd(presetBulkSCTPFastRtxWnd)
+	settingEngine.SetSCTPCwndCAStep(presetBulkSCTPCwndCAStep)
+}
+
+func applyPresetLowLatencyVideo(
+	mediaEngine *MediaEngine, interceptorRegistry *interceptor.Registry, settingEngine *SettingEngine,
+) error {
+	if mediaEngine == nil && interceptorRegistry != nil {
+		return errPresetInterceptorsMediaEngine
+	}
+
+	if settingEngine != nil {
+		settingEngine.SetICETimeouts(presetLowLatencyDisconnected, presetLowLatencyFailed, presetLowLatencyKeepalive)
+		settingEngine.SetSCTPRTOMax(presetLowLatencySCTPRTOMax)
+	}
+
+	if mediaEngine == nil {
+		return nil
+	}
+
+	if err := mediaEngine.RegisterDefaultCodecs(); err != nil {
+		return err
+	}
+
+	if interceptorRegistry == nil {
+		return nil
+	}
+
+	return RegisterDefaultInterceptorsWithOptions(mediaEngine, interceptorRegistry,
+		WithNackGeneratorOptions(
+			nack.GeneratorInterval(presetLowLatencyNackInterval),
+			nack.GeneratorSize(presetLowLatencyNackSize),
+		),
+		WithNackResponderOptions(nack.ResponderSize(presetLowLatencyNackSize)),
+		WithReportReceiverOptions(report.ReceiverInterval(presetLowLatencyReportRate)),
+		WithReportSenderOptions(report.SenderInterval(presetLowLatencyReportRate)),
+	)
+}
+
+func applyPresetCPUConstrained(
+	mediaEngine *MediaEngine, interceptorRegistry *interceptor.Registry, settingEngine *SettingEngine,
+) error {
+	if mediaEngine == nil && interceptorRegistry != nil {
+		return errPresetInterceptorsMediaEngine
+	}
+
+	if settingEngine != nil {
+		settingEngine.DisableMediaEngineMultipleCodecs(true)
+	}
+
+	if mediaEngine == nil {
+		return nil
+	}
+
+	if err := mediaEngine.RegisterCodec(RTPCodecParameters{
+		RTPCodecCapability: RTPCodecCapability{MimeTypeOpus, 48000, 2, "minptime=10;useinbandfec=1", nil},
+		PayloadType:        111,
+	}, RTPCodecTypeAudio); err != nil {
+		return err
+	}
+
+	if err := mediaEngine.RegisterCodec(RTPCodecParameters{
+		RTPCodecCapability: RTPCodecCapability{
+			MimeTypeVP8, 90000, 0, "",
+			[]RTCPFeedback{{"nack", ""}, {"nack", "pli"}},
+		},
+		PayloadType: 96,
+	}, RTPCodecTypeVideo); err != nil {
+		return err
+	}
+
+	if err := mediaEngine.RegisterCodec(RTPCodecParameters{
+		RTPCodecCapability: RTPCodecCapability{MimeTypeRTX, 90000, 0, "apt=96", nil},
+		PayloadType:        97,
+	}, RTPCodecTypeVideo); err != nil {
+		return err
+	}
+
+	if interceptorRegistry == nil {
+		return nil
+	}
+
+	if err := ConfigureNackWithOptions(mediaEngine, interceptorRegistry,
+		[]nack.GeneratorOption{nack.GeneratorSize(presetCPUConstrainedNackSize)},
+		nack.ResponderSize(presetCPUConstrainedNackSize),
+	); err != nil {
+		return err
+	}
+
+	return ConfigureRTCPReportsWithOptions(interceptorRegistry,
+		[]report.ReceiverOption{report.ReceiverInterval(presetCPUConstrainedReportRate)},
+		report.SenderInterval(presetCPUConstrainedReportRate),
+	)
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"testing"
+
+	"github.com/pion/interceptor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreset_String(t *testing.T) {
+	testCases := []struct {
+		preset         Preset
+		expectedString string
+	}{
+		{PresetUnknown, ErrUnknownType.Error()},
+		{PresetAudioOnlyLowBandwidth, "audio-only-low-bandwidth"},
+		{PresetDataChannelBulk, "datachannel-bulk"},
+		{PresetLowLatencyVideo, "low-latency-video"},
+		{PresetCPUConstrained, "cpu-constrained"},
+	}
+
+	for i, testCase := range testCases {
+		assert.Equal(t, testCase.expectedString, testCase.preset.String(), "testCase: %d %v", i, testCase)
+	}
+}
+
+func TestPreset_Apply(t *testing.T) {
+	t.Run("Unknown", func(t *testing.T) {
+		assert.ErrorIs(t, PresetUnknown.Apply(nil, nil, nil), errPresetUnknown)
+	})
+
+	t.Run("AudioOnlyLowBandwidth", func(t *testing.T) {
+		mediaEngine := &MediaEngine{}
+		assert.NoError(t, PresetAudioOnlyLowBandwidth.Apply(mediaEngine, &interceptor.Registry{}, &SettingEngine{}))
+
+		assert.Len(t, mediaEngine.audioCodecs, 1)
+		assert.Equal(t, presetLowBandwidthOpusFmtp, mediaEngine.audioCodecs[0].SDPFmtpLine)
+		assert.Empty(t, mediaEngine.videoCodecs)
+	})
+
+	t.Run("DataChannelBulk", func(t *testing.T) {
+		mediaEngine := &MediaEngine{}
+		settingEngine := &SettingEngine{}
+		assert.NoError(t, PresetDataChannelBulk.Apply(mediaEngine, &interceptor.Registry{}, settingEngine))
+
+		assert.Empty(t, mediaEngine.audioCodecs)
+		assert.Empty(t, mediaEngine.videoCodecs)
+		assert.Equal(t, uint32(presetBulkSCTPReceiveBuffer), settingEngine.sctp.maxReceiveBufferSize)
+		assert.Equal(t, uint32(presetBulkSCTPMinCwnd), settingEngine.sctp.minCwnd)
+	})
+
+	t.Run("LowLatencyVideo", func(t *testing.T) {
+		mediaEngine := &MediaEngine{}
+		settingEngine := &SettingEngine{}
+		assert.NoError(t, PresetLowLatencyVideo.Apply(mediaEngine, &interceptor.Registry{}, settingEngine))
+
+		assert.NotEmpty(t, mediaEngine.videoCodecs)
+		assert.Equal(t, presetLowLatencyDisconnected, *settingEngine.timeout.ICEDisconnectedTimeout)
+		assert.Equal(t, presetLowLatencySCTPRTOMax, settingEngine.sctp.rtoMax)
+	})
+
+	t.Run("CPUConstrained", func(t *testing.T) {
+		mediaEngine := &MediaEngine{}
+		settingEngine := &SettingEngine{}
+		assert.NoError(t, PresetCPUConstrained.Apply(mediaEngine, &interceptor.Registry{}, settingEngine))
+
+		assert.Len(t, mediaEngine.audioCodecs, 1)
+		assert.Len(t, mediaEngine.videoCodecs, 2)
+		assert.True(t, settingEngine.disableMediaEngineMultipleCodecs)
+	})
+
+	t.Run("NilArguments", func(t *testing.T) {
+		for _, preset := range []Preset{
+			PresetAudioOnlyLowBandwidth, PresetDataChannelBulk, PresetLowLatencyVideo, PresetCPUConstrained,
+		} {
+			assert.NoError(t, preset.Apply(nil, nil, nil), preset.String())
+		}
+	})
+
+	t.Run("InterceptorsWithoutMediaEngine", func(t *testing.T) {
+		for _, preset := range []Preset{PresetLowLatencyVideo, PresetCPUConstrained} {
+			settingEngine := &SettingEngine{}
+			err := preset.Apply(nil, &interceptor.Registry{}, settingEngine)
+			assert.ErrorIs(t, err, errPresetInterceptorsMediaEngine, preset.String())
+			assert.Equal(t, SettingEngine{}, *settingEngine, "%s changed the SettingEngine", preset)
+		}
+
+		assert.NoError(t, PresetAudioOnlyLowBandwidth.Apply(nil, &interceptor.Registry{}, nil))
+	})
+}
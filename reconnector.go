@@ -0,0 +1,265 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	defaultReconnectDisconnectedTimeout = 2 * time.Second
+	defaultReconnectInitialBackoff      = time.Second
+	defaultReconnectMaxBackoff          = 30 * time.Second
+	defaultReconnectMaxAttempts         = 5
+)
+
+// ReconnectOptions configures a Reconnector.
+type ReconnectOptions struct {
+	// Signal sends an ICE restart offer to the remote peer. Its answer has to be
+	// applied with SetRemoteDescription, candidates are trickled with
+	// OnICECandidate as usual. Signal is required.
+	Signal func(offer SessionDescription) error
+
+	// DisconnectedTimeout is how long the PeerConnection may stay Disconnected
+	// before it is restarted, two seconds when zero. Failed PeerConnections are
+	// restarted immediately.
+	DisconnectedTimeout time.Duration
+
+	// InitialBackoff is how long the first ICE restart waits for the
+	// PeerConnection to connect before the next one, one second when zero. It
+	// doubles with every attempt, up to MaxBackoff, thirty seconds when zero.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	// MaxAttempts is the number of ICE restarts before the Reconnector gives up,
+	// five when zero.
+	MaxAttempts int
+}
+
+// Reconnector restarts ICE when a PeerConnection is Failed or stays
+// Disconnected, retrying with exponential backoff until it is connected again
+// or the attempts are exhausted.
+//
+// Only one of the peers should use a Reconnector, usually the one that created
+// the initial offer, so their ICE restart offers don't collide. Attempts are
+// skipped while an offer of the remote peer is being negotiated.
+type Reconnector struct {
+	pc      *PeerConnection
+	options ReconnectOptions
+
+	mu                sync.Mutex
+	closed            bool
+	reconnecting      bool
+	disconnectedTimer *time.Timer
+	stop              chan struct{}
+	connected         chan struct{}
+	onAttempt         func(attempt int)
+	onReconnect       func()
+	onGiveUp          func(err error)
+}
+
+// NewReconnector returns a Reconnector which watches the connection state of the
+// PeerConnection until it is closed. A PeerConnection has at most one
+// Reconnector, NewReconnector returns an error while another one isn't closed.
+// Handlers set with OnConnectionStateChange are invoked as usual.
+func NewReconnector(pc *PeerConnection, options ReconnectOptions) (*Reconnector, error) {
+	if options.Signal == nil {
+		return nil, errReconnectorNoSignal
+	}
+	if options.DisconnectedTimeout <= 0 {
+		options.DisconnectedTimeout = defaultReconnectDisconnectedTimeout
+	}
+	if options.InitialBackoff <= 0 {
+		options.InitialBackoff = defaultReconnectInitialBackoff
+	}
+	if options.MaxBackoff <= 0 {
+		options.MaxBackoff = defaultReconnectMaxBackoff
+	}
+	if options.MaxAttempts <= 0 {
+		options.MaxAttempts = defaultReconnectMaxAttempts
+	}
+
+	reconnector := &Reconnector{
+		pc:        pc,
+		options:   options,
+		stop:      make(chan struct{}),
+		connected: make(chan struct{}, 1),
+	}
+	if !pc.reconnector.CompareAndSwap(nil, reconnector) {
+		return nil, errReconnectorInstalled
+	}
+
+	return reconnector, nil
+}
+
+// OnAttempt sets an event handler which is invoked before every ICE restart,
+// starting with attempt 1.
+func (r *Reconnector) OnAttempt(f func(attempt int)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.onAttempt = f
+}
+
+// OnReconnect sets an event handler which is invoked once the PeerConnection
+// is connected again after an ICE restart.
+func (r *Reconnector) OnReconnect(f func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.onReconnect = f
+}
+
+// OnGiveUp sets an event handler which is invoked with ErrReconnectFailed when
+// the PeerConnection didn't connect within MaxAttempts ICE restarts. The
+// Reconnector stops and the PeerConnection is left to the caller.
+func (r *Reconnector) OnGiveUp(f func(err error)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.onGiveUp = f
+}
+
+// Close stops the Reconnector. The PeerConnection isn't closed.
+func (r *Reconnector) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	close(r.stop)
+	if r.disconnectedTimer != nil {
+		r.disconnectedTimer.Stop()
+	}
+	r.pc.reconnector.CompareAndSwap(r, nil)
+
+	return nil
+}
+
+func (r *Reconnector) handleConnectionState(state PeerConnectionState) {
+	switch state {
+	case PeerConnectionStateConnected:
+		r.mu.Lock()
+		if r.disconnectedTimer != nil {
+			r.disconnectedTimer.Stop()
+			r.disconnectedTimer = nil
+		}
+		r.mu.Unlock()
+
+		select {
+		case r.connected <- struct{}{}:
+		default:
+		}
+	case PeerConnectionStateDisconnected:
+		r.mu.Lock()
+		if !r.closed && r.disconnectedTimer == nil {
+			r.disconnectedTimer = time.AfterFunc(r.options.DisconnectedTimeout, func() {
+				if r.pc.ConnectionState() == PeerConnectionStateDisconnected {
+					r.reconnect()
+				}
+			})
+		}
+		r.mu.Unlock()
+	case PeerConnectionStateFailed:
+		r.reconnect()
+	case PeerConnectionStateClosed:
+		_ = r.Close()
+	default:
+	}
+}
+
+// reconnect starts restarting ICE, unless it is already in progress.
+func (r *Reconnector) reconnect() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed || r.reconnecting {
+		return
+	}
+	r.reconnecting = true
+
+	select {
+	case <-r.connected:
+	default:
+	}
+
+	go r.run()
+}
+
+func (r *Reconnector) run() {
+	defer func() {
+		r.mu.Lock()
+		r.reconnecting = false
+		r.disconnectedTimer = nil
+		r.mu.Unlock()
+	}()
+
+	backoff := r.options.InitialBackoff
+	for attempt := 1; attempt <= r.options.MaxAttempts; attempt++ {
+		r.mu.Lock()
+		onAttempt := r.onAttempt
+		r.mu.Unlock()
+		if onAttempt != nil {
+			onAttempt(attempt)
+		}
+
+		if err := r.restartICE(); err != nil {
+			r.pc.log.Warnf("ICE restart attempt %d failed: %s", attempt, err)
+		}
+
+		select {
+		case <-r.stop:
+			return
+		case <-r.connected:
+			r.mu.Lock()
+			onReconnect := r.onReconnect
+			r.mu.Unlock()
+			if onReconnect != nil {
+				onReconnect()
+			}
+
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff = min(2*backoff, r.options.MaxBackoff)
+	}
+
+	r.mu.Lock()
+	onGiveUp := r.onGiveUp
+	r.mu.Unlock()
+	if onGiveUp != nil {
+		onGiveUp(ErrReconnectFailed)
+	}
+}
+
+func (r *Reconnector) restartICE() error {
+	// An unanswered offer, like the one of a previous attempt, is sent again, while
+	// an offer of the remote peer is answered first.
+	switch r.pc.SignalingState() {
+	case SignalingStateStable:
+	case SignalingStateHaveLocalOffer:
+		if offer := r.pc.PendingLocalDescription(); offer != nil {
+			return r.options.Signal(*offer)
+		}
+	default:
+		return errReconnectorNegotiating
+	}
+
+	offer, err := r.pc.CreateOffer(&OfferOptions{ICERestart: true})
+	if err != nil {
+		return err
+	}
+
+	if err = r.pc.SetLocalDescription(offer); err != nil {
+		return err
+	}
+
+	return r.options.Signal(offer)
+}
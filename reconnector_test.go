@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconnector(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	pcOffer, pcAnswer, err := newPair()
+	require.NoError(t, err)
+
+	connected := untilConnectionState(PeerConnectionStateConnected, pcOffer, pcAnswer)
+	require.NoError(t, signalPair(pcOffer, pcAnswer))
+	connected.Wait()
+
+	reconnector, err := NewReconnector(pcOffer, ReconnectOptions{
+		Signal: func(offer SessionDescription) error {
+			go func() {
+				assert.NoError(t, pcAnswer.SetRemoteDescription(offer))
+				answer, answerErr := pcAnswer.CreateAnswer(nil)
+				assert.NoError(t, answerErr)
+
+				gatherComplete := GatheringCompletePromise(pcAnswer)
+				assert.NoError(t, pcAnswer.SetLocalDescription(answer))
+				<-gatherComplete
+				assert.NoError(t, pcOffer.SetRemoteDescription(*pcAnswer.LocalDescription()))
+			}()
+
+			return nil
+		},
+	})
+	require.NoError(t, err)
+
+	attempts := make(chan int, 5)
+	reconnector.OnAttempt(func(attempt int) {
+		attempts <- attempt
+	})
+	reconnected := make(chan struct{})
+	reconnector.OnReconnect(func() {
+		close(reconnected)
+	})
+
+	reconnector.handleConnectionState(PeerConnectionStateFailed)
+	assert.Equal(t, 1, <-attempts)
+	<-reconnected
+
+	assert.Equal(t, PeerConnectionStateConnected, pcOffer.ConnectionState())
+	assert.NoError(t, reconnector.Close())
+	closePairNow(t, pcOffer, pcAnswer)
+}
+
+func TestReconnector_GiveUp(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	pcOffer, pcAnswer, err := newPair()
+	require.NoError(t, err)
+
+	connected := untilConnectionState(PeerConnectionStateConnected, pcOffer, pcAnswer)
+	require.NoError(t, signalPair(pcOffer, pcAnswer))
+	connected.Wait()
+
+	_, err = NewReconnector(pcOffer, ReconnectOptions{})
+	assert.ErrorIs(t, err, errReconnectorNoSignal)
+
+	errSignal := errors.New("signaling is down")
+	var signaled atomic.Int32
+	reconnector, err := NewReconnector(pcOffer, ReconnectOptions{
+		Signal: func(SessionDescription) error {
+			signaled.Add(1)
+
+			return errSignal
+		},
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+		MaxAttempts:    3,
+	})
+	require.NoError(t, err)
+
+	_, err = NewReconnector(pcOffer, ReconnectOptions{Signal: func(SessionDescription) error { return nil }})
+	assert.ErrorIs(t, err, errReconnectorInstalled)
+
+	gaveUp := make(chan error, 1)
+	reconnector.OnGiveUp(func(err error) {
+		gaveUp <- err
+	})
+
+	reconnector.handleConnectionState(PeerConnectionStateFailed)
+	assert.ErrorIs(t, <-gaveUp, ErrReconnectFailed)
+	assert.Equal(t, int32(3), signaled.Load())
+	assert.Equal(t, SignalingStateHaveLocalOffer, pcOffer.SignalingState())
+
+	assert.NoError(t, reconnector.Close())
+	reconnector, err = NewReconnector(pcOffer, ReconnectOptions{Signal: func(SessionDescription) error { return nil }})
+	assert.NoError(t, err)
+	assert.NoError(t, reconnector.Close())
+	closePairNow(t, pcOffer, pcAnswer)
+}
@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"time"
+)
+
+const defaultRelayUsageInterval = time.Second
+
+// RelayUsageBudget configures the relay traffic thresholds that trigger a
+// RelayUsageAlarm. Relay traffic is the traffic of candidate pairs where at least
+// one candidate is a relay candidate, see TransportStats.Relay.
+type RelayUsageBudget struct {
+	// Bytes is the total amount of bytes sent and received over relayed candidate
+	// pairs. A RelayUsageAlarmTypeBytes alarm is emitted once when it is exceeded.
+	// Zero disables the byte budget.
+	Bytes uint64
+
+	// Bitrate is the relayed bitrate, in bits per second, measured over Interval.
+	// A RelayUsageAlarmTypeBitrate alarm is emitted every time it is crossed,
+	// upwards and downwards. Zero disables the bitrate budget.
+	Bitrate uint64
+
+	// Interval is how often relay usage is sampled. Defaults to one second.
+	Interval time.Duration
+}
+
+// RelayUsageAlarmType indicates which part of a RelayUsageBudget was crossed.
+type RelayUsageAlarmType int
+
+const (
+	// RelayUsageAlarmTypeUnknown is the enum's zero-value.
+	RelayUsageAlarmTypeUnknown RelayUsageAlarmType = iota
+
+	// RelayUsageAlarmTypeBytes indicates RelayUsageBudget.Bytes was exceeded.
+	RelayUsageAlarmTypeBytes
+
+	// RelayUsageAlarmTypeBitrate indicates RelayUsageBudget.Bitrate was crossed.
+	RelayUsageAlarmTypeBitrate
+)
+
+func (t RelayUsageAlarmType) String() string {
+	switch t {
+	case RelayUsageAlarmTypeBytes:
+		return "bytes"
+	case RelayUsageAlarmTypeBitrate:
+		return "bitrate"
+	default:
+		return ErrUnknownType.Error()
+	}
+}
+
+// RelayUsageAlarm is emitted when relay traffic crosses a RelayUsageBudget threshold.
+type RelayUsageAlarm struct {
+	Type RelayUsageAlarmType
+
+	// Exceeded is true when the usage went above the budget and false when the
+	// bitrate fell back below it.
+	Exceeded bool
+
+	// Bytes is the total amount of relayed bytes at the time of the alarm.
+	Bytes uint64
+
+	// Bitrate is the relayed bitrate, in bits per second, of the last interval.
+	Bitrate uint64
+}
+
+// relayUsageMonitor turns samples of relayed bytes into RelayUsageAlarms.
+type relayUsageMonitor struct {
+	budget RelayUsageBudget
+
+	lastBytes       uint64
+	bytesExceeded   bool
+	bitrateExceeded bool
+}
+
+func newRelayUsageMonitor(budget RelayUsageBudget) *relayUsageMonitor {
+	if budget.Interval <= 0 {
+		budget.Interval = defaultRelayUsageInterval
+	}
+
+	return &relayUsageMonitor{budget: budget}
+}
+
+func (m *relayUsageMonitor) sample(totalBytes uint64, elapsed time.Duration) []RelayUsageAlarm {
+	var bitrate uint64
+	if elapsed > 0 && totalBytes >= m.lastBytes {
+		bitrate = uint64(float64((totalBytes-m.lastBytes)*8) / elapsed.Seconds())
+	}
+	m.lastBytes = totalBytes
+
+	var alarms []RelayUsageAlarm
+	if m.budget.Bytes != 0 && !m.bytesExceeded && totalBytes > m.budget.Bytes {
+		m.bytesExceeded = true
+		alarms = append(alarms, RelayUsageAlarm{
+			Type: RelayUsageAlarmTypeBytes, Exceeded: true, Bytes: totalBytes, Bitrate: bitrate,
+		})
+	}
+
+	if m.budget.Bitrate != 0 && (bitrate > m.budget.Bitrate) != m.bitrateExceeded {
+		m.bitrateExceeded = !m.bitrateExceeded
+		alarms = append(alarms, RelayUsageAlarm{
+			Type: RelayUsageAlarmTypeBitrate, Exceeded: m.bitrateExceeded, Bytes: totalBytes, Bitrate: bitrate,
+		})
+	}
+
+	return alarms
+}
+
+// OnRelayUsageAlarm sets an event handler which is invoked when the traffic relayed
+// through TURN servers for this PeerConnection crosses the provided budget. This
+// allows applications paying for TURN egress to take cost-control actions like
+// lowering the quality of the media they send.
+// Calling OnRelayUsageAlarm again replaces the previous budget and handler, a nil
+// handler stops monitoring. Monitoring stops when the PeerConnection is closed.
+func (pc *PeerConnection) OnRelayUsageAlarm(budget RelayUsageBudget, f func(RelayUsageAlarm)) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	if pc.relayUsageMonitorStop != nil {
+		close(pc.relayUsageMonitorStop)
+		pc.relayUsageMonitorStop = nil
+	}
+
+	if f == nil || pc.isClosed.Load() {
+		return
+	}
+
+	stop := make(chan struct{})
+	pc.relayUsageMonitorStop = stop
+	go pc.runRelayUsageMonitor(newRelayUsageMonitor(budget), stop, f)
+}
+
+func (pc *PeerConnection) runRelayUsageMonitor(
+	monitor *relayUsageMonitor, stop chan struct{}, f func(RelayUsageAlarm),
+) {
+	ticker := time.NewTicker(monitor.budget.Interval)
+	defer ticker.Stop()
+
+	last := time.Now()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-pc.isCloseDone:
+			return
+		case now := <-ticker.C:
+			relay := pc.iceTransport.Stats().Relay
+			for _, alarm := range monitor.sample(relay.BytesSent+relay.BytesReceived, now.Sub(last)) {
+				f(alarm)
+			}
+			last = now
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelayUsageAlarmType_String(t *testing.T) {
+	assert.Equal(t, ErrUnknownType.Error(), RelayUsageAlarmTypeUnknown.String())
+	assert.Equal(t, "bytes", RelayUsageAlarmTypeBytes.String())
+	assert.Equal(t, "bitrate", RelayUsageAlarmTypeBitrate.String())
+}
+
+func TestRelayUsageMonitor(t *testing.T) {
+	t.Run("Bytes", func(t *testing.T) {
+		monitor := newRelayUsageMonitor(RelayUsageBudget{Bytes: 1000})
+		assert.Equal(t, defaultRelayUsageInterval, monitor.budget.Interval)
+
+		assert.Empty(t, monitor.sample(500, time.Second))
+		assert.Equal(t, []RelayUsageAlarm{
+			{Type: RelayUsageAlarmTypeBytes, Exceeded: true, Bytes: 1500, Bitrate: 8000},
+		}, monitor.sample(1500, time.Second))
+
+		// The byte budget only fires once
+		assert.Empty(t, monitor.sample(3000, time.Second))
+	})
+
+	t.Run("Bitrate", func(t *testing.T) {
+		monitor := newRelayUsageMonitor(RelayUsageBudget{Bitrate: 8000})
+
+		assert.Empty(t, monitor.sample(1000, time.Second))
+		assert.Equal(t, []RelayUsageAlarm{
+			{Type: RelayUsageAlarmTypeBitrate, Exceeded: true, Bytes: 3000, Bitrate: 16000},
+		}, monitor.sample(3000, time.Second))
+		assert.Empty(t, monitor.sample(5000, time.Second))
+		assert.Equal(t, []RelayUsageAlarm{
+			{Type: RelayUsageAlarmTypeBitrate, Exceeded: false, Bytes: 5100, Bitrate: 800},
+		}, monitor.sample(5100, time.Second))
+	})
+}
+
+func TestPeerConnection_OnRelayUsageAlarm(t *testing.T) {
+	lim := test.TimeOut(time.Second * 10)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	pc, err := NewPeerConnection(Configuration{})
+	assert.NoError(t, err)
+
+	pc.OnRelayUsageAlarm(RelayUsageBudget{Bytes: 1, Interval: time.Millisecond}, func(RelayUsageAlarm) {
+		assert.Fail(t, "no relay traffic expected")
+	})
+	time.Sleep(10 * time.Millisecond)
+
+	// Replacing and clearing the handler stops the previous monitor
+	pc.OnRelayUsageAlarm(RelayUsageBudget{}, nil)
+	assert.Nil(t, pc.relayUsageMonitorStop)
+
+	pc.OnRelayUsageAlarm(RelayUsageBudget{Interval: time.Millisecond}, func(RelayUsageAlarm) {})
+	assert.NoError(t, pc.Close())
+}
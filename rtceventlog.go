@@ -0,0 +1,402 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pion/interceptor"
+	"github.com/pion/rtcp"
+	"github.com/pion/rtp"
+)
+
+// The messages and enums of the legacy rtc_event_log.proto of libwebrtc, which
+// is still read by its tooling like event_log_visualizer and rtc_event_log_to_text.
+const (
+	rtcEventLogEventStreamField = 1
+
+	rtcEventLogEventTimestampField               = 1
+	rtcEventLogEventTypeField                    = 2
+	rtcEventLogEventRTPPacketField               = 3
+	rtcEventLogEventRTCPPacketField              = 4
+	rtcEventLogEventVideoReceiverConfigField     = 8
+	rtcEventLogEventVideoSenderConfigField       = 9
+	rtcEventLogEventAudioReceiverConfigField     = 10
+	rtcEventLogEventAudioSenderConfigField       = 11
+	rtcEventLogEventICECandidatePairConfigField  = 20
+	rtcEventLogEventTypeLogStart                 = 1
+	rtcEventLogEventTypeLogEnd                   = 2
+	rtcEventLogEventTypeRTP                      = 3
+	rtcEventLogEventTypeRTCP                     = 4
+	rtcEventLogEventTypeVideoReceiverConfig      = 8
+	rtcEventLogEventTypeVideoSenderConfig        = 9
+	rtcEventLogEventTypeAudioReceiverConfig      = 10
+	rtcEventLogEventTypeAudioSenderConfig        = 11
+	rtcEventLogEventTypeICECandidatePairConfig   = 20
+	rtcEventLogPacketIncomingField               = 1
+	rtcEventLogRTPPacketLengthField              = 3
+	rtcEventLogRTPPacketHeaderField              = 4
+	rtcEventLogRTCPPacketDataField               = 3
+	rtcEventLogReceiverConfigRemoteSSRCField     = 1
+	rtcEventLogVideoReceiverConfigExtensionField = 6
+	rtcEventLogAudioReceiverConfigExtensionField = 3
+	rtcEventLogSenderConfigSSRCField             = 1
+	rtcEventLogSenderConfigExtensionField        = 2
+	rtcEventLogHeaderExtensionNameField          = 1
+	rtcEventLogHeaderExtensionIDField            = 2
+
+	rtcEventLogICECandidatePairConfigTypeField         = 1
+	rtcEventLogICECandidatePairConfigIDField           = 2
+	rtcEventLogICECandidatePairConfigLocalTypeField    = 3
+	rtcEventLogICECandidatePairConfigLocalFamilyField  = 6
+	rtcEventLogICECandidatePairConfigRemoteTypeField   = 7
+	rtcEventLogICECandidatePairConfigRemoteFamilyField = 8
+	rtcEventLogICECandidatePairConfigProtocolField     = 9
+	rtcEventLogICECandidatePairConfigSelected          = 3
+)
+
+// rtcEventLog writes the events of a PeerConnection in the binary
+// rtc_event_log format of libwebrtc, see PeerConnection.StartRTCEventLog.
+type rtcEventLog struct {
+	mu             sync.Mutex
+	w              io.Writer
+	err            error
+	candidatePairs map[string]uint32
+}
+
+// StartRTCEventLog starts writing the events of the PeerConnection to w in the
+// legacy rtc_event_log format of libwebrtc, so the log can be analyzed with its
+// tooling like event_log_visualizer. The log contains the configuration of the
+// RTP streams, the RTP headers and RTCP packets sent and received and the
+// selected ICE candidate pairs, but no media.
+//
+// w is written to while packets are sent and received, so it shouldn't block,
+// like a bufio.Writer. Logging stops at the first write error, which is
+// returned by StopRTCEventLog. A running log is stopped first. An error is
+// returned unless SettingEngine.EnableRTCEventLog is used.
+func (pc *PeerConnection) StartRTCEventLog(w io.Writer) error {
+	if pc.eventLogInterceptor == nil {
+		return errRTCEventLogDisabled
+	}
+
+	log := &rtcEventLog{w: w, candidatePairs: map[string]uint32{}}
+	log.writeEvent(rtcEventLogEventTypeLogStart, 0, nil)
+	pc.eventLogInterceptor.logStreamConfigs(log)
+	log.logSelectedCandidatePair(pc.iceTransport.getLastSelectedCandidatePair())
+
+	if previous := pc.eventLogInterceptor.swap(log); previous != nil {
+		_ = previous.stop()
+	}
+
+	return nil
+}
+
+// StopRTCEventLog stops writing the events of the PeerConnection, returning the
+// first error of writing the log.
+func (pc *PeerConnection) StopRTCEventLog() error {
+	if pc.eventLogInterceptor == nil {
+		return nil
+	}
+
+	log := pc.eventLogInterceptor.swap(nil)
+	if log == nil {
+		return nil
+	}
+
+	return log.stop()
+}
+
+func (l *rtcEventLog) stop() error {
+	l.writeEvent(rtcEventLogEventTypeLogEnd, 0, nil)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.err
+}
+
+func (l *rtcEventLog) writeEvent(eventType, field int, message []byte) {
+	event := appendProtoVarint(nil, rtcEventLogEventTimestampField, uint64(time.Now().UnixMicro())) //nolint:gosec // G115
+	event = appendProtoVarint(event, rtcEventLogEventTypeField, uint64(eventType))                  //nolint:gosec // G115
+	if message != nil {
+		event = appendProtoBytes(event, field, message)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.err != nil {
+		return
+	}
+	_, l.err = l.w.Write(appendProtoBytes(nil, rtcEventLogEventStreamField, event))
+}
+
+func (l *rtcEventLog) logRTP(incoming bool, header []byte, length int) {
+	packet := appendProtoBool(nil, rtcEventLogPacketIncomingField, incoming)
+	packet = appendProtoVarint(packet, rtcEventLogRTPPacketLengthField, uint64(length)) //nolint:gosec // G115
+	packet = appendProtoBytes(packet, rtcEventLogRTPPacketHeaderField, header)
+	l.writeEvent(rtcEventLogEventTypeRTP, rtcEventLogEventRTPPacketField, packet)
+}
+
+func (l *rtcEventLog) logRTCP(incoming bool, data []byte) {
+	packet := appendProtoBool(nil, rtcEventLogPacketIncomingField, incoming)
+	packet = appendProtoBytes(packet, rtcEventLogRTCPPacketDataField, data)
+	l.writeEvent(rtcEventLogEventTypeRTCP, rtcEventLogEventRTCPPacketField, packet)
+}
+
+func (l *rtcEventLog) logStreamConfig(incoming bool, info *interceptor.StreamInfo) {
+	video := strings.HasPrefix(strings.ToLower(info.MimeType), "video/")
+
+	var config []byte
+	extensionField := rtcEventLogSenderConfigExtensionField
+	if incoming {
+		config = appendProtoVarint(nil, rtcEventLogReceiverConfigRemoteSSRCField, uint64(info.SSRC))
+		extensionField = rtcEventLogAudioReceiverConfigExtensionField
+		if video {
+			extensionField = rtcEventLogVideoReceiverConfigExtensionField
+		}
+	} else {
+		config = appendProtoVarint(nil, rtcEventLogSenderConfigSSRCField, uint64(info.SSRC))
+	}
+
+	for _, extension := range info.RTPHeaderExtensions {
+		message := appendProtoBytes(nil, rtcEventLogHeaderExtensionNameField, []byte(extension.URI))
+		message = appendProtoVarint(message, rtcEventLogHeaderExtensionIDField, uint64(extension.ID)) //nolint:gosec // G115
+		config = appendProtoBytes(config, extensionField, message)
+	}
+
+	switch {
+	case incoming && video:
+		l.writeEvent(rtcEventLogEventTypeVideoReceiverConfig, rtcEventLogEventVideoReceiverConfigField, config)
+	case incoming:
+		l.writeEvent(rtcEventLogEventTypeAudioReceiverConfig, rtcEventLogEventAudioReceiverConfigField, config)
+	case video:
+		l.writeEvent(rtcEventLogEventTypeVideoSenderConfig, rtcEventLogEventVideoSenderConfigField, config)
+	default:
+		l.writeEvent(rtcEventLogEventTypeAudioSenderConfig, rtcEventLogEventAudioSenderConfigField, config)
+	}
+}
+
+func (l *rtcEventLog) logSelectedCandidatePair(pair *ICECandidatePair) {
+	if pair == nil || pair.Local == nil || pair.Remote == nil {
+		return
+	}
+
+	key := pair.Local.String() + " " + pair.Remote.String()
+	l.mu.Lock()
+	id, ok := l.candidatePairs[key]
+	if !ok {
+		id = uint32(len(l.candidatePairs)) //nolint:gosec // G115
+		l.candidatePairs[key] = id
+	}
+	l.mu.Unlock()
+
+	config := appendProtoVarint(nil, rtcEventLogICECandidatePairConfigTypeField, rtcEventLogICECandidatePairConfigSelected)
+	config = appendProtoVarint(config, rtcEventLogICECandidatePairConfigIDField, uint64(id))
+	config = appendProtoVarint(config, rtcEventLogICECandidatePairConfigLocalTypeField,
+		rtcEventLogCandidateType(pair.Local.Typ))
+	config = appendProtoVarint(config, rtcEventLogICECandidatePairConfigLocalFamilyField,
+		rtcEventLogAddressFamily(pair.Local.Address))
+	config = appendProtoVarint(config, rtcEventLogICECandidatePairConfigRemoteTypeField,
+		rtcEventLogCandidateType(pair.Remote.Typ))
+	config = appendProtoVarint(config, rtcEventLogICECandidatePairConfigRemoteFamilyField,
+		rtcEventLogAddressFamily(pair.Remote.Address))
+	config = appendProtoVarint(config, rtcEventLogICECandidatePairConfigProtocolField,
+		rtcEventLogProtocol(pair.Local.Protocol))
+	l.writeEvent(rtcEventLogEventTypeICECandidatePairConfig, rtcEventLogEventICECandidatePairConfigField, config)
+}
+
+func rtcEventLogCandidateType(typ ICECandidateType) uint64 {
+	switch typ {
+	case ICECandidateTypeHost:
+		return 0
+	case ICECandidateTypeSrflx:
+		return 1
+	case ICECandidateTypePrflx:
+		return 2
+	case ICECandidateTypeRelay:
+		return 3
+	default:
+		return 4
+	}
+}
+
+func rtcEventLogAddressFamily(address string) uint64 {
+	ip := net.ParseIP(address)
+	switch {
+	case ip == nil:
+		return 2
+	case ip.To4() != nil:
+		return 0
+	default:
+		return 1
+	}
+}
+
+func rtcEventLogProtocol(protocol ICEProtocol) uint64 {
+	switch protocol {
+	case ICEProtocolUDP:
+		return 0
+	case ICEProtocolTCP:
+		return 1
+	default:
+		return 4
+	}
+}
+
+func appendProtoVarint(b []byte, field int, value uint64) []byte {
+	b = binary.AppendUvarint(b, uint64(field)<<3) //nolint:gosec // G115
+
+	return binary.AppendUvarint(b, value)
+}
+
+func appendProtoBool(b []byte, field int, value bool) []byte {
+	if value {
+		return appendProtoVarint(b, field, 1)
+	}
+
+	return appendProtoVarint(b, field, 0)
+}
+
+func appendProtoBytes(b []byte, field int, value []byte) []byte {
+	b = binary.AppendUvarint(b, uint64(field)<<3|2) //nolint:gosec // G115
+	b = binary.AppendUvarint(b, uint64(len(value)))
+
+	return append(b, value...)
+}
+
+// rtcEventLogInterceptor logs the packets of a PeerConnection to its running
+// rtcEventLog. It is the first interceptor of the chain after the ones of the
+// Instrumentation, so the packets are logged as they are sent and received. It
+// is only added with SettingEngine.EnableRTCEventLog.
+type rtcEventLogInterceptor struct {
+	interceptor.NoOp
+	log atomic.Value // *rtcEventLog
+
+	mu            sync.Mutex
+	localStreams  map[uint32]*interceptor.StreamInfo
+	remoteStreams map[uint32]*interceptor.StreamInfo
+}
+
+func newRTCEventLogInterceptor() *rtcEventLogInterceptor {
+	return &rtcEventLogInterceptor{
+		localStreams:  map[uint32]*interceptor.StreamInfo{},
+		remoteStreams: map[uint32]*interceptor.StreamInfo{},
+	}
+}
+
+func (i *rtcEventLogInterceptor) load() *rtcEventLog {
+	log, _ := i.log.Load().(*rtcEventLog)
+
+	return log
+}
+
+func (i *rtcEventLogInterceptor) swap(log *rtcEventLog) *rtcEventLog {
+	previous, _ := i.log.Swap(log).(*rtcEventLog)
+
+	return previous
+}
+
+// logStreamConfigs logs the configuration of the streams that were bound before
+// the log started.
+func (i *rtcEventLogInterceptor) logStreamConfigs(log *rtcEventLog) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for _, info := range i.localStreams {
+		log.logStreamConfig(false, info)
+	}
+	for _, info := range i.remoteStreams {
+		log.logStreamConfig(true, info)
+	}
+}
+
+func (i *rtcEventLogInterceptor) BindRTCPReader(reader interceptor.RTCPReader) interceptor.RTCPReader {
+	return interceptor.RTCPReaderFunc(func(b []byte, a interceptor.Attributes) (int, interceptor.Attributes, error) {
+		n, a, err := reader.Read(b, a)
+		if log := i.load(); log != nil && err == nil {
+			log.logRTCP(true, b[:n])
+		}
+
+		return n, a, err
+	})
+}
+
+func (i *rtcEventLogInterceptor) BindRTCPWriter(writer interceptor.RTCPWriter) interceptor.RTCPWriter {
+	return interceptor.RTCPWriterFunc(func(pkts []rtcp.Packet, a interceptor.Attributes) (int, error) {
+		if log := i.load(); log != nil {
+			if data, err := rtcp.Marshal(pkts); err == nil {
+				log.logRTCP(false, data)
+			}
+		}
+
+		return writer.Write(pkts, a)
+	})
+}
+
+func (i *rtcEventLogInterceptor) BindLocalStream(
+	info *interceptor.StreamInfo, writer interceptor.RTPWriter,
+) interceptor.RTPWriter {
+	i.mu.Lock()
+	i.localStreams[info.SSRC] = info
+	i.mu.Unlock()
+	if log := i.load(); log != nil {
+		log.logStreamConfig(false, info)
+	}
+
+	return interceptor.RTPWriterFunc(func(header *rtp.Header, payload []byte, a interceptor.Attributes) (int, error) {
+		if log := i.load(); log != nil {
+			if headerData, err := header.Marshal(); err == nil {
+				log.logRTP(false, headerData, len(headerData)+len(payload))
+			}
+		}
+
+		return writer.Write(header, payload, a)
+	})
+}
+
+func (i *rtcEventLogInterceptor) UnbindLocalStream(info *interceptor.StreamInfo) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	delete(i.localStreams, info.SSRC)
+}
+
+func (i *rtcEventLogInterceptor) BindRemoteStream(
+	info *interceptor.StreamInfo, reader interceptor.RTPReader,
+) interceptor.RTPReader {
+	i.mu.Lock()
+	i.remoteStreams[info.SSRC] = info
+	i.mu.Unlock()
+	if log := i.load(); log != nil {
+		log.logStreamConfig(true, info)
+	}
+
+	return interceptor.RTPReaderFunc(func(b []byte, a interceptor.Attributes) (int, interceptor.Attributes, error) {
+		n, a, err := reader.Read(b, a)
+		if log := i.load(); log != nil && err == nil {
+			header := &rtp.Header{}
+			if headerLen, headerErr := header.Unmarshal(b[:n]); headerErr == nil {
+				log.logRTP(true, b[:headerLen], n)
+			}
+		}
+
+		return n, a, err
+	})
+}
+
+func (i *rtcEventLogInterceptor) UnbindRemoteStream(info *interceptor.StreamInfo) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	delete(i.remoteStreams, info.SSRC)
+}
@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/pion/transport/v4/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readRTCEventLog returns the types of the events of a log, with the fields of
+// their messages.
+func readRTCEventLog(t *testing.T, b []byte) (types []uint64, messages []map[uint64][]byte) {
+	t.Helper()
+
+	readField := func(b []byte) (field, wireType uint64, value []byte, varint uint64, rest []byte) {
+		tag, n := binary.Uvarint(b)
+		require.Greater(t, n, 0)
+		b = b[n:]
+		switch tag & 7 {
+		case 0:
+			varint, n = binary.Uvarint(b)
+			require.Greater(t, n, 0)
+
+			return tag >> 3, 0, nil, varint, b[n:]
+		case 2:
+			length, n := binary.Uvarint(b)
+			require.Greater(t, n, 0)
+			b = b[n:]
+			require.GreaterOrEqual(t, uint64(len(b)), length)
+
+			return tag >> 3, 2, b[:length], 0, b[length:]
+		default:
+			require.FailNow(t, "unexpected wire type")
+
+			return 0, 0, nil, 0, nil
+		}
+	}
+
+	for len(b) > 0 {
+		field, wireType, event, _, rest := readField(b)
+		require.Equal(t, uint64(rtcEventLogEventStreamField), field)
+		require.Equal(t, uint64(2), wireType)
+		b = rest
+
+		var eventType uint64
+		var message []byte
+		for len(event) > 0 {
+			field, _, value, varint, rest := readField(event)
+			switch field {
+			case rtcEventLogEventTimestampField:
+				assert.NotZero(t, varint)
+			case rtcEventLogEventTypeField:
+				eventType = varint
+			default:
+				message = value
+			}
+			event = rest
+		}
+
+		fields := map[uint64][]byte{}
+		for len(message) > 0 {
+			field, wireType, value, varint, rest := readField(message)
+			if wireType == 0 {
+				value = binary.AppendUvarint(nil, varint)
+			}
+			fields[field] = value
+			message = rest
+		}
+
+		types = append(types, eventType)
+		messages = append(messages, fields)
+	}
+
+	return types, messages
+}
+
+func TestPeerConnection_RTCEventLog(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	s := SettingEngine{}
+	s.EnableRTCEventLog(true)
+	pcOffer, pcAnswer, err := NewAPI(WithSettingEngine(s)).newPair(Configuration{})
+	require.NoError(t, err)
+
+	track, err := NewTrackLocalStaticRTP(RTPCodecCapability{MimeType: MimeTypeVP8}, "video", "pion")
+	require.NoError(t, err)
+	_, err = pcOffer.AddTrack(track)
+	require.NoError(t, err)
+
+	var offerLog, answerLog bytes.Buffer
+	require.NoError(t, pcOffer.StartRTCEventLog(&offerLog))
+
+	received, receivedDone := context.WithCancel(context.Background())
+	pcAnswer.OnTrack(func(remote *TrackRemote, _ *RTPReceiver) {
+		assert.NoError(t, pcAnswer.StartRTCEventLog(&answerLog))
+		_, _, readErr := remote.ReadRTP()
+		assert.NoError(t, readErr)
+		receivedDone()
+	})
+
+	require.NoError(t, signalPair(pcOffer, pcAnswer))
+
+	func() {
+		for {
+			select {
+			case <-received.Done():
+				return
+			case <-time.After(20 * time.Millisecond):
+				assert.NoError(t, track.WriteRTP(&rtp.Packet{
+					Header:  rtp.Header{Version: 2, Marker: true},
+					Payload: []byte{0x10, 0x00},
+				}))
+			}
+		}
+	}()
+
+	assert.NoError(t, pcOffer.StopRTCEventLog())
+	assert.NoError(t, pcAnswer.StopRTCEventLog())
+	assert.NoError(t, pcOffer.StopRTCEventLog())
+
+	types, messages := readRTCEventLog(t, offerLog.Bytes())
+	require.NotEmpty(t, types)
+	assert.Equal(t, uint64(rtcEventLogEventTypeLogStart), types[0])
+	assert.Equal(t, uint64(rtcEventLogEventTypeLogEnd), types[len(types)-1])
+	assert.Contains(t, types, uint64(rtcEventLogEventTypeVideoSenderConfig))
+	assert.Contains(t, types, uint64(rtcEventLogEventTypeICECandidatePairConfig))
+
+	var sentRTP int
+	for i, eventType := range types {
+		if eventType != rtcEventLogEventTypeRTP {
+			continue
+		}
+		sentRTP++
+		assert.Equal(t, []byte{0}, messages[i][rtcEventLogPacketIncomingField])
+
+		header := &rtp.Header{}
+		_, err = header.Unmarshal(messages[i][rtcEventLogRTPPacketHeaderField])
+		assert.NoError(t, err)
+		assert.True(t, header.Marker)
+	}
+	assert.NotZero(t, sentRTP)
+
+	// The answer started logging once the track was bound, so its configuration
+	// is logged when starting.
+	types, messages = readRTCEventLog(t, answerLog.Bytes())
+	assert.Equal(t, uint64(rtcEventLogEventTypeLogStart), types[0])
+	assert.Contains(t, types, uint64(rtcEventLogEventTypeVideoReceiverConfig))
+	assert.Contains(t, types, uint64(rtcEventLogEventTypeICECandidatePairConfig))
+	for i, eventType := range types {
+		if eventType == rtcEventLogEventTypeRTP {
+			assert.Equal(t, []byte{1}, messages[i][rtcEventLogPacketIncomingField])
+		}
+	}
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
+
+func TestPeerConnection_RTCEventLogDisabled(t *testing.T) {
+	pc, err := NewPeerConnection(Configuration{})
+	require.NoError(t, err)
+
+	assert.Nil(t, pc.eventLogInterceptor, "the packets are watched without an event log")
+	var log bytes.Buffer
+	assert.ErrorIs(t, pc.StartRTCEventLog(&log), errRTCEventLogDisabled)
+	assert.Empty(t, log.Bytes())
+	assert.NoError(t, pc.StopRTCEventLog())
+
+	assert.NoError(t, pc.Close())
+}
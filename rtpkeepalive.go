@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/pion/webrtc/v4/internal/util"
+)
+
+// rtpKeepAliveStream is the RTP stream a recvonly RTPTransceiver sends its
+// keep-alives on, it has no SSRC of its own.
+type rtpKeepAliveStream struct {
+	ssrc           uint32
+	sequenceNumber uint16
+	timestamp      uint32
+}
+
+// startRTPKeepAlives starts sending the keep-alives of
+// SettingEngine.SetRTPKeepAliveInterval, they stop when the PeerConnection is
+// closed.
+func (pc *PeerConnection) startRTPKeepAlives() {
+	if interval := pc.api.settingEngine.rtpKeepAliveInterval; interval > 0 {
+		go pc.runRTPKeepAlives(interval)
+	}
+}
+
+func (pc *PeerConnection) runRTPKeepAlives(interval time.Duration) {
+	srtpSession, err := pc.dtlsTransport.getSRTPSession()
+	if err != nil {
+		pc.log.Warnf("RTP keep-alives failed to open SrtpSession: %v", err)
+
+		return
+	}
+
+	writeStream, err := srtpSession.OpenWriteStream()
+	if err != nil {
+		pc.log.Warnf("RTP keep-alives failed to open WriteStream: %v", err)
+
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	streams := map[*RTPTransceiver]*rtpKeepAliveStream{}
+	for {
+		select {
+		case <-pc.isCloseDone:
+			return
+		case <-ticker.C:
+		}
+
+		payloadType, ok := pc.rtpKeepAlivePayloadType()
+		if !ok {
+			continue
+		}
+
+		for _, transceiver := range pc.GetTransceivers() {
+			if transceiver.getCurrentDirection() != RTPTransceiverDirectionRecvonly {
+				delete(streams, transceiver)
+
+				continue
+			}
+
+			stream, ok := streams[transceiver]
+			if !ok {
+				stream = &rtpKeepAliveStream{
+					ssrc:           uint32(pc.api.settingEngine.generateSSRC()),
+					sequenceNumber: uint16(util.RandUint32()), //nolint:gosec // G115
+					timestamp:      util.RandUint32(),
+				}
+				streams[transceiver] = stream
+			}
+			stream.sequenceNumber++
+
+			header := &rtp.Header{
+				Version:        2,
+				PayloadType:    uint8(payloadType),
+				SequenceNumber: stream.sequenceNumber,
+				Timestamp:      stream.timestamp,
+				SSRC:           stream.ssrc,
+			}
+			if _, err := writeStream.WriteRTP(header, nil); err != nil {
+				pc.log.Tracef("Failed to send RTP keep-alive for %s: %v", transceiver.Mid(), err)
+			}
+		}
+	}
+}
+
+// rtpKeepAlivePayloadType returns a dynamic payload type no codec uses, neither
+// a local one nor one of the remote description that wasn't negotiated.
+func (pc *PeerConnection) rtpKeepAlivePayloadType() (PayloadType, bool) {
+	remotePayloadTypes := pc.remotePayloadTypes()
+	for payloadType := PayloadType(127); payloadType >= 96; payloadType-- {
+		if remotePayloadTypes[payloadType] {
+			continue
+		}
+		if _, _, err := pc.api.mediaEngine.getCodecByPayload(payloadType); errors.Is(err, ErrCodecNotFound) {
+			return payloadType, true
+		}
+	}
+
+	return 0, false
+}
+
+// remotePayloadTypes returns the payload types of the media sections of the
+// current remote description.
+func (pc *PeerConnection) remotePayloadTypes() map[PayloadType]bool {
+	pc.mu.RLock()
+	defer pc.mu.RUnlock()
+
+	payloadTypes := map[PayloadType]bool{}
+	if pc.currentRemoteDescription == nil || pc.currentRemoteDescription.parsed == nil {
+		return payloadTypes
+	}
+	for _, media := range pc.currentRemoteDescription.parsed.MediaDescriptions {
+		for _, format := range media.MediaName.Formats {
+			if payloadType, err := strconv.ParseUint(format, 10, 7); err == nil {
+				payloadTypes[PayloadType(payloadType)] = true
+			}
+		}
+	}
+
+	return payloadTypes
+}
+
+// isRTPKeepAlive returns true if the packet has no payload and a payload type
+// no codec uses, like the keep-alives of RFC 6263 Section 4.6.
+func (pc *PeerConnection) isRTPKeepAlive(packet []byte) bool {
+	header := rtp.Header{}
+	n, err := header.Unmarshal(packet)
+	if err != nil || n != len(packet) {
+		return false
+	}
+
+	_, _, err = pc.api.mediaEngine.getCodecByPayload(PayloadType(header.PayloadType))
+
+	return errors.Is(err, ErrCodecNotFound)
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pion/sdp/v3"
+	"github.com/pion/transport/v4/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeerConnection_RTPKeepAlive(t *testing.T) {
+	lim := test.TimeOut(time.Second * 10)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	offerSettings := SettingEngine{}
+	offerSettings.SetRTPKeepAliveInterval(10 * time.Millisecond)
+	pcOffer, err := NewAPI(WithSettingEngine(offerSettings)).NewPeerConnection(Configuration{})
+	assert.NoError(t, err)
+
+	var keepAlivePayloadType atomic.Uint32
+	keepAlives := make(chan struct{}, 100)
+	unhandledSSRC := make(chan struct{})
+	answerSettings := SettingEngine{
+		LoggerFactory: &undeclaredSsrcLoggerFactory{unhandledSSRC},
+	}
+	answerSettings.SetInboundRTPPacketFilter(func(_ SSRC, payloadType PayloadType, _ int) bool {
+		if uint32(payloadType) == keepAlivePayloadType.Load() {
+			select {
+			case keepAlives <- struct{}{}:
+			default:
+			}
+		}
+
+		return true
+	})
+	pcAnswer, err := NewAPI(WithSettingEngine(answerSettings)).NewPeerConnection(Configuration{})
+	assert.NoError(t, err)
+
+	payloadType, ok := pcOffer.rtpKeepAlivePayloadType()
+	assert.True(t, ok)
+	keepAlivePayloadType.Store(uint32(payloadType))
+
+	_, err = pcOffer.AddTransceiverFromKind(RTPCodecTypeVideo, RTPTransceiverInit{
+		Direction: RTPTransceiverDirectionRecvonly,
+	})
+	assert.NoError(t, err)
+
+	// The answerer sends nothing, so the offerer only keeps the NAT open with keep-alives.
+	track, err := NewTrackLocalStaticSample(RTPCodecCapability{MimeType: MimeTypeVP8}, "video", "pion")
+	assert.NoError(t, err)
+	_, err = pcAnswer.AddTrack(track)
+	assert.NoError(t, err)
+
+	var trackReceived atomic.Bool
+	pcAnswer.OnTrack(func(*TrackRemote, *RTPReceiver) {
+		trackReceived.Store(true)
+	})
+
+	assert.NoError(t, signalPair(pcOffer, pcAnswer))
+	for range 3 {
+		<-keepAlives
+	}
+
+	assert.False(t, trackReceived.Load(), "a keep-alive was handled as media")
+	select {
+	case <-unhandledSSRC:
+		assert.Fail(t, "the SSRC of the keep-alives wasn't ignored")
+	default:
+	}
+	assert.Len(t, pcAnswer.GetTransceivers(), 1)
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
+
+func TestPeerConnection_isRTPKeepAlive(t *testing.T) {
+	pc, err := NewPeerConnection(Configuration{})
+	assert.NoError(t, err)
+
+	payloadType, ok := pc.rtpKeepAlivePayloadType()
+	assert.True(t, ok)
+	_, _, err = pc.api.mediaEngine.getCodecByPayload(payloadType)
+	assert.ErrorIs(t, err, ErrCodecNotFound)
+
+	keepAlive := []byte{0x80, byte(payloadType), 0x00, 0x01, 0, 0, 0, 0, 0, 0, 0, 1}
+	assert.True(t, pc.isRTPKeepAlive(keepAlive))
+	assert.False(t, pc.isRTPKeepAlive(append(keepAlive, 0xff)), "the packet has a payload")
+
+	media := []byte{0x80, 96, 0x00, 0x01, 0, 0, 0, 0, 0, 0, 0, 1}
+	assert.False(t, pc.isRTPKeepAlive(media), "the payload type is used by a codec")
+	assert.False(t, pc.isRTPKeepAlive(keepAlive[:4]))
+
+	// The payload types of the remote description aren't used, even if they
+	// weren't negotiated.
+	pc.currentRemoteDescription = &SessionDescription{parsed: &sdp.SessionDescription{
+		MediaDescriptions: []*sdp.MediaDescription{{
+			MediaName: sdp.MediaName{Media: "video", Formats: []string{strconv.Itoa(int(payloadType)), "webrtc-datachannel"}},
+		}},
+	}}
+	remotePayloadType, ok := pc.rtpKeepAlivePayloadType()
+	assert.True(t, ok)
+	assert.NotEqual(t, payloadType, remotePayloadType)
+
+	assert.NoError(t, pc.Close())
+}
@@ -75,6 +75,11 @@ type RTPReceiver struct {
 
 	rtxPool sync.Pool
 
+	dtmf                  dtmfReceiver
+	onComfortNoiseHandler func(ComfortNoise)
+
+	rtcpReadBuffer pooledReadBuffer
+
 	log logging.LeveledLogger
 }
 
@@ -122,6 +127,7 @@ func (r *RTPReceiver) getParameters() RTPParameters {
 	)
 	if r.tr != nil {
 		parameters.Codecs = r.tr.getCodecs()
+		parameters.HeaderExtensions = r.tr.filterHeaderExtensions(parameters.HeaderExtensions)
 	}
 
 	return parameters
@@ -329,13 +335,12 @@ func (r *RTPReceiver) ReadSimulcast(b []byte, rid string) (n int, a interceptor.
 // ReadRTCP is a convenience method that wraps Read and unmarshal for you.
 // It also runs any configured interceptors.
 func (r *RTPReceiver) ReadRTCP() ([]rtcp.Packet, interceptor.Attributes, error) {
-	b := make([]byte, r.api.settingEngine.getReceiveMTU())
-	i, attributes, err := r.Read(b)
+	b, attributes, err := r.api.settingEngine.readPacket(&r.rtcpReadBuffer, r.Read)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	pkts, err := rtcp.Unmarshal(b[:i])
+	pkts, err := r.api.mediaEngine.unmarshalRTCP(b)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -345,13 +350,15 @@ func (r *RTPReceiver) ReadRTCP() ([]rtcp.Packet, interceptor.Attributes, error)
 
 // ReadSimulcastRTCP is a convenience method that wraps ReadSimulcast and unmarshal for you.
 func (r *RTPReceiver) ReadSimulcastRTCP(rid string) ([]rtcp.Packet, interceptor.Attributes, error) {
-	b := make([]byte, r.api.settingEngine.getReceiveMTU())
-	i, attributes, err := r.ReadSimulcast(b, rid)
+	read := func(b []byte) (int, interceptor.Attributes, error) {
+		return r.ReadSimulcast(b, rid)
+	}
+	b, attributes, err := r.api.settingEngine.readPacket(&r.rtcpReadBuffer, read)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	pkts, err := rtcp.Unmarshal(b[:i])
+	pkts, err := r.api.mediaEngine.unmarshalRTCP(b)
 
 	return pkts, attributes, err
 }
@@ -462,6 +469,13 @@ func (r *RTPReceiver) collectStats(collector *statsReportCollector, statsGetter
 			CodecID:     codecID,
 		}
 		r.populateInboundStats(&inboundStats, statsGetter, remoteTrack)
+		if r.kind == RTPCodecTypeAudio {
+			remoteTrack.audioStats.populate(&inboundStats, nowTime)
+		}
+		remoteTrack.populateDecoderStats(&inboundStats)
+		if r.jitterHistogramsEnabled() {
+			remoteTrack.jitterHistograms.populate(&inboundStats)
+		}
 
 		collector.Collect(inboundID, inboundStats)
 
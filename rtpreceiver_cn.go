@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"github.com/pion/rtp"
+)
+
+// OnComfortNoise sets an event handler which is invoked for every RFC 3389
+// comfort noise packet received, so audio pipelines can generate matching
+// background noise while the remote is silent. Comfort noise is detected while
+// the packets of the track are read, the CN codec has to be registered with the
+// MediaEngine and negotiated with the remote peer.
+func (r *RTPReceiver) OnComfortNoise(f func(ComfortNoise)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.onComfortNoiseHandler = f
+}
+
+// handleComfortNoise is called with every comfort noise packet of the stream.
+func (r *RTPReceiver) handleComfortNoise(b []byte) {
+	r.mu.RLock()
+	handler := r.onComfortNoiseHandler
+	r.mu.RUnlock()
+
+	if handler == nil {
+		return
+	}
+
+	packet := &rtp.Packet{}
+	if err := packet.Unmarshal(b); err != nil {
+		return
+	}
+
+	var noise ComfortNoise
+	if err := noise.unmarshal(packet.Payload); err != nil {
+		return
+	}
+	noise.Timestamp = packet.Timestamp
+
+	handler(noise)
+}
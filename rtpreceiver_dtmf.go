@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pion/rtp"
+)
+
+// DTMFTone is a tone received as a RFC 4733 telephone-event.
+type DTMFTone struct {
+	// Tone is one of 0-9, A-D, # and *.
+	Tone string
+
+	// Duration is how long the tone was played.
+	Duration time.Duration
+
+	// Volume is the power level of the tone in -dBm0, 0 is the loudest.
+	Volume uint8
+}
+
+// dtmfReceiver turns the telephone-event packets of an audio stream into
+// DTMFTones. Every event is reported once when it ends, even though senders
+// repeat packets for reliability.
+type dtmfReceiver struct {
+	mu     sync.Mutex
+	onTone func(DTMFTone)
+
+	active    bool
+	event     uint8
+	volume    uint8
+	clockRate uint32
+
+	// timestamp is the timestamp of the current segment of the event, see RFC 4733
+	// Section 2.5.2.3. baseTicks is the duration of the previous segments.
+	timestamp    uint32
+	baseTicks    uint32
+	segmentTicks uint32
+
+	hasEnded       bool
+	endedTimestamp uint32
+}
+
+func (d *dtmfReceiver) setOnTone(f func(DTMFTone)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.onTone = f
+}
+
+// handle is called with every telephone-event packet of the stream.
+func (d *dtmfReceiver) handle(b []byte, clockRate uint32) {
+	packet := &rtp.Packet{}
+	if err := packet.Unmarshal(b); err != nil {
+		return
+	}
+
+	var event dtmfEvent
+	if err := event.unmarshal(packet.Payload); err != nil {
+		return
+	}
+
+	// Events that aren't DTMF tones, like the fax and modem tones of RFC 4734, are ignored.
+	if _, ok := dtmfEventToTone(event.event); !ok {
+		return
+	}
+
+	d.mu.Lock()
+	tones := d.update(packet.Timestamp, event, clockRate)
+	onTone := d.onTone
+	d.mu.Unlock()
+
+	if onTone == nil {
+		return
+	}
+
+	for _, tone := range tones {
+		onTone(tone)
+	}
+}
+
+// update applies an event packet and returns the tones that ended. d.mu must be held.
+func (d *dtmfReceiver) update(timestamp uint32, event dtmfEvent, clockRate uint32) []DTMFTone {
+	var tones []DTMFTone
+
+	switch {
+	case d.hasEnded && timestamp == d.endedTimestamp:
+		// Retransmitted final packet, or a reordered packet of an event that ended.
+		return nil
+	case d.active && timestamp == d.timestamp:
+	case d.active && event.event == d.event && timestamp == d.timestamp+d.segmentTicks:
+		d.baseTicks += d.segmentTicks
+		d.segmentTicks = 0
+		d.timestamp = timestamp
+	default:
+		// A new event, the previous one ended without its final packet arriving.
+		if d.active {
+			tones = append(tones, d.tone())
+		}
+
+		d.active = true
+		d.event = event.event
+		d.clockRate = clockRate
+		d.timestamp = timestamp
+		d.baseTicks = 0
+		d.segmentTicks = 0
+	}
+
+	d.segmentTicks = max(d.segmentTicks, uint32(event.duration))
+	d.volume = event.volume
+
+	if event.end {
+		tones = append(tones, d.tone())
+		d.active = false
+		d.hasEnded = true
+		d.endedTimestamp = timestamp
+	}
+
+	return tones
+}
+
+// tone returns the current event as a DTMFTone. d.mu must be held.
+func (d *dtmfReceiver) tone() DTMFTone {
+	tone, _ := dtmfEventToTone(d.event)
+
+	var duration time.Duration
+	if d.clockRate != 0 {
+		duration = time.Duration(uint64(d.baseTicks+d.segmentTicks) * uint64(time.Second) / uint64(d.clockRate))
+	}
+
+	return DTMFTone{Tone: tone, Duration: duration, Volume: d.volume}
+}
+
+// OnDTMFTone sets an event handler which is invoked for every DTMF tone received
+// as a RFC 4733 telephone-event. Every tone is reported once after it ended,
+// repeated packets are deduplicated. Tones are detected while the packets of the
+// track are read, the telephone-event codec has to be registered with the
+// MediaEngine and negotiated with the remote peer.
+func (r *RTPReceiver) OnDTMFTone(f func(DTMFTone)) {
+	r.dtmf.setOnTone(f)
+}
@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/pion/transport/v4/test"
+	"github.com/pion/webrtc/v4/pkg/media"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func marshalDTMFPacket(t *testing.T, seq uint16, timestamp uint32, event dtmfEvent) []byte {
+	t.Helper()
+
+	payload := make([]byte, dtmfEventPayloadSize)
+	event.marshalTo(payload)
+
+	b, err := (&rtp.Packet{
+		Header:  rtp.Header{Version: 2, PayloadType: 101, SequenceNumber: seq, Timestamp: timestamp},
+		Payload: payload,
+	}).Marshal()
+	require.NoError(t, err)
+
+	return b
+}
+
+func Test_dtmfReceiver(t *testing.T) {
+	var receiver dtmfReceiver
+	var tones []DTMFTone
+	receiver.setOnTone(func(tone DTMFTone) {
+		tones = append(tones, tone)
+	})
+
+	for _, p := range []struct {
+		timestamp uint32
+		event     dtmfEvent
+	}{
+		// "5" with three final packets.
+		{1000, dtmfEvent{event: 5, volume: 10, duration: 400}},
+		{1000, dtmfEvent{event: 5, volume: 10, duration: 800}},
+		{1000, dtmfEvent{event: 5, volume: 10, duration: 1200, end: true}},
+		{1000, dtmfEvent{event: 5, volume: 10, duration: 1200, end: true}},
+		{1000, dtmfEvent{event: 5, volume: 10, duration: 1200, end: true}},
+
+		// "#" whose final packets are lost, reported when "A" starts.
+		{5000, dtmfEvent{event: 11, volume: 20, duration: 400}},
+		{9000, dtmfEvent{event: 12, volume: 10, duration: 0xFFFF}},
+
+		// The second segment of the long "A".
+		{9000 + 0xFFFF, dtmfEvent{event: 12, volume: 10, duration: 8000, end: true}},
+
+		// Events that aren't DTMF are ignored.
+		{90000, dtmfEvent{event: 32, volume: 10, duration: 400, end: true}},
+	} {
+		receiver.handle(marshalDTMFPacket(t, 0, p.timestamp, p.event), 8000)
+	}
+
+	assert.Equal(t, []DTMFTone{
+		{Tone: "5", Duration: 150 * time.Millisecond, Volume: 10},
+		{Tone: "#", Duration: 50 * time.Millisecond, Volume: 20},
+		{Tone: "A", Duration: time.Duration(0xFFFF+8000) * time.Second / 8000, Volume: 10},
+	}, tones)
+
+	// Malformed packets are ignored.
+	receiver.handle([]byte{0x80}, 8000)
+	receiver.handle(marshalDTMFPacket(t, 0, 1, dtmfEvent{})[:14], 8000)
+	assert.Len(t, tones, 3)
+}
+
+func Test_RTPReceiver_OnDTMFTone(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	mediaEngine := &MediaEngine{}
+	require.NoError(t, mediaEngine.RegisterCodec(RTPCodecParameters{
+		RTPCodecCapability: RTPCodecCapability{MimeType: MimeTypeOpus, ClockRate: 48000, Channels: 2},
+		PayloadType:        111,
+	}, RTPCodecTypeAudio))
+	require.NoError(t, mediaEngine.RegisterCodec(RTPCodecParameters{
+		RTPCodecCapability: RTPCodecCapability{MimeType: MimeTypeTelephoneEvent, ClockRate: 48000, SDPFmtpLine: "0-16"},
+		PayloadType:        101,
+	}, RTPCodecTypeAudio))
+
+	pcOffer, pcAnswer, err := NewAPI(WithMediaEngine(mediaEngine)).newPair(Configuration{})
+	require.NoError(t, err)
+
+	track, err := NewTrackLocalStaticSample(RTPCodecCapability{MimeType: MimeTypeOpus}, "audio", "pion")
+	require.NoError(t, err)
+
+	sender, err := pcOffer.AddTrack(track)
+	require.NoError(t, err)
+
+	tones := make(chan DTMFTone, 2)
+	onTrackFired := make(chan struct{})
+	pcAnswer.OnTrack(func(track *TrackRemote, receiver *RTPReceiver) {
+		receiver.OnDTMFTone(func(tone DTMFTone) {
+			tones <- tone
+		})
+		close(onTrackFired)
+
+		for {
+			if _, _, readErr := track.ReadRTP(); readErr != nil {
+				return
+			}
+			assert.Equal(t, MimeTypeOpus, track.Codec().MimeType)
+		}
+	})
+
+	require.NoError(t, signalPair(pcOffer, pcAnswer))
+
+	writeCtx, writeCancel := context.WithCancel(context.Background())
+	writeDone := make(chan struct{})
+	go func() {
+		defer close(writeDone)
+		for {
+			select {
+			case <-writeCtx.Done():
+				return
+			case <-time.After(20 * time.Millisecond):
+				assert.NoError(t, track.WriteSample(media.Sample{Data: []byte{0x00}, Duration: 20 * time.Millisecond}))
+			}
+		}
+	}()
+
+	<-onTrackFired
+	require.NoError(t, sender.InsertDTMF("7#", 100*time.Millisecond, 0))
+
+	first, second := <-tones, <-tones
+	assert.Equal(t, "7", first.Tone)
+	assert.Equal(t, 100*time.Millisecond, first.Duration)
+	assert.Equal(t, "#", second.Tone)
+
+	writeCancel()
+	<-writeDone
+	closePairNow(t, pcOffer, pcAnswer)
+}
@@ -52,6 +52,11 @@ type RTPSender struct {
 
 	rtpTransceiver *RTPTransceiver
 
+	// audioStream is only set for audio senders.
+	audioStream *audioSenderStream
+
+	rtcpReadBuffer pooledReadBuffer
+
 	mu                     sync.RWMutex
 	sendCalled, stopCalled chan struct{}
 }
@@ -78,6 +83,10 @@ func (api *API) NewRTPSender(track TrackLocal, transport *DTLSTransport) (*RTPSe
 		kind:       track.Kind(),
 	}
 
+	if r.kind == RTPCodecTypeAudio {
+		r.audioStream = newAudioSenderStream()
+	}
+
 	r.addEncoding(track)
 
 	return r, nil
@@ -142,6 +151,7 @@ func (r *RTPSender) GetParameters() RTPSendParameters {
 	}
 	if r.rtpTransceiver != nil {
 		sendParameters.Codecs = r.rtpTransceiver.getCodecs()
+		sendParameters.HeaderExtensions = r.rtpTransceiver.filterHeaderExtensions(sendParameters.HeaderExtensions)
 	} else {
 		sendParameters.Codecs = r.api.mediaEngine.getCodecsByKind(r.kind)
 	}
@@ -200,15 +210,15 @@ func (r *RTPSender) AddEncoding(track TrackLocal) error { //nolint:cyclop
 func (r *RTPSender) addEncoding(track TrackLocal) {
 	trackEncoding := &trackEncoding{
 		track: track,
-		ssrc:  SSRC(util.RandUint32()),
+		ssrc:  r.api.settingEngine.generateSSRC(),
 	}
 
 	if r.api.mediaEngine.isRTXEnabled(r.kind, []RTPTransceiverDirection{RTPTransceiverDirectionSendonly}) {
-		trackEncoding.ssrcRTX = SSRC(util.RandUint32())
+		trackEncoding.ssrcRTX = r.api.settingEngine.generateSSRC()
 	}
 
 	if r.api.mediaEngine.isFECEnabled(r.kind, []RTPTransceiverDirection{RTPTransceiverDirectionSendonly}) {
-		trackEncoding.ssrcFEC = SSRC(util.RandUint32())
+		trackEncoding.ssrcFEC = r.api.settingEngine.generateSSRC()
 	}
 
 	r.trackEncodings = append(r.trackEncodings, trackEncoding)
@@ -277,6 +287,8 @@ func (r *RTPSender) ReplaceTrack(track TrackLocal) error { //nolint:cyclop
 		ssrcFEC:         context.SSRCForwardErrorCorrection(),
 		writeStream:     context.WriteStream(),
 		rtcpInterceptor: context.RTCPReader(),
+
+		packetizationTimes: r.packetizationTimes,
 	})
 	if err != nil {
 		// Re-bind the original track
@@ -313,10 +325,18 @@ func (r *RTPSender) Send(parameters RTPSendParameters) error {
 		trackEncoding := r.trackEncodings[idx]
 		srtpStream := &srtpWriterFuture{ssrc: parameters.Encodings[idx].SSRC, rtpSender: r}
 		writeStream := &interceptorToTrackLocalWriter{}
+		var trackWriteStream TrackLocalWriter = writeStream
+		if idx == 0 && r.audioStream != nil {
+			r.audioStream.setWriter(writeStream)
+			trackWriteStream = r.audioStream
+		}
 		rtpParameters := r.api.mediaEngine.getRTPParametersByKind(
 			trackEncoding.track.Kind(),
 			[]RTPTransceiverDirection{RTPTransceiverDirectionSendonly},
 		)
+		if r.rtpTransceiver != nil {
+			rtpParameters = r.rtpTransceiver.filterRTPParameters(rtpParameters)
+		}
 
 		trackEncoding.srtpStream = srtpStream
 		trackEncoding.ssrc = parameters.Encodings[idx].SSRC
@@ -337,8 +357,10 @@ func (r *RTPSender) Send(parameters RTPSendParameters) error {
 			ssrc:            parameters.Encodings[idx].SSRC,
 			ssrcFEC:         parameters.Encodings[idx].FEC.SSRC,
 			ssrcRTX:         parameters.Encodings[idx].RTX.SSRC,
-			writeStream:     writeStream,
+			writeStream:     trackWriteStream,
 			rtcpInterceptor: trackEncoding.rtcpInterceptor,
+
+			packetizationTimes: r.packetizationTimes,
 		}
 
 		codec, err := trackEncoding.track.Bind(trackEncoding.context)
@@ -359,9 +381,17 @@ func (r *RTPSender) Send(parameters RTPSendParameters) error {
 			parameters.HeaderExtensions,
 		)
 
+		marshalers := r.api.mediaEngine.headerExtensionMarshalers(parameters.HeaderExtensions)
 		rtpInterceptor := r.api.interceptor.BindLocalStream(
 			&trackEncoding.streamInfo,
 			interceptor.RTPWriterFunc(func(header *rtp.Header, payload []byte, _ interceptor.Attributes) (int, error) {
+				if marshalers != nil {
+					var err error
+					if header, err = setHeaderExtensions(marshalers, header, payload); err != nil {
+						return 0, err
+					}
+				}
+
 				return srtpStream.WriteRTP(header, payload)
 			}),
 		)
@@ -387,6 +417,10 @@ func (r *RTPSender) Stop() error {
 	close(r.stopCalled)
 	r.mu.Unlock()
 
+	if r.audioStream != nil {
+		r.audioStream.close()
+	}
+
 	if !r.hasSent() {
 		return nil
 	}
@@ -418,13 +452,12 @@ func (r *RTPSender) Read(b []byte) (n int, a interceptor.Attributes, err error)
 
 // ReadRTCP is a convenience method that wraps Read and unmarshals for you.
 func (r *RTPSender) ReadRTCP() ([]rtcp.Packet, interceptor.Attributes, error) {
-	b := make([]byte, r.api.settingEngine.getReceiveMTU())
-	i, attributes, err := r.Read(b)
+	b, attributes, err := r.api.settingEngine.readPacket(&r.rtcpReadBuffer, r.Read)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	pkts, err := rtcp.Unmarshal(b[:i])
+	pkts, err := r.api.mediaEngine.unmarshalRTCP(b)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -455,13 +488,15 @@ func (r *RTPSender) ReadSimulcast(b []byte, rid string) (n int, a interceptor.At
 
 // ReadSimulcastRTCP is a convenience method that wraps ReadSimulcast and unmarshal for you.
 func (r *RTPSender) ReadSimulcastRTCP(rid string) ([]rtcp.Packet, interceptor.Attributes, error) {
-	b := make([]byte, r.api.settingEngine.getReceiveMTU())
-	i, attributes, err := r.ReadSimulcast(b, rid)
+	read := func(b []byte) (int, interceptor.Attributes, error) {
+		return r.ReadSimulcast(b, rid)
+	}
+	b, attributes, err := r.api.settingEngine.readPacket(&r.rtcpReadBuffer, read)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	pkts, err := rtcp.Unmarshal(b[:i])
+	pkts, err := r.api.mediaEngine.unmarshalRTCP(b)
 
 	return pkts, attributes, err
 }
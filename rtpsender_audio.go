@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/pion/webrtc/v4/internal/util"
+)
+
+// audioSenderStream sits between the track of an audio RTPSender and the
+// interceptors. It inserts packets that aren't produced by the track, like RFC
+// 4733 telephone-events and RFC 3389 comfort noise, into the outgoing stream and
+// rewrites the sequence numbers of the audio packets so all of them share one
+// contiguous sequence space. Audio packets are dropped while a tone plays.
+type audioSenderStream struct {
+	writer TrackLocalWriter
+
+	mu sync.Mutex
+
+	// seqOffset is added to the sequence number of every audio packet.
+	seqOffset uint16
+
+	// lastSeq is the last sent sequence number, lastTimestamp and lastWrite
+	// describe the last sent audio packet and are used to timestamp inserted packets.
+	hasSent       bool
+	lastSeq       uint16
+	lastTimestamp uint32
+	lastWrite     time.Time
+
+	// resync is set when packets were inserted before any audio, the offset is
+	// computed from the first audio packet.
+	resync bool
+
+	// ssrc and clockRate are the ones of the audio stream.
+	ssrc      SSRC
+	clockRate uint32
+
+	closed    chan struct{}
+	closeOnce sync.Once
+
+	// DTMF, see rtpsender_dtmf.go.
+	playing         bool
+	toneBuffer      string
+	duration        time.Duration
+	interToneGap    time.Duration
+	dtmfPayloadType PayloadType
+	dtmfRunning     bool
+	onToneChange    func(tone string)
+
+	// Comfort noise, see rtpsender_cn.go.
+	cnStop chan struct{}
+}
+
+func newAudioSenderStream() *audioSenderStream {
+	return &audioSenderStream{
+		closed: make(chan struct{}),
+	}
+}
+
+func (s *audioSenderStream) setWriter(writer TrackLocalWriter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.writer = writer
+}
+
+// WriteRTP is called for every audio packet of the track.
+func (s *audioSenderStream) WriteRTP(header *rtp.Header, payload []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.playing {
+		s.seqOffset--
+
+		return 0, nil
+	}
+
+	if s.resync {
+		s.resync = false
+		s.seqOffset = s.lastSeq + 1 - header.SequenceNumber
+	}
+
+	seq := header.SequenceNumber
+	header.SequenceNumber += s.seqOffset
+	defer func() { header.SequenceNumber = seq }()
+
+	s.hasSent = true
+	s.lastSeq = header.SequenceNumber
+	s.lastTimestamp = header.Timestamp
+	s.lastWrite = time.Now()
+
+	return s.writer.WriteRTP(header, payload)
+}
+
+// Write is called for every marshaled audio packet of the track.
+func (s *audioSenderStream) Write(b []byte) (int, error) {
+	packet := &rtp.Packet{}
+	if err := packet.Unmarshal(b); err != nil {
+		return 0, err
+	}
+
+	return s.WriteRTP(&packet.Header, packet.Payload)
+}
+
+func (s *audioSenderStream) close() {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+	})
+}
+
+// sleep waits for duration and returns false if the stream was closed meanwhile.
+func (s *audioSenderStream) sleep(duration time.Duration) bool {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-s.closed:
+		return false
+	}
+}
+
+// nextTimestamp returns the RTP timestamp for an inserted packet, extrapolated
+// from the last audio packet. s.mu must be held.
+func (s *audioSenderStream) nextTimestamp() uint32 {
+	if !s.hasSent {
+		return util.RandUint32()
+	}
+
+	elapsed := time.Since(s.lastWrite)
+
+	return s.lastTimestamp + uint32(elapsed.Seconds()*float64(s.clockRate))
+}
+
+// writeInserted sends a packet that wasn't produced by the track count times
+// with the same sequence number.
+func (s *audioSenderStream) writeInserted(header *rtp.Header, payload []byte, count int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.hasSent {
+		s.hasSent = true
+		s.resync = true
+		s.lastSeq = uint16(util.RandUint32()) //nolint:gosec // G115
+		s.lastTimestamp = header.Timestamp
+		s.lastWrite = time.Now()
+	} else {
+		s.lastSeq++
+		s.seqOffset++
+	}
+	header.SequenceNumber = s.lastSeq
+
+	for range count {
+		// Errors are not actionable here, the same as for a dropped packet.
+		_, _ = s.writer.WriteRTP(header, payload)
+	}
+}
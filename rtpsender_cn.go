@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pion/rtp"
+)
+
+const (
+	comfortNoiseDefaultSilenceThreshold = 60 * time.Millisecond
+	comfortNoiseDefaultUpdateInterval   = 200 * time.Millisecond
+	comfortNoiseCheckInterval           = 20 * time.Millisecond
+)
+
+// ComfortNoiseOptions configures the RFC 3389 comfort noise an audio RTPSender
+// sends while its track is silent.
+type ComfortNoiseOptions struct {
+	// Noise is the comfort noise that is sent, its Timestamp is ignored.
+	Noise ComfortNoise
+
+	// SilenceThreshold is how long no audio has to be written before the track is
+	// considered silent, e.g. because the encoder uses DTX. Defaults to 60ms.
+	SilenceThreshold time.Duration
+
+	// UpdateInterval is how often comfort noise is repeated while the track stays
+	// silent. Defaults to 200ms.
+	UpdateInterval time.Duration
+}
+
+// startComfortNoise replaces the comfort noise configuration, nil stops sending it.
+func (s *audioSenderStream) startComfortNoise(
+	options *ComfortNoiseOptions, payloadType PayloadType, clockRate uint32, ssrc SSRC,
+) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cnStop != nil {
+		close(s.cnStop)
+		s.cnStop = nil
+	}
+
+	if options == nil {
+		return
+	}
+
+	opts := *options
+	if opts.SilenceThreshold <= 0 {
+		opts.SilenceThreshold = comfortNoiseDefaultSilenceThreshold
+	}
+	if opts.UpdateInterval <= 0 {
+		opts.UpdateInterval = comfortNoiseDefaultUpdateInterval
+	}
+
+	s.clockRate = clockRate
+	s.ssrc = ssrc
+	s.cnStop = make(chan struct{})
+	go s.runComfortNoise(opts, payloadType, s.cnStop)
+}
+
+func (s *audioSenderStream) runComfortNoise(options ComfortNoiseOptions, payloadType PayloadType, stop chan struct{}) {
+	ticker := time.NewTicker(comfortNoiseCheckInterval)
+	defer ticker.Stop()
+
+	payload := options.Noise.marshal()
+	var lastSent time.Time
+	for {
+		select {
+		case <-stop:
+			return
+		case <-s.closed:
+			return
+		case now := <-ticker.C:
+			s.mu.Lock()
+			silent := s.hasSent && !s.playing && now.Sub(s.lastWrite) >= options.SilenceThreshold
+			due := lastSent.Before(s.lastWrite) || now.Sub(lastSent) >= options.UpdateInterval
+			header := rtp.Header{
+				Version:     2,
+				PayloadType: uint8(payloadType),
+				SSRC:        uint32(s.ssrc),
+				Timestamp:   s.nextTimestamp(),
+			}
+			s.mu.Unlock()
+
+			if silent && due {
+				s.writeInserted(&header, payload, 1)
+				lastSent = now
+			}
+		}
+	}
+}
+
+// SetComfortNoise enables sending RFC 3389 comfort noise while the track of the
+// RTPSender is silent, which SIP endpoints that don't support DTX rely on to
+// avoid dead air. nil disables comfort noise. The CN codec has to be registered
+// with the MediaEngine with the clock rate of the audio codec and negotiated
+// with the remote peer, SetComfortNoise must be called after Send.
+func (r *RTPSender) SetComfortNoise(options *ComfortNoiseOptions) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	switch {
+	case r.hasStopped():
+		return errRTPSenderStopped
+	case !r.hasSent():
+		return errRTPSenderSendNotCalled
+	case r.audioStream == nil:
+		return errRTPSenderCNNotAudio
+	}
+
+	if options == nil {
+		r.audioStream.startComfortNoise(nil, 0, 0, 0)
+
+		return nil
+	}
+
+	payloadType, clockRate, ok := r.negotiatedAudioCodec(MimeTypeCN)
+	if !ok {
+		return errRTPSenderCNNotNegotiated
+	}
+
+	r.audioStream.startComfortNoise(options, payloadType, clockRate, r.trackEncodings[0].ssrc)
+
+	return nil
+}
+
+// negotiatedAudioCodec finds the negotiated codec with mimeType and the clock
+// rate of the codec the track was bound with. r.mu must be held.
+func (r *RTPSender) negotiatedAudioCodec(mimeType string) (PayloadType, uint32, bool) {
+	context := r.trackEncodings[0].context
+	if context == nil || len(context.params.Codecs) == 0 {
+		return 0, 0, false
+	}
+	clockRate := context.params.Codecs[0].ClockRate
+
+	for _, codec := range r.api.mediaEngine.getCodecsByKind(RTPCodecTypeAudio) {
+		if strings.EqualFold(codec.MimeType, mimeType) && codec.ClockRate == clockRate {
+			return codec.PayloadType, clockRate, true
+		}
+	}
+
+	return 0, 0, false
+}
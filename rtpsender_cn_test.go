@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/pion/transport/v4/test"
+	"github.com/pion/webrtc/v4/pkg/media"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_audioSenderStream_ComfortNoise(t *testing.T) {
+	lim := test.TimeOut(time.Second * 10)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	writer := &recordingTrackLocalWriter{}
+	stream := newAudioSenderStream()
+	stream.setWriter(writer)
+	defer stream.close()
+
+	_, err := stream.WriteRTP(&rtp.Header{SequenceNumber: 100, Timestamp: 8000}, []byte{0x00})
+	require.NoError(t, err)
+
+	stream.startComfortNoise(&ComfortNoiseOptions{
+		Noise:            ComfortNoise{Level: 70},
+		SilenceThreshold: 20 * time.Millisecond,
+		UpdateInterval:   time.Hour,
+	}, 13, 8000, 5000)
+
+	assert.Eventually(t, func() bool {
+		writer.mu.Lock()
+		defer writer.mu.Unlock()
+
+		return len(writer.packets) == 2
+	}, time.Second, 5*time.Millisecond)
+
+	// Audio resumes, the next silence sends comfort noise again.
+	_, err = stream.WriteRTP(&rtp.Header{SequenceNumber: 101, Timestamp: 9000}, []byte{0x00})
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		writer.mu.Lock()
+		defer writer.mu.Unlock()
+
+		return len(writer.packets) == 4
+	}, time.Second, 5*time.Millisecond)
+
+	stream.startComfortNoise(nil, 0, 0, 0)
+
+	writer.mu.Lock()
+	defer writer.mu.Unlock()
+
+	expected := []struct {
+		seq         uint16
+		payloadType uint8
+	}{{100, 0}, {101, 13}, {102, 0}, {103, 13}}
+	for i, packet := range writer.packets {
+		assert.Equal(t, expected[i].seq, packet.SequenceNumber)
+		assert.Equal(t, expected[i].payloadType, packet.PayloadType)
+	}
+
+	cn := writer.packets[1]
+	assert.Equal(t, []byte{70}, cn.Payload)
+	assert.Equal(t, uint32(5000), cn.SSRC)
+	assert.GreaterOrEqual(t, cn.Timestamp, uint32(8000+160))
+}
+
+func Test_RTPSender_SetComfortNoise(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	mediaEngine := &MediaEngine{}
+	require.NoError(t, mediaEngine.RegisterCodec(RTPCodecParameters{
+		RTPCodecCapability: RTPCodecCapability{MimeType: MimeTypePCMU, ClockRate: 8000},
+		PayloadType:        0,
+	}, RTPCodecTypeAudio))
+	require.NoError(t, mediaEngine.RegisterCodec(RTPCodecParameters{
+		RTPCodecCapability: RTPCodecCapability{MimeType: MimeTypeCN, ClockRate: 8000},
+		PayloadType:        13,
+	}, RTPCodecTypeAudio))
+
+	pcOffer, pcAnswer, err := NewAPI(WithMediaEngine(mediaEngine)).newPair(Configuration{})
+	require.NoError(t, err)
+
+	track, err := NewTrackLocalStaticSample(RTPCodecCapability{MimeType: MimeTypePCMU}, "audio", "pion")
+	require.NoError(t, err)
+
+	sender, err := pcOffer.AddTrack(track)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, sender.SetComfortNoise(&ComfortNoiseOptions{}), errRTPSenderSendNotCalled)
+
+	noise := make(chan ComfortNoise, 1)
+	pcAnswer.OnTrack(func(track *TrackRemote, receiver *RTPReceiver) {
+		receiver.OnComfortNoise(func(n ComfortNoise) {
+			select {
+			case noise <- n:
+			default:
+			}
+		})
+
+		for {
+			if _, _, readErr := track.ReadRTP(); readErr != nil {
+				return
+			}
+			assert.Equal(t, MimeTypePCMU, track.Codec().MimeType)
+		}
+	})
+
+	require.NoError(t, signalPair(pcOffer, pcAnswer))
+	require.NoError(t, sender.SetComfortNoise(&ComfortNoiseOptions{Noise: ComfortNoise{Level: 80}}))
+
+	// A short burst of audio followed by silence.
+	for range 5 {
+		require.NoError(t, track.WriteSample(media.Sample{Data: []byte{0xff}, Duration: 20 * time.Millisecond}))
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	assert.Equal(t, uint8(80), (<-noise).Level)
+
+	require.NoError(t, sender.SetComfortNoise(nil))
+	closePairNow(t, pcOffer, pcAnswer)
+	assert.ErrorIs(t, sender.SetComfortNoise(nil), errRTPSenderStopped)
+}
+
+func Test_RTPSender_SetComfortNoise_NotNegotiated(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	pcOffer, pcAnswer, err := newPair()
+	require.NoError(t, err)
+
+	audioTrack, err := NewTrackLocalStaticSample(RTPCodecCapability{MimeType: MimeTypeOpus}, "audio", "pion")
+	require.NoError(t, err)
+	audioSender, err := pcOffer.AddTrack(audioTrack)
+	require.NoError(t, err)
+
+	videoTrack, err := NewTrackLocalStaticSample(RTPCodecCapability{MimeType: MimeTypeVP8}, "video", "pion")
+	require.NoError(t, err)
+	videoSender, err := pcOffer.AddTrack(videoTrack)
+	require.NoError(t, err)
+
+	require.NoError(t, signalPair(pcOffer, pcAnswer))
+
+	assert.ErrorIs(t, audioSender.SetComfortNoise(&ComfortNoiseOptions{}), errRTPSenderCNNotNegotiated)
+	assert.ErrorIs(t, videoSender.SetComfortNoise(&ComfortNoiseOptions{}), errRTPSenderCNNotAudio)
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
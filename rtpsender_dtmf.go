@@ -0,0 +1,259 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"time"
+
+	"github.com/pion/rtp"
+)
+
+// Limits and defaults of the W3C RTCDTMFSender.
+const (
+	dtmfDefaultDuration     = 100 * time.Millisecond
+	dtmfMinDuration         = 40 * time.Millisecond
+	dtmfMaxDuration         = 6000 * time.Millisecond
+	dtmfDefaultInterToneGap = 70 * time.Millisecond
+	dtmfMinInterToneGap     = 30 * time.Millisecond
+	dtmfPauseDuration       = 2 * time.Second
+)
+
+const (
+	// dtmfPacketInterval is how often an event packet is sent while a tone plays.
+	dtmfPacketInterval = 50 * time.Millisecond
+
+	// dtmfEndPacketCount is how often the final packet of an event is sent, see RFC 4733 Section 2.5.1.4.
+	dtmfEndPacketCount = 3
+
+	// dtmfVolume is the power level of sent tones in -dBm0.
+	dtmfVolume = 10
+
+	dtmfMaxEventDuration = 0xFFFF
+)
+
+func (s *audioSenderStream) insertDTMF(
+	tones string, duration, interToneGap time.Duration, payloadType PayloadType, clockRate uint32, ssrc SSRC,
+) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.toneBuffer = tones
+	s.duration = duration
+	s.interToneGap = interToneGap
+	s.dtmfPayloadType = payloadType
+	s.clockRate = clockRate
+	s.ssrc = ssrc
+
+	if !s.dtmfRunning && tones != "" {
+		s.dtmfRunning = true
+		go s.runDTMF()
+	}
+}
+
+func (s *audioSenderStream) getToneBuffer() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.toneBuffer
+}
+
+func (s *audioSenderStream) setOnToneChange(f func(tone string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.onToneChange = f
+}
+
+// runDTMF plays the tone buffer until it is empty.
+func (s *audioSenderStream) runDTMF() {
+	for {
+		s.mu.Lock()
+		onToneChange := s.onToneChange
+		if s.toneBuffer == "" {
+			s.dtmfRunning = false
+			s.mu.Unlock()
+			if onToneChange != nil {
+				onToneChange("")
+			}
+
+			return
+		}
+
+		tone := s.toneBuffer[0]
+		s.toneBuffer = s.toneBuffer[1:]
+		duration, interToneGap := s.duration, s.interToneGap
+		s.mu.Unlock()
+
+		if onToneChange != nil {
+			onToneChange(string(tone))
+		}
+
+		if tone == dtmfPauseTone {
+			if !s.sleep(dtmfPauseDuration) {
+				return
+			}
+		} else if event, ok := dtmfToneToEvent(tone); ok {
+			if !s.playTone(event, duration) {
+				return
+			}
+		}
+
+		if !s.sleep(interToneGap) {
+			return
+		}
+	}
+}
+
+// playTone sends the packets of a single event, it returns false if the sender
+// was closed before the end of the tone.
+func (s *audioSenderStream) playTone(event uint8, duration time.Duration) bool {
+	s.mu.Lock()
+	s.playing = true
+	clockRate, payloadType, ssrc := s.clockRate, s.dtmfPayloadType, s.ssrc
+	timestamp := s.nextTimestamp()
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		s.playing = false
+		s.mu.Unlock()
+	}()
+
+	toTicks := func(duration time.Duration) uint32 {
+		return uint32(duration.Seconds() * float64(clockRate))
+	}
+
+	header := rtp.Header{
+		Version:     2,
+		Marker:      true,
+		PayloadType: uint8(payloadType),
+		SSRC:        uint32(ssrc),
+		Timestamp:   timestamp,
+	}
+
+	// Events longer than the duration field can hold are sent as segments with
+	// their own timestamp, see RFC 4733 Section 2.5.2.3.
+	var segmentStart uint32
+	for elapsed := dtmfPacketInterval; ; elapsed += dtmfPacketInterval {
+		end := elapsed >= duration
+		ticks := toTicks(min(elapsed, duration))
+		for ticks-segmentStart > dtmfMaxEventDuration {
+			segmentStart += dtmfMaxEventDuration
+			header.Timestamp = timestamp + segmentStart
+		}
+
+		packet := dtmfEvent{
+			event:    event,
+			end:      end,
+			volume:   dtmfVolume,
+			duration: uint16(ticks - segmentStart), //nolint:gosec // G115
+		}
+
+		payload := make([]byte, dtmfEventPayloadSize)
+		packet.marshalTo(payload)
+
+		count := 1
+		if end {
+			count = dtmfEndPacketCount
+		}
+		s.writeInserted(&header, payload, count)
+		header.Marker = false
+
+		if end {
+			return true
+		}
+
+		if !s.sleep(dtmfPacketInterval) {
+			return false
+		}
+	}
+}
+
+// InsertDTMF queues tones to be sent as RFC 4733 telephone-events on the audio
+// stream of the RTPSender. tones may contain 0-9, A-D, # and *, while a ','
+// pauses for two seconds. duration is how long each tone plays, between 40ms and
+// 6s, and interToneGap the silence between tones, at least 30ms. Zero selects
+// 100ms and 70ms respectively, out of range values are clamped.
+//
+// Calling InsertDTMF replaces the tones that haven't been played yet, an empty
+// string stops after the current tone. The telephone-event codec has to be
+// registered with the MediaEngine with the clock rate of the audio codec and
+// negotiated with the remote peer, see CanInsertDTMF.
+func (r *RTPSender) InsertDTMF(tones string, duration, interToneGap time.Duration) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	switch {
+	case r.hasStopped():
+		return errRTPSenderStopped
+	case !r.hasSent():
+		return errRTPSenderSendNotCalled
+	case r.audioStream == nil:
+		return errRTPSenderDTMFNotAudio
+	}
+
+	payloadType, clockRate, ok := r.negotiatedAudioCodec(MimeTypeTelephoneEvent)
+	if !ok {
+		return errRTPSenderDTMFNotNegotiated
+	}
+
+	tones, ok = normalizeDTMFTones(tones)
+	if !ok {
+		return errRTPSenderDTMFInvalidTone
+	}
+
+	if duration == 0 {
+		duration = dtmfDefaultDuration
+	}
+	duration = min(max(duration, dtmfMinDuration), dtmfMaxDuration)
+
+	if interToneGap == 0 {
+		interToneGap = dtmfDefaultInterToneGap
+	}
+	interToneGap = max(interToneGap, dtmfMinInterToneGap)
+
+	r.audioStream.insertDTMF(tones, duration, interToneGap, payloadType, clockRate, r.trackEncodings[0].ssrc)
+
+	return nil
+}
+
+// CanInsertDTMF returns true if the RTPSender is sending audio and the
+// telephone-event codec was negotiated.
+func (r *RTPSender) CanInsertDTMF() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.audioStream == nil || r.hasStopped() {
+		return false
+	}
+
+	_, _, ok := r.negotiatedAudioCodec(MimeTypeTelephoneEvent)
+
+	return ok
+}
+
+// ToneBuffer returns the tones that remain to be played.
+func (r *RTPSender) ToneBuffer() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.audioStream == nil {
+		return ""
+	}
+
+	return r.audioStream.getToneBuffer()
+}
+
+// OnToneChange sets an event handler which is invoked when a tone starts playing.
+// It is invoked with an empty string once the tone buffer is played completely.
+func (r *RTPSender) OnToneChange(f func(tone string)) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.audioStream != nil {
+		r.audioStream.setOnToneChange(f)
+	}
+}
@@ -0,0 +1,247 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/pion/transport/v4/test"
+	"github.com/pion/webrtc/v4/pkg/media"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingTrackLocalWriter struct {
+	mu      sync.Mutex
+	packets []rtp.Packet
+}
+
+func (w *recordingTrackLocalWriter) WriteRTP(header *rtp.Header, payload []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.packets = append(w.packets, rtp.Packet{Header: *header, Payload: append([]byte{}, payload...)})
+
+	return len(payload), nil
+}
+
+func (w *recordingTrackLocalWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+func Test_audioSenderStream_DTMF(t *testing.T) {
+	lim := test.TimeOut(time.Second * 10)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	writer := &recordingTrackLocalWriter{}
+	sender := newAudioSenderStream()
+	sender.setWriter(writer)
+	defer sender.close()
+
+	var tones []string
+	done := make(chan struct{})
+	sender.setOnToneChange(func(tone string) {
+		tones = append(tones, tone)
+		if tone == "" {
+			close(done)
+		}
+	})
+
+	for seq := uint16(10); seq < 12; seq++ {
+		_, err := sender.WriteRTP(&rtp.Header{SequenceNumber: seq, Timestamp: 960 * uint32(seq)}, []byte{0x00})
+		require.NoError(t, err)
+	}
+
+	sender.insertDTMF("1", 100*time.Millisecond, dtmfMinInterToneGap, 101, 48000, 5000)
+	<-done
+	assert.Equal(t, "", sender.getToneBuffer())
+
+	header := &rtp.Header{SequenceNumber: 12, Timestamp: 960 * 12}
+	_, err := sender.WriteRTP(header, []byte{0x00})
+	require.NoError(t, err)
+	assert.Equal(t, uint16(12), header.SequenceNumber, "the track's header must not be modified")
+
+	assert.Equal(t, []string{"1", ""}, tones)
+
+	writer.mu.Lock()
+	defer writer.mu.Unlock()
+
+	require.Len(t, writer.packets, 7)
+
+	expectedSequence := []uint16{10, 11, 12, 13, 13, 13, 14}
+	for i, packet := range writer.packets {
+		assert.Equal(t, expectedSequence[i], packet.SequenceNumber)
+	}
+
+	events := writer.packets[2:6]
+	for i, packet := range events {
+		assert.Equal(t, uint8(101), packet.PayloadType)
+		assert.Equal(t, uint32(5000), packet.SSRC)
+		assert.Equal(t, i == 0, packet.Marker)
+		assert.Equal(t, events[0].Timestamp, packet.Timestamp)
+
+		var event dtmfEvent
+		require.NoError(t, event.unmarshal(packet.Payload))
+		assert.Equal(t, uint8(1), event.event)
+		assert.Equal(t, uint8(dtmfVolume), event.volume)
+		assert.Equal(t, i != 0, event.end)
+		if i == 0 {
+			assert.Equal(t, uint16(2400), event.duration)
+		} else {
+			assert.Equal(t, uint16(4800), event.duration)
+		}
+	}
+	assert.GreaterOrEqual(t, events[0].Timestamp, uint32(960*11))
+}
+
+func Test_audioSenderStream_DTMFBeforeAudio(t *testing.T) {
+	lim := test.TimeOut(time.Second * 10)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	writer := &recordingTrackLocalWriter{}
+	sender := newAudioSenderStream()
+	sender.setWriter(writer)
+	defer sender.close()
+
+	done := make(chan struct{})
+	sender.setOnToneChange(func(tone string) {
+		if tone == "" {
+			close(done)
+		}
+	})
+
+	sender.insertDTMF("#", dtmfMinDuration, dtmfMinInterToneGap, 101, 8000, 5000)
+	<-done
+
+	_, err := sender.WriteRTP(&rtp.Header{SequenceNumber: 500}, []byte{0x00})
+	require.NoError(t, err)
+
+	writer.mu.Lock()
+	defer writer.mu.Unlock()
+
+	require.Len(t, writer.packets, 4)
+	assert.Equal(t, writer.packets[0].SequenceNumber+1, writer.packets[3].SequenceNumber)
+}
+
+func Test_RTPSender_InsertDTMF(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	mediaEngine := &MediaEngine{}
+	require.NoError(t, mediaEngine.RegisterCodec(RTPCodecParameters{
+		RTPCodecCapability: RTPCodecCapability{MimeType: MimeTypeOpus, ClockRate: 48000, Channels: 2},
+		PayloadType:        111,
+	}, RTPCodecTypeAudio))
+	require.NoError(t, mediaEngine.RegisterCodec(RTPCodecParameters{
+		RTPCodecCapability: RTPCodecCapability{MimeType: MimeTypeTelephoneEvent, ClockRate: 48000, SDPFmtpLine: "0-16"},
+		PayloadType:        101,
+	}, RTPCodecTypeAudio))
+
+	pcOffer, pcAnswer, err := NewAPI(WithMediaEngine(mediaEngine)).newPair(Configuration{})
+	require.NoError(t, err)
+
+	track, err := NewTrackLocalStaticSample(RTPCodecCapability{MimeType: MimeTypeOpus}, "audio", "pion")
+	require.NoError(t, err)
+
+	sender, err := pcOffer.AddTrack(track)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, sender.InsertDTMF("1", 0, 0), errRTPSenderSendNotCalled)
+
+	seenDTMF, seenDTMFCancel := context.WithCancel(context.Background())
+	pcAnswer.OnTrack(func(track *TrackRemote, _ *RTPReceiver) {
+		for {
+			packet, _, readErr := track.ReadRTP()
+			if readErr != nil {
+				return
+			}
+
+			if packet.PayloadType == 101 {
+				seenDTMFCancel()
+			}
+		}
+	})
+
+	require.NoError(t, signalPair(pcOffer, pcAnswer))
+
+	writeCtx, writeCancel := context.WithCancel(context.Background())
+	writeDone := make(chan struct{})
+	go func() {
+		defer close(writeDone)
+		for {
+			select {
+			case <-writeCtx.Done():
+				return
+			case <-time.After(20 * time.Millisecond):
+				assert.NoError(t, track.WriteSample(media.Sample{Data: []byte{0x00}, Duration: 20 * time.Millisecond}))
+			}
+		}
+	}()
+
+	assert.True(t, sender.CanInsertDTMF())
+	assert.ErrorIs(t, sender.InsertDTMF("1x", 0, 0), errRTPSenderDTMFInvalidTone)
+
+	tones := make(chan string, 3)
+	sender.OnToneChange(func(tone string) {
+		tones <- tone
+	})
+	require.NoError(t, sender.InsertDTMF("1a", time.Millisecond, time.Millisecond))
+	assert.Equal(t, "1", <-tones)
+	assert.Equal(t, "A", <-tones)
+	assert.Equal(t, "", <-tones)
+	assert.Equal(t, "", sender.ToneBuffer())
+
+	<-seenDTMF.Done()
+	writeCancel()
+	<-writeDone
+
+	closePairNow(t, pcOffer, pcAnswer)
+
+	assert.ErrorIs(t, sender.InsertDTMF("1", 0, 0), errRTPSenderStopped)
+	assert.False(t, sender.CanInsertDTMF())
+}
+
+func Test_RTPSender_InsertDTMF_NotNegotiated(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	pcOffer, pcAnswer, err := newPair()
+	require.NoError(t, err)
+
+	audioTrack, err := NewTrackLocalStaticSample(RTPCodecCapability{MimeType: MimeTypeOpus}, "audio", "pion")
+	require.NoError(t, err)
+	audioSender, err := pcOffer.AddTrack(audioTrack)
+	require.NoError(t, err)
+
+	videoTrack, err := NewTrackLocalStaticSample(RTPCodecCapability{MimeType: MimeTypeVP8}, "video", "pion")
+	require.NoError(t, err)
+	videoSender, err := pcOffer.AddTrack(videoTrack)
+	require.NoError(t, err)
+
+	require.NoError(t, signalPair(pcOffer, pcAnswer))
+
+	assert.False(t, audioSender.CanInsertDTMF())
+	assert.ErrorIs(t, audioSender.InsertDTMF("1", 0, 0), errRTPSenderDTMFNotNegotiated)
+	assert.ErrorIs(t, videoSender.InsertDTMF("1", 0, 0), errRTPSenderDTMFNotAudio)
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
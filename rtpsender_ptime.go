@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"time"
+
+	"github.com/pion/sdp/v3"
+)
+
+// packetizationTimes are the ptime and maxptime the remote peer signaled for a
+// media section. Zero values weren't signaled.
+type packetizationTimes struct {
+	ptime, maxPtime time.Duration
+}
+
+func (t *RTPTransceiver) setPacketizationTimes(times packetizationTimes) {
+	t.packetizationTimes.Store(times)
+}
+
+func (t *RTPTransceiver) getPacketizationTimes() packetizationTimes {
+	if times, ok := t.packetizationTimes.Load().(packetizationTimes); ok {
+		return times
+	}
+
+	return packetizationTimes{}
+}
+
+// setRTPTransceiverPacketizationTimes stores the ptime and maxptime of every
+// media section of the remote description on the transceiver with its mid, so
+// each audio track is packetized as its m-section asks for.
+func setRTPTransceiverPacketizationTimes(desc *sdp.SessionDescription, transceivers []*RTPTransceiver) {
+	transceivers = append([]*RTPTransceiver{}, transceivers...)
+	for _, media := range desc.MediaDescriptions {
+		midValue := getMidValue(media)
+		if midValue == "" || media.MediaName.Media == mediaSectionApplication {
+			continue
+		}
+
+		var transceiver *RTPTransceiver
+		if transceiver, transceivers = findByMid(midValue, transceivers); transceiver != nil {
+			transceiver.setPacketizationTimes(getPacketizationTimes(media))
+		}
+	}
+}
+
+// Ptime returns the packetization time the remote peer asked for in the media
+// section of the sender, the duration of media in each packet. It is zero if
+// the remote peer didn't signal one.
+func (r *RTPSender) Ptime() time.Duration {
+	ptime, _ := r.packetizationTimes()
+
+	return ptime
+}
+
+// MaxPtime returns the longest duration of media the remote peer accepts in a
+// packet of the sender. It is zero if the remote peer didn't signal one.
+// TrackLocalStaticSample rejects samples that are longer.
+func (r *RTPSender) MaxPtime() time.Duration {
+	_, maxPtime := r.packetizationTimes()
+
+	return maxPtime
+}
+
+func (r *RTPSender) packetizationTimes() (ptime, maxPtime time.Duration) {
+	r.mu.RLock()
+	transceiver := r.rtpTransceiver
+	r.mu.RUnlock()
+	if transceiver == nil {
+		return 0, 0
+	}
+
+	times := transceiver.getPacketizationTimes()
+
+	return times.ptime, times.maxPtime
+}
+
+// Ptime returns the packetization time of the RTPSender, see RTPSender.Ptime.
+func (t *baseTrackLocalContext) Ptime() time.Duration {
+	if t.packetizationTimes == nil {
+		return 0
+	}
+	ptime, _ := t.packetizationTimes()
+
+	return ptime
+}
+
+// MaxPtime returns the maximum packetization time of the RTPSender, see
+// RTPSender.MaxPtime.
+func (t *baseTrackLocalContext) MaxPtime() time.Duration {
+	if t.packetizationTimes == nil {
+		return 0
+	}
+	_, maxPtime := t.packetizationTimes()
+
+	return maxPtime
+}
+
+// maxPtimeContext is implemented by the TrackLocalContexts of RTPSenders, the
+// maximum packetization time can change with every negotiation.
+type maxPtimeContext interface {
+	MaxPtime() time.Duration
+}
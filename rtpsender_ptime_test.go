@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/pion/webrtc/v4/pkg/media"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRTPSender_PacketizationTimes(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	pcOffer, pcAnswer, err := newPair()
+	require.NoError(t, err)
+
+	for range 2 {
+		_, err = pcOffer.AddTransceiverFromKind(RTPCodecTypeAudio, RTPTransceiverInit{
+			Direction: RTPTransceiverDirectionRecvonly,
+		})
+		require.NoError(t, err)
+	}
+
+	offer, err := pcOffer.CreateOffer(nil)
+	require.NoError(t, err)
+	offerGatheringComplete := GatheringCompletePromise(pcOffer)
+	require.NoError(t, pcOffer.SetLocalDescription(offer))
+	<-offerGatheringComplete
+
+	// Each audio m-section asks for different packetization times.
+	offer = *pcOffer.LocalDescription()
+	offer.SDP = strings.Replace(offer.SDP, "a=mid:0\r\n", "a=mid:0\r\na=ptime:20\r\na=maxptime:40\r\n", 1)
+	offer.SDP = strings.Replace(offer.SDP, "a=mid:1\r\n", "a=mid:1\r\na=ptime:60\r\na=maxptime:120\r\n", 1)
+	require.NoError(t, pcAnswer.SetRemoteDescription(offer))
+
+	trackShort, err := NewTrackLocalStaticSample(RTPCodecCapability{MimeType: MimeTypeOpus}, "short", "pion")
+	require.NoError(t, err)
+	senderShort, err := pcAnswer.AddTrack(trackShort)
+	require.NoError(t, err)
+
+	trackLong, err := NewTrackLocalStaticSample(RTPCodecCapability{MimeType: MimeTypeOpus}, "long", "pion")
+	require.NoError(t, err)
+	senderLong, err := pcAnswer.AddTrack(trackLong)
+	require.NoError(t, err)
+
+	answer, err := pcAnswer.CreateAnswer(nil)
+	require.NoError(t, err)
+	answerGatheringComplete := GatheringCompletePromise(pcAnswer)
+	require.NoError(t, pcAnswer.SetLocalDescription(answer))
+	<-answerGatheringComplete
+	require.NoError(t, pcOffer.SetRemoteDescription(*pcAnswer.LocalDescription()))
+
+	assert.Equal(t, 20*time.Millisecond, senderShort.Ptime())
+	assert.Equal(t, 40*time.Millisecond, senderShort.MaxPtime())
+	assert.Equal(t, 60*time.Millisecond, senderLong.Ptime())
+	assert.Equal(t, 120*time.Millisecond, senderLong.MaxPtime())
+
+	sample := media.Sample{Data: []byte{0x00}, Duration: 60 * time.Millisecond}
+	assert.ErrorIs(t, trackShort.WriteSample(sample), ErrSampleExceedsMaxPtime)
+	assert.NoError(t, trackLong.WriteSample(sample))
+
+	sample.Duration = 20 * time.Millisecond
+	assert.NoError(t, trackShort.WriteSample(sample))
+
+	// The offerer didn't receive packetization times from the answer.
+	for _, transceiver := range pcOffer.GetTransceivers() {
+		assert.Zero(t, transceiver.getPacketizationTimes())
+	}
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
@@ -24,9 +24,17 @@ type RTPTransceiver struct {
 	direction              atomic.Value // RTPTransceiverDirection
 	currentDirection       atomic.Value // RTPTransceiverDirection
 	currentRemoteDirection atomic.Value // RTPTransceiverDirection
+	packetizationTimes     atomic.Value // packetizationTimes
 
 	codecs []RTPCodecParameters // User provided codecs via SetCodecPreferences
 
+	disabledRTCPFeedback map[string]struct{} // via SetRTCPFeedback
+
+	// poolPlaceholder is the track a sendonly transceiver of a pool sends while
+	// no track is bound to it, see PeerConnection.AddTransceiverPool.
+	poolPlaceholder TrackLocal
+	poolBound       bool
+
 	kind RTPCodecType
 
 	api *API
@@ -75,7 +83,7 @@ func (t *RTPTransceiver) getCodecs() []RTPCodecParameters {
 
 	mediaEngineCodecs := t.api.mediaEngine.getCodecsByKind(t.kind)
 	if len(t.codecs) == 0 {
-		return filterUnattachedRTX(mediaEngineCodecs)
+		return t.filterRTCPFeedback(filterUnattachedRTX(mediaEngineCodecs))
 	}
 
 	filteredCodecs := []RTPCodecParameters{}
@@ -89,7 +97,7 @@ func (t *RTPTransceiver) getCodecs() []RTPCodecParameters {
 		}
 	}
 
-	return filterUnattachedRTX(filteredCodecs)
+	return t.filterRTCPFeedback(filterUnattachedRTX(filteredCodecs))
 }
 
 // match codecs from remote description, used when remote is offerer and creating a transceiver
@@ -173,6 +181,9 @@ func (t *RTPTransceiver) setCodecPreferencesFromRemoteDescription(media *sdp.Med
 
 		for _, rtxCodec := range leftCodecs {
 			if rtxCodec.PayloadType == mediaEngineRTX {
+				if remoteRTXCodec := findCodecByPayload(remoteCodecs, remoteRTX); remoteRTXCodec != nil {
+					rtxCodec.RTCPFeedback = rtcpFeedbackIntersection(rtxCodec.RTCPFeedback, remoteRTXCodec.RTCPFeedback)
+				}
 				filteredCodecs = append(filteredCodecs, rtxCodec)
 
 				break
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+// AddTransceiverPool adds count transceivers of the kind, so tracks can be sent
+// and received later without renegotiating. The sendonly ones send nothing
+// until BindPoolTrack binds a track to them, the recvonly ones receive the
+// tracks the remote peer sends on them. The negotiation of these transceivers
+// is started as for AddTransceiverFromKind.
+func (pc *PeerConnection) AddTransceiverPool(
+	kind RTPCodecType,
+	direction RTPTransceiverDirection,
+	count int,
+) ([]*RTPTransceiver, error) {
+	if direction != RTPTransceiverDirectionSendonly && direction != RTPTransceiverDirectionRecvonly {
+		return nil, errPeerConnTransceiverPoolDirection
+	}
+
+	transceivers := make([]*RTPTransceiver, 0, count)
+	for range count {
+		transceiver, err := pc.AddTransceiverFromKind(kind, RTPTransceiverInit{Direction: direction})
+		if err != nil {
+			return transceivers, err
+		}
+
+		if sender := transceiver.Sender(); sender != nil {
+			transceiver.mu.Lock()
+			transceiver.poolPlaceholder = sender.Track()
+			transceiver.mu.Unlock()
+		}
+		transceivers = append(transceivers, transceiver)
+	}
+
+	return transceivers, nil
+}
+
+// BindPoolTrack sends the track on a sendonly transceiver of a pool added by
+// AddTransceiverPool, which has to be negotiated already. Unlike AddTrack it
+// doesn't need a renegotiation, the remote peer receives the track with the ID
+// and the stream ID the transceiver was negotiated with. It fails with
+// ErrTransceiverPoolExhausted if all of them are bound.
+func (pc *PeerConnection) BindPoolTrack(track TrackLocal) (*RTPSender, error) {
+	for _, transceiver := range pc.GetTransceivers() {
+		sender := transceiver.Sender()
+		if transceiver.Kind() != track.Kind() || sender == nil || !sender.hasSent() ||
+			!transceiver.claimPoolTransceiver() {
+			continue
+		}
+
+		if err := sender.ReplaceTrack(track); err != nil {
+			transceiver.releasePoolTransceiver()
+
+			return nil, err
+		}
+
+		return sender, nil
+	}
+
+	return nil, ErrTransceiverPoolExhausted
+}
+
+// ReleasePoolTrack stops sending the track BindPoolTrack bound to the sender,
+// the transceiver can be bound to a track again.
+func (pc *PeerConnection) ReleasePoolTrack(sender *RTPSender) error {
+	for _, transceiver := range pc.GetTransceivers() {
+		if transceiver.Sender() != sender {
+			continue
+		}
+
+		transceiver.mu.RLock()
+		placeholder, bound := transceiver.poolPlaceholder, transceiver.poolBound
+		transceiver.mu.RUnlock()
+		if placeholder == nil || !bound {
+			break
+		}
+
+		if err := sender.ReplaceTrack(placeholder); err != nil {
+			return err
+		}
+		transceiver.releasePoolTransceiver()
+
+		return nil
+	}
+
+	return errPeerConnTransceiverPoolSender
+}
+
+// claimPoolTransceiver marks a transceiver of a pool as bound, it returns false
+// if the transceiver isn't part of a pool or bound already.
+func (t *RTPTransceiver) claimPoolTransceiver() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.poolPlaceholder == nil || t.poolBound {
+		return false
+	}
+	t.poolBound = true
+
+	return true
+}
+
+// isPoolPlaceholderMsid returns whether the msid is the one of the placeholder
+// of a transceiver of a pool, binding a track doesn't need a renegotiation.
+func (t *RTPTransceiver) isPoolPlaceholderMsid(msid string) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.poolPlaceholder != nil && msid == t.poolPlaceholder.StreamID()+" "+t.poolPlaceholder.ID()
+}
+
+func (t *RTPTransceiver) releasePoolTransceiver() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.poolBound = false
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/pion/webrtc/v4/pkg/media"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerConnection_TransceiverPool(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	pcOffer, pcAnswer, err := newPair()
+	require.NoError(t, err)
+
+	_, err = pcOffer.AddTransceiverPool(RTPCodecTypeVideo, RTPTransceiverDirectionSendrecv, 1)
+	assert.ErrorIs(t, err, errPeerConnTransceiverPoolDirection)
+
+	transceivers, err := pcOffer.AddTransceiverPool(RTPCodecTypeVideo, RTPTransceiverDirectionSendonly, 2)
+	require.NoError(t, err)
+	assert.Len(t, transceivers, 2)
+	_, err = pcOffer.AddTransceiverPool(RTPCodecTypeAudio, RTPTransceiverDirectionRecvonly, 1)
+	require.NoError(t, err)
+
+	track, err := NewTrackLocalStaticSample(RTPCodecCapability{MimeType: MimeTypeVP8}, "video", "pion")
+	require.NoError(t, err)
+
+	// The transceivers have to be negotiated first.
+	_, err = pcOffer.BindPoolTrack(track)
+	assert.ErrorIs(t, err, ErrTransceiverPoolExhausted)
+
+	tracksReceived := make(chan *TrackRemote, 2)
+	pcAnswer.OnTrack(func(remote *TrackRemote, _ *RTPReceiver) {
+		tracksReceived <- remote
+	})
+	require.NoError(t, signalPair(pcOffer, pcAnswer))
+
+	sender, err := pcOffer.BindPoolTrack(track)
+	require.NoError(t, err)
+	assert.Equal(t, transceivers[0].Sender(), sender)
+
+	func() {
+		ticker := time.NewTicker(20 * time.Millisecond)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-tracksReceived:
+				return
+			case <-ticker.C:
+				require.NoError(t, track.WriteSample(media.Sample{Data: []byte{0x00}, Duration: time.Second}))
+			}
+		}
+	}()
+
+	other, err := NewTrackLocalStaticSample(RTPCodecCapability{MimeType: MimeTypeVP8}, "other", "pion")
+	require.NoError(t, err)
+	otherSender, err := pcOffer.BindPoolTrack(other)
+	require.NoError(t, err)
+	assert.Equal(t, transceivers[1].Sender(), otherSender)
+
+	_, err = pcOffer.BindPoolTrack(track)
+	assert.ErrorIs(t, err, ErrTransceiverPoolExhausted)
+
+	// Tracks aren't bound to recvonly transceivers.
+	audio, err := NewTrackLocalStaticSample(RTPCodecCapability{MimeType: MimeTypeOpus}, "audio", "pion")
+	require.NoError(t, err)
+	_, err = pcOffer.BindPoolTrack(audio)
+	assert.ErrorIs(t, err, ErrTransceiverPoolExhausted)
+
+	// A released transceiver is bound again.
+	require.NoError(t, pcOffer.ReleasePoolTrack(otherSender))
+	assert.ErrorIs(t, pcOffer.ReleasePoolTrack(otherSender), errPeerConnTransceiverPoolSender)
+	require.NoError(t, pcOffer.ReleasePoolTrack(sender))
+	sender, err = pcOffer.BindPoolTrack(other)
+	require.NoError(t, err)
+	assert.Equal(t, transceivers[0].Sender(), sender)
+	assert.Equal(t, other, sender.Track())
+
+	assert.False(t, pcOffer.checkNegotiationNeeded())
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"github.com/pion/sdp/v3"
+)
+
+// SetRTCPFeedback enables or disables a type of RTCP feedback for the codecs
+// of the RTPTransceiver, like TypeRTCPFBTransportCC, TypeRTCPFBNACK or
+// TypeRTCPFBGoogREMB. All feedback of the MediaEngine is enabled by default and
+// only that can be enabled. Disabling TypeRTCPFBTransportCC also removes the
+// transport-wide congestion control header extension. Changes apply from the
+// next offer or answer on, and to the streams started after it.
+func (t *RTPTransceiver) SetRTCPFeedback(feedbackType string, enabled bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if enabled {
+		delete(t.disabledRTCPFeedback, feedbackType)
+
+		return
+	}
+
+	if t.disabledRTCPFeedback == nil {
+		t.disabledRTCPFeedback = map[string]struct{}{}
+	}
+	t.disabledRTCPFeedback[feedbackType] = struct{}{}
+}
+
+// filterRTPParameters removes the disabled feedback and its header extensions
+// from the parameters.
+func (t *RTPTransceiver) filterRTPParameters(parameters RTPParameters) RTPParameters {
+	t.mu.RLock()
+	parameters.Codecs = t.filterRTCPFeedback(parameters.Codecs)
+	t.mu.RUnlock()
+	parameters.HeaderExtensions = t.filterHeaderExtensions(parameters.HeaderExtensions)
+
+	return parameters
+}
+
+// filterRTCPFeedback removes the disabled feedback from copies of the codecs,
+// the caller should hold the lock.
+func (t *RTPTransceiver) filterRTCPFeedback(codecs []RTPCodecParameters) []RTPCodecParameters {
+	if len(t.disabledRTCPFeedback) == 0 {
+		return codecs
+	}
+
+	filtered := make([]RTPCodecParameters, 0, len(codecs))
+	for _, codec := range codecs {
+		var feedback []RTCPFeedback
+		for _, f := range codec.RTCPFeedback {
+			if _, disabled := t.disabledRTCPFeedback[f.Type]; !disabled {
+				feedback = append(feedback, f)
+			}
+		}
+		codec.RTCPFeedback = feedback
+		filtered = append(filtered, codec)
+	}
+
+	return filtered
+}
+
+// filterHeaderExtensions removes the header extensions of disabled feedback.
+func (t *RTPTransceiver) filterHeaderExtensions(
+	headerExtensions []RTPHeaderExtensionParameter,
+) []RTPHeaderExtensionParameter {
+	t.mu.RLock()
+	_, disabled := t.disabledRTCPFeedback[TypeRTCPFBTransportCC]
+	t.mu.RUnlock()
+	if !disabled {
+		return headerExtensions
+	}
+
+	filtered := make([]RTPHeaderExtensionParameter, 0, len(headerExtensions))
+	for _, headerExtension := range headerExtensions {
+		if headerExtension.URI != sdp.TransportCCURI {
+			filtered = append(filtered, headerExtension)
+		}
+	}
+
+	return filtered
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/pion/sdp/v3"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -273,3 +274,71 @@ func Test_RTPTransceiver_UnattachedRTX(t *testing.T) {
 
 	closePairNow(t, offerPC, answerPC)
 }
+
+func Test_RTPTransceiver_SetRTCPFeedback(t *testing.T) {
+	offerPC, answerPC, err := newPair()
+	assert.NoError(t, err)
+
+	track, err := NewTrackLocalStaticSample(RTPCodecCapability{MimeType: MimeTypeVP8}, "video", "pion")
+	assert.NoError(t, err)
+	withoutFeedback, err := offerPC.AddTransceiverFromTrack(track)
+	assert.NoError(t, err)
+	withoutFeedback.SetRTCPFeedback(TypeRTCPFBNACK, false)
+	withoutFeedback.SetRTCPFeedback(TypeRTCPFBTransportCC, false)
+	_, err = offerPC.AddTransceiverFromKind(RTPCodecTypeVideo)
+	assert.NoError(t, err)
+
+	mediaFeedback := func(desc SessionDescription) (feedback []string) {
+		parsed, unmarshalErr := desc.Unmarshal()
+		assert.NoError(t, unmarshalErr)
+		for _, media := range parsed.MediaDescriptions {
+			var lines []string
+			for _, attribute := range media.Attributes {
+				if attribute.Key == "rtcp-fb" || attribute.Key == "extmap" {
+					lines = append(lines, attribute.Value)
+				}
+			}
+			feedback = append(feedback, strings.Join(lines, "\n"))
+		}
+
+		return feedback
+	}
+
+	offer, err := offerPC.CreateOffer(nil)
+	assert.NoError(t, err)
+	feedback := mediaFeedback(offer)
+	assert.NotContains(t, feedback[0], "nack")
+	assert.NotContains(t, feedback[0], "transport-cc")
+	assert.NotContains(t, feedback[0], "transport-wide-cc")
+	assert.Contains(t, feedback[0], "ccm fir")
+	assert.Contains(t, feedback[1], "nack pli")
+	assert.Contains(t, feedback[1], "transport-cc")
+	assert.Contains(t, feedback[1], "transport-wide-cc")
+
+	assert.NoError(t, signalPair(offerPC, answerPC))
+
+	answerFeedback := mediaFeedback(*answerPC.LocalDescription())
+	assert.NotContains(t, answerFeedback[0], "nack")
+	assert.Contains(t, answerFeedback[1], "nack")
+
+	// The streams are bound to the interceptors without the feedback.
+	sender := withoutFeedback.Sender()
+	sender.mu.RLock()
+	streamInfo := sender.trackEncodings[0].streamInfo
+	sender.mu.RUnlock()
+	for _, f := range streamInfo.RTCPFeedback {
+		assert.NotEqual(t, TypeRTCPFBNACK, f.Type)
+	}
+	for _, headerExtension := range streamInfo.RTPHeaderExtensions {
+		assert.NotEqual(t, sdp.TransportCCURI, headerExtension.URI)
+	}
+
+	withoutFeedback.SetRTCPFeedback(TypeRTCPFBNACK, true)
+	offer, err = offerPC.CreateOffer(nil)
+	assert.NoError(t, err)
+	feedback = mediaFeedback(offer)
+	assert.Contains(t, feedback[0], "nack pli")
+	assert.NotContains(t, feedback[0], "transport-cc")
+
+	closePairNow(t, offerPC, answerPC)
+}
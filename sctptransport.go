@@ -6,7 +6,10 @@
 package webrtc
 
 import (
+	"context"
+	"encoding/binary"
 	"errors"
+	"fmt"
 	"io"
 	"net"
 	"sync"
@@ -18,9 +21,26 @@ import (
 	"github.com/pion/webrtc/v4/pkg/rtcerr"
 )
 
-const sctpMaxChannels = uint16(65535)
+const (
+	sctpMaxChannels = uint16(65535)
+
+	// sctpInitStreamsOffset is the offset of the number of outbound streams in
+	// an INIT chunk, the number of inbound streams follows it. RFC 9260 section
+	// 3.3.2.
+	sctpInitStreamsOffset = 12
+
+	// sctpShutdownDrainInterval is how often a graceful shutdown checks whether the
+	// queued messages are sent and acknowledged.
+	sctpShutdownDrainInterval = 10 * time.Millisecond
+)
 
 // SCTPTransport provides details about the SCTP transport.
+//
+// The association lives as long as the DTLS connection. An ICE restart keeps
+// the DTLS connection, which moves to the new candidate pair, so the DataChannels
+// keep working without being opened again. The DTLS connection itself is never
+// restarted, a PeerConnection with a new DTLS connection is a new
+// PeerConnection.
 type SCTPTransport struct {
 	lock sync.RWMutex
 
@@ -37,12 +57,26 @@ type SCTPTransport struct {
 	// be used simultaneously.
 	maxChannels *uint16
 
-	// OnStateChange  func()
-
-	onErrorHandler func(error)
-	onCloseHandler func(error)
-
-	sctpAssociation            *sctp.Association
+	onStateChangeHandler func(SCTPTransportState)
+	onErrorHandler       func(error)
+	onCloseHandler       func(error)
+	onWarningHandler     func(SCTPTransportWarning)
+
+	// scheduler is nil unless SettingEngine.SetDataChannelScheduling is used.
+	scheduler *dataChannelScheduler
+
+	// bufferedAmountMu guards the fields of OnBufferedAmountLow, it is taken
+	// instead of lock when the stream of a DataChannel reports its buffered
+	// amount from the read loop of the association.
+	bufferedAmountMu           sync.Mutex
+	bufferedAmountLowThreshold int
+	onBufferedAmountLowHandler func()
+	bufferedAmountHigh         bool
+
+	sctpAssociation *sctp.Association
+	// associationShutDown is the association once it was shut down gracefully,
+	// Stop doesn't abort it then.
+	associationShutDown        *sctp.Association
 	onDataChannelHandler       func(*DataChannel)
 	onDataChannelOpenedHandler func(*DataChannel)
 
@@ -55,6 +89,14 @@ type SCTPTransport struct {
 
 	localSctpInit []byte
 
+	// counters are the extended statistics of the association, counted from its
+	// log.
+	counters sctpAssociationCounters
+
+	// idReleases release the IDs of the DataChannels closed by the remote peer,
+	// see dataChannelIDReleaseDelay.
+	idReleases dataChannelIDReleases
+
 	api *API
 	log logging.LeveledLogger
 }
@@ -71,7 +113,8 @@ func (api *API) NewSCTPTransport(dtls *DTLSTransport) *SCTPTransport {
 		dataChannelIDsUsed: make(map[uint16]struct{}),
 	}
 
-	res.updateMaxChannels()
+	res.updateMaxChannels(nil, nil)
+	res.scheduler = newDataChannelScheduler(res)
 
 	return res
 }
@@ -126,14 +169,21 @@ func (r *SCTPTransport) Start(capabilities SCTPCapabilities) error {
 	}
 	sctpAssociation, err := sctp.ClientWithOptions(opts...)
 	if err != nil {
+		r.lock.Lock()
+		handler := r.setState(SCTPTransportStateClosed)
+		r.lock.Unlock()
+		handler()
+
 		return err
 	}
 
 	r.lock.Lock()
 	r.sctpAssociation = sctpAssociation
-	r.state = SCTPTransportStateConnected
+	r.updateMaxChannels(r.localSctpInit, remoteSctpInit)
+	handler := r.setState(SCTPTransportStateConnected)
 	dataChannels := append([]*DataChannel{}, r.dataChannels...)
 	r.lock.Unlock()
+	handler()
 
 	var openedDCCount uint32
 	for _, d := range dataChannels {
@@ -160,7 +210,7 @@ func (r *SCTPTransport) Start(capabilities SCTPCapabilities) error {
 func (r *SCTPTransport) sctpClientOptions(netConn net.Conn, maxMessageSize uint32) []sctp.ClientOption {
 	opts := []sctp.ClientOption{
 		sctp.WithNetConn(netConn),
-		sctp.WithLoggerFactory(r.api.settingEngine.LoggerFactory),
+		sctp.WithLoggerFactory(&sctpWarningLoggerFactory{LoggerFactory: r.api.settingEngine.LoggerFactory, transport: r}),
 		sctp.WithMTU(outboundMTU),
 		sctp.WithMaxMessageSize(maxMessageSize),
 	}
@@ -205,18 +255,74 @@ func (r *SCTPTransport) optionalSCTPClientOptions() []sctp.ClientOption {
 	return opts
 }
 
-// Stop stops the SCTPTransport.
+// Stop stops the SCTPTransport. The SCTP association is aborted, unless a drain
+// timeout is set with SettingEngine.SetSCTPShutdownTimeout. Then the association
+// is shut down gracefully first, so the queued messages of reliable DataChannels
+// are delivered, and only aborted if this takes longer than the timeout.
 func (r *SCTPTransport) Stop() error {
+	if timeout := r.api.settingEngine.sctp.shutdownTimeout; timeout > 0 {
+		if err := r.shutdown(timeout); err != nil {
+			r.log.Warnf("Failed to shut down SCTP association gracefully: %v", err)
+		}
+	}
+
 	r.lock.Lock()
-	defer r.lock.Unlock()
-	if r.sctpAssociation == nil {
+	r.idReleases.stop()
+	association := r.sctpAssociation
+	shutDown := association != nil && association == r.associationShutDown
+	r.sctpAssociation = nil
+	r.lock.Unlock()
+
+	// Closing the association waits for its read loop, which invokes the
+	// handlers of the streams, so the lock isn't held.
+	switch {
+	case association == nil:
+	case shutDown:
+		// The association is closed already, this only waits for it to finish.
+		_ = association.Close()
+	default:
+		association.Abort("")
+	}
+
+	r.lock.Lock()
+	handler := r.setState(SCTPTransportStateClosed)
+	r.lock.Unlock()
+	handler()
+
+	return nil
+}
+
+// shutdown shuts down the SCTP association gracefully, waiting at most the
+// timeout for the remote peer to acknowledge the queued data and the shutdown.
+// Stop still has to be called.
+func (r *SCTPTransport) shutdown(timeout time.Duration) error {
+	association := r.association()
+	if association == nil {
 		return nil
 	}
 
-	r.sctpAssociation.Abort("")
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
 
-	r.sctpAssociation = nil
-	r.state = SCTPTransportStateClosed
+	// The association only waits for the chunks in flight, not for the messages
+	// that are still queued.
+	ticker := time.NewTicker(sctpShutdownDrainInterval)
+	defer ticker.Stop()
+	for association.BufferedAmount() > 0 {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if err := association.Shutdown(ctx); err != nil && !errors.Is(err, sctp.ErrShutdownNonEstablished) {
+		return err
+	}
+
+	r.lock.Lock()
+	r.associationShutDown = association
+	r.lock.Unlock()
 
 	return nil
 }
@@ -253,6 +359,14 @@ ACCEPT:
 			LoggerFactory: r.api.settingEngine.LoggerFactory,
 		}, dataChannels...)
 		if err != nil {
+			r.lock.Lock()
+			handler := func() {}
+			if r.sctpAssociation == assoc {
+				handler = r.setState(SCTPTransportStateClosed)
+			}
+			r.lock.Unlock()
+			handler()
+
 			if !errors.Is(err, io.EOF) {
 				r.log.Errorf("Failed to accept data channel: %v", err)
 				r.onError(err)
@@ -297,14 +411,22 @@ ACCEPT:
 		}
 
 		sid := dc.StreamIdentifier()
+		reliable := ordered && maxRetransmits == nil && maxPacketLifeTime == nil
+		protocol, fragmentation := parseFragmentationExtension(
+			dc.Config.Protocol, reliable, r.api.settingEngine.sctp.acceptFragmentation,
+		)
+		protocol, compression := parseCompressionExtension(protocol, ordered, r.api.settingEngine.sctp.acceptCompression)
 		rtcDC, err := r.api.newDataChannel(&DataChannelParameters{
 			ID:                &sid,
 			Label:             dc.Config.Label,
-			Protocol:          dc.Config.Protocol,
+			Protocol:          protocol,
+			Compression:       compression,
+			Fragmentation:     fragmentation,
 			Negotiated:        dc.Config.Negotiated,
 			Ordered:           ordered,
 			MaxPacketLifeTime: maxPacketLifeTime,
 			MaxRetransmits:    maxRetransmits,
+			Priority:          dataChannelPriorityFromDCEP(dc.Config.Priority),
 		}, r, r.api.settingEngine.LoggerFactory.NewLogger("ortc"))
 		if err != nil {
 			// This data channel is invalid. Close it and log an error.
@@ -331,6 +453,31 @@ ACCEPT:
 	}
 }
 
+// OnStateChange sets an event handler which is invoked when the state of the
+// SCTPTransport changes: to connected once the SCTP association is
+// established, and to closed when it is stopped, fails to be established or
+// is closed or aborted by the remote peer. This tells SCTP failures apart from
+// the state of the DTLSTransport and ICETransport. The handler is invoked
+// synchronously by the goroutine changing the state.
+func (r *SCTPTransport) OnStateChange(f func(SCTPTransportState)) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.onStateChangeHandler = f
+}
+
+// setState returns a function invoking the OnStateChange handler, to be called
+// after releasing the lock. The caller holds the lock.
+func (r *SCTPTransport) setState(state SCTPTransportState) func() {
+	handler := r.onStateChangeHandler
+	changed := r.state != state
+	r.state = state
+	if !changed || handler == nil {
+		return func() {}
+	}
+
+	return func() { handler(state) }
+}
+
 // OnError sets an event handler which is invoked when the SCTP Association errors.
 func (r *SCTPTransport) OnError(f func(err error)) {
 	r.lock.Lock()
@@ -412,12 +559,36 @@ func (r *SCTPTransport) onDataChannel(dc *DataChannel) (done chan struct{}) {
 	return
 }
 
-func (r *SCTPTransport) updateMaxChannels() {
+// updateMaxChannels sets MaxChannels to the streams the SCTP association can
+// use in both directions, the smaller of the streams each peer offers and the
+// other accepts. They are read from the INIT chunks exchanged with SNAP. The
+// association negotiates them itself otherwise and doesn't report the result,
+// it offers the maximum number of streams. The caller holds the lock.
+func (r *SCTPTransport) updateMaxChannels(localSctpInit, remoteSctpInit []byte) {
 	val := sctpMaxChannels
+	localOutbound, localInbound, localOK := sctpInitStreams(localSctpInit)
+	remoteOutbound, remoteInbound, remoteOK := sctpInitStreams(remoteSctpInit)
+	if localOK && remoteOK {
+		val = min(val, localOutbound, localInbound, remoteOutbound, remoteInbound)
+	}
 	r.maxChannels = &val
 }
 
+// sctpInitStreams returns the number of outbound and inbound streams of an
+// INIT chunk.
+func sctpInitStreams(init []byte) (outbound, inbound uint16, ok bool) {
+	if len(init) < sctpInitStreamsOffset+4 || init[0] != 1 {
+		return 0, 0, false
+	}
+
+	return binary.BigEndian.Uint16(init[sctpInitStreamsOffset:]),
+		binary.BigEndian.Uint16(init[sctpInitStreamsOffset+2:]), true
+}
+
 // MaxChannels is the maximum number of RTCDataChannels that can be open simultaneously.
+// Once the SCTP association is established with SNAP, it is the number of
+// streams negotiated in the INIT chunks, CreateDataChannel fails for IDs that
+// aren't below it.
 func (r *SCTPTransport) MaxChannels() uint16 {
 	r.lock.Lock()
 	defer r.lock.Unlock()
@@ -454,6 +625,7 @@ func (r *SCTPTransport) Stats() SCTPTransportStats {
 		stats.ReceiverWindow = association.RWND()
 		stats.MTU = association.MTU()
 	}
+	r.counters.fillStats(&stats)
 
 	return stats
 }
@@ -475,7 +647,9 @@ func (r *SCTPTransport) generateAndSetDataChannelID(dtlsRole DTLSRole, idOut **u
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
-	for ; id < maxVal-1; id += 2 {
+	// The ID is counted as an int, it would wrap around at 65535 otherwise.
+	for next := int(id); next < int(maxVal); next += 2 {
+		id = uint16(next) //nolint:gosec // G115
 		if _, ok := r.dataChannelIDsUsed[id]; ok {
 			continue
 		}
@@ -485,9 +659,73 @@ func (r *SCTPTransport) generateAndSetDataChannelID(dtlsRole DTLSRole, idOut **u
 		return nil
 	}
 
+	if maxVal < sctpMaxChannels {
+		return &rtcerr.OperationError{Err: fmt.Errorf("%w: %d streams", ErrDataChannelIDExceedsStreams, maxVal)}
+	}
+
 	return &rtcerr.OperationError{Err: ErrMaxDataChannelID}
 }
 
+// releaseDataChannelID makes the ID of a closed DataChannel available again, so
+// DataChannels can be created and closed without running out of IDs.
+func (r *SCTPTransport) releaseDataChannelID(id uint16) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.dataChannelIDsUsed, id)
+}
+
+// addDataChannel adds a DataChannel created by CreateDataChannel. Until the SCTP
+// association is established the DataChannels are queued, within the limits of
+// SettingEngine.SetDataChannelQueueLimits, and opened in the order they were
+// added once it is.
+func (r *SCTPTransport) addDataChannel(dc *DataChannel) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.state != SCTPTransportStateConnected {
+		if err := r.checkDataChannelQueueLimits(dc); err != nil {
+			return err
+		}
+	}
+
+	r.dataChannels = append(r.dataChannels, dc)
+	if dc.ID() != nil {
+		r.dataChannelIDsUsed[*dc.ID()] = struct{}{}
+	}
+	r.dataChannelsRequested++
+
+	return nil
+}
+
+func (r *SCTPTransport) checkDataChannelQueueLimits(dc *DataChannel) error {
+	maxChannels := r.api.settingEngine.sctp.dataChannelQueueMaxChannels
+	maxBytes := r.api.settingEngine.sctp.dataChannelQueueMaxBytes
+	if maxChannels <= 0 && maxBytes <= 0 {
+		return nil
+	}
+
+	queuedChannels := 1
+	queuedBytes := len(dc.label) + len(dc.wireProtocol())
+	for _, d := range r.dataChannels {
+		if d.ReadyState() != DataChannelStateConnecting {
+			continue
+		}
+		// The label and protocol don't change after the DataChannel is created.
+		queuedChannels++
+		queuedBytes += len(d.label) + len(d.wireProtocol())
+	}
+
+	switch {
+	case maxChannels > 0 && queuedChannels > maxChannels:
+		return &rtcerr.OperationError{Err: ErrDataChannelQueueChannelsExceeded}
+	case maxBytes > 0 && queuedBytes > maxBytes:
+		return &rtcerr.OperationError{Err: ErrDataChannelQueueBytesExceeded}
+	default:
+		return nil
+	}
+}
+
 func (r *SCTPTransport) association() *sctp.Association {
 	if r == nil {
 		return nil
@@ -500,6 +738,7 @@ func (r *SCTPTransport) association() *sctp.Association {
 }
 
 // BufferedAmount returns total amount (in bytes) of currently buffered user data.
+// It includes the messages queued by SettingEngine.SetDataChannelScheduling.
 func (r *SCTPTransport) BufferedAmount() int {
 	r.lock.Lock()
 	defer r.lock.Unlock()
@@ -507,7 +746,97 @@ func (r *SCTPTransport) BufferedAmount() int {
 		return 0
 	}
 
-	return r.sctpAssociation.BufferedAmount()
+	return r.sctpAssociation.BufferedAmount() + r.scheduler.queuedAmount()
+}
+
+// BufferedAmountLowThreshold returns the threshold of OnBufferedAmountLow.
+func (r *SCTPTransport) BufferedAmountLowThreshold() int {
+	r.bufferedAmountMu.Lock()
+	defer r.bufferedAmountMu.Unlock()
+
+	return r.bufferedAmountLowThreshold
+}
+
+// SetBufferedAmountLowThreshold sets the threshold of OnBufferedAmountLow,
+// which is 0 by default.
+func (r *SCTPTransport) SetBufferedAmountLowThreshold(threshold int) {
+	r.bufferedAmountMu.Lock()
+	defer r.bufferedAmountMu.Unlock()
+
+	r.bufferedAmountLowThreshold = threshold
+}
+
+// OnBufferedAmountLow sets an event handler which is invoked when the
+// BufferedAmount of all DataChannels together decreases from above the
+// threshold set with SetBufferedAmountLowThreshold to or below it. Senders
+// multiplexing many DataChannels can use it to stop sending on all of them
+// while the association is congested. The association doesn't report the
+// bytes it releases across its streams, so while the BufferedAmount is above
+// the threshold every DataChannel reports when its stream released its share
+// of the excess. Messages sent on detached DataChannels aren't noticed until
+// one is sent with Send or SendText.
+func (r *SCTPTransport) OnBufferedAmountLow(f func()) {
+	r.bufferedAmountMu.Lock()
+	defer r.bufferedAmountMu.Unlock()
+
+	r.onBufferedAmountLowHandler = f
+}
+
+// checkBufferedAmount is called after a message was sent and, with released
+// set, when the stream of a DataChannel released its share of the excess over
+// the threshold, the association doesn't report releasing buffered bytes
+// across its streams. The handler of OnBufferedAmountLow is invoked once the
+// buffered amount is at or below the threshold after it was above it. The
+// shares stay valid while messages are sent, they are only divided again once
+// one is released. It must not be called from the handlers of the streams,
+// which the read loop invokes while Stop may wait for it.
+func (r *SCTPTransport) checkBufferedAmount(released bool) {
+	r.lock.RLock()
+	association, dataChannels := r.sctpAssociation, r.dataChannels
+	r.lock.RUnlock()
+
+	r.bufferedAmountMu.Lock()
+	if r.onBufferedAmountLowHandler == nil || association == nil {
+		r.bufferedAmountMu.Unlock()
+
+		return
+	}
+	excess := association.BufferedAmount() + r.scheduler.queuedAmount() - r.bufferedAmountLowThreshold
+	low := r.bufferedAmountHigh && excess <= 0
+	divide := excess > 0 && (released || !r.bufferedAmountHigh)
+	r.bufferedAmountHigh = excess > 0
+	handler := r.onBufferedAmountLowHandler
+	r.bufferedAmountMu.Unlock()
+
+	if divide {
+		setBufferedAmountShares(dataChannels, excess)
+	}
+	if low {
+		go handler()
+	}
+}
+
+// setBufferedAmountShares makes the streams of the DataChannels report once
+// one of them released its share of the excess, the buffered amount of all of
+// them together can't drop to the threshold before.
+func setBufferedAmountShares(dataChannels []*DataChannel, excess int) {
+	buffered := make([]uint64, len(dataChannels))
+	sending := 0
+	for i, d := range dataChannels {
+		if buffered[i] = d.streamBufferedAmount(); buffered[i] > 0 {
+			sending++
+		}
+	}
+	if sending == 0 {
+		return
+	}
+
+	share := uint64((excess + sending - 1) / sending) //nolint:gosec // G115, excess is positive
+	for i, d := range dataChannels {
+		if buffered[i] > 0 {
+			d.setTransportBufferedAmountLevel(buffered[i] - min(share, buffered[i]))
+		}
+	}
 }
 
 // GetSctpInit returns the current sctp-init attribute and caches the last created.
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"sync"
+	"time"
+)
+
+// dataChannelIDReleaseDelay is how long the ID of a DataChannel closed by the
+// remote peer stays in use. The datachannel package resets our outgoing stream
+// only once the remote peer reset its own, and the association reports neither
+// when the remote peer performed the reset nor which request a response is
+// for. Until then the messages of a new DataChannel with the same ID would be
+// delivered to the closed one, so the delay covers a few retransmissions of
+// the reset request.
+const dataChannelIDReleaseDelay = 10 * time.Second
+
+// dataChannelIDReleases are the pending releases of the IDs of DataChannels
+// closed by the remote peer.
+type dataChannelIDReleases struct {
+	mu     sync.Mutex
+	timers map[uint16]*time.Timer
+
+	// delay is dataChannelIDReleaseDelay if zero.
+	delay time.Duration
+}
+
+// schedule releases the ID after the delay, unless stop is called before.
+func (r *dataChannelIDReleases) schedule(id uint16, release func(uint16)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.timers == nil {
+		r.timers = make(map[uint16]*time.Timer)
+	}
+	if timer, ok := r.timers[id]; ok {
+		timer.Stop()
+	}
+
+	delay := r.delay
+	if delay == 0 {
+		delay = dataChannelIDReleaseDelay
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(delay, func() {
+		r.mu.Lock()
+		current := r.timers[id] == timer
+		if current {
+			delete(r.timers, id)
+		}
+		r.mu.Unlock()
+
+		if current {
+			release(id)
+		}
+	})
+	r.timers[id] = timer
+}
+
+// stop cancels the pending releases once the association is closed.
+func (r *dataChannelIDReleases) stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, timer := range r.timers {
+		timer.Stop()
+	}
+	r.timers = nil
+}
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"sync/atomic"
+)
+
+// The SCTP association doesn't export its counters, these are the formats of
+// the log messages the extended statistics are counted from.
+const (
+	sctpFastRetransmitFormat = "[%s] fast-retransmit: tsn=%d sent=%d htna=%d"
+	sctpRACKLostFormat       = "[%s] RACK: mark lost tsn=%d (sent=%v, delivered=%v, reoWnd=%v)"
+	sctpRACKTimerLostFormat  = "[%s] RACK timer: mark lost tsn=%d"
+	sctpPTOProbeFormat       = "[%s] PTO fired: probe tsn=%d"
+	sctpDataReceivedFormat   = "[%s] DATA: tsn=%d immediateSack=%v len=%d"
+	sctpStateChangeFormat    = "[%s] state change: '%s' => '%s'"
+)
+
+// sctpAssociationCounters are updated with the lock of the association held,
+// so they are atomics and don't take the lock of the SCTPTransport.
+type sctpAssociationCounters struct {
+	retransmittedChunks     atomic.Uint64
+	fastRetransmits         atomic.Uint64
+	t3Timeouts              atomic.Uint64
+	outOfOrderChunks        atomic.Uint64
+	highestReceivedTSN      atomic.Uint32
+	highestReceivedTSNValid atomic.Bool
+	state                   atomic.Value // string
+}
+
+func (c *sctpAssociationCounters) associationState() string {
+	if state, ok := c.state.Load().(string); ok {
+		return state
+	}
+
+	return ""
+}
+
+// dataReceived counts the DATA chunks with a TSN below the highest one
+// received, they arrived after chunks sent later.
+func (c *sctpAssociationCounters) dataReceived(tsn uint32) {
+	if !c.highestReceivedTSNValid.Load() {
+		c.highestReceivedTSN.Store(tsn)
+		c.highestReceivedTSNValid.Store(true)
+
+		return
+	}
+
+	// TSNs wrap around, see RFC 9260 section 1.6.
+	highest := c.highestReceivedTSN.Load()
+	if diff := int32(tsn - highest); diff > 0 { //nolint:gosec // G115
+		c.highestReceivedTSN.Store(tsn)
+	} else if diff < 0 {
+		c.outOfOrderChunks.Add(1)
+	}
+}
+
+// countTrace counts the chunks the association logs at the trace level.
+func (c *sctpAssociationCounters) countTrace(format string, args []any) {
+	switch format {
+	case sctpFastRetransmitFormat:
+		c.fastRetransmits.Add(1)
+		c.retransmittedChunks.Add(1)
+	case sctpRACKLostFormat, sctpRACKTimerLostFormat, sctpPTOProbeFormat:
+		c.retransmittedChunks.Add(1)
+	case sctpDataReceivedFormat:
+		if len(args) < 2 {
+			return
+		}
+		if tsn, ok := args[1].(uint32); ok {
+			c.dataReceived(tsn)
+		}
+	}
+}
+
+// countDebug counts the retransmission timeouts and notes the state changes the
+// association logs at the debug level.
+func (c *sctpAssociationCounters) countDebug(format string, args []any) {
+	switch format {
+	case sctpT3RTXTimeoutFormat:
+		c.t3Timeouts.Add(1)
+	case sctpStateChangeFormat:
+		if len(args) < 3 {
+			return
+		}
+		if state, ok := args[2].(string); ok {
+			c.state.Store(state)
+		}
+	}
+}
+
+func (c *sctpAssociationCounters) fillStats(stats *SCTPTransportStats) {
+	stats.RetransmittedChunks = c.retransmittedChunks.Load()
+	stats.FastRetransmits = c.fastRetransmits.Load()
+	stats.T3Timeouts = c.t3Timeouts.Load()
+	stats.OutOfOrderChunksReceived = c.outOfOrderChunks.Load()
+	stats.AssociationState = c.associationState()
+}
@@ -8,12 +8,26 @@ package webrtc
 import (
 	"bufio"
 	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"go/build"
+	"io"
+	"math"
 	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/pion/sctp"
+	"github.com/pion/transport/v4/test"
+	"github.com/pion/webrtc/v4/pkg/rtcerr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -62,6 +76,104 @@ func TestGenerateDataChannelID(t *testing.T) {
 	}
 }
 
+func TestGenerateDataChannelIDNegotiatedStreams(t *testing.T) {
+	maxChannels := uint16(4)
+	transport := &SCTPTransport{
+		maxChannels:        &maxChannels,
+		dataChannelIDsUsed: map[uint16]struct{}{0: {}},
+	}
+
+	idPtr := new(uint16)
+	require.NoError(t, transport.generateAndSetDataChannelID(DTLSRoleClient, &idPtr))
+	assert.Equal(t, uint16(2), *idPtr)
+
+	var operationErr *rtcerr.OperationError
+	err := transport.generateAndSetDataChannelID(DTLSRoleClient, &idPtr)
+	require.ErrorAs(t, err, &operationErr)
+	assert.ErrorIs(t, err, ErrDataChannelIDExceedsStreams)
+
+	// Without a negotiated limit the IDs up to 65534 are used.
+	maxChannels = sctpMaxChannels
+	for id := uint16(2); id < maxChannels-1; id += 2 {
+		transport.dataChannelIDsUsed[id] = struct{}{}
+	}
+	require.NoError(t, transport.generateAndSetDataChannelID(DTLSRoleClient, &idPtr))
+	assert.Equal(t, uint16(65534), *idPtr)
+	assert.ErrorIs(t, transport.generateAndSetDataChannelID(DTLSRoleClient, &idPtr), ErrMaxDataChannelID)
+}
+
+func TestSCTPInitStreams(t *testing.T) {
+	init, err := sctp.GenerateOutOfBandToken()
+	require.NoError(t, err)
+
+	outbound, inbound, ok := sctpInitStreams(init)
+	assert.True(t, ok)
+	assert.Equal(t, sctpMaxChannels, outbound)
+	assert.Equal(t, sctpMaxChannels, inbound)
+
+	_, _, ok = sctpInitStreams(init[:sctpInitStreamsOffset])
+	assert.False(t, ok)
+
+	transport := &SCTPTransport{}
+	remoteInit := append([]byte{}, init...)
+	binary.BigEndian.PutUint16(remoteInit[sctpInitStreamsOffset:], 100)
+	binary.BigEndian.PutUint16(remoteInit[sctpInitStreamsOffset+2:], 50)
+	transport.updateMaxChannels(init, remoteInit)
+	assert.Equal(t, uint16(50), transport.MaxChannels())
+
+	transport.updateMaxChannels(init, nil)
+	assert.Equal(t, sctpMaxChannels, transport.MaxChannels())
+}
+
+func TestSCTPTransport_MaxChannelsFromSNAP(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	settingEngine := SettingEngine{}
+	settingEngine.EnableSctpSnap(true)
+	api := NewAPI(WithSettingEngine(settingEngine))
+
+	pcOffer, err := api.NewPeerConnection(Configuration{})
+	require.NoError(t, err)
+	pcAnswer, err := api.NewPeerConnection(Configuration{})
+	require.NoError(t, err)
+
+	// The offer advertises 16 streams in each direction.
+	sctpInit := regexp.MustCompile(`a=sctp-init:(\S+)`)
+	connected := untilConnectionState(PeerConnectionStateConnected, pcOffer, pcAnswer)
+	require.NoError(t, signalPairWithModification(pcOffer, pcAnswer, func(sdp string) string {
+		return sctpInit.ReplaceAllStringFunc(sdp, func(attribute string) string {
+			init, decodeErr := base64.StdEncoding.DecodeString(sctpInit.FindStringSubmatch(attribute)[1])
+			require.NoError(t, decodeErr)
+			binary.BigEndian.PutUint16(init[sctpInitStreamsOffset:], 16)
+			binary.BigEndian.PutUint16(init[sctpInitStreamsOffset+2:], 16)
+
+			return "a=sctp-init:" + base64.StdEncoding.EncodeToString(init)
+		})
+	}))
+	connected.Wait()
+
+	assert.Eventually(t, func() bool {
+		return pcAnswer.SCTP().State() == SCTPTransportStateConnected
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, uint16(16), pcAnswer.SCTP().MaxChannels())
+
+	negotiated, id := true, uint16(20)
+	_, err = pcAnswer.CreateDataChannel("negotiated", &DataChannelInit{Negotiated: &negotiated, ID: &id})
+	var operationErr *rtcerr.OperationError
+	assert.ErrorAs(t, err, &operationErr)
+	assert.ErrorIs(t, err, ErrDataChannelIDExceedsStreams)
+
+	id = 10
+	_, err = pcAnswer.CreateDataChannel("negotiated", &DataChannelInit{Negotiated: &negotiated, ID: &id})
+	assert.NoError(t, err)
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
+
 func TestSCTPTransport_sctpClientOptions_IncludesOptionalOptions(t *testing.T) {
 	clientConn, serverConn := net.Pipe()
 	defer func() {
@@ -216,6 +328,89 @@ func TestSCTPTransportOnClose(t *testing.T) {
 	}
 }
 
+func TestSCTPTransportStopShutdownTimeout(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	const messages = 64
+
+	s := SettingEngine{}
+	s.SetSCTPShutdownTimeout(5 * time.Second)
+	offerPC, err := NewAPI(WithSettingEngine(s)).NewPeerConnection(Configuration{})
+	require.NoError(t, err)
+	answerPC, err := NewPeerConnection(Configuration{})
+	require.NoError(t, err)
+
+	received, receivedDone := context.WithCancel(context.Background())
+	answerPC.OnDataChannel(func(dc *DataChannel) {
+		if dc.Label() != expectedLabel {
+			return
+		}
+
+		var count int
+		dc.OnMessage(func(_ DataChannelMessage) {
+			if count++; count == messages {
+				receivedDone()
+			}
+		})
+	})
+
+	opened, openedDone := context.WithCancel(context.Background())
+	dc, err := offerPC.CreateDataChannel(expectedLabel, nil)
+	require.NoError(t, err)
+	dc.OnOpen(openedDone)
+
+	require.NoError(t, signalPair(offerPC, answerPC))
+	<-opened.Done()
+
+	// The messages are still queued when the PeerConnection is closed, they are
+	// only delivered because the association is shut down gracefully.
+	payload := make([]byte, 16*1024)
+	for i := 0; i < messages; i++ {
+		require.NoError(t, dc.Send(payload))
+	}
+	require.NoError(t, offerPC.Close())
+
+	select {
+	case <-received.Done():
+	case <-time.After(10 * time.Second):
+		assert.Fail(t, "timed out waiting for the queued messages")
+	}
+
+	require.NoError(t, answerPC.Close())
+}
+
+func TestSCTPTransport_OnStateChange(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	offerPC, answerPC, err := newPair()
+	require.NoError(t, err)
+
+	states := make(chan SCTPTransportState, 4)
+	offerPC.SCTP().OnStateChange(func(state SCTPTransportState) {
+		states <- state
+	})
+
+	require.NoError(t, signalPair(offerPC, answerPC))
+	assert.Equal(t, SCTPTransportStateConnected, <-states)
+	assert.Equal(t, SCTPTransportStateConnected, offerPC.SCTP().State())
+
+	// The association is aborted by the remote peer.
+	require.NoError(t, answerPC.Close())
+	assert.Equal(t, SCTPTransportStateClosed, <-states)
+	assert.Equal(t, SCTPTransportStateClosed, offerPC.SCTP().State())
+
+	require.NoError(t, offerPC.Close())
+	assert.Empty(t, states)
+}
+
 // TestSCTPTransportOnCloseImmediate tests that OnClose fires immediately
 // when Stop() is called directly on the SCTP transport, even if acceptDataChannels
 // is blocked waiting for a new data channel. This test would fail "sometimes" without the fix
@@ -482,3 +677,256 @@ func TestMaxMessageSizeSignaling(t *testing.T) {
 		closePairNow(t, offerPeerConnection, answerPeerConnection)
 	})
 }
+
+// sctpDroppingConn drops the packets written while drop is set.
+type sctpDroppingConn struct {
+	net.Conn
+	drop atomic.Bool
+}
+
+func (c *sctpDroppingConn) Write(b []byte) (int, error) {
+	if c.drop.Load() {
+		return len(b), nil
+	}
+
+	return c.Conn.Write(b)
+}
+
+func TestSCTPTransport_OnWarning(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	settingEngine := SettingEngine{}
+	settingEngine.SetSCTPRetransmissionWarningThreshold(2)
+	api := NewAPI(WithSettingEngine(settingEngine))
+
+	// The warnings are raised from the log of the pinned pion/sctp, with short
+	// retransmission timeouts.
+	newTransport := func() (*SCTPTransport, chan SCTPTransportWarning, sctp.AssociationOption) {
+		transport := api.NewSCTPTransport(nil)
+		warnings := make(chan SCTPTransportWarning, 16)
+		transport.OnWarning(func(warning SCTPTransportWarning) {
+			warnings <- warning
+		})
+
+		loggerFactory := &sctpWarningLoggerFactory{LoggerFactory: api.settingEngine.LoggerFactory, transport: transport}
+
+		return transport, warnings, sctp.WithLoggerFactory(loggerFactory)
+	}
+
+	t.Run("Scope", func(t *testing.T) {
+		transport, _, _ := newTransport()
+		loggerFactory := &sctpWarningLoggerFactory{LoggerFactory: api.settingEngine.LoggerFactory, transport: transport}
+		_, isWatched := loggerFactory.NewLogger("datachannel").(*sctpWarningLogger)
+		assert.False(t, isWatched)
+	})
+
+	t.Run("Retransmission Threshold", func(t *testing.T) {
+		clientConn, serverConn := net.Pipe()
+		conn := &sctpDroppingConn{Conn: clientConn}
+		transport, warnings, loggerFactory := newTransport()
+
+		server := make(chan *sctp.Association, 1)
+		go func() {
+			association, err := sctp.ServerWithOptions(sctp.WithNetConn(serverConn))
+			assert.NoError(t, err)
+			server <- association
+		}()
+		client, err := sctp.ClientWithOptions(sctp.WithNetConn(conn), loggerFactory, sctp.WithRTOMax(20))
+		require.NoError(t, err)
+		serverAssociation := <-server
+
+		stream, err := client.OpenStream(1, sctp.PayloadTypeWebRTCBinary)
+		require.NoError(t, err)
+		conn.drop.Store(true)
+		_, err = stream.Write([]byte{0x00})
+		require.NoError(t, err)
+
+		assert.Equal(t, SCTPTransportWarning{
+			Type:                SCTPTransportWarningTypeRetransmissionThreshold,
+			Timer:               "T3-rtx",
+			ConsecutiveTimeouts: 2,
+		}, <-warnings)
+		assert.GreaterOrEqual(t, transport.Stats().T3Timeouts, uint64(2))
+
+		client.Abort("")
+		assert.NoError(t, serverAssociation.Close())
+	})
+
+	t.Run("Path Failure", func(t *testing.T) {
+		clientConn, serverConn := net.Pipe()
+		go func() {
+			_, _ = io.Copy(io.Discard, serverConn)
+		}()
+		_, warnings, loggerFactory := newTransport()
+
+		_, err := sctp.ClientWithOptions(sctp.WithNetConn(clientConn), loggerFactory, sctp.WithRTOMax(10))
+		require.Error(t, err)
+		assert.Equal(t, SCTPTransportWarning{Type: SCTPTransportWarningTypePathFailure, Timer: "T1-init"}, <-warnings)
+		assert.NoError(t, serverConn.Close())
+	})
+
+	assert.Equal(t, "retransmission-threshold", SCTPTransportWarningTypeRetransmissionThreshold.String())
+	assert.Equal(t, "unknown", SCTPTransportWarningTypeUnknown.String())
+}
+
+func TestSCTPTransport_ExtendedStats(t *testing.T) {
+	api := NewAPI()
+	transport := api.NewSCTPTransport(nil)
+	assert.Empty(t, transport.Stats().AssociationState)
+
+	logger := (&sctpWarningLoggerFactory{LoggerFactory: api.settingEngine.LoggerFactory, transport: transport}).
+		NewLogger("sctp")
+	logger.Debugf(sctpStateChangeFormat, "client", "CookieEchoed", "Established")
+	logger.Debugf(sctpT3RTXTimeoutFormat, "client", uint(1), 1200, 4800)
+	logger.Tracef(sctpFastRetransmitFormat, "client", uint32(10), uint(2), uint32(12))
+	logger.Tracef(sctpRACKLostFormat, "client", uint32(11), time.Time{}, time.Time{}, time.Duration(0))
+	logger.Tracef(sctpPTOProbeFormat, "client", uint32(12))
+	for _, tsn := range []uint32{math.MaxUint32, 1, 0, 2, 1} {
+		logger.Tracef(sctpDataReceivedFormat, "client", tsn, false, 100)
+	}
+
+	stats := transport.Stats()
+	assert.Equal(t, "Established", stats.AssociationState)
+	assert.Equal(t, uint64(1), stats.T3Timeouts)
+	assert.Equal(t, uint64(1), stats.FastRetransmits)
+	assert.Equal(t, uint64(3), stats.RetransmittedChunks)
+	assert.Equal(t, uint64(2), stats.OutOfOrderChunksReceived)
+}
+
+// sctpSources returns the sources of the pinned pion/sctp module, the formats
+// of the log messages the SCTPTransport watches are checked against them.
+func sctpSources(t *testing.T) string {
+	t.Helper()
+
+	pkg, err := build.Import("github.com/pion/sctp", ".", build.FindOnly)
+	if err != nil {
+		t.Skipf("the sources of pion/sctp aren't available: %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(pkg.Dir, "*.go"))
+	require.NoError(t, err)
+
+	var sources strings.Builder
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		b, err := os.ReadFile(file) //nolint:gosec // G304
+		require.NoError(t, err)
+		sources.Write(b)
+	}
+
+	return sources.String()
+}
+
+func TestSCTPTransport_ExtendedStatsFormats(t *testing.T) {
+	sources := sctpSources(t)
+	for _, format := range []string{
+		sctpFastRetransmitFormat,
+		sctpRACKLostFormat,
+		sctpRACKTimerLostFormat,
+		sctpPTOProbeFormat,
+		sctpDataReceivedFormat,
+		sctpStateChangeFormat,
+		sctpT3RTXTimeoutFormat,
+	} {
+		assert.Contains(t, sources, strconv.Quote(format), "pion/sctp doesn't log %q anymore", format)
+	}
+}
+
+func TestSCTPTransport_IDReleases(t *testing.T) {
+	transport := NewAPI().NewSCTPTransport(nil)
+	transport.idReleases.delay = 50 * time.Millisecond
+	for _, id := range []uint16{1, 3} {
+		transport.dataChannelIDsUsed[id] = struct{}{}
+	}
+	idUsed := func(id uint16) bool {
+		transport.lock.RLock()
+		defer transport.lock.RUnlock()
+		_, used := transport.dataChannelIDsUsed[id]
+
+		return used
+	}
+
+	transport.idReleases.schedule(1, transport.releaseDataChannelID)
+	assert.True(t, idUsed(1), "the ID was released before the delay")
+	assert.Eventually(t, func() bool { return !idUsed(1) }, time.Second, 10*time.Millisecond)
+
+	// The releases pending when the transport is stopped are dropped.
+	transport.idReleases.schedule(3, transport.releaseDataChannelID)
+	require.NoError(t, transport.Stop())
+	time.Sleep(100 * time.Millisecond)
+	assert.True(t, idUsed(3))
+}
+
+func TestSCTPTransport_OnBufferedAmountLow(t *testing.T) {
+	const (
+		messages  = 48
+		threshold = 64 * 1024
+	)
+
+	// The streams of DataChannels with a threshold above the one of the
+	// transport don't report the buffered amount dropping below it by
+	// themselves.
+	for _, dataChannelThreshold := range []uint64{0, 1024 * 1024} {
+		t.Run(fmt.Sprint(dataChannelThreshold), func(t *testing.T) {
+			lim := test.TimeOut(time.Second * 30)
+			defer lim.Stop()
+
+			report := test.CheckRoutines(t)
+			defer report()
+
+			offerPC, answerPC, err := newPair()
+			require.NoError(t, err)
+
+			var dataChannels []*DataChannel
+			var opened sync.WaitGroup
+			for _, label := range []string{"a", "b"} {
+				dc, createErr := offerPC.CreateDataChannel(label, nil)
+				require.NoError(t, createErr)
+				dc.SetBufferedAmountLowThreshold(dataChannelThreshold)
+				opened.Add(1)
+				dc.OnOpen(opened.Done)
+				dataChannels = append(dataChannels, dc)
+			}
+
+			require.NoError(t, signalPair(offerPC, answerPC))
+			opened.Wait()
+
+			transport := offerPC.SCTP()
+			transport.SetBufferedAmountLowThreshold(threshold)
+			assert.Equal(t, threshold, transport.BufferedAmountLowThreshold())
+
+			bufferedAmounts := make(chan int, messages*len(dataChannels))
+			transport.OnBufferedAmountLow(func() {
+				bufferedAmounts <- transport.BufferedAmount()
+			})
+
+			// Every DataChannel stays below the threshold, only all of them
+			// together exceed it.
+			payload := make([]byte, 1024)
+			for i := 0; i < messages; i++ {
+				for _, dc := range dataChannels {
+					require.NoError(t, dc.Send(payload))
+				}
+			}
+			for _, dc := range dataChannels {
+				assert.Less(t, dc.BufferedAmount(), uint64(threshold))
+			}
+			assert.Greater(t, transport.BufferedAmount(), threshold)
+
+			select {
+			case bufferedAmount := <-bufferedAmounts:
+				assert.LessOrEqual(t, bufferedAmount, threshold)
+			case <-time.After(10 * time.Second):
+				assert.Fail(t, "timed out waiting for OnBufferedAmountLow")
+			}
+
+			closePairNow(t, offerPC, answerPC)
+		})
+	}
+}
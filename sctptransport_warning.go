@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"strings"
+
+	"github.com/pion/logging"
+)
+
+const (
+	// The SCTP association only reports its retransmission timeouts and failures
+	// in its log, these are the formats it uses.
+	sctpT3RTXTimeoutFormat             = "[%s] T3-rtx timed out: nRtos=%d cwnd=%d ssthresh=%d"
+	sctpRetransmissionFailureFormatPre = "[%s] retransmission failure: "
+
+	defaultSCTPRetransmissionWarningThreshold = 3
+)
+
+// SCTPTransportWarningType is the type of an SCTPTransportWarning.
+type SCTPTransportWarningType int
+
+const (
+	// SCTPTransportWarningTypeUnknown is the enum's zero-value.
+	SCTPTransportWarningTypeUnknown SCTPTransportWarningType = iota
+
+	// SCTPTransportWarningTypeRetransmissionThreshold indicates that the DATA
+	// chunks in flight timed out consecutively as often as the threshold set with
+	// SettingEngine.SetSCTPRetransmissionWarningThreshold, so the remote peer
+	// is likely unreachable. It is reported for every further timeout until a
+	// chunk is acknowledged.
+	SCTPTransportWarningTypeRetransmissionThreshold
+
+	// SCTPTransportWarningTypePathFailure indicates that a retransmission timer
+	// exceeded its maximum retransmissions, the association is aborted next.
+	SCTPTransportWarningTypePathFailure
+)
+
+// This is done this way because of a linter.
+const (
+	sctpTransportWarningTypeRetransmissionThresholdStr = "retransmission-threshold"
+	sctpTransportWarningTypePathFailureStr             = "path-failure"
+)
+
+func (t SCTPTransportWarningType) String() string {
+	switch t {
+	case SCTPTransportWarningTypeRetransmissionThreshold:
+		return sctpTransportWarningTypeRetransmissionThresholdStr
+	case SCTPTransportWarningTypePathFailure:
+		return sctpTransportWarningTypePathFailureStr
+	default:
+		return ErrUnknownType.Error()
+	}
+}
+
+// SCTPTransportWarning is a problem of the SCTP association which isn't fatal
+// yet, see SCTPTransport.OnWarning.
+type SCTPTransportWarning struct {
+	Type SCTPTransportWarningType
+
+	// Timer is the retransmission timer that expired, like T3-rtx for DATA
+	// chunks or T1-init for the association setup.
+	Timer string
+
+	// ConsecutiveTimeouts is the number of consecutive timeouts of the timer, it
+	// is zero for path failures.
+	ConsecutiveTimeouts uint
+}
+
+// OnWarning sets an event handler which is invoked when the SCTP association
+// has trouble reaching the remote peer, before it is aborted. Applications can
+// use it to stop relying on DataChannels early, like by falling back to media
+// only.
+func (r *SCTPTransport) OnWarning(f func(SCTPTransportWarning)) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.onWarningHandler = f
+}
+
+func (r *SCTPTransport) onWarning(warning SCTPTransportWarning) {
+	r.lock.RLock()
+	handler := r.onWarningHandler
+	r.lock.RUnlock()
+
+	r.log.Warnf("SCTP %s warning: %s timer, %d consecutive timeouts",
+		warning.Type, warning.Timer, warning.ConsecutiveTimeouts)
+	if handler != nil {
+		handler(warning)
+	}
+}
+
+// sctpWarningLoggerFactory creates the loggers of an SCTP association, watching
+// the log of the association for retransmission timeouts and failures and
+// counting the extended statistics of SCTPTransportStats.
+type sctpWarningLoggerFactory struct {
+	logging.LoggerFactory
+	transport *SCTPTransport
+}
+
+func (f *sctpWarningLoggerFactory) NewLogger(scope string) logging.LeveledLogger {
+	logger := f.LoggerFactory.NewLogger(scope)
+	if scope != "sctp" {
+		return logger
+	}
+
+	return &sctpWarningLogger{LeveledLogger: logger, transport: f.transport}
+}
+
+// sctpWarningLogger is called with the lock of the association held, so the
+// warnings are handled in their own goroutines.
+type sctpWarningLogger struct {
+	logging.LeveledLogger
+	transport *SCTPTransport
+}
+
+func (l *sctpWarningLogger) Debugf(format string, args ...any) {
+	l.LeveledLogger.Debugf(format, args...)
+	l.transport.counters.countDebug(format, args)
+
+	if format != sctpT3RTXTimeoutFormat || len(args) < 2 {
+		return
+	}
+	go l.transport.onRetransmissionTimeout()
+
+	threshold := l.transport.api.settingEngine.sctp.retransmissionWarningThreshold
+	if threshold == 0 {
+		threshold = defaultSCTPRetransmissionWarningThreshold
+	}
+	if nRtos, ok := args[1].(uint); ok && nRtos >= threshold {
+		go l.transport.onWarning(SCTPTransportWarning{
+			Type:                SCTPTransportWarningTypeRetransmissionThreshold,
+			Timer:               "T3-rtx",
+			ConsecutiveTimeouts: nRtos,
+		})
+	}
+}
+
+func (l *sctpWarningLogger) Tracef(format string, args ...any) {
+	l.LeveledLogger.Tracef(format, args...)
+	l.transport.counters.countTrace(format, args)
+}
+
+func (l *sctpWarningLogger) Errorf(format string, args ...any) {
+	l.LeveledLogger.Errorf(format, args...)
+
+	if timer, ok := strings.CutPrefix(format, sctpRetransmissionFailureFormatPre); ok {
+		go l.transport.onWarning(SCTPTransportWarning{
+			Type:  SCTPTransportWarningTypePathFailure,
+			Timer: strings.TrimSuffix(timer, " (DATA)"),
+		})
+	}
+}
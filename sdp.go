@@ -15,6 +15,7 @@ import (
 	"strconv"
 	"strings"
 	"sync/atomic"
+	"time"
 
 	"github.com/pion/ice/v4"
 	"github.com/pion/logging"
@@ -616,7 +617,7 @@ func addTransceiverSDP(
 	}
 
 	parameters := mediaEngine.getRTPParametersByKind(transceiver.kind, directions)
-	for _, rtpExtension := range parameters.HeaderExtensions {
+	for _, rtpExtension := range transceiver.filterHeaderExtensions(parameters.HeaderExtensions) {
 		if mediaSection.matchExtensions != nil {
 			if _, enabled := mediaSection.matchExtensions[rtpExtension.URI]; !enabled {
 				continue
@@ -678,6 +679,17 @@ type mediaSection struct {
 	rids            []*simulcastRid
 }
 
+// dataSectionMid returns the mid of a data section added after the media
+// sections, the number of media sections unless a media section uses it.
+func dataSectionMid(mediaSections []mediaSection) string {
+	for id := len(mediaSections); ; id++ {
+		mid := strconv.Itoa(id)
+		if !slices.ContainsFunc(mediaSections, func(m mediaSection) bool { return m.id == mid }) {
+			return mid
+		}
+	}
+}
+
 func bundleMatchFromRemote(matchBundleGroup *string) func(mid string) bool {
 	if matchBundleGroup == nil {
 		return func(string) bool {
@@ -877,50 +889,58 @@ func extractBundleID(desc *sdp.SessionDescription) string {
 	return bundleIDs[1]
 }
 
-func extractFingerprint(desc *sdp.SessionDescription) (string, string, error) { //nolint:gocognit,cyclop
-	fingerprint := ""
-
-	// Fingerprint on session level has highest priority
-	if sessionFingerprint, haveFingerprint := desc.Attribute("fingerprint"); haveFingerprint {
-		fingerprint = sessionFingerprint
-	}
-
-	if fingerprint == "" { //nolint:nestif
+// extractFingerprints returns the fingerprints of the remote certificate. A
+// section may have a fingerprint for each of several hash algorithms, all of them
+// are returned.
+func extractFingerprints(desc *sdp.SessionDescription) ([]DTLSFingerprint, error) {
+	// Fingerprints on session level have highest priority
+	fingerprints := fingerprintAttributes(desc.Attributes)
+
+	if len(fingerprints) == 0 {
+		// Locate the fingerprints of the bundled media section, or without bundle
+		// of the first media section which has any.
+		// Note: According to Bundle spec each media section would have it's own transport
+		//       with it's own cert and fingerprint each, so we would need to return a list.
 		bundleID := extractBundleID(desc)
-		if bundleID != "" {
-			// Locate the fingerprint of the bundled media section
-			for _, mediaDescr := range desc.MediaDescriptions {
-				if mid, haveMid := mediaDescr.Attribute("mid"); haveMid {
-					if mid == bundleID && fingerprint == "" {
-						if mediaFingerprint, haveFingerprint := mediaDescr.Attribute("fingerprint"); haveFingerprint {
-							fingerprint = mediaFingerprint
-						}
-					}
+		for _, mediaDescr := range desc.MediaDescriptions {
+			if bundleID != "" {
+				if mid, _ := mediaDescr.Attribute("mid"); mid != bundleID {
+					continue
 				}
 			}
-		} else {
-			// Take the fingerprint from the first media section which has one.
-			// Note: According to Bundle spec each media section would have it's own transport
-			//       with it's own cert and fingerprint each, so we would need to return a list.
-			for _, mediaDescr := range desc.MediaDescriptions {
-				mediaFingerprint, haveFingerprint := mediaDescr.Attribute("fingerprint")
-				if haveFingerprint && fingerprint == "" {
-					fingerprint = mediaFingerprint
-				}
+
+			if fingerprints = fingerprintAttributes(mediaDescr.Attributes); len(fingerprints) != 0 {
+				break
 			}
 		}
 	}
 
-	if fingerprint == "" {
-		return "", "", ErrSessionDescriptionNoFingerprint
+	if len(fingerprints) == 0 {
+		return nil, ErrSessionDescriptionNoFingerprint
 	}
 
-	parts := strings.Split(fingerprint, " ")
-	if len(parts) != 2 {
-		return "", "", ErrSessionDescriptionInvalidFingerprint
+	dtlsFingerprints := make([]DTLSFingerprint, 0, len(fingerprints))
+	for _, fingerprint := range fingerprints {
+		parts := strings.Split(fingerprint, " ")
+		if len(parts) != 2 {
+			return nil, ErrSessionDescriptionInvalidFingerprint
+		}
+
+		dtlsFingerprints = append(dtlsFingerprints, DTLSFingerprint{Algorithm: parts[0], Value: parts[1]})
+	}
+
+	return dtlsFingerprints, nil
+}
+
+func fingerprintAttributes(attributes []sdp.Attribute) []string {
+	var fingerprints []string
+	for _, attribute := range attributes {
+		if attribute.Key == "fingerprint" && attribute.Value != "" {
+			fingerprints = append(fingerprints, attribute.Value)
+		}
 	}
 
-	return parts[1], parts[0], nil
+	return fingerprints
 }
 
 // identifiedMediaDescription contains a MediaDescription with sdpMid and sdpMLineIndex.
@@ -1205,6 +1225,30 @@ func isExtMapAllowMixedSet(desc *sdp.SessionDescription) bool {
 	return false
 }
 
+// isCryptexSet returns whether the description signals Cryptex, RFC 9335, for
+// all of its RTP media sections. All of them share the SRTP session of the
+// BUNDLE group, so a single one without the attribute disables it.
+func isCryptexSet(desc *sdp.SessionDescription) bool {
+	for _, a := range desc.Attributes {
+		if strings.TrimSpace(a.Key) == sdp.AttrKeyCryptex {
+			return true
+		}
+	}
+
+	hasRTPMedia := false
+	for _, media := range desc.MediaDescriptions {
+		if media.MediaName.Media == mediaSectionApplication || media.MediaName.Port.Value == 0 {
+			continue
+		}
+		if _, ok := media.Attribute(sdp.AttrKeyCryptex); !ok {
+			return false
+		}
+		hasRTPMedia = true
+	}
+
+	return hasRTPMedia
+}
+
 func getMaxMessageSize(desc *sdp.MediaDescription) uint32 {
 	for _, a := range desc.Attributes {
 		if strings.TrimSpace(a.Key) == "max-message-size" {
@@ -1217,6 +1261,29 @@ func getMaxMessageSize(desc *sdp.MediaDescription) uint32 {
 	return 0
 }
 
+// getPacketizationTimes returns the ptime and maxptime attributes of a media
+// section, see RFC 4566 section 6. Missing or invalid ones are zero.
+func getPacketizationTimes(media *sdp.MediaDescription) packetizationTimes {
+	var times packetizationTimes
+	for _, a := range media.Attributes {
+		var target *time.Duration
+		switch strings.TrimSpace(a.Key) {
+		case "ptime":
+			target = &times.ptime
+		case "maxptime":
+			target = &times.maxPtime
+		default:
+			continue
+		}
+
+		if v, err := strconv.ParseFloat(strings.TrimSpace(a.Value), 64); err == nil && v > 0 {
+			*target = time.Duration(v * float64(time.Millisecond))
+		}
+	}
+
+	return times
+}
+
 func getSctpInit(desc *sdp.MediaDescription) ([]byte, error) {
 	for _, a := range desc.Attributes {
 		if strings.TrimSpace(a.Key) == "sctp-init" {
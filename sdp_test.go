@@ -12,6 +12,7 @@ import (
 	"encoding/base64"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/pion/sdp/v3"
 	"github.com/pion/transport/v4/test"
@@ -19,16 +20,15 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestExtractFingerprint(t *testing.T) {
+func TestExtractFingerprints(t *testing.T) {
 	t.Run("Good Session Fingerprint", func(t *testing.T) {
 		s := &sdp.SessionDescription{
 			Attributes: []sdp.Attribute{{Key: "fingerprint", Value: "foo bar"}},
 		}
 
-		fingerprint, hash, err := extractFingerprint(s)
+		fingerprints, err := extractFingerprints(s)
 		assert.NoError(t, err)
-		assert.Equal(t, fingerprint, "bar")
-		assert.Equal(t, hash, "foo")
+		assert.Equal(t, []DTLSFingerprint{{Algorithm: "foo", Value: "bar"}}, fingerprints)
 	})
 
 	t.Run("Good Media Fingerprint", func(t *testing.T) {
@@ -38,16 +38,15 @@ func TestExtractFingerprint(t *testing.T) {
 			},
 		}
 
-		fingerprint, hash, err := extractFingerprint(s)
+		fingerprints, err := extractFingerprints(s)
 		assert.NoError(t, err)
-		assert.Equal(t, fingerprint, "bar")
-		assert.Equal(t, hash, "foo")
+		assert.Equal(t, []DTLSFingerprint{{Algorithm: "foo", Value: "bar"}}, fingerprints)
 	})
 
 	t.Run("No Fingerprint", func(t *testing.T) {
 		s := &sdp.SessionDescription{}
 
-		_, _, err := extractFingerprint(s)
+		_, err := extractFingerprints(s)
 		assert.Equal(t, ErrSessionDescriptionNoFingerprint, err)
 	})
 
@@ -56,7 +55,7 @@ func TestExtractFingerprint(t *testing.T) {
 			Attributes: []sdp.Attribute{{Key: "fingerprint", Value: "foo"}},
 		}
 
-		_, _, err := extractFingerprint(s)
+		_, err := extractFingerprints(s)
 		assert.Equal(t, ErrSessionDescriptionInvalidFingerprint, err)
 	})
 
@@ -68,10 +67,9 @@ func TestExtractFingerprint(t *testing.T) {
 			},
 		}
 
-		fingerprint, hash, err := extractFingerprint(s)
+		fingerprints, err := extractFingerprints(s)
 		assert.NoError(t, err)
-		assert.Equal(t, fingerprint, "bar")
-		assert.Equal(t, hash, "foo")
+		assert.Equal(t, []DTLSFingerprint{{Algorithm: "foo", Value: "bar"}}, fingerprints)
 	})
 
 	t.Run("Fingerprint from master bundle section", func(t *testing.T) {
@@ -91,10 +89,9 @@ func TestExtractFingerprint(t *testing.T) {
 			},
 		}
 
-		fingerprint, hash, err := extractFingerprint(descr)
+		fingerprints, err := extractFingerprints(descr)
 		assert.NoError(t, err)
-		assert.Equal(t, fingerprint, "foo")
-		assert.Equal(t, hash, "bar")
+		assert.Equal(t, []DTLSFingerprint{{Algorithm: "bar", Value: "foo"}}, fingerprints)
 	})
 
 	t.Run("Fingerprint from first media section", func(t *testing.T) {
@@ -111,10 +108,28 @@ func TestExtractFingerprint(t *testing.T) {
 			},
 		}
 
-		fingerprint, hash, err := extractFingerprint(descr)
+		fingerprints, err := extractFingerprints(descr)
 		assert.NoError(t, err)
-		assert.Equal(t, fingerprint, "boo")
-		assert.Equal(t, hash, "zoo")
+		assert.Equal(t, []DTLSFingerprint{{Algorithm: "zoo", Value: "boo"}}, fingerprints)
+	})
+
+	t.Run("Multiple fingerprints", func(t *testing.T) {
+		descr := &sdp.SessionDescription{
+			MediaDescriptions: []*sdp.MediaDescription{
+				{Attributes: []sdp.Attribute{
+					{Key: "mid", Value: "0"},
+					{Key: "fingerprint", Value: "sha-384 AA"},
+					{Key: "fingerprint", Value: "sha-512 BB"},
+				}},
+			},
+		}
+
+		fingerprints, err := extractFingerprints(descr)
+		assert.NoError(t, err)
+		assert.Equal(t, []DTLSFingerprint{
+			{Algorithm: "sha-384", Value: "AA"},
+			{Algorithm: "sha-512", Value: "BB"},
+		}, fingerprints)
 	})
 }
 
@@ -1585,3 +1600,51 @@ func TestSctpInit(t *testing.T) {
 		assert.ErrorAs(t, err, &corruptInputError)
 	})
 }
+
+func TestGetPacketizationTimes(t *testing.T) {
+	media := &sdp.MediaDescription{
+		Attributes: []sdp.Attribute{
+			{Key: "ptime", Value: "20"},
+			{Key: "maxptime", Value: "2.5"},
+		},
+	}
+	assert.Equal(t, packetizationTimes{
+		ptime:    20 * time.Millisecond,
+		maxPtime: 2500 * time.Microsecond,
+	}, getPacketizationTimes(media))
+
+	media.Attributes = []sdp.Attribute{{Key: "ptime", Value: "abc"}, {Key: "maxptime", Value: "-1"}}
+	assert.Equal(t, packetizationTimes{}, getPacketizationTimes(media))
+}
+
+func TestIsCryptexSet(t *testing.T) {
+	cryptex := sdp.Attribute{Key: sdp.AttrKeyCryptex}
+	newMedia := func(kind string, attributes ...sdp.Attribute) *sdp.MediaDescription {
+		return &sdp.MediaDescription{
+			MediaName:  sdp.MediaName{Media: kind, Port: sdp.RangedPort{Value: 9}},
+			Attributes: attributes,
+		}
+	}
+
+	assert.True(t, isCryptexSet(&sdp.SessionDescription{
+		Attributes:        []sdp.Attribute{cryptex},
+		MediaDescriptions: []*sdp.MediaDescription{newMedia("audio")},
+	}))
+	assert.True(t, isCryptexSet(&sdp.SessionDescription{
+		MediaDescriptions: []*sdp.MediaDescription{
+			newMedia("audio", cryptex), newMedia("video", cryptex), newMedia(mediaSectionApplication),
+		},
+	}))
+	assert.False(t, isCryptexSet(&sdp.SessionDescription{
+		MediaDescriptions: []*sdp.MediaDescription{newMedia("audio", cryptex), newMedia("video")},
+	}))
+	assert.False(t, isCryptexSet(&sdp.SessionDescription{
+		MediaDescriptions: []*sdp.MediaDescription{newMedia(mediaSectionApplication, cryptex)},
+	}))
+
+	rejected := newMedia("video")
+	rejected.MediaName.Port.Value = 0
+	assert.True(t, isCryptexSet(&sdp.SessionDescription{
+		MediaDescriptions: []*sdp.MediaDescription{newMedia("audio", cryptex), rejected},
+	}))
+}
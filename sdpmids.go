@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pion/sdp/v3"
+)
+
+// synthesizeRemoteMids assigns mids to the media sections of a remote
+// description without one and adds a BUNDLE group if there is none, see
+// SettingEngine.SetSynthesizeMissingMids.
+func (pc *PeerConnection) synthesizeRemoteMids(desc *SessionDescription) error {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	var localMedia []*sdp.MediaDescription
+	if desc.Type == SDPTypeAnswer || desc.Type == SDPTypePranswer {
+		if local := pc.pendingLocalDescription; local != nil && local.parsed != nil {
+			localMedia = local.parsed.MediaDescriptions
+		}
+	}
+
+	used := map[string]bool{}
+	for _, media := range desc.parsed.MediaDescriptions {
+		if mid := getMidValue(media); mid != "" {
+			used[mid] = true
+		}
+	}
+
+	changed := false
+	mids := make([]string, 0, len(desc.parsed.MediaDescriptions))
+	for i, media := range desc.parsed.MediaDescriptions {
+		if mid := getMidValue(media); mid != "" {
+			mids = append(mids, mid)
+
+			continue
+		}
+
+		mid, ok := pc.synthesizedMids[i]
+		if !ok && i < len(localMedia) {
+			mid = getMidValue(localMedia[i])
+		}
+		if mid == "" || used[mid] {
+			mid = unusedMid(i, used)
+		}
+		used[mid] = true
+
+		if pc.synthesizedMids == nil {
+			pc.synthesizedMids = map[int]string{}
+		}
+		pc.synthesizedMids[i] = mid
+		media.WithValueAttribute(sdp.AttrKeyMID, mid)
+		mids = append(mids, mid)
+		changed = true
+	}
+
+	if _, ok := desc.parsed.Attribute(sdp.AttrKeyGroup); !ok && len(mids) != 0 {
+		desc.parsed.WithValueAttribute(sdp.AttrKeyGroup, "BUNDLE "+strings.Join(mids, " "))
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	b, err := desc.parsed.Marshal()
+	if err != nil {
+		return err
+	}
+	desc.SDP = string(b)
+
+	return nil
+}
+
+// unusedMid returns the index of the media section as mid, like the ones of
+// offers, or the first greater number that isn't used.
+func unusedMid(index int, used map[string]bool) string {
+	for ; ; index++ {
+		if mid := strconv.Itoa(index); !used[mid] {
+			return mid
+		}
+	}
+}
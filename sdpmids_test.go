@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var midAndGroupLines = regexp.MustCompile(`(?m)^a=(mid|group):.*\r\n`)
+
+func stripMids(desc SessionDescription) SessionDescription {
+	desc.SDP = midAndGroupLines.ReplaceAllString(desc.SDP, "")
+
+	return desc
+}
+
+func descriptionMids(t *testing.T, desc SessionDescription) []string {
+	t.Helper()
+
+	parsed, err := desc.Unmarshal()
+	require.NoError(t, err)
+
+	mids := []string{}
+	for _, media := range parsed.MediaDescriptions {
+		mids = append(mids, getMidValue(media))
+	}
+
+	return mids
+}
+
+func TestPeerConnection_SynthesizeMissingMids(t *testing.T) {
+	s := SettingEngine{}
+	s.SetSynthesizeMissingMids(true)
+	pcOffer, err := NewPeerConnection(Configuration{})
+	require.NoError(t, err)
+	pcAnswer, err := NewAPI(WithSettingEngine(s)).NewPeerConnection(Configuration{})
+	require.NoError(t, err)
+
+	_, err = pcOffer.AddTransceiverFromKind(RTPCodecTypeAudio)
+	require.NoError(t, err)
+	_, err = pcOffer.AddTransceiverFromKind(RTPCodecTypeVideo)
+	require.NoError(t, err)
+
+	pcPlain, err := NewPeerConnection(Configuration{})
+	require.NoError(t, err)
+	offer, err := pcOffer.CreateOffer(nil)
+	require.NoError(t, err)
+	assert.ErrorIs(t, pcPlain.SetRemoteDescription(stripMids(offer)), errPeerConnRemoteDescriptionWithoutMidValue)
+	require.NoError(t, pcPlain.Close())
+
+	// The answers have the same mids in every negotiation.
+	for range 2 {
+		offer, err = pcOffer.CreateOffer(nil)
+		require.NoError(t, err)
+		require.NoError(t, pcOffer.SetLocalDescription(offer))
+		require.NoError(t, pcAnswer.SetRemoteDescription(stripMids(offer)))
+
+		answer, err := pcAnswer.CreateAnswer(nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"0", "1"}, descriptionMids(t, answer))
+		assert.Contains(t, answer.SDP, "a=group:BUNDLE 0 1\r\n")
+		require.NoError(t, pcAnswer.SetLocalDescription(answer))
+		require.NoError(t, pcOffer.SetRemoteDescription(answer))
+
+		assert.Len(t, pcAnswer.GetTransceivers(), 2)
+	}
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
+
+func TestPeerConnection_SynthesizeMissingMids_Answer(t *testing.T) {
+	s := SettingEngine{}
+	s.SetSynthesizeMissingMids(true)
+	pcOffer, err := NewAPI(WithSettingEngine(s)).NewPeerConnection(Configuration{})
+	require.NoError(t, err)
+	pcAnswer, err := NewPeerConnection(Configuration{})
+	require.NoError(t, err)
+
+	_, err = pcOffer.AddTransceiverFromKind(RTPCodecTypeVideo)
+	require.NoError(t, err)
+	_, err = pcOffer.CreateDataChannel("data", nil)
+	require.NoError(t, err)
+
+	offer, err := pcOffer.CreateOffer(nil)
+	require.NoError(t, err)
+	require.NoError(t, pcOffer.SetLocalDescription(offer))
+	require.NoError(t, pcAnswer.SetRemoteDescription(offer))
+	answer, err := pcAnswer.CreateAnswer(nil)
+	require.NoError(t, err)
+	require.NoError(t, pcAnswer.SetLocalDescription(answer))
+
+	// The mids of an answer without them are the ones of the offer.
+	require.NoError(t, pcOffer.SetRemoteDescription(stripMids(answer)))
+	assert.Equal(t, descriptionMids(t, *pcOffer.LocalDescription()), descriptionMids(t, *pcOffer.RemoteDescription()))
+	assert.Equal(t, "0", pcOffer.GetTransceivers()[0].Mid())
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
@@ -7,20 +7,24 @@ package webrtc
 
 import (
 	"context"
+	"crypto"
 	"crypto/x509"
 	"errors"
 	"io"
+	"math/big"
 	"net"
 	"time"
 
 	"github.com/pion/dtls/v3"
 	dtlsElliptic "github.com/pion/dtls/v3/pkg/crypto/elliptic"
+	"github.com/pion/dtls/v3/pkg/crypto/fingerprint"
 	"github.com/pion/dtls/v3/pkg/protocol/handshake"
 	"github.com/pion/ice/v4"
 	"github.com/pion/logging"
 	"github.com/pion/stun/v3"
 	"github.com/pion/transport/v4"
 	"github.com/pion/transport/v4/packetio"
+	"github.com/pion/webrtc/v4/internal/util"
 	"golang.org/x/net/proxy"
 )
 
@@ -44,14 +48,25 @@ type SettingEngine struct {
 		ICEPrflxAcceptanceMinWait *time.Duration
 		ICERelayAcceptanceMinWait *time.Duration
 		ICESTUNGatherTimeout      *time.Duration
+		ConnectionPhases          ConnectionPhaseTimeouts
+		ICEPairTimeouts           map[ICECandidateType]iceTimeouts
+		ICECellularTimeouts       *iceTimeouts
 	}
 	renomination renominationSettings
-	candidates   struct {
+	generators   struct {
+		ssrc                    func() SSRC
+		mid                     func() string
+		certificateSerialNumber func() *big.Int
+		iceCredentials          func() (usernameFragment, password string)
+		sessionID               func() uint64
+	}
+	candidates struct {
 		ICELite                  bool
 		ICENetworkTypes          []NetworkType
 		InterfaceFilter          func(string) (keep bool)
 		IPFilter                 func(net.IP) (keep bool)
 		RemoteIPFilter           func(net.IP) (keep bool)
+		RemotePriorityHook       func(ICECandidate) (priority uint32)
 		NAT1To1IPs               []string
 		NAT1To1IPCandidateType   ICECandidateType
 		addressRewriteRules      []ice.AddressRewriteRule
@@ -70,6 +85,9 @@ type SettingEngine struct {
 		insecureSkipHelloVerify       bool
 		disableInsecureSkipVerify     bool
 		retransmissionInterval        time.Duration
+		disableRetransmitBackoff      bool
+		mtu                           int
+		handshakeTimeout              time.Duration
 		ellipticCurves                []dtlsElliptic.Curve
 		connectContextMaker           func() (context.Context, func())
 		extendedMasterSecret          dtls.ExtendedMasterSecretType
@@ -83,6 +101,11 @@ type SettingEngine struct {
 		serverHelloMessageHook        func(handshake.MessageServerHello) handshake.Message
 		certificateRequestMessageHook func(handshake.MessageCertificateRequest) handshake.Message
 		supportedProtocols            []string
+		fingerprintAlgorithms         []crypto.Hash
+		sessionCache                  *DTLSSessionCache
+		sharedCertificates            []Certificate
+		verifyPeerCertificate         func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error
+		connectionIDGenerator         func() []byte
 	}
 	sctp struct {
 		maxReceiveBufferSize uint32
@@ -93,8 +116,30 @@ type SettingEngine struct {
 		fastRtxWnd           uint32
 		cwndCAStep           uint32
 		enableSnap           bool
+
+		retransmissionWarningThreshold uint
+		shutdownTimeout                time.Duration
+
+		schedulingPolicy            DataChannelSchedulingPolicy
+		schedulingMaxBufferedAmount int
+
+		dataChannelQueueMaxChannels int
+		dataChannelQueueMaxBytes    int
+
+		dataChannelOpenTimeout time.Duration
+
+		receiveQueueMaxMessages int
+		receiveQueueMaxBytes    int
+		receiveQueuePolicy      DataChannelReceiveQueuePolicy
+
+		reuseReadBuffer bool
+
+		acceptCompression   *DataChannelCompression
+		acceptFragmentation *DataChannelFragmentation
 	}
 	sdpMediaLevelFingerprints                 bool
+	jitterHistograms                          bool
+	synthesizeMissingMids                     bool
 	answeringDTLSRole                         DTLSRole
 	disableCertificateFingerprintVerification bool
 	disableSRTPReplayProtection               bool
@@ -110,6 +155,7 @@ type SettingEngine struct {
 	disableMediaEngineCopy                    bool
 	disableMediaEngineMultipleCodecs          bool
 	srtpProtectionProfiles                    []dtls.SRTPProtectionProfile
+	enableCryptex                             bool
 	receiveMTU                                uint
 	iceMaxBindingRequests                     *uint16
 	fireOnTrackBeforeFirstRTP                 bool
@@ -117,6 +163,12 @@ type SettingEngine struct {
 	dataChannelBlockWrite                     bool
 	handleUndeclaredSSRCWithoutAnswer         bool
 	ignoreRidPauseForRecv                     bool
+	reportUnknownHeaderExtensions             bool
+	inboundRTPPacketFilter                    func(ssrc SSRC, payloadType PayloadType, size int) (keep bool)
+	rtpKeepAliveInterval                      time.Duration
+	rtpPacketPooling                          bool
+	rtcEventLog                               bool
+	instrumentation                           *Instrumentation
 }
 
 type renominationSettings struct {
@@ -209,6 +261,32 @@ func (e *SettingEngine) getReceiveMTU() uint {
 	return receiveMTU
 }
 
+// iceCredentials returns the local ICE credentials, empty ones are generated by
+// the ICE agent.
+func (e *SettingEngine) iceCredentials() (usernameFragment, password string) {
+	if e.generators.iceCredentials != nil {
+		return e.generators.iceCredentials()
+	}
+
+	return e.candidates.UsernameFragment, e.candidates.Password
+}
+
+func (e *SettingEngine) generateSSRC() SSRC {
+	if e.generators.ssrc != nil {
+		return e.generators.ssrc()
+	}
+
+	return SSRC(util.RandUint32())
+}
+
+func (e *SettingEngine) generateCertificate(secretKey crypto.PrivateKey) (*Certificate, error) {
+	if e.generators.certificateSerialNumber != nil {
+		return generateCertificate(secretKey, e.generators.certificateSerialNumber())
+	}
+
+	return GenerateCertificate(secretKey)
+}
+
 // DetachDataChannels enables detaching data channels. When enabled
 // data channels have to be detached in the OnOpen callback using the
 // DataChannel.Detach method.
@@ -228,6 +306,18 @@ func (e *SettingEngine) SetSRTPProtectionProfiles(profiles ...dtls.SRTPProtectio
 	e.srtpProtectionProfiles = profiles
 }
 
+// EnableCryptex signals Cryptex, RFC 9335, in offers and in answers to offers
+// that signal it. When both peers signal it, the header extensions and CSRCs of
+// RTP packets are encrypted too, so audio levels and MIDs aren't visible on the
+// network. The SRTP session is shared by all media of the BUNDLE group, so the
+// remote description must signal it for the session or all of its RTP media
+// sections, and it is decided by the first negotiation. RTP packets with header
+// extensions other than the one-byte and two-byte ones of RFC 8285 can't be
+// sent then.
+func (e *SettingEngine) EnableCryptex(enable bool) {
+	e.enableCryptex = enable
+}
+
 // SetICETimeouts sets the behavior around ICE Timeouts
 //
 // disconnectedTimeout:
@@ -241,6 +331,9 @@ func (e *SettingEngine) SetSRTPProtectionProfiles(profiles ...dtls.SRTPProtectio
 // keepAliveInterval:
 //
 //	How often the ICE Agent sends extra traffic if there is no activity, if media is flowing no traffic will be sent.
+//	The keepalives are STUN Binding Requests, which are also consent checks (RFC 7675). pion/ice builds them and
+//	refuses to send other STUN messages on the selected pair, so Binding Indications, padding or a jittered interval
+//	aren't available.
 //
 // Default is 2 seconds.
 func (e *SettingEngine) SetICETimeouts(disconnectedTimeout, failedTimeout, keepAliveInterval time.Duration) {
@@ -249,7 +342,46 @@ func (e *SettingEngine) SetICETimeouts(disconnectedTimeout, failedTimeout, keepA
 	e.timeout.ICEKeepaliveInterval = &keepAliveInterval
 }
 
+// SetICETimeoutsForCandidateType sets the disconnected and failed timeouts of
+// SetICETimeouts for when the selected candidate pair has the candidate type.
+// The type of a pair is relay if one of its candidates is relayed, srflx if one is
+// server or peer reflexive and host otherwise, so use ICECandidateTypeHost,
+// ICECandidateTypeSrflx or ICECandidateTypeRelay. The timeouts are updated when
+// another pair is selected.
+func (e *SettingEngine) SetICETimeoutsForCandidateType(
+	candidateType ICECandidateType,
+	disconnectedTimeout, failedTimeout time.Duration,
+) {
+	if e.timeout.ICEPairTimeouts == nil {
+		e.timeout.ICEPairTimeouts = map[ICECandidateType]iceTimeouts{}
+	}
+	e.timeout.ICEPairTimeouts[candidateType] = iceTimeouts{disconnected: disconnectedTimeout, failed: failedTimeout}
+}
+
+// SetICECellularTimeouts sets the disconnected and failed timeouts of
+// SetICETimeouts for when the selected candidate pair is on a cellular network,
+// overriding the ones of SetICETimeoutsForCandidateType. Cellular networks are
+// recognized by the network-cost extension of the remote candidate, which
+// libwebrtc sets between 250 and 980 for them.
+func (e *SettingEngine) SetICECellularTimeouts(disconnectedTimeout, failedTimeout time.Duration) {
+	e.timeout.ICECellularTimeouts = &iceTimeouts{disconnected: disconnectedTimeout, failed: failedTimeout}
+}
+
+// SetConnectionPhaseTimeouts sets deadlines for the phases of establishing a
+// PeerConnection. A PeerConnection moves to Failed once a phase exceeds its
+// deadline, ConnectionFailureCause returns which one. Unlike the ICE timeouts,
+// they apply before the PeerConnection was connected. The phase isn't aborted,
+// the PeerConnection recovers if it completes later, like after an ICE restart.
+func (e *SettingEngine) SetConnectionPhaseTimeouts(timeouts ConnectionPhaseTimeouts) {
+	e.timeout.ConnectionPhases = timeouts
+}
+
 // SetHostAcceptanceMinWait sets the ICEHostAcceptanceMinWait.
+// The DTLS handshake starts once the ICE agent selected a candidate pair, and
+// the acceptance minimum waits delay the selection by the controlling agent,
+// so lowering them speeds up connection establishment. pion/ice doesn't allow
+// sending before a pair is selected, DTLS can't start on the first succeeded
+// pair and move to the nominated one.
 func (e *SettingEngine) SetHostAcceptanceMinWait(t time.Duration) {
 	e.timeout.ICEHostAcceptanceMinWait = &t
 }
@@ -325,6 +457,21 @@ func (e *SettingEngine) SetRemoteIPFilter(filter func(net.IP) (keep bool)) {
 	e.candidates.RemoteIPFilter = filter
 }
 
+// SetICERemoteCandidatePriorityHook sets a function that returns the priority to use
+// for a remote candidate before it is added to the ICE agent. Candidate pair priorities
+// are derived from the priorities of both candidates (RFC 8445 Section 6.1.2.3), so this
+// allows applications to steer path selection before connectivity checks begin, e.g.
+// preferring candidates advertised with a lower network-cost.
+// Returning the candidate's own Priority keeps the default ordering.
+//
+// Only remote candidates are passed to the hook. The priorities of the local
+// candidates are computed by the ICE agent, which has no way to override them,
+// so preferring a local interface has to be done with SetInterfaceFilter or
+// SetIPFilter instead.
+func (e *SettingEngine) SetICERemoteCandidatePriorityHook(hook func(candidate ICECandidate) (priority uint32)) {
+	e.candidates.RemotePriorityHook = hook
+}
+
 // SetNAT1To1IPs sets a list of external IP addresses of 1:1 (D)NAT
 // and a candidate type for which the external IP address is used.
 // This is useful when you host a server using Pion on an AWS EC2 instance
@@ -444,6 +591,116 @@ func (e *SettingEngine) SetICECredentials(usernameFragment, password string) {
 	e.candidates.Password = password
 }
 
+// SetICECredentialGenerator sets a generator of the local ICE credentials, which
+// is called when gathering starts and on every ICE restart. It takes precedence
+// over SetICECredentials.
+//
+// This is useful for reproducible environments that restart ICE.
+func (e *SettingEngine) SetICECredentialGenerator(generator func() (usernameFragment, password string)) {
+	e.generators.iceCredentials = generator
+}
+
+// The lengths of ICE credentials, see RFC 8839 section 5.4.
+const (
+	iceUsernameFragmentMinLength = 4
+	icePasswordMinLength         = 22
+	iceCredentialMaxLength       = 256
+)
+
+// SetSharedCredentials sets the ICE credentials and DTLS certificates of all
+// PeerConnections and DTLSTransports created with the SettingEngine. Nodes of
+// an anycast or load-balanced deployment that share them accept the
+// connectivity checks and the DTLS handshake of a session that fails over from
+// another node. Anyone knowing them can impersonate the nodes, distribute them
+// securely and rotate them.
+//
+// The credentials replace the ones of SetICECredentials and
+// SetICECredentialGenerator and are kept on ICE restarts. The certificates are
+// used unless the Configuration has Certificates. An error is returned if the
+// credentials don't satisfy RFC 8839 section 5.4, or if no certificate is given
+// or one is expired.
+//
+// The ICE muxes of SetICEUDPMux and SetICETCPMux demultiplex by the local
+// username fragment, so with shared credentials the PeerConnections using the
+// same mux share one connection of it and read each other's packets, and closing
+// one of them closes that connection for all. With a mux, use them for a single
+// PeerConnection per node at a time.
+func (e *SettingEngine) SetSharedCredentials(usernameFragment, password string, certificates ...Certificate) error {
+	if !isICECredential(usernameFragment, iceUsernameFragmentMinLength) {
+		return errSharedCredentialsUsernameFragment
+	}
+	if !isICECredential(password, icePasswordMinLength) {
+		return errSharedCredentialsPassword
+	}
+	if len(certificates) == 0 {
+		return errSharedCredentialsNoCertificate
+	}
+	now := time.Now()
+	for _, certificate := range certificates {
+		if !certificate.Expires().IsZero() && now.After(certificate.Expires()) {
+			return ErrCertificateExpired
+		}
+	}
+
+	e.candidates.UsernameFragment = usernameFragment
+	e.candidates.Password = password
+	e.generators.iceCredentials = nil
+	e.dtls.sharedCertificates = append([]Certificate{}, certificates...)
+
+	return nil
+}
+
+// isICECredential returns whether s consists of minLength to 256 ice-chars,
+// see RFC 8839 section 5.4.
+func isICECredential(s string, minLength int) bool {
+	if len(s) < minLength || len(s) > iceCredentialMaxLength {
+		return false
+	}
+
+	for _, c := range s {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '+', c == '/':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// SetSSRCGenerator sets a generator of the SSRCs of the RTP streams sent,
+// including their RTX and FEC streams, instead of random SSRCs. The SSRCs have
+// to be unique within a PeerConnection.
+//
+// This is useful for golden-file SDP tests and record/replay tests.
+func (e *SettingEngine) SetSSRCGenerator(generator func() SSRC) {
+	e.generators.ssrc = generator
+}
+
+// SetMidGenerator sets a generator of the mids of the transceivers a
+// PeerConnection offers, instead of numbering them. The mids have to be unique
+// within a PeerConnection.
+func (e *SettingEngine) SetMidGenerator(generator func() string) {
+	e.generators.mid = generator
+}
+
+// SetSessionIDGenerator sets a generator of the session ID in the origin of the
+// descriptions of a PeerConnection, instead of a random session ID. Its highest
+// bit is cleared as required by RFC 8829, and the session ID of the first
+// description set is kept for the later ones. The session version still starts
+// at the current time.
+func (e *SettingEngine) SetSessionIDGenerator(generator func() uint64) {
+	e.generators.sessionID = generator
+}
+
+// SetCertificateSerialNumberGenerator sets a generator of the serial numbers of
+// the certificates generated for PeerConnections and DTLSTransports without
+// certificates, instead of random serial numbers. Their keys are still random,
+// so set Configuration.Certificates for reproducible fingerprints.
+func (e *SettingEngine) SetCertificateSerialNumberGenerator(generator func() *big.Int) {
+	e.generators.certificateSerialNumber = generator
+}
+
 // DisableCertificateFingerprintVerification disables fingerprint verification after DTLS Handshake has finished.
 func (e *SettingEngine) DisableCertificateFingerprintVerification(isDisabled bool) {
 	e.disableCertificateFingerprintVerification = isDisabled
@@ -466,6 +723,71 @@ func (e *SettingEngine) SetSRTCPReplayProtectionWindow(n uint) {
 	e.replayProtection.SRTCP = &n
 }
 
+// SetInboundRTPPacketFilter sets a function that decides whether an inbound
+// RTP packet is processed. It is called with the SSRC and payload type of the
+// packet, which SRTP doesn't encrypt, and the size of the packet before the
+// packet is decrypted, so floods of unwanted packets can be dropped cheaply.
+// The filter is called for every packet from the read loop of the SRTP
+// session, it must not block. Dropped packets are counted by
+// DTLSTransport.RTPPacketFilterStats.
+func (e *SettingEngine) SetInboundRTPPacketFilter(
+	filter func(ssrc SSRC, payloadType PayloadType, size int) (keep bool),
+) {
+	e.inboundRTPPacketFilter = filter
+}
+
+// EnableRTPPacketPooling makes TrackRemote.ReadRTP, and ReadRTCP and
+// ReadSimulcastRTCP of RTPReceiver and RTPSender, read packets into pooled
+// buffers of the receive MTU, instead of allocating a buffer of the receive MTU
+// for every packet. This pays off for SFUs reading many packets. The buffer of
+// a packet goes back to the pool with the next read of the same TrackRemote,
+// RTPReceiver or RTPSender, so the packets, and the Attributes returned along
+// with them, are only valid until then. TrackRemote.ReadSample copies the
+// packets of the samples it builds. It is disabled by default.
+func (e *SettingEngine) EnableRTPPacketPooling(enable bool) {
+	e.rtpPacketPooling = enable
+}
+
+// EnableRTCEventLog allows PeerConnection.StartRTCEventLog. The PeerConnections
+// then watch their packets to log them once an event log is started, which
+// costs a check for every packet while no log is running. It is disabled by
+// default, StartRTCEventLog returns an error then.
+func (e *SettingEngine) EnableRTCEventLog(enable bool) {
+	e.rtcEventLog = enable
+}
+
+// SetInstrumentation makes the PeerConnections count their RTP and RTCP
+// packets, DTLS handshakes and DataChannel messages, and time their
+// interceptors, in the Instrumentation. The messages of detached DataChannels
+// aren't counted. Timing costs a few clock reads per packet, so it is disabled
+// by default.
+func (e *SettingEngine) SetInstrumentation(instrumentation *Instrumentation) {
+	e.instrumentation = instrumentation
+}
+
+// SetRTPKeepAliveInterval makes the PeerConnection send an RTP keep-alive
+// every interval on each media section it only receives on, so NATs that only
+// keep a mapping open for outbound traffic keep forwarding the media. The
+// keep-alives are RTP packets without payload with a payload type that isn't
+// negotiated, RFC 6263 Section 4.6, which receivers drop. Zero, the default,
+// disables them.
+//
+// The ICE agent also sends STUN Binding Requests while nothing else is sent,
+// their interval is configured by SetICETimeouts.
+func (e *SettingEngine) SetRTPKeepAliveInterval(interval time.Duration) {
+	e.rtpKeepAliveInterval = interval
+}
+
+// EnableJitterHistograms makes the InboundRTPStreamStats of remote tracks
+// report histograms of the jitter and the transit delay of their packets, see
+// InboundRTPStreamStats.JitterHistogram. Unlike the single Jitter estimate of
+// RFC 3550 they show the tail of the delays. The packets are timed when they
+// are read from the TrackRemote, like the interceptors time them. The
+// histograms grow until TrackRemote.ResetJitterHistograms is called.
+func (e *SettingEngine) EnableJitterHistograms(enable bool) {
+	e.jitterHistograms = enable
+}
+
 // DisableSRTPReplayProtection disables SRTP replay protection.
 func (e *SettingEngine) DisableSRTPReplayProtection(isDisabled bool) {
 	e.disableSRTPReplayProtection = isDisabled
@@ -484,6 +806,15 @@ func (e *SettingEngine) SetSDPMediaLevelFingerprints(sdpMediaLevelFingerprints b
 	e.sdpMediaLevelFingerprints = sdpMediaLevelFingerprints
 }
 
+// SetSynthesizeMissingMids enables a compatibility mode for remote descriptions
+// without a=mid attributes, as sent by older SIP equipment. The media sections
+// missing one are assigned a mid, the one of the local description for answers,
+// and a BUNDLE group of all media sections is added if there is none. The mids
+// are kept per media section, so the descriptions of renegotiations match.
+func (e *SettingEngine) SetSynthesizeMissingMids(synthesize bool) {
+	e.synthesizeMissingMids = synthesize
+}
+
 // SetICETCPMux enables ICE-TCP when set to a non-nil value. Make sure that
 // NetworkTypeTCP4 or NetworkTypeTCP6 is enabled as well.
 func (e *SettingEngine) SetICETCPMux(tcpMux ice.TCPMux) {
@@ -530,6 +861,14 @@ func (e *SettingEngine) DisableMediaEngineMultipleCodecs(isDisabled bool) {
 	e.disableMediaEngineMultipleCodecs = isDisabled
 }
 
+// SetReportUnknownHeaderExtensions reports the header extensions of received
+// packets that weren't negotiated, see ReceivedHeaderExtensionsFromAttributes.
+// This is useful to experiment with draft extensions, but every packet with
+// header extensions is parsed an additional time.
+func (e *SettingEngine) SetReportUnknownHeaderExtensions(report bool) {
+	e.reportUnknownHeaderExtensions = report
+}
+
 // SetReceiveMTU sets the size of read buffer that copies incoming packets. This is optional.
 // Leave this 0 for the default receiveMTU.
 func (e *SettingEngine) SetReceiveMTU(receiveMTU uint) {
@@ -541,6 +880,41 @@ func (e *SettingEngine) SetDTLSRetransmissionInterval(interval time.Duration) {
 	e.dtls.retransmissionInterval = interval
 }
 
+// SetDTLSDisableRetransmitBackoff disables the exponential backoff of the DTLS
+// retransmission timer, flights are retransmitted every retransmission interval
+// instead. This speeds up handshakes on lossy networks at the cost of more
+// retransmissions.
+func (e *SettingEngine) SetDTLSDisableRetransmitBackoff(disable bool) {
+	e.dtls.disableRetransmitBackoff = disable
+}
+
+// SetDTLSMTU sets the size at which DTLS handshake messages are fragmented, like
+// to avoid IP fragmentation of the certificate on paths with a small MTU. Leave
+// this 0 for the default of 1200 bytes.
+func (e *SettingEngine) SetDTLSMTU(mtu int) {
+	e.dtls.mtu = mtu
+}
+
+// SetDTLSHandshakeTimeout sets the time after which the DTLS handshake is given up
+// while flights aren't answered, the DTLSTransport fails then. It applies on top
+// of the context of SetDTLSConnectContextMaker. Leave this 0 to not time out.
+func (e *SettingEngine) SetDTLSHandshakeTimeout(timeout time.Duration) {
+	e.dtls.handshakeTimeout = timeout
+}
+
+// SetDTLSConnectionIDGenerator enables the DTLS Connection ID extension of RFC
+// 9146, generator returns the connection IDs the remote peer puts into its
+// records, like dtls.RandomCIDGenerator(8). dtls.OnlySendCIDGenerator sends
+// the connection IDs of the remote peer without asking for ones of our own.
+// Connection IDs are only used if the remote peer supports them, browsers
+// don't. The ICETransport keeps the DTLS session when it switches candidate
+// pairs or restarts without them, they are for remote peers that rely on them
+// to find the session of a record. Leave this nil to not negotiate them, which
+// is the default.
+func (e *SettingEngine) SetDTLSConnectionIDGenerator(generator func() []byte) {
+	e.dtls.connectionIDGenerator = generator
+}
+
 // SetDTLSInsecureSkipHelloVerify sets the skip HelloVerify flag for DTLS.
 // If true and when acting as DTLS server, will allow client to skip hello verify phase and
 // receive ServerHello after initial ClientHello. This will mean faster connect times,
@@ -591,6 +965,23 @@ func (e *SettingEngine) SetDTLSRootCAs(rootCAs *x509.CertPool) {
 	e.dtls.rootCAs = rootCAs
 }
 
+// SetDTLSVerifyPeerCertificate sets a callback which is invoked with the
+// certificates the remote peer sent in the DTLS handshake, its certificate
+// first and then the intermediates, after the fingerprint of its certificate
+// was verified. The handshake fails if it returns an error, so applications can
+// authenticate peers by their certificates on top of the fingerprints, like
+// with a private PKI. verifiedChains is empty unless
+// SetDTLSDisableInsecureSkipVerify is used with SetDTLSRootCAs for clients or
+// SetDTLSClientCAs for servers, the callback can verify rawCerts itself
+// otherwise. Resumed sessions, see SetDTLSSessionCache, don't exchange
+// certificates and don't invoke it. Send an intermediate chain with
+// CertificateFromX509Chain.
+func (e *SettingEngine) SetDTLSVerifyPeerCertificate(
+	verify func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error,
+) {
+	e.dtls.verifyPeerCertificate = verify
+}
+
 // SetDTLSKeyLogWriter sets the destination of the TLS key material for debugging.
 // Logging key material compromises security and should only be use for debugging.
 func (e *SettingEngine) SetDTLSKeyLogWriter(writer io.Writer) {
@@ -662,12 +1053,165 @@ func (e *SettingEngine) SetDTLSSupportedProtocols(protocols ...string) {
 	e.dtls.supportedProtocols = protocols
 }
 
+// SetDTLSSessionCache enables DTLS session resumption, the DTLSTransports store
+// their sessions in the cache and resume them when connecting to the same remote
+// peer later, saving a round trip of the handshake. Sessions are bound to the
+// fingerprint of the remote certificate, so they are only resumed with peers that
+// use the same certificate again, like with Configuration.Certificates. Share the
+// cache between the PeerConnections that should resume sessions.
+//
+// DTLS servers don't resume sessions of clients that sent a certificate, see
+// CVE-2016-5419, so sessions are only resumed when DTLSRoleServer doesn't request
+// client certificates with SetDTLSClientAuth(dtls.NoClientCert). The server can't
+// verify the fingerprint of the client then.
+func (e *SettingEngine) SetDTLSSessionCache(cache *DTLSSessionCache) {
+	e.dtls.sessionCache = cache
+}
+
+// SetDTLSFingerprintAlgorithms sets the hash algorithms of the fingerprints of
+// the local certificate, a fingerprint line is added to the SDP for each of them
+// in order. The remote peer verifies the certificate with the fingerprints of the
+// strongest algorithm it supports, like this PeerConnection does with remote
+// fingerprints. An error is returned if an algorithm can't be used for
+// fingerprints. Leave this unset for SHA-256, SHA-384 and SHA-512, in this
+// order. Set SHA-256 only for remote peers that reject session descriptions
+// with several fingerprints.
+func (e *SettingEngine) SetDTLSFingerprintAlgorithms(algorithms ...crypto.Hash) error {
+	for _, algorithm := range algorithms {
+		if _, err := fingerprint.StringFromHash(algorithm); err != nil {
+			return err
+		}
+	}
+
+	e.dtls.fingerprintAlgorithms = algorithms
+
+	return nil
+}
+
+func (e *SettingEngine) getDTLSFingerprintAlgorithms() []crypto.Hash {
+	if len(e.dtls.fingerprintAlgorithms) != 0 {
+		return e.dtls.fingerprintAlgorithms
+	}
+
+	return defaultFingerprintAlgorithms
+}
+
 // SetSCTPRTOMax sets the maximum retransmission timeout.
 // Leave this 0 for the default timeout.
 func (e *SettingEngine) SetSCTPRTOMax(rtoMax time.Duration) {
 	e.sctp.rtoMax = rtoMax
 }
 
+// SetSCTPRetransmissionWarningThreshold sets the number of consecutive
+// retransmission timeouts of DATA chunks after which SCTPTransport.OnWarning is
+// invoked. Leave this 0 for the default of 3.
+func (e *SettingEngine) SetSCTPRetransmissionWarningThreshold(threshold uint) {
+	e.sctp.retransmissionWarningThreshold = threshold
+}
+
+// SetSCTPShutdownTimeout makes SCTPTransport.Stop, and so PeerConnection.Close,
+// shut down the SCTP association gracefully. The messages queued on reliable
+// DataChannels are delivered and acknowledged first, waiting at most the
+// timeout before the association is aborted. Leave this 0 to abort the
+// association right away, dropping the queued messages.
+func (e *SettingEngine) SetSCTPShutdownTimeout(timeout time.Duration) {
+	e.sctp.shutdownTimeout = timeout
+}
+
+// SetDataChannelScheduling sets how the DataChannels share the SCTP association
+// by their priorities, see DataChannelInit.Priority. While the association
+// buffers more than maxBufferedAmount bytes Send and SendText queue the
+// messages, which are passed to the association by the policy once it drains.
+// Errors of queued messages are reported with DataChannel.OnError. Leave
+// maxBufferedAmount 0 for the default of 64KiB. Detached DataChannels aren't
+// scheduled. The default policy DataChannelSchedulingPolicyFIFO doesn't queue
+// messages.
+//
+// The chunks of messages are never interleaved, pion/sctp doesn't support the
+// I-DATA chunks of RFC 8260. A large message passed to the association delays
+// the messages of all other DataChannels until it is sent, keep messages small
+// or lower maxBufferedAmount to bound this.
+func (e *SettingEngine) SetDataChannelScheduling(policy DataChannelSchedulingPolicy, maxBufferedAmount int) {
+	e.sctp.schedulingPolicy = policy
+	e.sctp.schedulingMaxBufferedAmount = maxBufferedAmount
+}
+
+// SetDataChannelQueueLimits bounds the DataChannels that CreateDataChannel
+// queues before the SCTP association is established. maxChannels is the maximum
+// number of queued DataChannels and maxBytes the maximum sum of the lengths of
+// their labels and protocols, which are sent in their DATA_CHANNEL_OPEN messages.
+// CreateDataChannel returns ErrDataChannelQueueChannelsExceeded or
+// ErrDataChannelQueueBytesExceeded when a limit is exceeded. Leave a limit 0 to
+// not bound the queue.
+func (e *SettingEngine) SetDataChannelQueueLimits(maxChannels, maxBytes int) {
+	e.sctp.dataChannelQueueMaxChannels = maxChannels
+	e.sctp.dataChannelQueueMaxBytes = maxBytes
+}
+
+// SetDataChannelReceiveQueueLimits passes the received messages of every
+// DataChannel to its OnMessage handler through a queue of its own, instead of
+// invoking the handler from the read loop. A slow handler doesn't stop the
+// DataChannel from reading then, and the queue holds at most maxMessages
+// messages with at most maxBytes bytes in total. The policy decides what
+// happens to the messages received while the queue is full, dropped messages
+// are counted in DataChannelStats.MessagesDropped. Leave a limit 0 to not
+// bound the queue by it, with both limits 0 there is no queue, which is the
+// default. Without the queue the remote peer can't send faster than the
+// handler reads, the SCTP receive window fills up instead. Detached
+// DataChannels aren't queued.
+func (e *SettingEngine) SetDataChannelReceiveQueueLimits(
+	maxMessages, maxBytes int,
+	policy DataChannelReceiveQueuePolicy,
+) {
+	e.sctp.receiveQueueMaxMessages = maxMessages
+	e.sctp.receiveQueueMaxBytes = maxBytes
+	e.sctp.receiveQueuePolicy = policy
+}
+
+// SetDataChannelOpenTimeout closes the DataChannels created by this peer that
+// the remote peer doesn't acknowledge within timeout, their OnOpen handler is
+// never invoked. DataChannel.OnError is invoked with ErrDataChannelOpenTimeout
+// and the DataChannel is closed like with DataChannel.Close. The timeout starts
+// when the DATA_CHANNEL_OPEN message is sent, once the SCTP association is
+// established. Negotiated and detached DataChannels aren't acknowledged and
+// never time out. Leave this 0 to wait for the acknowledgement forever, which
+// is the default.
+func (e *SettingEngine) SetDataChannelOpenTimeout(timeout time.Duration) {
+	e.sctp.dataChannelOpenTimeout = timeout
+}
+
+// AcceptDataChannelCompression makes the ordered DataChannels the remote peer
+// opens with compression use it as well, their messages are compressed with
+// the Threshold of compression. The remote peer starts
+// compressing once this peer confirmed it. The DataChannels the remote peer
+// opens are uncompressed and their Protocol keeps the extension when nil, which
+// is the default.
+func (e *SettingEngine) AcceptDataChannelCompression(compression *DataChannelCompression) {
+	e.sctp.acceptCompression = compression
+}
+
+// AcceptDataChannelFragmentation makes the reliable and ordered DataChannels the
+// remote peer opens with fragmentation use it as well, with the FragmentSize
+// and MaxMessageSize of fragmentation. The remote peer starts fragmenting once
+// this peer confirmed it. The DataChannels the remote peer opens aren't
+// fragmented and their Protocol keeps the extension when nil, which is the
+// default.
+func (e *SettingEngine) AcceptDataChannelFragmentation(fragmentation *DataChannelFragmentation) {
+	e.sctp.acceptFragmentation = fragmentation
+}
+
+// EnableDataChannelReadBufferReuse makes DataChannels pass the buffer they read
+// messages into to the OnMessage handler instead of a copy of every message,
+// which saves an allocation per message for applications with high message
+// rates. The Data of a DataChannelMessage is then only valid until the handler
+// returns and is overwritten by the next message, the handler must copy it to
+// keep it. Decompressed messages are allocated anyway, and detached
+// DataChannels read into the buffers of the application. It is disabled by
+// default.
+func (e *SettingEngine) EnableDataChannelReadBufferReuse(enable bool) {
+	e.sctp.reuseReadBuffer = enable
+}
+
 // SetSCTPMinCwnd sets the minimum congestion window size. The congestion window
 // will not be smaller than this value during congestion control.
 func (e *SettingEngine) SetSCTPMinCwnd(minCwnd uint32) {
@@ -680,6 +1224,11 @@ func (e *SettingEngine) SetSCTPFastRtxWnd(fastRtxWnd uint32) {
 }
 
 // SetSCTPCwndCAStep sets congestion window adjustment step size during congestion avoidance.
+// The window grows by at least one MTU per round trip by default, a larger step
+// lets it reach the bandwidth-delay product of a long fat link sooner. pion/sctp
+// only implements the AIMD congestion control of RFC 9260 Section 7.2, there is
+// no other congestion controller to select. SetSCTPMinCwnd, SetSCTPFastRtxWnd and
+// this step are the parameters of it.
 func (e *SettingEngine) SetSCTPCwndCAStep(cwndCAStep uint32) {
 	e.sctp.cwndCAStep = cwndCAStep
 }
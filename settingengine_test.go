@@ -8,8 +8,17 @@ package webrtc
 import (
 	"bytes"
 	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/x509"
+	"errors"
+	"fmt"
+	"math/big"
 	"net"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -21,6 +30,7 @@ import (
 	"github.com/pion/stun/v3"
 	"github.com/pion/transport/v4/test"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/net/proxy"
 )
 
@@ -54,6 +64,17 @@ func TestSetConnectionTimeout(t *testing.T) {
 	assert.Equal(t, *s.timeout.ICEKeepaliveInterval, 3*time.Second)
 }
 
+func TestSetICETimeoutsForCandidateType(t *testing.T) {
+	s := SettingEngine{}
+	s.SetICETimeoutsForCandidateType(ICECandidateTypeRelay, time.Second, 2*time.Second)
+	s.SetICECellularTimeouts(3*time.Second, 4*time.Second)
+
+	assert.Equal(t, map[ICECandidateType]iceTimeouts{
+		ICECandidateTypeRelay: {disconnected: time.Second, failed: 2 * time.Second},
+	}, s.timeout.ICEPairTimeouts)
+	assert.Equal(t, &iceTimeouts{disconnected: 3 * time.Second, failed: 4 * time.Second}, s.timeout.ICECellularTimeouts)
+}
+
 func TestICERenomination(t *testing.T) {
 	t.Run("EnableWithDefaultGenerator", func(t *testing.T) {
 		s := SettingEngine{}
@@ -197,6 +218,23 @@ func ExampleSettingEngine_SetICEAddressRewriteRules_appendSrflx() {
 	)
 }
 
+// ExampleSettingEngine_SetDTLSVerifyPeerCertificate_pinning demonstrates
+// rejecting every peer whose certificate isn't a pinned one during the DTLS
+// handshake.
+func ExampleSettingEngine_SetDTLSVerifyPeerCertificate_pinning() {
+	// The SHA-256 digests of the certificates peers may use.
+	pinned := map[[sha256.Size]byte]bool{}
+
+	var se SettingEngine
+	se.SetDTLSVerifyPeerCertificate(func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if !pinned[sha256.Sum256(rawCerts[0])] {
+			return errors.New("the certificate of the peer isn't pinned") //nolint:err113
+		}
+
+		return nil
+	})
+}
+
 func TestSetAnsweringDTLSRole(t *testing.T) {
 	s := SettingEngine{}
 	assert.Error(
@@ -363,6 +401,20 @@ func TestSetDTLSRetransmissionInterval(t *testing.T) {
 	)
 }
 
+func TestSetDTLSHandshakeTimingAndMTU(t *testing.T) {
+	s := SettingEngine{}
+	assert.False(t, s.dtls.disableRetransmitBackoff)
+	assert.Zero(t, s.dtls.mtu)
+	assert.Zero(t, s.dtls.handshakeTimeout)
+
+	s.SetDTLSDisableRetransmitBackoff(true)
+	s.SetDTLSMTU(1000)
+	s.SetDTLSHandshakeTimeout(10 * time.Second)
+	assert.True(t, s.dtls.disableRetransmitBackoff)
+	assert.Equal(t, 1000, s.dtls.mtu)
+	assert.Equal(t, 10*time.Second, s.dtls.handshakeTimeout)
+}
+
 func TestSetDTLSEllipticCurves(t *testing.T) {
 	s := SettingEngine{}
 	assert.Empty(t, s.dtls.ellipticCurves)
@@ -398,6 +450,23 @@ func TestSetSCTPRTOMax(t *testing.T) {
 	assert.Equal(t, expSize, s.sctp.rtoMax)
 }
 
+func TestSetSCTPShutdownTimeout(t *testing.T) {
+	s := SettingEngine{}
+	assert.Equal(t, time.Duration(0), s.sctp.shutdownTimeout)
+
+	s.SetSCTPShutdownTimeout(time.Second)
+	assert.Equal(t, time.Second, s.sctp.shutdownTimeout)
+}
+
+func TestSetDataChannelScheduling(t *testing.T) {
+	s := SettingEngine{}
+	assert.Equal(t, DataChannelSchedulingPolicyFIFO, s.sctp.schedulingPolicy)
+
+	s.SetDataChannelScheduling(DataChannelSchedulingPolicyWeighted, 1024)
+	assert.Equal(t, DataChannelSchedulingPolicyWeighted, s.sctp.schedulingPolicy)
+	assert.Equal(t, 1024, s.sctp.schedulingMaxBufferedAmount)
+}
+
 func TestSetICEBindingRequestHandler(t *testing.T) {
 	seenICEControlled, seenICEControlledCancel := context.WithCancel(context.Background())
 	seenICEControlling, seenICEControllingCancel := context.WithCancel(context.Background())
@@ -760,3 +829,244 @@ func TestSettingEngine_HandleUndeclaredSSRCWithoutAnswer(t *testing.T) {
 	se.SetHandleUndeclaredSSRCWithoutAnswer(true)
 	assert.True(t, se.handleUndeclaredSSRCWithoutAnswer)
 }
+
+func TestSettingEngine_Generators(t *testing.T) {
+	report := test.CheckRoutines(t)
+	defer report()
+
+	newOffer := func() (SessionDescription, *PeerConnection) {
+		var ssrc SSRC = 1000
+		var mid, credentials int
+
+		se := SettingEngine{}
+		se.SetSSRCGenerator(func() SSRC {
+			ssrc++
+
+			return ssrc
+		})
+		se.SetMidGenerator(func() string {
+			mid++
+
+			return fmt.Sprintf("m%d", mid)
+		})
+		se.SetICECredentialGenerator(func() (string, string) {
+			credentials++
+
+			return fmt.Sprintf("ufrag%d", credentials), fmt.Sprintf("password%024d", credentials)
+		})
+		se.SetCertificateSerialNumberGenerator(func() *big.Int {
+			return big.NewInt(42)
+		})
+		se.SetSessionIDGenerator(func() uint64 {
+			return 1<<63 | 42
+		})
+
+		pc, err := NewAPI(WithSettingEngine(se)).NewPeerConnection(Configuration{})
+		require.NoError(t, err)
+
+		track, err := NewTrackLocalStaticSample(RTPCodecCapability{MimeType: MimeTypeVP8}, "video", "pion")
+		require.NoError(t, err)
+		_, err = pc.AddTrack(track)
+		require.NoError(t, err)
+
+		offer, err := pc.CreateOffer(nil)
+		require.NoError(t, err)
+		gatherComplete := GatheringCompletePromise(pc)
+		require.NoError(t, pc.SetLocalDescription(offer))
+		<-gatherComplete
+
+		return offer, pc
+	}
+
+	offer, pc := newOffer()
+	assert.Contains(t, offer.SDP, "a=mid:m1\r\n")
+	assert.Contains(t, offer.SDP, "a=ssrc:1001 ")
+	assert.Contains(t, offer.SDP, "a=ice-ufrag:ufrag1\r\n")
+	assert.Contains(t, offer.SDP, "o=- 42 ")
+	assert.Equal(t, big.NewInt(42), pc.configuration.Certificates[0].x509Cert.SerialNumber)
+
+	restart, err := pc.CreateOffer(&OfferOptions{ICERestart: true})
+	require.NoError(t, err)
+	assert.Contains(t, restart.SDP, "a=ice-ufrag:ufrag2\r\n")
+	assert.Contains(t, restart.SDP, "a=mid:m1\r\n")
+	assert.Contains(t, restart.SDP, "o=- 42 ")
+	require.NoError(t, pc.Close())
+
+	other, pc := newOffer()
+	assert.Equal(t, sdpMediaLines(t, offer), sdpMediaLines(t, other))
+	require.NoError(t, pc.Close())
+}
+
+func TestSettingEngine_MidGeneratorDataSection(t *testing.T) {
+	lim := test.TimeOut(time.Second * 30)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	// The generated mids are the numbers the data section is numbered with.
+	var mid int
+	se := SettingEngine{}
+	se.SetMidGenerator(func() string {
+		mid++
+
+		return strconv.Itoa(mid)
+	})
+
+	offerPC, err := NewAPI(WithSettingEngine(se)).NewPeerConnection(Configuration{})
+	require.NoError(t, err)
+	answerPC, err := NewPeerConnection(Configuration{})
+	require.NoError(t, err)
+
+	_, err = offerPC.AddTransceiverFromKind(RTPCodecTypeVideo)
+	require.NoError(t, err)
+	_, err = offerPC.CreateDataChannel("data", nil)
+	require.NoError(t, err)
+
+	offer, err := offerPC.CreateOffer(nil)
+	require.NoError(t, err)
+	mids := sdpMids(t, offer)
+	assert.Equal(t, []string{"1", "2"}, mids)
+	require.NoError(t, signalPair(offerPC, answerPC))
+
+	// The mid of the data section is skipped for the transceivers added later.
+	_, err = offerPC.AddTransceiverFromKind(RTPCodecTypeAudio)
+	require.NoError(t, err)
+	offer, err = offerPC.CreateOffer(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2", "3"}, sdpMids(t, offer))
+
+	closePairNow(t, offerPC, answerPC)
+}
+
+func sdpMids(t *testing.T, description SessionDescription) []string {
+	t.Helper()
+
+	parsed, err := description.Unmarshal()
+	require.NoError(t, err)
+
+	var mids []string
+	for _, media := range parsed.MediaDescriptions {
+		mids = append(mids, getMidValue(media))
+	}
+
+	return mids
+}
+
+// sdpMediaLines returns the media sections of a description without their
+// candidates and fingerprints, which differ between runs.
+func sdpMediaLines(t *testing.T, description SessionDescription) []string {
+	t.Helper()
+
+	parsed, err := description.Unmarshal()
+	require.NoError(t, err)
+
+	var lines []string
+	for _, media := range parsed.MediaDescriptions {
+		for _, attribute := range media.Attributes {
+			if attribute.Key != "candidate" && attribute.Key != "fingerprint" && attribute.Key != "end-of-candidates" {
+				lines = append(lines, attribute.String())
+			}
+		}
+	}
+
+	return lines
+}
+
+func TestSetDataChannelQueueLimits(t *testing.T) {
+	s := SettingEngine{}
+	assert.Zero(t, s.sctp.dataChannelQueueMaxChannels)
+	assert.Zero(t, s.sctp.dataChannelQueueMaxBytes)
+
+	s.SetDataChannelQueueLimits(16, 4096)
+	assert.Equal(t, 16, s.sctp.dataChannelQueueMaxChannels)
+	assert.Equal(t, 4096, s.sctp.dataChannelQueueMaxBytes)
+}
+
+func TestSetDTLSSessionCache(t *testing.T) {
+	s := SettingEngine{}
+	assert.Nil(t, s.dtls.sessionCache)
+
+	cache := NewDTLSSessionCache()
+	s.SetDTLSSessionCache(cache)
+	assert.Equal(t, cache, s.dtls.sessionCache)
+}
+
+func TestSetDTLSFingerprintAlgorithms(t *testing.T) {
+	s := SettingEngine{}
+	assert.Equal(t, []crypto.Hash{crypto.SHA256, crypto.SHA384, crypto.SHA512}, s.getDTLSFingerprintAlgorithms())
+
+	assert.NoError(t, s.SetDTLSFingerprintAlgorithms(crypto.SHA384, crypto.SHA256))
+	assert.Equal(t, []crypto.Hash{crypto.SHA384, crypto.SHA256}, s.getDTLSFingerprintAlgorithms())
+
+	assert.Error(t, s.SetDTLSFingerprintAlgorithms(crypto.SHA3_256))
+	assert.Equal(t, []crypto.Hash{crypto.SHA384, crypto.SHA256}, s.getDTLSFingerprintAlgorithms())
+}
+
+func TestSetSharedCredentials(t *testing.T) {
+	const (
+		usernameFragment = "node"
+		password         = "0123456789abcdefghij+/"
+	)
+
+	secretKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	certificate, err := GenerateCertificate(secretKey)
+	require.NoError(t, err)
+	expiredCertificate, err := NewCertificate(secretKey, x509.Certificate{
+		Version:      2,
+		SerialNumber: big.NewInt(1653),
+		NotBefore:    time.Now().AddDate(0, -2, 0),
+		NotAfter:     time.Now().AddDate(0, -1, 0),
+	})
+	require.NoError(t, err)
+
+	t.Run("Invalid", func(t *testing.T) {
+		s := SettingEngine{}
+		assert.ErrorIs(t, s.SetSharedCredentials("abc", password, *certificate), errSharedCredentialsUsernameFragment)
+		assert.ErrorIs(t, s.SetSharedCredentials("no-de", password, *certificate), errSharedCredentialsUsernameFragment)
+		assert.ErrorIs(t, s.SetSharedCredentials(usernameFragment, "tooshort", *certificate), errSharedCredentialsPassword)
+		assert.ErrorIs(t, s.SetSharedCredentials(usernameFragment, password), errSharedCredentialsNoCertificate)
+		assert.ErrorIs(t, s.SetSharedCredentials(usernameFragment, password, *expiredCertificate), ErrCertificateExpired)
+		assert.Empty(t, s.candidates.UsernameFragment)
+		assert.Empty(t, s.dtls.sharedCertificates)
+	})
+
+	t.Run("Shared by PeerConnections", func(t *testing.T) {
+		s := SettingEngine{}
+		s.SetICECredentialGenerator(func() (string, string) { return "generated", "generatedgeneratedgenerated" })
+		require.NoError(t, s.SetSharedCredentials(usernameFragment, password, *certificate))
+
+		api := NewAPI(WithSettingEngine(s))
+		var fingerprints []string
+		for range 2 {
+			pc, err := api.NewPeerConnection(Configuration{})
+			require.NoError(t, err)
+
+			_, err = pc.CreateDataChannel("data", nil)
+			require.NoError(t, err)
+			offer, err := pc.CreateOffer(nil)
+			require.NoError(t, err)
+			gatheringComplete := GatheringCompletePromise(pc)
+			require.NoError(t, pc.SetLocalDescription(offer))
+			<-gatheringComplete
+
+			parsed := pc.LocalDescription().parsed
+			iceDetails, err := extractICEDetails(parsed, nil)
+			require.NoError(t, err)
+			assert.Equal(t, usernameFragment, iceDetails.Ufrag)
+			assert.Equal(t, password, iceDetails.Password)
+
+			pcFingerprints, err := extractFingerprints(parsed)
+			require.NoError(t, err)
+			fingerprints = append(fingerprints, pcFingerprints[0].Value)
+
+			require.NoError(t, pc.Close())
+		}
+		assert.Equal(t, fingerprints[0], fingerprints[1])
+
+		expected, err := certificate.GetFingerprints()
+		require.NoError(t, err)
+		assert.True(t, strings.EqualFold(expected[0].Value, fingerprints[0]))
+	})
+}
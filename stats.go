@@ -442,6 +442,16 @@ type InboundRTPStreamStats struct {
 	// Jitter is the packet jitter measured in seconds for this SSRC
 	Jitter float64 `json:"jitter"`
 
+	// JitterHistogram counts the differences of the transit times of consecutive
+	// packets, the samples Jitter smooths. It is nil unless
+	// SettingEngine.EnableJitterHistograms is used. Pion specific.
+	JitterHistogram *DelayHistogram `json:"jitterHistogram,omitempty"`
+
+	// TransitDelayHistogram counts the transit times of the packets relative to
+	// the fastest packet received, their one-way delay variation. It is nil unless
+	// SettingEngine.EnableJitterHistograms is used. Pion specific.
+	TransitDelayHistogram *DelayHistogram `json:"transitDelayHistogram,omitempty"`
+
 	// PacketsDiscarded is the cumulative number of RTP packets discarded by the jitter
 	// buffer due to late or early-arrival, i.e., these packets are not played out.
 	// RTP packets discarded due to packet duplication are not reported in this metric.
@@ -478,6 +488,16 @@ type InboundRTPStreamStats struct {
 	// GapDiscardRate is the fraction of RTP packets discarded during the gap periods.
 	GapDiscardRate float64 `json:"gapDiscardRate"`
 
+	// IntervalPacketsReceived is the number of RTP packets read from the track
+	// during the last complete interval of one second, so every report of the
+	// same interval is the same. Pion specific, only reported for audio.
+	IntervalPacketsReceived uint32 `json:"intervalPacketsReceived,omitzero"`
+
+	// IntervalPacketsLost is the number of RTP packets lost during the last
+	// complete interval of one second, detected from gaps in the sequence numbers
+	// of the packets read from the track. Pion specific, only reported for audio.
+	IntervalPacketsLost uint32 `json:"intervalPacketsLost,omitzero"`
+
 	// TrackID is the identifier of the stats object representing the receiving track,
 	// a ReceiverAudioTrackAttachmentStats or ReceiverVideoTrackAttachmentStats.
 	TrackID string `json:"trackId"`
@@ -1455,6 +1475,11 @@ type DataChannelStats struct {
 	// BytesReceived represents the total number of bytes received on this
 	// datachannel not including headers or padding.
 	BytesReceived uint64 `json:"bytesReceived"`
+
+	// MessagesDropped is the number of received messages dropped because the
+	// receive queue was full, see SettingEngine.SetDataChannelReceiveQueueLimits.
+	// Pion specific.
+	MessagesDropped uint32 `json:"messagesDropped,omitempty"`
 }
 
 func (s DataChannelStats) statsMarker() {}
@@ -1994,10 +2019,60 @@ type TransportStats struct {
 	// as defined in the "Description" column of the IANA cipher suite registry.
 	DTLSCipher string `json:"dtlsCipher"`
 
+	// TLSVersion is the DTLS version negotiated with the remote peer, as the
+	// uppercase hex of the two version bytes like "FEFD" for DTLS 1.2. Present
+	// only once the handshake completed. pion/dtls doesn't implement DTLS 1.3
+	// yet, so it is always DTLS 1.2.
+	TLSVersion string `json:"tlsVersion,omitempty"`
+
+	// DTLSHandshakeDuration is the time the DTLS handshake took in seconds, zero
+	// until it completed. It includes the retransmissions of lost flights. Pion specific.
+	DTLSHandshakeDuration float64 `json:"dtlsHandshakeDuration,omitempty"`
+
 	// SRTPCipher is the descriptive name of the protection profile used for the SRTP
 	// transport, as defined in the "Profile" column of the IANA DTLS-SRTP protection
 	// profile registry.
 	SRTPCipher string `json:"srtpCipher"`
+
+	// UDP is the traffic carried by candidate pairs where both candidates are UDP and
+	// neither is a relay candidate. Pion specific.
+	UDP TransportTrafficStats `json:"udp,omitzero"`
+
+	// TCP is the traffic carried by candidate pairs where both candidates are TCP and
+	// neither is a relay candidate. Pion specific.
+	TCP TransportTrafficStats `json:"tcp,omitzero"`
+
+	// Relay is the traffic carried by candidate pairs where at least one candidate is
+	// a relay candidate, regardless of the protocol used to reach the TURN server.
+	// Pion specific.
+	Relay TransportTrafficStats `json:"relay,omitzero"`
+
+	// CandidatePairs attributes the traffic of this transport to each candidate pair,
+	// keyed by the ID of the corresponding ICECandidatePairStats. Pion specific.
+	CandidatePairs map[string]TransportTrafficStats `json:"candidatePairs,omitempty"`
+}
+
+// TransportTrafficStats contains the packet and byte counters for a subset of the
+// traffic carried by a transport.
+type TransportTrafficStats struct {
+	// PacketsSent represents the total number of packets sent.
+	PacketsSent uint32 `json:"packetsSent"`
+
+	// PacketsReceived represents the total number of packets received.
+	PacketsReceived uint32 `json:"packetsReceived"`
+
+	// BytesSent represents the total number of payload bytes sent.
+	BytesSent uint64 `json:"bytesSent"`
+
+	// BytesReceived represents the total number of payload bytes received.
+	BytesReceived uint64 `json:"bytesReceived"`
+}
+
+func (s *TransportTrafficStats) add(other TransportTrafficStats) {
+	s.PacketsSent += other.PacketsSent
+	s.PacketsReceived += other.PacketsReceived
+	s.BytesSent += other.BytesSent
+	s.BytesReceived += other.BytesReceived
 }
 
 func (s TransportStats) statsMarker() {}
@@ -2434,6 +2509,28 @@ type SCTPTransportStats struct {
 
 	// BytesReceived represents the total number of bytes received on this SCTPTransport
 	BytesReceived uint64 `json:"bytesReceived"`
+
+	// RetransmittedChunks is the number of DATA chunks retransmitted by fast
+	// retransmit, after RACK declared them lost or as tail loss probes. The
+	// chunks resent after a T3-rtx timeout are counted by T3Timeouts instead.
+	// Pion specific.
+	RetransmittedChunks uint64 `json:"retransmittedChunks"`
+
+	// FastRetransmits is the number of DATA chunks retransmitted by fast
+	// retransmit, after the remote peer reported them missing. Pion specific.
+	FastRetransmits uint64 `json:"fastRetransmits"`
+
+	// T3Timeouts is the number of T3-rtx timeouts, after each of them all DATA
+	// chunks in flight are retransmitted. Pion specific.
+	T3Timeouts uint64 `json:"t3Timeouts"`
+
+	// OutOfOrderChunksReceived is the number of DATA chunks received with a TSN
+	// below the highest one received, like retransmissions filling a gap. Pion specific.
+	OutOfOrderChunksReceived uint64 `json:"outOfOrderChunksReceived"`
+
+	// AssociationState is the state of the SCTP association, like Established or
+	// ShutdownSent. It is empty before the association is started. Pion specific.
+	AssociationState string `json:"associationState"`
 }
 
 func (s SCTPTransportStats) statsMarker() {}
@@ -1582,11 +1582,18 @@ func TestPeerConnection_GetStats(t *testing.T) { //nolint:cyclop // involves mul
 	offerICETransportStats := getTransportStats(t, reportPCOffer, "iceTransport")
 	assert.GreaterOrEqual(t, offerICETransportStats.BytesSent, answerICETransportStats.BytesReceived)
 	assert.GreaterOrEqual(t, answerICETransportStats.BytesSent, offerICETransportStats.BytesReceived)
+	assert.NotEmpty(t, offerICETransportStats.SelectedCandidatePairID)
+	assert.Contains(t, offerICETransportStats.CandidatePairs, offerICETransportStats.SelectedCandidatePairID)
+	assert.NotZero(t, offerICETransportStats.UDP.BytesSent)
+	assert.Zero(t, offerICETransportStats.Relay)
+	assert.Zero(t, offerICETransportStats.TCP)
 
 	answerSCTPTransportStats := getSctpTransportStats(t, reportPCAnswer)
 	offerSCTPTransportStats := getSctpTransportStats(t, reportPCOffer)
 	assert.GreaterOrEqual(t, offerSCTPTransportStats.BytesSent, answerSCTPTransportStats.BytesReceived)
 	assert.GreaterOrEqual(t, answerSCTPTransportStats.BytesSent, offerSCTPTransportStats.BytesReceived)
+	assert.Equal(t, "Established", offerSCTPTransportStats.AssociationState)
+	assert.Equal(t, "Established", answerSCTPTransportStats.AssociationState)
 
 	certificates := offerPC.configuration.Certificates
 
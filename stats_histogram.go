@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package webrtc
+
+// DelayHistogram is a histogram of the delays of the packets of an inbound RTP
+// stream, see SettingEngine.EnableJitterHistograms. Like the buckets of an HDR
+// histogram its buckets grow with the delays, a bucket is at most an eighth of
+// its lower bound wide. Delays are measured in microseconds.
+type DelayHistogram struct {
+	// Count is the number of delays in the histogram.
+	Count uint64 `json:"count"`
+
+	// Sum is the sum of the delays in seconds.
+	Sum float64 `json:"sum"`
+
+	// Max is the largest delay in seconds.
+	Max float64 `json:"max"`
+
+	// Buckets are the buckets with delays in the order of their bounds, empty
+	// buckets are left out.
+	Buckets []DelayHistogramBucket `json:"buckets"`
+}
+
+// DelayHistogramBucket is a bucket of a DelayHistogram.
+type DelayHistogramBucket struct {
+	// UpperBound is the upper bound in seconds of the delays in the bucket, the
+	// delays are below it and at least the upper bound of the previous bucket.
+	UpperBound float64 `json:"upperBound"`
+
+	// Count is the number of delays in the bucket.
+	Count uint64 `json:"count"`
+}
+
+// Quantile returns the delay in seconds that the fraction q of the delays
+// doesn't exceed, like 0.99 for the 99th percentile. It is the upper bound of
+// the bucket of the delay, at most Max. It returns 0 for an empty histogram.
+func (h *DelayHistogram) Quantile(q float64) float64 {
+	if h.Count == 0 {
+		return 0
+	}
+
+	rank := uint64(q * float64(h.Count))
+	var count uint64
+	for _, bucket := range h.Buckets {
+		count += bucket.Count
+		if count > rank {
+			return min(bucket.UpperBound, h.Max)
+		}
+	}
+
+	return h.Max
+}
@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package webrtc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// statsMembersPionSpecific are the members of Stats that browsers don't report.
+var statsMembersPionSpecific = map[StatsType][]string{ //nolint:gochecknoglobals
+	StatsTypeInboundRTP: {
+		"intervalPacketsReceived", "intervalPacketsLost", "jitterHistogram", "transitDelayHistogram",
+	},
+	StatsTypeTransport:       {"dtlsHandshakeDuration", "udp", "tcp", "relay", "candidatePairs"},
+	StatsTypeLocalCandidate:  {"deleted"},
+	StatsTypeRemoteCandidate: {"deleted"},
+	StatsTypeDataChannel:     {"messagesDropped"},
+	StatsTypeSCTPTransport: {
+		"retransmittedChunks", "fastRetransmits", "t3Timeouts", "outOfOrderChunksReceived", "associationState",
+	},
+}
+
+// MarshalJSON returns the report in the shape of the RTCStatsReport returned by
+// getStats() in browsers: an object of the stats keyed by their ID, each with
+// the camelCase members of https://www.w3.org/TR/webrtc-stats/.
+//
+// Members that aren't available, like empty strings, "unknown" states and
+// timestamps of events that didn't happen yet, are omitted as browsers do, and
+// members that only Pion reports are dropped. The address of ICE candidates is
+// reported as address instead of ip.
+func (r StatsReport) MarshalJSON() ([]byte, error) {
+	report := make(map[string]json.RawMessage, len(r))
+	for id, stats := range r {
+		b, err := marshalBrowserStats(stats)
+		if err != nil {
+			return nil, fmt.Errorf("marshal stats %s: %w", id, err)
+		}
+		report[id] = b
+	}
+
+	return json.Marshal(report)
+}
+
+func marshalBrowserStats(stats Stats) (json.RawMessage, error) {
+	b, err := json.Marshal(stats)
+	if err != nil {
+		return nil, err
+	}
+
+	// Numbers are kept as they are, so 64-bit counters don't lose precision.
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+
+	var members map[string]any
+	if err = decoder.Decode(&members); err != nil {
+		return nil, err
+	}
+
+	for name, value := range members {
+		switch value := value.(type) {
+		case string:
+			if value == "" || value == ErrUnknownType.Error() {
+				delete(members, name)
+			}
+		case json.Number:
+			if name != "timestamp" && strings.HasSuffix(name, "Timestamp") && !statsNumberPositive(value) {
+				delete(members, name)
+			}
+		}
+	}
+
+	statsType, _ := members["type"].(string)
+	for _, name := range statsMembersPionSpecific[StatsType(statsType)] {
+		delete(members, name)
+	}
+
+	switch StatsType(statsType) {
+	case StatsTypeCodec:
+		if channels, ok := members["channels"].(json.Number); ok && !statsNumberPositive(channels) {
+			delete(members, "channels")
+		}
+	case StatsTypeLocalCandidate, StatsTypeRemoteCandidate:
+		if ip, ok := members["ip"]; ok {
+			members["address"] = ip
+			delete(members, "ip")
+		}
+	default:
+	}
+
+	return json.Marshal(members)
+}
+
+func statsNumberPositive(n json.Number) bool {
+	f, err := n.Float64()
+
+	return err == nil && f > 0
+}
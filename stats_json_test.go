@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+package webrtc
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsReport_MarshalJSON(t *testing.T) {
+	report := StatsReport{
+		"T01": TransportStats{
+			Timestamp:  1688978831527.718,
+			Type:       StatsTypeTransport,
+			ID:         "T01",
+			BytesSent:  1 << 60,
+			ICERole:    ICERoleControlling,
+			DTLSState:  DTLSTransportStateConnected,
+			UDP:        TransportTrafficStats{PacketsSent: 1},
+			DTLSCipher: "TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+		},
+		"I01": ICECandidateStats{
+			Timestamp:     1688978831527.718,
+			Type:          StatsTypeLocalCandidate,
+			ID:            "I01",
+			TransportID:   "T01",
+			IP:            "192.0.2.1",
+			Port:          50000,
+			Protocol:      "udp",
+			CandidateType: ICECandidateTypeHost,
+		},
+		"COT01_96": CodecStats{
+			Timestamp:   1688978831527.718,
+			Type:        StatsTypeCodec,
+			ID:          "COT01_96",
+			PayloadType: 96,
+			MimeType:    MimeTypeVP8,
+			ClockRate:   90000,
+		},
+		"OT01V123": OutboundRTPStreamStats{
+			Timestamp:               1688978831527.718,
+			Type:                    StatsTypeOutboundRTP,
+			ID:                      "OT01V123",
+			SSRC:                    123,
+			Kind:                    "video",
+			LastPacketSentTimestamp: statsTimestampFrom(time.Time{}),
+		},
+		"IT01V456": InboundRTPStreamStats{
+			Timestamp:       1688978831527.718,
+			Type:            StatsTypeInboundRTP,
+			ID:              "IT01V456",
+			SSRC:            456,
+			Kind:            "video",
+			JitterHistogram: &DelayHistogram{Count: 1},
+		},
+		"D1": DataChannelStats{
+			Timestamp:       1688978831527.718,
+			Type:            StatsTypeDataChannel,
+			ID:              "D1",
+			Label:           "chat",
+			MessagesDropped: 2,
+		},
+		"SCTP01": SCTPTransportStats{
+			Timestamp:        1688978831527.718,
+			Type:             StatsTypeSCTPTransport,
+			ID:               "SCTP01",
+			T3Timeouts:       1,
+			AssociationState: "Established",
+		},
+	}
+
+	b, err := json.Marshal(report)
+	require.NoError(t, err)
+
+	var decoded map[string]map[string]any
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	require.Len(t, decoded, 7)
+
+	transport := decoded["T01"]
+	assert.Equal(t, "transport", transport["type"])
+	assert.Equal(t, "T01", transport["id"])
+	assert.InDelta(t, 1688978831527.718, transport["timestamp"], 0.001)
+	assert.Equal(t, "controlling", transport["iceRole"])
+	assert.Equal(t, "connected", transport["dtlsState"])
+	assert.Contains(t, string(b), `"bytesSent":1152921504606846976`)
+	for _, name := range []string{"iceState", "srtpCipher", "rtcpTransportStatsId", "udp", "tcp", "relay"} {
+		assert.NotContains(t, transport, name)
+	}
+
+	candidate := decoded["I01"]
+	assert.Equal(t, "192.0.2.1", candidate["address"])
+	assert.NotContains(t, candidate, "ip")
+	assert.NotContains(t, candidate, "deleted")
+	assert.NotContains(t, candidate, "url")
+	assert.Equal(t, "host", candidate["candidateType"])
+
+	assert.NotContains(t, decoded["COT01_96"], "channels")
+	assert.NotContains(t, decoded["OT01V123"], "lastPacketSentTimestamp")
+	assert.Contains(t, decoded["OT01V123"], "timestamp")
+
+	assert.NotContains(t, decoded["IT01V456"], "jitterHistogram")
+	assert.Equal(t, "video", decoded["IT01V456"]["kind"])
+
+	assert.NotContains(t, decoded["D1"], "messagesDropped")
+	assert.Equal(t, "chat", decoded["D1"]["label"])
+
+	for _, name := range []string{"t3Timeouts", "fastRetransmits", "associationState"} {
+		assert.NotContains(t, decoded["SCTP01"], name)
+	}
+}
+
+// TestStatsMembersPionSpecific checks that the members documented as Pion
+// specific are dropped from the JSON of browsers.
+func TestStatsMembersPionSpecific(t *testing.T) {
+	statsTypes := map[string]StatsType{
+		"InboundRTPStreamStats": StatsTypeInboundRTP,
+		"TransportStats":        StatsTypeTransport,
+		"DataChannelStats":      StatsTypeDataChannel,
+		"SCTPTransportStats":    StatsTypeSCTPTransport,
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), "stats.go", nil, parser.ParseComments)
+	require.NoError(t, err)
+
+	ast.Inspect(file, func(node ast.Node) bool {
+		spec, ok := node.(*ast.TypeSpec)
+		if !ok {
+			return true
+		}
+		structType, ok := spec.Type.(*ast.StructType)
+		if !ok {
+			return false
+		}
+
+		for _, field := range structType.Fields.List {
+			if field.Doc == nil || !strings.Contains(field.Doc.Text(), "Pion specific") || field.Tag == nil {
+				continue
+			}
+
+			tag := reflect.StructTag(strings.Trim(field.Tag.Value, "`")).Get("json")
+			name, _, _ := strings.Cut(tag, ",")
+			statsType, ok := statsTypes[spec.Name.Name]
+			if assert.True(t, ok, "%s has Pion specific members", spec.Name.Name) {
+				assert.True(t, slices.Contains(statsMembersPionSpecific[statsType], name),
+					"%s.%s isn't dropped", spec.Name.Name, name)
+			}
+		}
+
+		return false
+	})
+}
@@ -4,6 +4,8 @@
 package webrtc
 
 import (
+	"time"
+
 	"github.com/pion/interceptor"
 	"github.com/pion/rtp"
 )
@@ -54,6 +56,9 @@ type baseTrackLocalContext struct {
 	ssrc, ssrcRTX, ssrcFEC SSRC
 	writeStream            TrackLocalWriter
 	rtcpInterceptor        interceptor.RTCPReader
+
+	// packetizationTimes returns the ptime and maxptime of the RTPSender.
+	packetizationTimes func() (ptime, maxPtime time.Duration)
 }
 
 // CodecParameters returns the negotiated RTPCodecParameters. These are the codecs supported by both
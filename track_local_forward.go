@@ -0,0 +1,237 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/pion/webrtc/v4/internal/util"
+	"github.com/pion/webrtc/v4/pkg/rtptranslator"
+)
+
+// RTPHeaderMunger rewrites the header of a packet forwarded by a TrackLocalForwarder.
+// It is called for every binding with a header that already carries the SSRC and
+// PayloadType negotiated for that binding. The Extensions of the header are a
+// private copy and can be modified freely. Returning false drops the packet for
+// that binding.
+type RTPHeaderMunger func(header *rtp.Header) (keep bool)
+
+// NewRTPSequenceMunger returns a RTPHeaderMunger that rewrites sequence numbers
+// with translator. Packets the translator can't map are dropped.
+func NewRTPSequenceMunger(translator *rtptranslator.SequenceTranslator) RTPHeaderMunger {
+	return func(header *rtp.Header) bool {
+		seq, ok := translator.Translate(header.SequenceNumber)
+		header.SequenceNumber = seq
+
+		return ok
+	}
+}
+
+// NewRTPTimestampMunger returns a RTPHeaderMunger that rewrites timestamps with translator.
+func NewRTPTimestampMunger(translator *rtptranslator.TimestampTranslator) RTPHeaderMunger {
+	return func(header *rtp.Header) bool {
+		header.Timestamp = translator.Translate(header.Timestamp, time.Now())
+
+		return true
+	}
+}
+
+// NewRTPHeaderExtensionStripper returns a RTPHeaderMunger that removes the header
+// extensions with the given IDs. If no IDs are given every extension is removed.
+func NewRTPHeaderExtensionStripper(ids ...uint8) RTPHeaderMunger {
+	return func(header *rtp.Header) bool {
+		if len(ids) == 0 {
+			header.Extensions = header.Extensions[:0]
+		} else {
+			for _, id := range ids {
+				_ = header.DelExtension(id)
+			}
+		}
+
+		if len(header.Extensions) == 0 {
+			header.Extension = false
+			header.ExtensionProfile = 0
+		}
+
+		return true
+	}
+}
+
+type forwarderBinding struct {
+	trackBinding
+
+	// header and extensions are reused for every packet so munging doesn't allocate.
+	header     rtp.Header
+	extensions []rtp.Extension
+}
+
+// TrackLocalForwarder is a TrackLocal optimized for SFU style forwarding. It accepts
+// already packetized RTP, never invokes a payloader and applies a chain of
+// RTPHeaderMungers to every packet. After the first packet for a binding, writing
+// doesn't allocate unless a write fails or a munger allocates.
+type TrackLocalForwarder struct {
+	mu                sync.Mutex
+	bindings          []*forwarderBinding
+	codec             RTPCodecCapability
+	mungers           []RTPHeaderMunger
+	id, rid, streamID string
+}
+
+// NewTrackLocalForwarder returns a TrackLocalForwarder.
+func NewTrackLocalForwarder(
+	c RTPCodecCapability,
+	id, streamID string,
+	options ...func(*TrackLocalForwarder),
+) (*TrackLocalForwarder, error) {
+	f := &TrackLocalForwarder{
+		codec:    c,
+		id:       id,
+		streamID: streamID,
+	}
+
+	for _, option := range options {
+		option(f)
+	}
+
+	return f, nil
+}
+
+// WithForwarderRTPStreamID sets the RTP stream ID for this TrackLocalForwarder.
+func WithForwarderRTPStreamID(rid string) func(*TrackLocalForwarder) {
+	return func(f *TrackLocalForwarder) {
+		f.rid = rid
+	}
+}
+
+// WithRTPHeaderMungers appends RTPHeaderMungers to the chain that is applied to
+// every forwarded packet. Mungers run in the order they were added.
+func WithRTPHeaderMungers(mungers ...RTPHeaderMunger) func(*TrackLocalForwarder) {
+	return func(f *TrackLocalForwarder) {
+		f.mungers = append(f.mungers, mungers...)
+	}
+}
+
+// Bind is called by the PeerConnection after negotiation is complete
+// This asserts that the code requested is supported by the remote peer.
+// If so it sets up all the state (SSRC and PayloadType) to have a call.
+func (f *TrackLocalForwarder) Bind(trackContext TrackLocalContext) (RTPCodecParameters, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	parameters := RTPCodecParameters{RTPCodecCapability: f.codec}
+	if codec, matchType := codecParametersFuzzySearch(
+		parameters,
+		trackContext.CodecParameters(),
+	); matchType != codecMatchNone {
+		f.bindings = append(f.bindings, &forwarderBinding{trackBinding: trackBinding{
+			ssrc:           trackContext.SSRC(),
+			ssrcRTX:        trackContext.SSRCRetransmission(),
+			ssrcFEC:        trackContext.SSRCForwardErrorCorrection(),
+			payloadType:    codec.PayloadType,
+			payloadTypeRTX: findRTXPayloadType(codec.PayloadType, trackContext.CodecParameters()),
+			writeStream:    trackContext.WriteStream(),
+			id:             trackContext.ID(),
+		}})
+
+		return codec, nil
+	}
+
+	return RTPCodecParameters{}, ErrUnsupportedCodec
+}
+
+// Unbind implements the teardown logic when the track is no longer needed. This happens
+// because a track has been stopped.
+func (f *TrackLocalForwarder) Unbind(t TrackLocalContext) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i := range f.bindings {
+		if f.bindings[i].id == t.ID() {
+			f.bindings[i] = f.bindings[len(f.bindings)-1]
+			f.bindings = f.bindings[:len(f.bindings)-1]
+
+			return nil
+		}
+	}
+
+	return ErrUnbindFailed
+}
+
+// ID is the unique identifier for this Track. This should be unique for the
+// stream, but doesn't have to globally unique. A common example would be 'audio' or 'video'
+// and StreamID would be 'desktop' or 'webcam'.
+func (f *TrackLocalForwarder) ID() string { return f.id }
+
+// StreamID is the group this track belongs too. This must be unique.
+func (f *TrackLocalForwarder) StreamID() string { return f.streamID }
+
+// RID is the RTP stream identifier.
+func (f *TrackLocalForwarder) RID() string { return f.rid }
+
+// Kind controls if this TrackLocal is audio or video.
+func (f *TrackLocalForwarder) Kind() RTPCodecType {
+	switch {
+	case strings.HasPrefix(f.codec.MimeType, "audio/"):
+		return RTPCodecTypeAudio
+	case strings.HasPrefix(f.codec.MimeType, "video/"):
+		return RTPCodecTypeVideo
+	default:
+		return RTPCodecType(0)
+	}
+}
+
+// Codec gets the Codec of the track.
+func (f *TrackLocalForwarder) Codec() RTPCodecCapability {
+	return f.codec
+}
+
+// WriteRTP forwards a RTP Packet to every binding of the TrackLocalForwarder. The
+// packet is not modified. If one PeerConnection fails the packets will still be sent
+// to all PeerConnections. The error message will contain the ID of the failed
+// PeerConnections so you can remove them.
+func (f *TrackLocalForwarder) WriteRTP(packet *rtp.Packet) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var writeErrs []error
+	for _, b := range f.bindings {
+		b.header = packet.Header
+		b.extensions = append(b.extensions[:0], packet.Header.Extensions...)
+		b.header.Extensions = b.extensions
+		b.header.SSRC = uint32(b.ssrc)
+		b.header.PayloadType = uint8(b.payloadType)
+		if packet.PaddingSize != 0 && b.header.PaddingSize == 0 {
+			b.header.PaddingSize = packet.PaddingSize
+		}
+
+		if !f.munge(&b.header) {
+			continue
+		}
+
+		if _, err := b.writeStream.WriteRTP(&b.header, packet.Payload); err != nil {
+			writeErrs = append(writeErrs, err)
+		}
+	}
+
+	if len(writeErrs) == 0 {
+		return nil
+	}
+
+	return util.FlattenErrs(writeErrs)
+}
+
+func (f *TrackLocalForwarder) munge(header *rtp.Header) bool {
+	for _, munger := range f.mungers {
+		if !munger(header) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"testing"
+
+	"github.com/pion/rtp"
+	"github.com/pion/webrtc/v4/pkg/rtptranslator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingWriter struct {
+	headers []rtp.Header
+}
+
+func (r *recordingWriter) WriteRTP(header *rtp.Header, _ []byte) (int, error) {
+	h := *header
+	h.Extensions = append([]rtp.Extension{}, header.Extensions...)
+	r.headers = append(r.headers, h)
+
+	return 0, nil
+}
+
+func (r *recordingWriter) Write(b []byte) (int, error) { return len(b), nil }
+
+func newForwarderTestContext(id string, ssrc SSRC, payloadType PayloadType, writer TrackLocalWriter) TrackLocalContext {
+	return &baseTrackLocalContext{
+		id:   id,
+		ssrc: ssrc,
+		params: RTPParameters{Codecs: []RTPCodecParameters{{
+			RTPCodecCapability: RTPCodecCapability{MimeType: MimeTypeVP8, ClockRate: 90000},
+			PayloadType:        payloadType,
+		}}},
+		writeStream: writer,
+	}
+}
+
+func newForwarderTestPacket() *rtp.Packet {
+	packet := &rtp.Packet{
+		Header:  rtp.Header{Version: 2, SequenceNumber: 10, Timestamp: 1000, SSRC: 1, PayloadType: 100},
+		Payload: []byte{0x01, 0x02},
+	}
+	_ = packet.Header.SetExtension(1, []byte{0xAA})
+	_ = packet.Header.SetExtension(2, []byte{0xBB})
+
+	return packet
+}
+
+func TestTrackLocalForwarder_WriteRTP(t *testing.T) {
+	translator := rtptranslator.NewSequenceTranslator()
+	forwarder, err := NewTrackLocalForwarder(
+		RTPCodecCapability{MimeType: MimeTypeVP8, ClockRate: 90000}, "video", "pion",
+		WithForwarderRTPStreamID("h"),
+		WithRTPHeaderMungers(NewRTPSequenceMunger(translator), NewRTPHeaderExtensionStripper(2)),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "h", forwarder.RID())
+	assert.Equal(t, RTPCodecTypeVideo, forwarder.Kind())
+
+	first, second := &recordingWriter{}, &recordingWriter{}
+	_, err = forwarder.Bind(newForwarderTestContext("a", 1111, 96, first))
+	require.NoError(t, err)
+	_, err = forwarder.Bind(newForwarderTestContext("b", 2222, 98, second))
+	require.NoError(t, err)
+
+	packet := newForwarderTestPacket()
+	require.NoError(t, forwarder.WriteRTP(packet))
+
+	// The original packet is left untouched
+	assert.Len(t, packet.Header.Extensions, 2)
+	assert.Equal(t, uint32(1), packet.SSRC)
+
+	for _, tc := range []struct {
+		writer      *recordingWriter
+		ssrc        uint32
+		payloadType uint8
+	}{
+		{first, 1111, 96},
+		{second, 2222, 98},
+	} {
+		require.Len(t, tc.writer.headers, 1)
+		header := tc.writer.headers[0]
+		assert.Equal(t, tc.ssrc, header.SSRC)
+		assert.Equal(t, tc.payloadType, header.PayloadType)
+		assert.Equal(t, uint16(10), header.SequenceNumber)
+		assert.Equal(t, []byte{0xAA}, header.GetExtension(1))
+		assert.Nil(t, header.GetExtension(2))
+	}
+
+	assert.NoError(t, forwarder.Unbind(newForwarderTestContext("a", 0, 0, nil)))
+	assert.ErrorIs(t, forwarder.Unbind(newForwarderTestContext("a", 0, 0, nil)), ErrUnbindFailed)
+}
+
+func TestTrackLocalForwarder_MungerDrops(t *testing.T) {
+	forwarder, err := NewTrackLocalForwarder(
+		RTPCodecCapability{MimeType: MimeTypeVP8, ClockRate: 90000}, "video", "pion",
+		WithRTPHeaderMungers(func(*rtp.Header) bool { return false }),
+	)
+	require.NoError(t, err)
+
+	writer := &recordingWriter{}
+	_, err = forwarder.Bind(newForwarderTestContext("a", 1111, 96, writer))
+	require.NoError(t, err)
+
+	require.NoError(t, forwarder.WriteRTP(newForwarderTestPacket()))
+	assert.Empty(t, writer.headers)
+}
+
+func TestTrackLocalForwarder_WriteRTPError(t *testing.T) {
+	forwarder, err := NewTrackLocalForwarder(RTPCodecCapability{MimeType: MimeTypeVP8}, "video", "pion")
+	require.NoError(t, err)
+
+	_, err = forwarder.Bind(newForwarderTestContext("a", 1111, 96, errWriter{}))
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, forwarder.WriteRTP(newForwarderTestPacket()), errWriteBoom)
+}
+
+func TestTrackLocalForwarder_ZeroAllocations(t *testing.T) {
+	forwarder, err := NewTrackLocalForwarder(
+		RTPCodecCapability{MimeType: MimeTypeVP8, ClockRate: 90000}, "video", "pion",
+		WithRTPHeaderMungers(
+			NewRTPSequenceMunger(rtptranslator.NewSequenceTranslator()),
+			NewRTPTimestampMunger(rtptranslator.NewTimestampTranslator(90000)),
+			NewRTPHeaderExtensionStripper(),
+		),
+	)
+	require.NoError(t, err)
+
+	_, err = forwarder.Bind(newForwarderTestContext("a", 1111, 96, dummyWriter{}))
+	require.NoError(t, err)
+
+	packet := newForwarderTestPacket()
+	require.NoError(t, forwarder.WriteRTP(packet))
+
+	allocs := testing.AllocsPerRun(100, func() {
+		packet.SequenceNumber++
+		_ = forwarder.WriteRTP(packet)
+	})
+	assert.Zero(t, allocs)
+}
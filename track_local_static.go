@@ -6,8 +6,10 @@
 package webrtc
 
 import (
+	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/pion/rtp"
 	"github.com/pion/webrtc/v4/internal/util"
@@ -22,6 +24,10 @@ type trackBinding struct {
 	ssrc, ssrcRTX, ssrcFEC      SSRC
 	payloadType, payloadTypeRTX PayloadType
 	writeStream                 TrackLocalWriter
+
+	// maxPtime returns the maximum packetization time of the binding, it is nil
+	// if the TrackLocalContext doesn't know it.
+	maxPtime func() time.Duration
 }
 
 // TrackLocalStaticRTP  is a TrackLocal that has a pre-set codec and accepts RTP Packets.
@@ -34,6 +40,8 @@ type TrackLocalStaticRTP struct {
 	id, rid, streamID string
 	initalTimestamp   *uint32
 	initialSeqNumber  *uint16
+	clockRate         uint32
+	frames            rtpFrameTimestamps
 }
 
 // NewTrackLocalStaticRTP returns a TrackLocalStaticRTP.
@@ -96,7 +104,7 @@ func (s *TrackLocalStaticRTP) Bind(trackContext TrackLocalContext) (RTPCodecPara
 		parameters,
 		trackContext.CodecParameters(),
 	); matchType != codecMatchNone {
-		s.bindings = append(s.bindings, trackBinding{
+		binding := trackBinding{
 			ssrc:           trackContext.SSRC(),
 			ssrcRTX:        trackContext.SSRCRetransmission(),
 			ssrcFEC:        trackContext.SSRCForwardErrorCorrection(),
@@ -104,7 +112,12 @@ func (s *TrackLocalStaticRTP) Bind(trackContext TrackLocalContext) (RTPCodecPara
 			payloadTypeRTX: findRTXPayloadType(codec.PayloadType, trackContext.CodecParameters()),
 			writeStream:    trackContext.WriteStream(),
 			id:             trackContext.ID(),
-		})
+		}
+		if c, ok := trackContext.(maxPtimeContext); ok {
+			binding.maxPtime = c.MaxPtime
+		}
+		s.bindings = append(s.bindings, binding)
+		s.clockRate = codec.ClockRate
 
 		return codec, nil
 	}
@@ -158,6 +171,25 @@ func (s *TrackLocalStaticRTP) Codec() RTPCodecCapability {
 	return s.codec
 }
 
+// maxPtime returns the shortest maximum packetization time of the bindings,
+// zero if none of them has one.
+func (s *TrackLocalStaticRTP) maxPtime() time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var maxPtime time.Duration
+	for _, b := range s.bindings {
+		if b.maxPtime == nil {
+			continue
+		}
+		if bindingMaxPtime := b.maxPtime(); bindingMaxPtime != 0 && (maxPtime == 0 || bindingMaxPtime < maxPtime) {
+			maxPtime = bindingMaxPtime
+		}
+	}
+
+	return maxPtime
+}
+
 // packetPool is a pool of packets used by WriteRTP and Write below
 // nolint:gochecknoglobals
 var rtpPacketPool = sync.Pool{
@@ -340,6 +372,9 @@ func (s *TrackLocalStaticSample) Unbind(t TrackLocalContext) error {
 // If one PeerConnection fails the packets will still be sent to
 // all PeerConnections. The error message will contain the ID of the failed
 // PeerConnections so you can remove them.
+//
+// Samples longer than the maxptime a remote peer signaled are rejected with
+// ErrSampleExceedsMaxPtime, see RTPSender.MaxPtime.
 func (s *TrackLocalStaticSample) WriteSample(sample media.Sample) error {
 	s.rtpTrack.mu.RLock()
 	packetizer := s.packetizer
@@ -350,6 +385,10 @@ func (s *TrackLocalStaticSample) WriteSample(sample media.Sample) error {
 		return nil
 	}
 
+	if maxPtime := s.rtpTrack.maxPtime(); maxPtime != 0 && sample.Duration > maxPtime {
+		return fmt.Errorf("%w: %v > %v", ErrSampleExceedsMaxPtime, sample.Duration, maxPtime)
+	}
+
 	s.mu.Lock()
 	remainder := s.remainder
 
@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pion/randutil"
+	"github.com/pion/rtp"
+	"github.com/pion/webrtc/v4/internal/util"
+)
+
+// rtpFrameTimestamps are the timestamps of the frames written with
+// TrackLocalStaticRTP.WriteFrame and its variants.
+type rtpFrameTimestamps struct {
+	mu        sync.Mutex
+	started   bool
+	timestamp uint32
+	remainder float64
+
+	firstCaptureTime time.Time
+	firstTimestamp   uint32
+}
+
+// next returns the timestamp of the next frame, initialized like the ones of
+// TrackLocalStaticSample.
+func (f *rtpFrameTimestamps) next(initialTimestamp *uint32) uint32 {
+	if !f.started {
+		f.started = true
+		if initialTimestamp != nil {
+			f.timestamp = *initialTimestamp
+		} else {
+			f.timestamp = randutil.NewMathRandomGenerator().Uint32()
+		}
+	}
+
+	return f.timestamp
+}
+
+// frameClockRate returns the clock rate the codec was negotiated with, or the
+// one of the codec before the track is bound.
+func (s *TrackLocalStaticRTP) frameClockRate() (uint32, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	clockRate := s.clockRate
+	if clockRate == 0 {
+		clockRate = s.codec.ClockRate
+	}
+	if clockRate == 0 {
+		return 0, errTrackNoClockRate
+	}
+
+	return clockRate, nil
+}
+
+// WriteFrame writes the RTP packets of a frame with the timestamp of the frame,
+// then advances the timestamp by the number of samples the frame lasts, like
+// 960 for 20ms of Opus or 3000 for a frame of 30fps video. The timestamps of the
+// packets are overwritten, the other fields are written as they are.
+func (s *TrackLocalStaticRTP) WriteFrame(packets []*rtp.Packet, samples uint32) error {
+	s.frames.mu.Lock()
+	timestamp := s.frames.next(s.initalTimestamp)
+	s.frames.timestamp += samples
+	s.frames.mu.Unlock()
+
+	return s.writeFrame(packets, timestamp)
+}
+
+// WriteFrameWithDuration is like WriteFrame, except that the frame lasts the
+// duration. It is converted with the clock rate of the negotiated codec, so
+// the timestamps of audio and video advance correctly.
+func (s *TrackLocalStaticRTP) WriteFrameWithDuration(packets []*rtp.Packet, duration time.Duration) error {
+	clockRate, err := s.frameClockRate()
+	if err != nil {
+		return err
+	}
+
+	s.frames.mu.Lock()
+	timestamp := s.frames.next(s.initalTimestamp)
+	ticks := duration.Seconds()*float64(clockRate) + s.frames.remainder
+	samples := uint32(ticks)
+	s.frames.remainder = ticks - float64(samples)
+	s.frames.timestamp += samples
+	s.frames.mu.Unlock()
+
+	return s.writeFrame(packets, timestamp)
+}
+
+// WriteFrameAt is like WriteFrame, except that the timestamp is the one of the
+// wall-clock time the frame was captured at, relative to the first frame. This
+// keeps the timestamps in sync when frames are dropped or late. It shouldn't be
+// mixed with the other variants on the same track.
+func (s *TrackLocalStaticRTP) WriteFrameAt(packets []*rtp.Packet, captureTime time.Time) error {
+	clockRate, err := s.frameClockRate()
+	if err != nil {
+		return err
+	}
+
+	s.frames.mu.Lock()
+	timestamp := s.frames.next(s.initalTimestamp)
+	if s.frames.firstCaptureTime.IsZero() {
+		s.frames.firstCaptureTime = captureTime
+		s.frames.firstTimestamp = timestamp
+	} else {
+		elapsed := captureTime.Sub(s.frames.firstCaptureTime).Seconds() * float64(clockRate)
+		timestamp = s.frames.firstTimestamp + uint32(int64(elapsed)) //nolint:gosec // G115, wraps like timestamps
+	}
+	s.frames.timestamp = timestamp
+	s.frames.mu.Unlock()
+
+	return s.writeFrame(packets, timestamp)
+}
+
+func (s *TrackLocalStaticRTP) writeFrame(packets []*rtp.Packet, timestamp uint32) error {
+	packet := getPacketAllocationFromPool()
+	defer resetPacketPoolAllocation(packet)
+
+	writeErrs := []error{}
+	for _, p := range packets {
+		*packet = *p
+		packet.Timestamp = timestamp
+		if err := s.writeRTP(packet); err != nil {
+			writeErrs = append(writeErrs, err)
+		}
+	}
+
+	return util.FlattenErrs(writeErrs)
+}
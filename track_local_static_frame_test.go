@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type timestampWriter struct {
+	timestamps *[]uint32
+}
+
+func (w timestampWriter) WriteRTP(header *rtp.Header, _ []byte) (int, error) {
+	*w.timestamps = append(*w.timestamps, header.Timestamp)
+
+	return 0, nil
+}
+
+func (timestampWriter) Write(_ []byte) (int, error) { return 0, nil }
+
+func newFrameTrack(t *testing.T, codec RTPCodecCapability) (*TrackLocalStaticRTP, *[]uint32) {
+	t.Helper()
+
+	track, err := NewTrackLocalStaticRTP(codec, "id", "stream", WithRTPTimestamp(1000))
+	require.NoError(t, err)
+
+	timestamps := &[]uint32{}
+	track.bindings = []trackBinding{{id: "b1", writeStream: timestampWriter{timestamps: timestamps}}}
+
+	return track, timestamps
+}
+
+func TestTrackLocalStaticRTP_WriteFrame(t *testing.T) {
+	track, timestamps := newFrameTrack(t, RTPCodecCapability{MimeType: MimeTypeVP8, ClockRate: 90000})
+
+	packet := &rtp.Packet{Header: rtp.Header{Timestamp: 5}, Payload: []byte{0x01}}
+	require.NoError(t, track.WriteFrame([]*rtp.Packet{packet, packet}, 3000))
+	require.NoError(t, track.WriteFrame([]*rtp.Packet{packet}, 3000))
+	assert.Equal(t, []uint32{1000, 1000, 4000}, *timestamps)
+	assert.Equal(t, uint32(5), packet.Timestamp)
+}
+
+func TestTrackLocalStaticRTP_WriteFrameWithDuration(t *testing.T) {
+	track, timestamps := newFrameTrack(t, RTPCodecCapability{MimeType: MimeTypeOpus, ClockRate: 48000, Channels: 2})
+
+	packet := &rtp.Packet{Payload: []byte{0x01}}
+	for range 3 {
+		require.NoError(t, track.WriteFrameWithDuration([]*rtp.Packet{packet}, 20*time.Millisecond))
+	}
+	assert.Equal(t, []uint32{1000, 1960, 2920}, *timestamps)
+
+	// The fractions of samples are carried over to the next frames, 1/30s is
+	// slightly less than 3000 samples.
+	track, timestamps = newFrameTrack(t, RTPCodecCapability{MimeType: MimeTypeVP8, ClockRate: 90000})
+	for range 4 {
+		require.NoError(t, track.WriteFrameWithDuration([]*rtp.Packet{packet}, time.Second/30))
+	}
+	assert.Equal(t, []uint32{1000, 3999, 6999, 9999}, *timestamps)
+
+	track, _ = newFrameTrack(t, RTPCodecCapability{MimeType: MimeTypeVP8})
+	assert.ErrorIs(t, track.WriteFrameWithDuration([]*rtp.Packet{packet}, time.Second), errTrackNoClockRate)
+}
+
+func TestTrackLocalStaticRTP_WriteFrameAt(t *testing.T) {
+	track, timestamps := newFrameTrack(t, RTPCodecCapability{MimeType: MimeTypeOpus, ClockRate: 48000, Channels: 2})
+
+	// The negotiated clock rate is used once the track is bound.
+	track.clockRate = 8000
+
+	start := time.Now()
+	packet := &rtp.Packet{Payload: []byte{0x01}}
+	require.NoError(t, track.WriteFrameAt([]*rtp.Packet{packet}, start))
+	require.NoError(t, track.WriteFrameAt([]*rtp.Packet{packet}, start.Add(20*time.Millisecond)))
+	require.NoError(t, track.WriteFrameAt([]*rtp.Packet{packet}, start.Add(100*time.Millisecond)))
+	assert.Equal(t, []uint32{1000, 1160, 1800}, *timestamps)
+}
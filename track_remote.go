@@ -6,9 +6,11 @@
 package webrtc
 
 import (
+	"encoding/binary"
 	"fmt"
 	"io"
 	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -41,6 +43,13 @@ type TrackRemote struct {
 	peekedPackets []*peekedPacket
 
 	audioPlayoutStatsProviders []AudioPlayoutStatsProvider
+	decoderStatsProvider       DecoderStatsProvider
+
+	audioStats       inboundAudioStats
+	jitterHistograms jitterHistograms
+
+	sampleReader trackSampleReader
+	readBuffer   pooledReadBuffer
 }
 
 func newTrackRemote(kind RTPCodecType, ssrc, rtxSsrc SSRC, rid string, receiver *RTPReceiver) *TrackRemote {
@@ -135,9 +144,11 @@ func (t *TrackRemote) Read(b []byte) (n int, attributes interceptor.Attributes,
 
 	if peekedPkt != nil {
 		n = copy(b, peekedPkt.payload)
-		err = t.checkAndUpdateTrack(b)
+		if err = t.checkAndUpdateTrack(b[:n]); err != nil {
+			return n, peekedPkt.attributes, err
+		}
 
-		return n, peekedPkt.attributes, err
+		return n, t.setReceivedHeaderExtensions(b[:n], peekedPkt.attributes), nil
 	}
 
 	// If there's a separate RTX track and an RTX packet is available, return that
@@ -153,9 +164,27 @@ func (t *TrackRemote) Read(b []byte) (n int, attributes interceptor.Attributes,
 	if err != nil {
 		return n, attributes, err
 	}
-	err = t.checkAndUpdateTrack(b)
+	if err = t.checkAndUpdateTrack(b[:n]); err != nil {
+		return n, attributes, err
+	}
+	if receiver.jitterHistogramsEnabled() && n >= rtpHeaderMinLength {
+		t.jitterHistograms.handlePacket(binary.BigEndian.Uint32(b[4:8]), t.Codec().ClockRate, time.Now())
+	}
 
-	return n, attributes, err
+	return n, t.setReceivedHeaderExtensions(b[:n], attributes), nil
+}
+
+// setReceivedHeaderExtensions adds the header extensions of a packet read from
+// the track to its attributes.
+func (t *TrackRemote) setReceivedHeaderExtensions(b []byte, attributes interceptor.Attributes) interceptor.Attributes {
+	if extensions, ok := t.receivedHeaderExtensions(b); ok {
+		if attributes == nil {
+			attributes = interceptor.Attributes{}
+		}
+		attributes.Set(receivedHeaderExtensionsKey{}, extensions)
+	}
+
+	return attributes
 }
 
 // checkAndUpdateTrack checks payloadType for every incoming packet
@@ -165,16 +194,33 @@ func (t *TrackRemote) checkAndUpdateTrack(b []byte) error {
 		return errRTPTooShort
 	}
 
+	if t.receiver.kind == RTPCodecTypeAudio && len(b) >= 4 {
+		t.audioStats.handleSequenceNumber(binary.BigEndian.Uint16(b[2:4]), time.Now())
+	}
+
 	payloadType := PayloadType(b[1] & rtpPayloadTypeBitmask)
 	if payloadType != t.PayloadType() || len(t.params.Codecs) == 0 {
-		t.mu.Lock()
-		defer t.mu.Unlock()
-
 		params, err := t.receiver.api.mediaEngine.getRTPParametersByPayloadType(payloadType)
 		if err != nil {
 			return err
 		}
 
+		// telephone-events and comfort noise are interleaved with the audio, they
+		// don't change the codec of the track.
+		switch {
+		case strings.EqualFold(params.Codecs[0].MimeType, MimeTypeTelephoneEvent):
+			t.receiver.dtmf.handle(b, params.Codecs[0].ClockRate)
+
+			return nil
+		case strings.EqualFold(params.Codecs[0].MimeType, MimeTypeCN):
+			t.receiver.handleComfortNoise(b)
+
+			return nil
+		}
+
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
 		t.kind = t.receiver.kind
 		t.payloadType = payloadType
 		t.codec = params.Codecs[0]
@@ -186,14 +232,13 @@ func (t *TrackRemote) checkAndUpdateTrack(b []byte) error {
 
 // ReadRTP is a convenience method that wraps Read and unmarshals for you.
 func (t *TrackRemote) ReadRTP() (*rtp.Packet, interceptor.Attributes, error) {
-	b := make([]byte, t.receiver.api.settingEngine.getReceiveMTU())
-	i, attributes, err := t.Read(b)
+	b, attributes, err := t.receiver.api.settingEngine.readPacket(&t.readBuffer, t.Read)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	r := &rtp.Packet{}
-	if err := r.Unmarshal(b[:i]); err != nil {
+	if err := r.Unmarshal(b); err != nil {
 		return nil, nil, err
 	}
 
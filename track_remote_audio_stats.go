@@ -0,0 +1,256 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// burstGapMinimum is Gmin of RFC 3611 Section 4.7.2, the number of consecutively
+	// received packets that ends a burst.
+	burstGapMinimum = 16
+
+	// audioStatsMaxDropout is the sequence number jump above which the stream is
+	// considered restarted instead of lossy, see RFC 3550 Appendix A.1.
+	audioStatsMaxDropout = 3000
+
+	// audioStatsInterval is the duration of the intervals of the interval
+	// metrics, the ones of the last complete interval are reported.
+	audioStatsInterval = time.Second
+)
+
+// burstPeriod accumulates a burst that hasn't ended yet.
+type burstPeriod struct {
+	packets   uint32
+	lost      uint32
+	discarded uint32
+}
+
+// burstGapTracker splits loss and discard events into bursts and gaps, see
+// RFC 3611 Section 4.7.2. A burst with a single loss or discard is counted in
+// the gap, like the algorithm of RFC 3611 Appendix A.2 does.
+type burstGapTracker struct {
+	// received is the number of packets received after the last loss or discard.
+	received uint32
+	inBurst  bool
+	current  burstPeriod
+
+	burstPackets      uint32
+	burstLost         uint32
+	burstDiscarded    uint32
+	burstLossCount    uint32
+	burstDiscardCount uint32
+
+	gapPackets   uint32
+	gapLost      uint32
+	gapDiscarded uint32
+}
+
+func (b *burstGapTracker) packetReceived() {
+	b.received++
+	if b.inBurst && b.received >= burstGapMinimum {
+		b.endBurst()
+	}
+}
+
+func (b *burstGapTracker) packetLost(discarded bool) {
+	if b.inBurst {
+		b.current.packets += b.received + 1
+	} else {
+		b.gapPackets += b.received
+		b.inBurst = true
+		b.current = burstPeriod{packets: 1}
+	}
+	b.received = 0
+
+	if discarded {
+		b.current.discarded++
+	} else {
+		b.current.lost++
+	}
+}
+
+func (b *burstGapTracker) endBurst() {
+	if b.current.lost+b.current.discarded > 1 {
+		b.burstPackets += b.current.packets
+		b.burstLost += b.current.lost
+		b.burstDiscarded += b.current.discarded
+		if b.current.lost > 0 {
+			b.burstLossCount++
+		}
+		if b.current.discarded > 0 {
+			b.burstDiscardCount++
+		}
+	} else {
+		b.gapPackets += b.current.packets
+		b.gapLost += b.current.lost
+		b.gapDiscarded += b.current.discarded
+	}
+
+	b.inBurst = false
+	b.current = burstPeriod{}
+}
+
+// populate reports the metrics as if the current burst ended now.
+func (b burstGapTracker) populate(stats *InboundRTPStreamStats) {
+	if b.inBurst {
+		b.endBurst()
+	}
+	b.gapPackets += b.received
+
+	stats.BurstPacketsLost = b.burstLost
+	stats.BurstPacketsDiscarded = b.burstDiscarded
+	stats.BurstLossCount = b.burstLossCount
+	stats.BurstDiscardCount = b.burstDiscardCount
+
+	if b.burstPackets != 0 {
+		stats.BurstLossRate = float64(b.burstLost) / float64(b.burstPackets)
+		stats.BurstDiscardRate = float64(b.burstDiscarded) / float64(b.burstPackets)
+	}
+	if b.gapPackets != 0 {
+		stats.GapLossRate = float64(b.gapLost) / float64(b.gapPackets)
+		stats.GapDiscardRate = float64(b.gapDiscarded) / float64(b.gapPackets)
+	}
+}
+
+// inboundAudioStats accumulates the loss, discard and jitter buffer metrics of a
+// remote audio track, which the interceptor stats don't provide.
+type inboundAudioStats struct {
+	mu sync.Mutex
+
+	started    bool
+	highestSeq uint16
+
+	burstGap burstGapTracker
+
+	packetsDiscarded uint32
+
+	// The interval metrics advance with the time, not with the reports, so
+	// every report of the same interval is the same.
+	intervalStart        time.Time
+	intervalReceived     uint32
+	intervalLost         uint32
+	lastIntervalReceived uint32
+	lastIntervalLost     uint32
+
+	jitterBufferDelay        float64
+	jitterBufferTargetDelay  float64
+	jitterBufferMinimumDelay float64
+	jitterBufferEmittedCount uint64
+}
+
+// advanceInterval completes the current interval once it is over. The caller
+// holds the lock.
+func (s *inboundAudioStats) advanceInterval(now time.Time) {
+	if s.intervalStart.IsZero() {
+		s.intervalStart = now
+
+		return
+	}
+
+	elapsed := now.Sub(s.intervalStart)
+	if elapsed < audioStatsInterval {
+		return
+	}
+
+	// No packet was read during the last interval if it isn't the current one.
+	s.lastIntervalReceived, s.lastIntervalLost = 0, 0
+	if elapsed < 2*audioStatsInterval {
+		s.lastIntervalReceived, s.lastIntervalLost = s.intervalReceived, s.intervalLost
+	}
+	s.intervalReceived, s.intervalLost = 0, 0
+	s.intervalStart = s.intervalStart.Add(elapsed.Truncate(audioStatsInterval))
+}
+
+// handleSequenceNumber is called with the sequence number of every packet read
+// from the track. Packets older than the highest sequence number were already
+// counted as lost and are ignored, as are duplicates.
+func (s *inboundAudioStats) handleSequenceNumber(seq uint16, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.advanceInterval(now)
+	if s.started {
+		diff := seq - s.highestSeq
+		if diff == 0 || diff >= 1<<15 {
+			return
+		}
+
+		if diff <= audioStatsMaxDropout {
+			for range diff - 1 {
+				s.burstGap.packetLost(false)
+				s.intervalLost++
+			}
+		}
+	}
+
+	s.started = true
+	s.highestSeq = seq
+	s.burstGap.packetReceived()
+	s.intervalReceived++
+}
+
+func (s *inboundAudioStats) packetsDiscardedByJitterBuffer(count uint32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.packetsDiscarded += count
+	for range count {
+		// Discarded packets were counted as received when they were read.
+		if s.burstGap.received > 0 {
+			s.burstGap.received--
+		}
+		s.burstGap.packetLost(true)
+	}
+}
+
+func (s *inboundAudioStats) jitterBufferEmitted(samples uint64, delay, targetDelay, minimumDelay time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jitterBufferEmittedCount += samples
+	s.jitterBufferDelay += delay.Seconds() * float64(samples)
+	s.jitterBufferTargetDelay += targetDelay.Seconds() * float64(samples)
+	s.jitterBufferMinimumDelay += minimumDelay.Seconds() * float64(samples)
+}
+
+// populate fills the audio metrics of the inbound stats, the interval metrics
+// of the last complete interval before now.
+func (s *inboundAudioStats) populate(stats *InboundRTPStreamStats, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.burstGap.populate(stats)
+
+	s.advanceInterval(now)
+	stats.PacketsDiscarded = s.packetsDiscarded
+	stats.IntervalPacketsReceived = s.lastIntervalReceived
+	stats.IntervalPacketsLost = s.lastIntervalLost
+
+	stats.JitterBufferDelay = s.jitterBufferDelay
+	stats.JitterBufferTargetDelay = s.jitterBufferTargetDelay
+	stats.JitterBufferMinimumDelay = s.jitterBufferMinimumDelay
+	stats.JitterBufferEmittedCount = s.jitterBufferEmittedCount
+}
+
+// ReportJitterBufferEmitted reports audio samples that left the application's
+// jitter buffer for playout. delay is how long the samples were buffered,
+// targetDelay and minimumDelay the current target and minimum delay of the
+// jitter buffer. They are accumulated into the jitterBuffer metrics of the
+// InboundRTPStreamStats of this track.
+func (t *TrackRemote) ReportJitterBufferEmitted(samples uint64, delay, targetDelay, minimumDelay time.Duration) {
+	t.audioStats.jitterBufferEmitted(samples, delay, targetDelay, minimumDelay)
+}
+
+// ReportPacketsDiscarded reports packets read from this track that the
+// application's jitter buffer discarded because they arrived too late or too
+// early. They are counted in PacketsDiscarded and the burst and gap discard
+// metrics of the InboundRTPStreamStats of this track.
+func (t *TrackRemote) ReportPacketsDiscarded(count uint32) {
+	t.audioStats.packetsDiscardedByJitterBuffer(count)
+}
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_inboundAudioStats_BurstGap(t *testing.T) {
+	var audioStats inboundAudioStats
+	start := time.Unix(0, 0)
+	receive := func(from, to uint16, at time.Duration) {
+		for seq := int(from); seq <= int(to); seq++ {
+			audioStats.handleSequenceNumber(uint16(seq), start.Add(at)) //nolint:gosec // G115
+		}
+	}
+	report := func(at time.Duration) InboundRTPStreamStats {
+		stats := InboundRTPStreamStats{}
+		audioStats.populate(&stats, start.Add(at))
+
+		return stats
+	}
+
+	// An isolated loss is counted in the gap, the losses of 41, 43 and 44 form a burst.
+	receive(0, 19, 0)
+	receive(21, 40, 0)
+	receive(42, 42, 0)
+	receive(45, 70, 0)
+
+	// Duplicates and reordered packets are ignored.
+	receive(70, 70, 0)
+	receive(60, 60, 0)
+
+	// The interval metrics are reported once the interval is complete.
+	stats := report(500 * time.Millisecond)
+	assert.Equal(t, uint32(0), stats.IntervalPacketsReceived)
+	assert.Equal(t, uint32(0), stats.IntervalPacketsLost)
+
+	stats = report(1100 * time.Millisecond)
+	assert.Equal(t, uint32(3), stats.BurstPacketsLost)
+	assert.Equal(t, uint32(1), stats.BurstLossCount)
+	assert.InDelta(t, 0.75, stats.BurstLossRate, 1e-9)
+	assert.InDelta(t, 1.0/67, stats.GapLossRate, 1e-9)
+	assert.Equal(t, uint32(67), stats.IntervalPacketsReceived)
+	assert.Equal(t, uint32(4), stats.IntervalPacketsLost)
+
+	// Reports don't restart the interval, every report of the interval is the same.
+	assert.Equal(t, stats, report(1200*time.Millisecond))
+
+	// A sequence number wrap is a single loss.
+	receive(71, 65535, 1500*time.Millisecond)
+	receive(1, 1, 1500*time.Millisecond)
+	stats = report(2100 * time.Millisecond)
+	assert.Equal(t, uint32(1), stats.IntervalPacketsLost)
+	assert.Equal(t, uint32(65535-71+2), stats.IntervalPacketsReceived)
+
+	// A jump beyond the maximum dropout is a restart of the stream, not a loss.
+	receive(1+audioStatsMaxDropout+1, 1+audioStatsMaxDropout+1, 2500*time.Millisecond)
+	stats = report(3200 * time.Millisecond)
+	assert.Equal(t, uint32(0), stats.IntervalPacketsLost)
+	assert.Equal(t, uint32(1), stats.IntervalPacketsReceived)
+
+	// Nothing was received during the last complete interval.
+	stats = report(5 * time.Second)
+	assert.Equal(t, uint32(0), stats.IntervalPacketsReceived)
+}
+
+func Test_inboundAudioStats_JitterBuffer(t *testing.T) {
+	var audioStats inboundAudioStats
+
+	for seq := range uint16(10) {
+		audioStats.handleSequenceNumber(seq, time.Now())
+	}
+	audioStats.packetsDiscardedByJitterBuffer(2)
+	for seq := uint16(10); seq < 30; seq++ {
+		audioStats.handleSequenceNumber(seq, time.Now())
+	}
+
+	audioStats.jitterBufferEmitted(960, 40*time.Millisecond, 30*time.Millisecond, 20*time.Millisecond)
+	audioStats.jitterBufferEmitted(960, 60*time.Millisecond, 30*time.Millisecond, 20*time.Millisecond)
+
+	stats := InboundRTPStreamStats{}
+	audioStats.populate(&stats, time.Now())
+
+	assert.Equal(t, uint32(2), stats.PacketsDiscarded)
+	assert.Equal(t, uint32(2), stats.BurstPacketsDiscarded)
+	assert.Equal(t, uint32(1), stats.BurstDiscardCount)
+	assert.InDelta(t, 1.0, stats.BurstDiscardRate, 1e-9)
+	assert.InDelta(t, 0.0, stats.GapDiscardRate, 1e-9)
+	assert.Equal(t, uint32(0), stats.BurstPacketsLost)
+
+	assert.Equal(t, uint64(1920), stats.JitterBufferEmittedCount)
+	assert.InDelta(t, 960*0.1, stats.JitterBufferDelay, 1e-9)
+	assert.InDelta(t, 1920*0.03, stats.JitterBufferTargetDelay, 1e-9)
+	assert.InDelta(t, 1920*0.02, stats.JitterBufferMinimumDelay, 1e-9)
+}
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"time"
+)
+
+// DecoderStats are the cumulative metrics of the decoder of a remote track,
+// which only the application can measure. They are merged into the
+// InboundRTPStreamStats of the track, see DecoderStatsProvider. The metrics of
+// the other kind are left zero.
+type DecoderStats struct {
+	// TotalSamplesReceived, ConcealedSamples, SilentConcealedSamples,
+	// ConcealmentEvents, InsertedSamplesForDeceleration and
+	// RemovedSamplesForAcceleration are the audio metrics of the same name of
+	// InboundRTPStreamStats.
+	TotalSamplesReceived           uint64
+	ConcealedSamples               uint64
+	SilentConcealedSamples         uint64
+	ConcealmentEvents              uint64
+	InsertedSamplesForDeceleration uint64
+	RemovedSamplesForAcceleration  uint64
+
+	// FramesDecoded, KeyFramesDecoded, FramesRendered, FramesDropped, FrameWidth,
+	// FrameHeight, FreezeCount and PauseCount are the video metrics of the same
+	// name of InboundRTPStreamStats.
+	FramesDecoded    uint32
+	KeyFramesDecoded uint32
+	FramesRendered   uint32
+	FramesDropped    uint32
+	FrameWidth       uint32
+	FrameHeight      uint32
+	FreezeCount      uint32
+	PauseCount       uint32
+
+	// TotalDecodeTime, TotalFreezesDuration and TotalPausesDuration are the total
+	// time spent decoding frames, in frozen frames and in pauses.
+	TotalDecodeTime      time.Duration
+	TotalFreezesDuration time.Duration
+	TotalPausesDuration  time.Duration
+
+	// DecoderImplementation identifies the decoder, like "libvpx".
+	DecoderImplementation string
+}
+
+// DecoderStatsProvider is implemented by the decoder of a remote track to
+// report its concealment, freeze and decoding metrics, see
+// TrackRemote.SetDecoderStatsProvider.
+type DecoderStatsProvider interface {
+	// DecoderStats returns the metrics of the decoder so far. It is called
+	// whenever the stats of the PeerConnection are collected.
+	DecoderStats() DecoderStats
+}
+
+// SetDecoderStatsProvider sets the DecoderStatsProvider of the track, whose
+// metrics are merged into the InboundRTPStreamStats of the track. Set it to nil
+// to stop reporting them.
+func (t *TrackRemote) SetDecoderStatsProvider(provider DecoderStatsProvider) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.decoderStatsProvider = provider
+}
+
+func (t *TrackRemote) populateDecoderStats(stats *InboundRTPStreamStats) {
+	t.mu.RLock()
+	provider := t.decoderStatsProvider
+	t.mu.RUnlock()
+	if provider == nil {
+		return
+	}
+
+	decoderStats := provider.DecoderStats()
+
+	stats.TotalSamplesReceived = decoderStats.TotalSamplesReceived
+	stats.ConcealedSamples = decoderStats.ConcealedSamples
+	stats.SilentConcealedSamples = decoderStats.SilentConcealedSamples
+	stats.ConcealmentEvents = decoderStats.ConcealmentEvents
+	stats.InsertedSamplesForDeceleration = decoderStats.InsertedSamplesForDeceleration
+	stats.RemovedSamplesForAcceleration = decoderStats.RemovedSamplesForAcceleration
+
+	stats.FramesDecoded = decoderStats.FramesDecoded
+	stats.KeyFramesDecoded = decoderStats.KeyFramesDecoded
+	stats.FramesRendered = decoderStats.FramesRendered
+	stats.FramesDropped = decoderStats.FramesDropped
+	stats.FrameWidth = decoderStats.FrameWidth
+	stats.FrameHeight = decoderStats.FrameHeight
+	stats.FreezeCount = decoderStats.FreezeCount
+	stats.PauseCount = decoderStats.PauseCount
+
+	stats.TotalDecodeTime = decoderStats.TotalDecodeTime.Seconds()
+	stats.TotalFreezesDuration = decoderStats.TotalFreezesDuration.Seconds()
+	stats.TotalPausesDuration = decoderStats.TotalPausesDuration.Seconds()
+
+	stats.DecoderImplementation = decoderStats.DecoderImplementation
+}
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pion/transport/v4/test"
+	"github.com/pion/webrtc/v4/pkg/media"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testDecoderStatsProvider DecoderStats
+
+func (p testDecoderStatsProvider) DecoderStats() DecoderStats {
+	return DecoderStats(p)
+}
+
+func TestTrackRemote_SetDecoderStatsProvider(t *testing.T) {
+	lim := test.TimeOut(time.Second * 20)
+	defer lim.Stop()
+
+	report := test.CheckRoutines(t)
+	defer report()
+
+	pcOffer, pcAnswer, err := newPair()
+	require.NoError(t, err)
+
+	track, err := NewTrackLocalStaticSample(RTPCodecCapability{MimeType: MimeTypeVP8}, "video", "pion")
+	require.NoError(t, err)
+	_, err = pcOffer.AddTrack(track)
+	require.NoError(t, err)
+
+	remoteTracks := make(chan *TrackRemote, 1)
+	pcAnswer.OnTrack(func(remote *TrackRemote, _ *RTPReceiver) {
+		remote.SetDecoderStatsProvider(testDecoderStatsProvider{
+			FramesDecoded:         30,
+			KeyFramesDecoded:      1,
+			FreezeCount:           2,
+			TotalFreezesDuration:  1500 * time.Millisecond,
+			TotalDecodeTime:       250 * time.Millisecond,
+			DecoderImplementation: "test",
+		})
+		remoteTracks <- remote
+	})
+
+	require.NoError(t, signalPair(pcOffer, pcAnswer))
+
+	var remote *TrackRemote
+	func() {
+		for {
+			select {
+			case remote = <-remoteTracks:
+				return
+			case <-time.After(20 * time.Millisecond):
+				assert.NoError(t, track.WriteSample(media.Sample{Data: []byte{0x00}, Duration: time.Second}))
+			}
+		}
+	}()
+
+	inbound := findInboundRTPStatsBySSRC(pcAnswer.GetStats(), remote.SSRC())
+	require.Len(t, inbound, 1)
+	assert.Equal(t, uint32(30), inbound[0].FramesDecoded)
+	assert.Equal(t, uint32(1), inbound[0].KeyFramesDecoded)
+	assert.Equal(t, uint32(2), inbound[0].FreezeCount)
+	assert.InDelta(t, 1.5, inbound[0].TotalFreezesDuration, 1e-9)
+	assert.InDelta(t, 0.25, inbound[0].TotalDecodeTime, 1e-9)
+	assert.Equal(t, "test", inbound[0].DecoderImplementation)
+	assert.Zero(t, inbound[0].ConcealedSamples)
+
+	remote.SetDecoderStatsProvider(nil)
+	inbound = findInboundRTPStatsBySSRC(pcAnswer.GetStats(), remote.SSRC())
+	require.Len(t, inbound, 1)
+	assert.Zero(t, inbound[0].FramesDecoded)
+
+	closePairNow(t, pcOffer, pcAnswer)
+}
@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"math/bits"
+	"sync"
+	"time"
+)
+
+const (
+	// delayHistogramSubBucketBits is the precision of the buckets of a
+	// delayHistogram, each power of two is split into 8 buckets.
+	delayHistogramSubBucketBits = 3
+	delayHistogramSubBuckets    = 1 << delayHistogramSubBucketBits
+
+	// delayHistogramPowers is the number of powers of two above the first
+	// sub-buckets, delays up to 2^35 microseconds are told apart.
+	delayHistogramPowers  = 32
+	delayHistogramBuckets = delayHistogramSubBuckets * (delayHistogramPowers + 1)
+)
+
+// delayHistogram counts delays in microseconds, the first sub-buckets are a
+// microsecond wide and the others double their width with every power of two.
+type delayHistogram struct {
+	counts [delayHistogramBuckets]uint64
+	count  uint64
+	sum    uint64
+	max    uint64
+}
+
+func delayHistogramIndex(delay uint64) int {
+	if delay < delayHistogramSubBuckets {
+		return int(delay)
+	}
+
+	power := bits.Len64(delay) - 1 - delayHistogramSubBucketBits
+	index := delayHistogramSubBuckets*(power+1) + int(delay>>power) - delayHistogramSubBuckets
+
+	return min(index, delayHistogramBuckets-1)
+}
+
+// delayHistogramUpperBound returns the exclusive upper bound of the bucket in
+// microseconds.
+func delayHistogramUpperBound(index int) uint64 {
+	if index < delayHistogramSubBuckets {
+		return uint64(index) + 1 //nolint:gosec // G115
+	}
+
+	power := index/delayHistogramSubBuckets - 1
+	subBucket := index % delayHistogramSubBuckets
+
+	return uint64(delayHistogramSubBuckets+subBucket+1) << power //nolint:gosec // G115
+}
+
+func (h *delayHistogram) record(delay uint64) {
+	h.counts[delayHistogramIndex(delay)]++
+	h.count++
+	h.sum += delay
+	h.max = max(h.max, delay)
+}
+
+func (h *delayHistogram) histogram() *DelayHistogram {
+	histogram := &DelayHistogram{
+		Count: h.count,
+		Sum:   float64(h.sum) / float64(time.Second/time.Microsecond),
+		Max:   float64(h.max) / float64(time.Second/time.Microsecond),
+	}
+	for i, count := range h.counts {
+		if count != 0 {
+			histogram.Buckets = append(histogram.Buckets, DelayHistogramBucket{
+				UpperBound: float64(delayHistogramUpperBound(i)) / float64(time.Second/time.Microsecond),
+				Count:      count,
+			})
+		}
+	}
+
+	return histogram
+}
+
+// jitterHistograms measures the transit times of the packets of a remote track
+// like RFC 3550 Section 6.4.1 does for the jitter estimate, and counts every
+// sample instead of smoothing them.
+type jitterHistograms struct {
+	mu sync.Mutex
+
+	started       bool
+	firstArrival  time.Time
+	lastTimestamp uint32
+
+	// timestamp is the RTP timestamp of the last packet relative to the first
+	// one, unwrapped.
+	timestamp int64
+
+	// lastTransit and minTransit are the transit times in microseconds relative
+	// to the one of the first packet.
+	lastTransit int64
+	minTransit  int64
+
+	jitter       delayHistogram
+	transitDelay delayHistogram
+}
+
+func (h *jitterHistograms) handlePacket(timestamp, clockRate uint32, arrival time.Time) {
+	if clockRate == 0 {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.started {
+		h.started = true
+		h.firstArrival = arrival
+		h.lastTimestamp = timestamp
+		h.transitDelay.record(0)
+
+		return
+	}
+
+	h.timestamp += int64(int32(timestamp - h.lastTimestamp)) //nolint:gosec // G115, RTP timestamps wrap around
+	h.lastTimestamp = timestamp
+
+	elapsed := h.timestamp * int64(time.Second/time.Microsecond) / int64(clockRate)
+	transit := arrival.Sub(h.firstArrival).Microseconds() - elapsed
+	diff := transit - h.lastTransit
+	if diff < 0 {
+		diff = -diff
+	}
+	h.lastTransit = transit
+	h.minTransit = min(h.minTransit, transit)
+
+	h.jitter.record(uint64(diff))                         //nolint:gosec // G115
+	h.transitDelay.record(uint64(transit - h.minTransit)) //nolint:gosec // G115
+}
+
+// reset empties the histograms, the transit times are still measured relative
+// to the fastest packet received.
+func (h *jitterHistograms) reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.jitter = delayHistogram{}
+	h.transitDelay = delayHistogram{}
+}
+
+func (h *jitterHistograms) populate(stats *InboundRTPStreamStats) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	stats.JitterHistogram = h.jitter.histogram()
+	stats.TransitDelayHistogram = h.transitDelay.histogram()
+}
+
+// jitterHistogramsEnabled returns true if SettingEngine.EnableJitterHistograms
+// is used, receivers without an API don't measure them.
+func (r *RTPReceiver) jitterHistogramsEnabled() bool {
+	return r.api != nil && r.api.settingEngine.jitterHistograms
+}
+
+// ResetJitterHistograms empties the jitter and transit delay histograms of the
+// InboundRTPStreamStats of this track, like to look at the delays since the
+// last stats report only. See SettingEngine.EnableJitterHistograms.
+func (t *TrackRemote) ResetJitterHistograms() {
+	t.jitterHistograms.reset()
+}
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_delayHistogramIndex(t *testing.T) {
+	for _, delay := range []uint64{0, 1, 7, 8, 9, 15, 16, 17, 100, 1000, 123456, 1 << 34} {
+		index := delayHistogramIndex(delay)
+		assert.Less(t, delay, delayHistogramUpperBound(index), "delay %d", delay)
+		if index > 0 {
+			assert.GreaterOrEqual(t, delay, delayHistogramUpperBound(index-1), "delay %d", delay)
+		}
+		// A bucket is at most an eighth of its lower bound wide.
+		if delay >= delayHistogramSubBuckets {
+			assert.LessOrEqual(t, delayHistogramUpperBound(index)-delayHistogramUpperBound(index-1), delay/8+1)
+		}
+	}
+
+	assert.Equal(t, delayHistogramBuckets-1, delayHistogramIndex(math.MaxUint64))
+}
+
+func Test_jitterHistograms(t *testing.T) {
+	var histograms jitterHistograms
+	start := time.Now()
+
+	// 20ms of audio at 48kHz per packet, the third packet is 30ms late and the
+	// fourth one 10ms.
+	histograms.handlePacket(math.MaxUint32-959, 48000, start)
+	histograms.handlePacket(0, 48000, start.Add(20*time.Millisecond))
+	histograms.handlePacket(960, 48000, start.Add(70*time.Millisecond))
+	histograms.handlePacket(1920, 48000, start.Add(70*time.Millisecond))
+	histograms.handlePacket(1920, 0, start.Add(80*time.Millisecond))
+
+	var stats InboundRTPStreamStats
+	histograms.populate(&stats)
+
+	require.NotNil(t, stats.JitterHistogram)
+	assert.Equal(t, uint64(3), stats.JitterHistogram.Count)
+	assert.InDelta(t, 0.05, stats.JitterHistogram.Sum, 1e-9)
+	assert.InDelta(t, 0.03, stats.JitterHistogram.Max, 1e-9)
+	assert.InDelta(t, 0.000001, stats.JitterHistogram.Quantile(0.3), 1e-9)
+	assert.InDelta(t, 0.03, stats.JitterHistogram.Quantile(0.99), 1e-9)
+	assert.InDelta(t, 0.02, stats.JitterHistogram.Quantile(0.5), 0.0025)
+
+	require.NotNil(t, stats.TransitDelayHistogram)
+	assert.Equal(t, uint64(4), stats.TransitDelayHistogram.Count)
+	assert.InDelta(t, 0.03, stats.TransitDelayHistogram.Max, 1e-9)
+
+	histograms.reset()
+	histograms.handlePacket(2880, 48000, start.Add(90*time.Millisecond))
+	histograms.populate(&stats)
+	assert.Equal(t, uint64(1), stats.JitterHistogram.Count)
+	assert.Zero(t, stats.JitterHistogram.Max)
+	assert.Equal(t, []DelayHistogramBucket{{UpperBound: 0.010240, Count: 1}}, stats.TransitDelayHistogram.Buckets)
+}
+
+func TestDelayHistogram_Quantile(t *testing.T) {
+	assert.Zero(t, (&DelayHistogram{}).Quantile(0.5))
+}
@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/pion/rtp"
+	"github.com/pion/webrtc/v4/pkg/media"
+	"github.com/pion/webrtc/v4/pkg/media/samplebuilder"
+)
+
+// defaultSampleReaderMaxLate is the number of packets a sample waits for its
+// missing packets by default.
+const defaultSampleReaderMaxLate = 50
+
+// SampleReaderOptions configures how TrackRemote.ReadSample builds samples from
+// the RTP packets of the track.
+type SampleReaderOptions struct {
+	// Depacketizer extracts the samples from the RTP payloads, it is picked by
+	// the codec of the track if nil. Several depacketizers are available in
+	// package github.com/pion/rtp/codecs.
+	Depacketizer rtp.Depacketizer
+
+	// MaxLate is the number of packets a sample waits for its missing packets
+	// before it is dropped, 50 by default. A larger MaxLate loses fewer samples
+	// but adds latency.
+	MaxLate uint16
+
+	// MaxLateDuration additionally bounds the time a sample waits for its missing
+	// packets, it is unbounded if zero.
+	MaxLateDuration time.Duration
+}
+
+type trackSampleReader struct {
+	mu      sync.Mutex
+	options SampleReaderOptions
+	builder *samplebuilder.SampleBuilder
+	eof     bool
+}
+
+// SetSampleReaderOptions configures ReadSample, it has to be called before the
+// first sample is read.
+func (t *TrackRemote) SetSampleReaderOptions(options SampleReaderOptions) error {
+	t.sampleReader.mu.Lock()
+	defer t.sampleReader.mu.Unlock()
+
+	if t.sampleReader.builder != nil {
+		return errSampleReaderStarted
+	}
+	t.sampleReader.options = options
+
+	return nil
+}
+
+// ReadSample reads the RTP packets of the track until the next sample is
+// complete. The samples are built by a samplebuilder.SampleBuilder for the codec
+// of the track, see SetSampleReaderOptions. Once the track ends the remaining
+// samples are returned before io.EOF. ReadSample takes over reading the track,
+// Read and ReadRTP must not be used besides it.
+func (t *TrackRemote) ReadSample() (*media.Sample, error) {
+	reader := &t.sampleReader
+	reader.mu.Lock()
+	defer reader.mu.Unlock()
+
+	if reader.builder == nil {
+		builder, err := t.newSampleBuilder(reader.options)
+		if err != nil {
+			return nil, err
+		}
+		reader.builder = builder
+	}
+
+	for {
+		if sample := reader.builder.Pop(); sample != nil {
+			return sample, nil
+		}
+		if reader.eof {
+			return nil, io.EOF
+		}
+
+		packet, _, err := t.ReadRTP()
+		if errors.Is(err, io.EOF) {
+			reader.eof = true
+			reader.builder.Flush()
+
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		// The builder keeps the packets until their sample is complete, pooled
+		// buffers go back to the pool with the next read.
+		if t.receiver.api.settingEngine.rtpPacketPooling {
+			packet = packet.Clone()
+		}
+		reader.builder.Push(packet)
+	}
+}
+
+// OnSample sets an event handler which is invoked with the samples of the track,
+// it calls ReadSample until it fails, like when the track ends.
+func (t *TrackRemote) OnSample(f func(*media.Sample)) {
+	go func() {
+		for {
+			sample, err := t.ReadSample()
+			if err != nil {
+				return
+			}
+			f(sample)
+		}
+	}()
+}
+
+func (t *TrackRemote) newSampleBuilder(options SampleReaderOptions) (*samplebuilder.SampleBuilder, error) {
+	codec := t.Codec()
+
+	depacketizer := options.Depacketizer
+	if depacketizer == nil {
+		var err error
+		if depacketizer, err = depacketizerForCodec(codec.RTPCodecCapability); err != nil {
+			return nil, err
+		}
+	}
+
+	maxLate := options.MaxLate
+	if maxLate == 0 {
+		maxLate = defaultSampleReaderMaxLate
+	}
+
+	var builderOptions []samplebuilder.Option
+	if options.MaxLateDuration > 0 {
+		builderOptions = append(builderOptions, samplebuilder.WithMaxTimeDelay(options.MaxLateDuration))
+	}
+
+	return samplebuilder.New(maxLate, depacketizer, codec.ClockRate, builderOptions...), nil
+}
@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2026 The Pion community <https://pion.ly>
+// SPDX-License-Identifier: MIT
+
+//go:build !js
+
+package webrtc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pion/rtp/codecs"
+	"github.com/pion/transport/v4/test"
+	"github.com/pion/webrtc/v4/pkg/media"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrackRemote_ReadSample(t *testing.T) {
+	// Pooled buffers go back to the pool with the next read, while a sample
+	// is built from several packets.
+	for _, pooling := range []bool{false, true} {
+		t.Run(fmt.Sprintf("pooling=%t", pooling), func(t *testing.T) {
+			lim := test.TimeOut(time.Second * 30)
+			defer lim.Stop()
+
+			report := test.CheckRoutines(t)
+			defer report()
+
+			settingEngine := SettingEngine{}
+			settingEngine.EnableRTPPacketPooling(pooling)
+			pcOffer, pcAnswer, err := NewAPI(WithSettingEngine(settingEngine)).newPair(Configuration{})
+			require.NoError(t, err)
+
+			video, err := NewTrackLocalStaticSample(RTPCodecCapability{MimeType: MimeTypeVP8}, "video", "pion")
+			require.NoError(t, err)
+			_, err = pcOffer.AddTrack(video)
+			require.NoError(t, err)
+
+			audio, err := NewTrackLocalStaticSample(RTPCodecCapability{MimeType: MimeTypeOpus}, "audio", "pion")
+			require.NoError(t, err)
+			_, err = pcOffer.AddTrack(audio)
+			require.NoError(t, err)
+
+			videoData := bytes.Repeat([]byte{0xAA}, 3000)
+			audioData := []byte{0xBB, 0xCC}
+
+			videoReceived, videoDone := context.WithCancel(context.Background())
+			audioReceived, audioDone := context.WithCancel(context.Background())
+			pcAnswer.OnTrack(func(track *TrackRemote, _ *RTPReceiver) {
+				if track.Kind() == RTPCodecTypeAudio {
+					assert.NoError(t, track.SetSampleReaderOptions(SampleReaderOptions{
+						Depacketizer:    &codecs.OpusPacket{},
+						MaxLate:         10,
+						MaxLateDuration: time.Second,
+					}))
+					track.OnSample(func(sample *media.Sample) {
+						assert.Equal(t, audioData, sample.Data)
+						audioDone()
+					})
+
+					return
+				}
+
+				sample, readErr := track.ReadSample()
+				assert.NoError(t, readErr)
+				assert.Equal(t, videoData, sample.Data)
+				assert.Equal(t, 20*time.Millisecond, sample.Duration)
+				assert.ErrorIs(t, track.SetSampleReaderOptions(SampleReaderOptions{}), errSampleReaderStarted)
+				videoDone()
+			})
+
+			require.NoError(t, signalPair(pcOffer, pcAnswer))
+
+			for videoReceived.Err() == nil || audioReceived.Err() == nil {
+				time.Sleep(20 * time.Millisecond)
+				assert.NoError(t, video.WriteSample(media.Sample{Data: videoData, Duration: 20 * time.Millisecond}))
+				assert.NoError(t, audio.WriteSample(media.Sample{Data: audioData, Duration: 20 * time.Millisecond}))
+			}
+
+			closePairNow(t, pcOffer, pcAnswer)
+		})
+	}
+}
+
+func TestDepacketizerForCodec(t *testing.T) {
+	for _, mimeType := range []string{MimeTypeH264, MimeTypeH265, MimeTypeOpus, MimeTypeVP8, MimeTypeVP9, MimeTypeAV1} {
+		depacketizer, err := depacketizerForCodec(RTPCodecCapability{MimeType: mimeType})
+		assert.NoError(t, err, mimeType)
+		assert.NotNil(t, depacketizer, mimeType)
+	}
+
+	_, err := depacketizerForCodec(RTPCodecCapability{MimeType: MimeTypePCMU})
+	assert.ErrorIs(t, err, ErrNoDepacketizerForCodec)
+}